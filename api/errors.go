@@ -42,3 +42,5 @@ func Error(err error) *ErrorResponse {
 }
 
 var ErrNotFound = &ErrorResponse{Code: 404, Status: "Resource not found."}
+
+var ErrUnauthorized = &ErrorResponse{Code: 401, Status: "Unauthorized."}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/operator/balances"
+)
+
+const (
+	defaultBalanceEpochs = 10
+	maxBalanceEpochs     = 225
+)
+
+type BalanceTracker interface {
+	LastEpoch() (phase0.Epoch, bool)
+	NegativeEpochs() uint64
+	Deltas(from, to phase0.Epoch) ([]*balances.EpochDeltas, error)
+	NegativeValidators(epoch phase0.Epoch) ([]*balances.NegativeValidator, error)
+}
+
+// Balances reports the balances of own validators at the start of each epoch and their
+// changes since the previous epoch, per validator and per committee.
+type Balances struct {
+	Tracker BalanceTracker
+}
+
+type validatorDeltaJSON struct {
+	ValidatorIndex phase0.ValidatorIndex  `json:"validator_index"`
+	Committee      []spectypes.OperatorID `json:"committee"`
+	Balance        phase0.Gwei            `json:"balance"`
+	Delta          int64                  `json:"delta"`
+}
+
+type committeeDeltaJSON struct {
+	Committee  []spectypes.OperatorID `json:"committee"`
+	Validators int                    `json:"validators"`
+	Delta      int64                  `json:"delta"`
+}
+
+type epochDeltasJSON struct {
+	Epoch      phase0.Epoch          `json:"epoch"`
+	Validators []*validatorDeltaJSON `json:"validators"`
+	Committees []*committeeDeltaJSON `json:"committees"`
+}
+
+type negativeValidatorJSON struct {
+	ValidatorIndex phase0.ValidatorIndex  `json:"validator_index"`
+	Committee      []spectypes.OperatorID `json:"committee"`
+	Delta          int64                  `json:"delta"`
+}
+
+func (h *Balances) List(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		// FromEpoch and ToEpoch default to the most recently tracked epochs.
+		FromEpoch uint64          `json:"from_epoch" form:"from_epoch"`
+		ToEpoch   uint64          `json:"to_epoch" form:"to_epoch"`
+		Indices   api.Uint64Slice `json:"indices" form:"indices"`
+	}
+	var response struct {
+		Data []*epochDeltasJSON `json:"data"`
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+
+	response.Data = []*epochDeltasJSON{}
+	lastEpoch, ok := h.Tracker.LastEpoch()
+	if !ok {
+		return api.Render(w, r, response)
+	}
+	to := phase0.Epoch(request.ToEpoch)
+	if to == 0 {
+		to = lastEpoch
+	}
+	from := phase0.Epoch(request.FromEpoch)
+	if from == 0 && to >= defaultBalanceEpochs {
+		from = to - defaultBalanceEpochs + 1
+	}
+	if from > to {
+		return api.BadRequestError(fmt.Errorf("'from_epoch' must be less than or equal to 'to_epoch'"))
+	}
+	if to-from >= maxBalanceEpochs {
+		return api.BadRequestError(fmt.Errorf("at most %d epochs can be requested", maxBalanceEpochs))
+	}
+
+	deltas, err := h.Tracker.Deltas(from, to)
+	if err != nil {
+		return api.Error(err)
+	}
+	for _, epochDeltas := range deltas {
+		// Committees cover all own validators regardless of the requested indices.
+		resp := &epochDeltasJSON{
+			Epoch:      epochDeltas.Epoch,
+			Validators: []*validatorDeltaJSON{},
+			Committees: []*committeeDeltaJSON{},
+		}
+		for _, validator := range epochDeltas.Validators {
+			if len(request.Indices) > 0 && !slices.Contains(request.Indices, uint64(validator.ValidatorIndex)) {
+				continue
+			}
+			resp.Validators = append(resp.Validators, &validatorDeltaJSON{
+				ValidatorIndex: validator.ValidatorIndex,
+				Committee:      validator.Committee,
+				Balance:        validator.Balance,
+				Delta:          validator.Delta,
+			})
+		}
+		for _, committee := range epochDeltas.Committees {
+			resp.Committees = append(resp.Committees, &committeeDeltaJSON{
+				Committee:  committee.Committee,
+				Validators: committee.Validators,
+				Delta:      committee.Delta,
+			})
+		}
+		response.Data = append(response.Data, resp)
+	}
+	return api.Render(w, r, response)
+}
+
+func (h *Balances) Negative(w http.ResponseWriter, r *http.Request) error {
+	var response struct {
+		Data struct {
+			Epoch phase0.Epoch `json:"epoch"`
+			// Epochs is the number of consecutive epochs in which the balance of the validators decreased.
+			Epochs     uint64                   `json:"epochs"`
+			Validators []*negativeValidatorJSON `json:"validators"`
+		} `json:"data"`
+	}
+
+	response.Data.Epochs = h.Tracker.NegativeEpochs()
+	response.Data.Validators = []*negativeValidatorJSON{}
+	lastEpoch, ok := h.Tracker.LastEpoch()
+	if !ok {
+		return api.Render(w, r, response)
+	}
+
+	validators, err := h.Tracker.NegativeValidators(lastEpoch)
+	if err != nil {
+		return api.Error(err)
+	}
+	response.Data.Epoch = lastEpoch
+	for _, validator := range validators {
+		response.Data.Validators = append(response.Data.Validators, &negativeValidatorJSON{
+			ValidatorIndex: validator.ValidatorIndex,
+			Committee:      validator.Committee,
+			Delta:          validator.Delta,
+		})
+	}
+	return api.Render(w, r, response)
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/operator/balances"
+)
+
+type fakeBalanceTracker struct {
+	lastEpoch *phase0.Epoch
+	deltas    []*balances.EpochDeltas
+	negative  []*balances.NegativeValidator
+}
+
+func (t *fakeBalanceTracker) LastEpoch() (phase0.Epoch, bool) {
+	if t.lastEpoch == nil {
+		return 0, false
+	}
+	return *t.lastEpoch, true
+}
+
+func (t *fakeBalanceTracker) NegativeEpochs() uint64 {
+	return 3
+}
+
+func (t *fakeBalanceTracker) Deltas(from, to phase0.Epoch) ([]*balances.EpochDeltas, error) {
+	var deltas []*balances.EpochDeltas
+	for _, epochDeltas := range t.deltas {
+		if epochDeltas.Epoch >= from && epochDeltas.Epoch <= to {
+			deltas = append(deltas, epochDeltas)
+		}
+	}
+	return deltas, nil
+}
+
+func (t *fakeBalanceTracker) NegativeValidators(phase0.Epoch) ([]*balances.NegativeValidator, error) {
+	return t.negative, nil
+}
+
+func TestBalances(t *testing.T) {
+	committee := []spectypes.OperatorID{1, 2, 3, 4}
+	lastEpoch := phase0.Epoch(20)
+	tracker := &fakeBalanceTracker{
+		lastEpoch: &lastEpoch,
+		deltas: []*balances.EpochDeltas{
+			{Epoch: 5},
+			{
+				Epoch: 20,
+				Validators: []*balances.ValidatorDelta{
+					{ValidatorIndex: 1, Committee: committee, Balance: 32_000_000_010, Delta: 10},
+					{ValidatorIndex: 2, Committee: committee, Balance: 31_999_999_995, Delta: -5},
+				},
+				Committees: []*balances.CommitteeDelta{
+					{Committee: committee, Validators: 2, Delta: 5},
+				},
+			},
+		},
+		negative: []*balances.NegativeValidator{
+			{ValidatorIndex: 2, Committee: committee, Epochs: 3, Delta: -15},
+		},
+	}
+	h := &Balances{Tracker: tracker}
+
+	list := func(query string) ([]*epochDeltasJSON, error) {
+		r := httptest.NewRequest(http.MethodGet, "/v1/balances?"+query, nil)
+		w := httptest.NewRecorder()
+		if err := h.List(w, r); err != nil {
+			return nil, err
+		}
+		var response struct {
+			Data []*epochDeltasJSON `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Data, nil
+	}
+
+	t.Run("most recent epochs", func(t *testing.T) {
+		deltas, err := list("")
+		require.NoError(t, err)
+		require.Len(t, deltas, 1)
+		require.Equal(t, phase0.Epoch(20), deltas[0].Epoch)
+		require.Len(t, deltas[0].Validators, 2)
+		require.Equal(t, int64(-5), deltas[0].Validators[1].Delta)
+		require.Equal(t, int64(5), deltas[0].Committees[0].Delta)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		deltas, err := list("from_epoch=1&to_epoch=20&indices=1")
+		require.NoError(t, err)
+		require.Len(t, deltas, 2)
+		require.Empty(t, deltas[0].Validators)
+		require.Len(t, deltas[1].Validators, 1)
+		require.Equal(t, phase0.ValidatorIndex(1), deltas[1].Validators[0].ValidatorIndex)
+		require.Len(t, deltas[1].Committees, 1)
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		for _, query := range []string{"from_epoch=21&to_epoch=20", "from_epoch=1&to_epoch=1000"} {
+			_, err := list(query)
+			var errResponse *api.ErrorResponse
+			require.ErrorAs(t, err, &errResponse)
+			require.Equal(t, http.StatusBadRequest, errResponse.Code)
+		}
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/v1/balances/negative", nil)
+		w := httptest.NewRecorder()
+		require.NoError(t, h.Negative(w, r))
+
+		var response struct {
+			Data struct {
+				Epoch      phase0.Epoch             `json:"epoch"`
+				Epochs     uint64                   `json:"epochs"`
+				Validators []*negativeValidatorJSON `json:"validators"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, phase0.Epoch(20), response.Data.Epoch)
+		require.Equal(t, uint64(3), response.Data.Epochs)
+		require.Len(t, response.Data.Validators, 1)
+		require.Equal(t, int64(-15), response.Data.Validators[0].Delta)
+	})
+
+	t.Run("not tracked yet", func(t *testing.T) {
+		h := &Balances{Tracker: &fakeBalanceTracker{}}
+		r := httptest.NewRequest(http.MethodGet, "/v1/balances", nil)
+		w := httptest.NewRecorder()
+		require.NoError(t, h.List(w, r))
+		require.JSONEq(t, `{"data":[]}`, w.Body.String())
+	})
+}
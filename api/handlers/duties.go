@@ -0,0 +1,384 @@
+package handlers
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/operator/duties/dutystore"
+	"github.com/ssvlabs/ssv/protocol/v2/types"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+const (
+	dutySourceStore      = "store"
+	dutySourceBeaconNode = "beacon_node"
+
+	// maxDutiesEpochs is the widest epoch range a single request may cover.
+	maxDutiesEpochs = 256
+
+	// How far past the current epoch (or sync committee period) beacon nodes provide duties.
+	// Proposers of the next epoch are provisional: the beacon node computes them from the current state,
+	// so they may still change until the epoch starts. The maintenance windows mark them as such.
+	attesterDutiesLookahead      = 1
+	proposerDutiesLookahead      = 1
+	syncCommitteeDutiesLookahead = 1
+)
+
+var dutiesRoles = []spectypes.BeaconRole{
+	spectypes.BNRoleAttester,
+	spectypes.BNRoleProposer,
+	spectypes.BNRoleSyncCommittee,
+	spectypes.BNRoleVoluntaryExit,
+}
+
+type DutiesBeaconNode interface {
+	AttesterDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*eth2apiv1.AttesterDuty, error)
+	ProposerDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*eth2apiv1.ProposerDuty, error)
+	SyncCommitteeDuties(ctx context.Context, epoch phase0.Epoch, indices []phase0.ValidatorIndex) ([]*eth2apiv1.SyncCommitteeDuty, error)
+}
+
+type DutiesShares interface {
+	List(txn basedb.Reader, filters ...registrystorage.SharesFilter) []*types.SSVShare
+}
+
+// Duties previews the duties of own validators. Duties already fetched by the duty scheduler
+// are served from the duty store, and later epochs are looked up through the beacon node
+// as far ahead as it provides them.
+type Duties struct {
+	NetworkConfig networkconfig.NetworkConfig
+	Store         *dutystore.Store
+	BeaconNode    DutiesBeaconNode
+	Shares        DutiesShares
+	OperatorID    func() spectypes.OperatorID
+}
+
+type dutyJSON struct {
+	Role           api.Role               `json:"role"`
+	PubKey         api.Hex                `json:"public_key"`
+	ValidatorIndex phase0.ValidatorIndex  `json:"validator_index"`
+	Committee      []spectypes.OperatorID `json:"committee"`
+	Epoch          phase0.Epoch           `json:"epoch"`
+	Slot           *phase0.Slot           `json:"slot,omitempty"`
+	// SyncCommitteePeriod and EndEpoch are set for sync committee duties, which span the whole period.
+	SyncCommitteePeriod *uint64       `json:"sync_committee_period,omitempty"`
+	EndEpoch            *phase0.Epoch `json:"end_epoch,omitempty"`
+	Source              string        `json:"source"`
+}
+
+func (h *Duties) List(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		// FromEpoch defaults to the current epoch, and ToEpoch to FromEpoch + 1.
+		FromEpoch  uint64          `json:"from_epoch" form:"from_epoch"`
+		ToEpoch    uint64          `json:"to_epoch" form:"to_epoch"`
+		Roles      api.RoleSlice   `json:"roles" form:"roles"`
+		PubKeys    api.HexSlice    `json:"pubkeys" form:"pubkeys"`
+		Indices    api.Uint64Slice `json:"indices" form:"indices"`
+		Committees requestClusters `json:"committees" form:"committees"`
+	}
+	var response struct {
+		Data []*dutyJSON `json:"data"`
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+
+	from := phase0.Epoch(request.FromEpoch)
+	if from == 0 {
+		from = h.NetworkConfig.Beacon.EstimatedCurrentEpoch()
+	}
+	to := phase0.Epoch(request.ToEpoch)
+	if to == 0 {
+		to = from + 1
+	}
+	if from > to {
+		return api.BadRequestError(fmt.Errorf("'from_epoch' must be less than or equal to 'to_epoch'"))
+	}
+	if to-from >= maxDutiesEpochs {
+		return api.BadRequestError(fmt.Errorf("at most %d epochs can be requested at once", maxDutiesEpochs))
+	}
+
+	roles := dutiesRoles
+	if len(request.Roles) > 0 {
+		roles = make([]spectypes.BeaconRole, len(request.Roles))
+		for i, role := range request.Roles {
+			if !slices.Contains(dutiesRoles, spectypes.BeaconRole(role)) {
+				return api.BadRequestError(fmt.Errorf("duties of role %s aren't scheduled ahead", spectypes.BeaconRole(role)))
+			}
+			roles[i] = spectypes.BeaconRole(role)
+		}
+	}
+
+	shares := h.ownShares(request.PubKeys, request.Indices, request.Committees)
+
+	duties, err := h.collect(r.Context(), roles, shares, from, to)
+	if err != nil {
+		return api.Error(err)
+	}
+	response.Data = duties
+	return api.Render(w, r, response)
+}
+
+// collect returns the duties of the roles in the epoch range, sorted by epoch, slot and validator index.
+func (h *Duties) collect(ctx context.Context, roles []spectypes.BeaconRole, shares map[phase0.ValidatorIndex]*types.SSVShare, from, to phase0.Epoch) ([]*dutyJSON, error) {
+	all := []*dutyJSON{}
+	for _, role := range roles {
+		var (
+			duties []*dutyJSON
+			err    error
+		)
+		switch role {
+		case spectypes.BNRoleAttester:
+			duties, err = h.attesterDuties(ctx, shares, from, to)
+		case spectypes.BNRoleProposer:
+			duties, err = h.proposerDuties(ctx, shares, from, to)
+		case spectypes.BNRoleSyncCommittee:
+			duties, err = h.syncCommitteeDuties(ctx, shares, from, to)
+		case spectypes.BNRoleVoluntaryExit:
+			duties = h.voluntaryExitDuties(shares, from, to)
+		}
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, duties...)
+	}
+
+	slices.SortStableFunc(all, func(a, b *dutyJSON) int {
+		return cmp.Or(
+			cmp.Compare(a.Epoch, b.Epoch),
+			cmp.Compare(dutySlot(a), dutySlot(b)),
+			cmp.Compare(a.ValidatorIndex, b.ValidatorIndex),
+		)
+	})
+	return all, nil
+}
+
+// ownShares returns the shares of the operator's active validators which match the filters, by validator index.
+func (h *Duties) ownShares(pubKeys []api.Hex, indices []uint64, committees requestClusters) map[phase0.ValidatorIndex]*types.SSVShare {
+	operatorID := h.OperatorID()
+	filters := []registrystorage.SharesFilter{
+		func(share *types.SSVShare) bool {
+			return share.BelongsToOperator(operatorID) && share.HasBeaconMetadata() && !share.Liquidated
+		},
+	}
+	if len(pubKeys) > 0 {
+		filters = append(filters, byPubKeys(pubKeys))
+	}
+	if len(indices) > 0 {
+		filters = append(filters, byIndices(indices))
+	}
+	if len(committees) > 0 {
+		filters = append(filters, byClusters(committees, false))
+	}
+
+	shares := make(map[phase0.ValidatorIndex]*types.SSVShare)
+	for _, share := range h.Shares.List(nil, filters...) {
+		shares[share.ValidatorIndex] = share
+	}
+	return shares
+}
+
+func (h *Duties) attesterDuties(ctx context.Context, shares map[phase0.ValidatorIndex]*types.SSVShare, from, to phase0.Epoch) ([]*dutyJSON, error) {
+	return epochsDuties(h, spectypes.BNRoleAttester, h.Store.Attester, attesterDutiesLookahead, shares, from, to,
+		func(epoch phase0.Epoch, indices []phase0.ValidatorIndex) ([]slotDuty, error) {
+			duties, err := h.BeaconNode.AttesterDuties(ctx, epoch, indices)
+			if err != nil {
+				return nil, err
+			}
+			result := make([]slotDuty, len(duties))
+			for i, duty := range duties {
+				result[i] = slotDuty{Slot: duty.Slot, ValidatorIndex: duty.ValidatorIndex}
+			}
+			return result, nil
+		})
+}
+
+func (h *Duties) proposerDuties(ctx context.Context, shares map[phase0.ValidatorIndex]*types.SSVShare, from, to phase0.Epoch) ([]*dutyJSON, error) {
+	return epochsDuties(h, spectypes.BNRoleProposer, h.Store.Proposer, proposerDutiesLookahead, shares, from, to,
+		func(epoch phase0.Epoch, indices []phase0.ValidatorIndex) ([]slotDuty, error) {
+			duties, err := h.BeaconNode.ProposerDuties(ctx, epoch, indices)
+			if err != nil {
+				return nil, err
+			}
+			result := make([]slotDuty, len(duties))
+			for i, duty := range duties {
+				result[i] = slotDuty{Slot: duty.Slot, ValidatorIndex: duty.ValidatorIndex}
+			}
+			return result, nil
+		})
+}
+
+// epochsDuties returns the duties of the role in the epoch range, from the store if it has the epoch
+// and otherwise from the beacon node, if the epoch is within its lookahead.
+func epochsDuties[D dutystore.Duty](
+	h *Duties,
+	role spectypes.BeaconRole,
+	store *dutystore.Duties[D],
+	lookahead phase0.Epoch,
+	shares map[phase0.ValidatorIndex]*types.SSVShare,
+	from, to phase0.Epoch,
+	fetch func(epoch phase0.Epoch, indices []phase0.ValidatorIndex) ([]slotDuty, error),
+) ([]*dutyJSON, error) {
+	lastFetchable := h.NetworkConfig.Beacon.EstimatedCurrentEpoch() + lookahead
+
+	var duties []*dutyJSON
+	for epoch := from; epoch <= to; epoch++ {
+		var (
+			epochDuties []slotDuty
+			source      string
+		)
+		switch {
+		case store.IsEpochSet(epoch):
+			epochDuties = storedEpochDuties(h.NetworkConfig, store, epoch)
+			source = dutySourceStore
+		case epoch <= lastFetchable && len(shares) > 0:
+			fetched, err := fetch(epoch, shareIndices(shares))
+			if err != nil {
+				return nil, fmt.Errorf("could not fetch %s duties of epoch %d: %w", role, epoch, err)
+			}
+			epochDuties = fetched
+			source = dutySourceBeaconNode
+		}
+
+		for _, duty := range epochDuties {
+			if share, ok := shares[duty.ValidatorIndex]; ok {
+				duties = append(duties, h.slotDuty(role, share, duty.Slot, source))
+			}
+		}
+	}
+	return duties, nil
+}
+
+func (h *Duties) syncCommitteeDuties(ctx context.Context, shares map[phase0.ValidatorIndex]*types.SSVShare, from, to phase0.Epoch) ([]*dutyJSON, error) {
+	beaconNetwork := h.NetworkConfig.Beacon
+	lastFetchable := beaconNetwork.EstimatedSyncCommitteePeriodAtEpoch(beaconNetwork.EstimatedCurrentEpoch()) + syncCommitteeDutiesLookahead
+
+	var duties []*dutyJSON
+	for period := beaconNetwork.EstimatedSyncCommitteePeriodAtEpoch(from); period <= beaconNetwork.EstimatedSyncCommitteePeriodAtEpoch(to); period++ {
+		var (
+			indices []phase0.ValidatorIndex
+			source  string
+		)
+		switch {
+		case h.Store.SyncCommittee.IsPeriodSet(period):
+			for _, duty := range h.Store.SyncCommittee.CommitteePeriodDuties(period) {
+				indices = append(indices, duty.ValidatorIndex)
+			}
+			source = dutySourceStore
+		case period <= lastFetchable && len(shares) > 0:
+			fetched, err := h.BeaconNode.SyncCommitteeDuties(ctx, beaconNetwork.FirstEpochOfSyncPeriod(period), shareIndices(shares))
+			if err != nil {
+				return nil, fmt.Errorf("could not fetch sync committee duties of period %d: %w", period, err)
+			}
+			for _, duty := range fetched {
+				indices = append(indices, duty.ValidatorIndex)
+			}
+			source = dutySourceBeaconNode
+		}
+
+		firstEpoch := beaconNetwork.FirstEpochOfSyncPeriod(period)
+		lastEpoch := beaconNetwork.FirstEpochOfSyncPeriod(period+1) - 1
+		for _, index := range indices {
+			share, ok := shares[index]
+			if !ok {
+				continue
+			}
+			duties = append(duties, &dutyJSON{
+				Role:                api.Role(spectypes.BNRoleSyncCommittee),
+				PubKey:              share.ValidatorPubKey[:],
+				ValidatorIndex:      share.ValidatorIndex,
+				Committee:           shareCommittee(share),
+				Epoch:               firstEpoch,
+				SyncCommitteePeriod: &period,
+				EndEpoch:            &lastEpoch,
+				Source:              source,
+			})
+		}
+	}
+	return duties, nil
+}
+
+func (h *Duties) voluntaryExitDuties(shares map[phase0.ValidatorIndex]*types.SSVShare, from, to phase0.Epoch) []*dutyJSON {
+	byPubKey := make(map[phase0.BLSPubKey]*types.SSVShare, len(shares))
+	for _, share := range shares {
+		byPubKey[phase0.BLSPubKey(share.ValidatorPubKey)] = share
+	}
+
+	var duties []*dutyJSON
+	for slot := h.NetworkConfig.Beacon.FirstSlotAtEpoch(from); slot < h.NetworkConfig.Beacon.FirstSlotAtEpoch(to+1); slot++ {
+		for _, pubKey := range h.Store.VoluntaryExit.PubKeys(slot) {
+			if share, ok := byPubKey[pubKey]; ok {
+				duties = append(duties, h.slotDuty(spectypes.BNRoleVoluntaryExit, share, slot, dutySourceStore))
+			}
+		}
+	}
+	return duties
+}
+
+func (h *Duties) slotDuty(role spectypes.BeaconRole, share *types.SSVShare, slot phase0.Slot, source string) *dutyJSON {
+	return &dutyJSON{
+		Role:           api.Role(role),
+		PubKey:         share.ValidatorPubKey[:],
+		ValidatorIndex: share.ValidatorIndex,
+		Committee:      shareCommittee(share),
+		Epoch:          h.NetworkConfig.Beacon.EstimatedEpochAtSlot(slot),
+		Slot:           &slot,
+		Source:         source,
+	}
+}
+
+type slotDuty struct {
+	Slot           phase0.Slot
+	ValidatorIndex phase0.ValidatorIndex
+}
+
+// storedEpochDuties returns the stored duties of own validators in the epoch.
+func storedEpochDuties[D dutystore.Duty](networkConfig networkconfig.NetworkConfig, duties *dutystore.Duties[D], epoch phase0.Epoch) []slotDuty {
+	var result []slotDuty
+	firstSlot := networkConfig.Beacon.FirstSlotAtEpoch(epoch)
+	for slot := firstSlot; slot < networkConfig.Beacon.FirstSlotAtEpoch(epoch+1); slot++ {
+		for _, duty := range duties.CommitteeSlotDuties(epoch, slot) {
+			switch duty := any(duty).(type) {
+			case *eth2apiv1.AttesterDuty:
+				result = append(result, slotDuty{Slot: duty.Slot, ValidatorIndex: duty.ValidatorIndex})
+			case *eth2apiv1.ProposerDuty:
+				result = append(result, slotDuty{Slot: duty.Slot, ValidatorIndex: duty.ValidatorIndex})
+			}
+		}
+	}
+	return result
+}
+
+func shareCommittee(share *types.SSVShare) []spectypes.OperatorID {
+	committee := make([]spectypes.OperatorID, len(share.Committee))
+	for i, op := range share.Committee {
+		committee[i] = op.Signer
+	}
+	return committee
+}
+
+func shareIndices(shares map[phase0.ValidatorIndex]*types.SSVShare) []phase0.ValidatorIndex {
+	indices := make([]phase0.ValidatorIndex, 0, len(shares))
+	for index := range shares {
+		indices = append(indices, index)
+	}
+	slices.Sort(indices)
+	return indices
+}
+
+func dutySlot(duty *dutyJSON) phase0.Slot {
+	if duty.Slot == nil {
+		return 0
+	}
+	return *duty.Slot
+}
@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/operator/duties/dutystore"
+	"github.com/ssvlabs/ssv/protocol/v2/types"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+type fakeDutiesShares []*types.SSVShare
+
+func (s fakeDutiesShares) List(_ basedb.Reader, filters ...registrystorage.SharesFilter) []*types.SSVShare {
+	var shares []*types.SSVShare
+	for _, share := range s {
+		matches := true
+		for _, filter := range filters {
+			matches = matches && filter(share)
+		}
+		if matches {
+			shares = append(shares, share)
+		}
+	}
+	return shares
+}
+
+type fakeDutiesBeaconNode struct {
+	attester      map[phase0.Epoch][]*eth2apiv1.AttesterDuty
+	proposer      map[phase0.Epoch][]*eth2apiv1.ProposerDuty
+	syncCommittee map[phase0.Epoch][]*eth2apiv1.SyncCommitteeDuty
+	calls         []string
+}
+
+func (b *fakeDutiesBeaconNode) AttesterDuties(_ context.Context, epoch phase0.Epoch, _ []phase0.ValidatorIndex) ([]*eth2apiv1.AttesterDuty, error) {
+	b.calls = append(b.calls, "attester")
+	return b.attester[epoch], nil
+}
+
+func (b *fakeDutiesBeaconNode) ProposerDuties(_ context.Context, epoch phase0.Epoch, _ []phase0.ValidatorIndex) ([]*eth2apiv1.ProposerDuty, error) {
+	b.calls = append(b.calls, "proposer")
+	return b.proposer[epoch], nil
+}
+
+func (b *fakeDutiesBeaconNode) SyncCommitteeDuties(_ context.Context, epoch phase0.Epoch, _ []phase0.ValidatorIndex) ([]*eth2apiv1.SyncCommitteeDuty, error) {
+	b.calls = append(b.calls, "sync_committee")
+	return b.syncCommittee[epoch], nil
+}
+
+func TestDuties(t *testing.T) {
+	beaconNetwork := networkconfig.TestNetwork.Beacon
+	epoch := beaconNetwork.EstimatedCurrentEpoch()
+	slot := beaconNetwork.FirstSlotAtEpoch(epoch)
+	nextSlot := beaconNetwork.FirstSlotAtEpoch(epoch + 1)
+	period := beaconNetwork.EstimatedSyncCommitteePeriodAtEpoch(epoch)
+
+	own := exitShare(phase0.BLSPubKey{1}, 10, eth2apiv1.ValidatorStateActiveOngoing, 1, 2, 3, 4)
+	otherCommittee := exitShare(phase0.BLSPubKey{2}, 11, eth2apiv1.ValidatorStateActiveOngoing, 1, 5, 6, 7)
+	foreign := exitShare(phase0.BLSPubKey{3}, 12, eth2apiv1.ValidatorStateActiveOngoing, 5, 6, 7, 8)
+
+	store := dutystore.New()
+	store.Attester.Set(epoch, []dutystore.StoreDuty[eth2apiv1.AttesterDuty]{
+		{Slot: slot + 1, ValidatorIndex: 10, Duty: &eth2apiv1.AttesterDuty{Slot: slot + 1, ValidatorIndex: 10}, InCommittee: true},
+		{Slot: slot + 2, ValidatorIndex: 11, Duty: &eth2apiv1.AttesterDuty{Slot: slot + 2, ValidatorIndex: 11}, InCommittee: true},
+	})
+	store.Proposer.Set(epoch, []dutystore.StoreDuty[eth2apiv1.ProposerDuty]{
+		{Slot: slot + 3, ValidatorIndex: 10, Duty: &eth2apiv1.ProposerDuty{Slot: slot + 3, ValidatorIndex: 10}, InCommittee: true},
+		{Slot: slot + 4, ValidatorIndex: 12, Duty: &eth2apiv1.ProposerDuty{Slot: slot + 4, ValidatorIndex: 12}, InCommittee: false},
+	})
+	store.VoluntaryExit.AddDuty(nextSlot+5, phase0.BLSPubKey(otherCommittee.ValidatorPubKey))
+
+	beaconNode := &fakeDutiesBeaconNode{
+		attester: map[phase0.Epoch][]*eth2apiv1.AttesterDuty{
+			epoch + 1: {{Slot: nextSlot + 7, ValidatorIndex: 10}, {Slot: nextSlot + 8, ValidatorIndex: 12}},
+		},
+		proposer: map[phase0.Epoch][]*eth2apiv1.ProposerDuty{
+			epoch + 1: {{Slot: nextSlot + 9, ValidatorIndex: 10}},
+		},
+		syncCommittee: map[phase0.Epoch][]*eth2apiv1.SyncCommitteeDuty{
+			beaconNetwork.FirstEpochOfSyncPeriod(period): {{ValidatorIndex: 11}},
+		},
+	}
+
+	h := &Duties{
+		NetworkConfig: networkconfig.TestNetwork,
+		Store:         store,
+		BeaconNode:    beaconNode,
+		Shares:        fakeDutiesShares{own, otherCommittee, foreign},
+		OperatorID:    func() spectypes.OperatorID { return 1 },
+	}
+
+	list := func(query string) ([]*dutyJSON, error) {
+		r := httptest.NewRequest(http.MethodGet, "/v1/duties?"+query, nil)
+		w := httptest.NewRecorder()
+		if err := h.List(w, r); err != nil {
+			return nil, err
+		}
+		var response struct {
+			Data []*dutyJSON `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Data, nil
+	}
+
+	t.Run("all roles", func(t *testing.T) {
+		beaconNode.calls = nil
+		duties, err := list("")
+		require.NoError(t, err)
+
+		type summary struct {
+			role   spectypes.BeaconRole
+			index  phase0.ValidatorIndex
+			slot   phase0.Slot
+			source string
+		}
+		var got []summary
+		for _, duty := range duties {
+			s := summary{role: spectypes.BeaconRole(duty.Role), index: duty.ValidatorIndex, source: duty.Source}
+			if duty.Slot != nil {
+				s.slot = *duty.Slot
+			}
+			got = append(got, s)
+		}
+		require.Equal(t, []summary{
+			{role: spectypes.BNRoleSyncCommittee, index: 11, source: dutySourceBeaconNode},
+			{role: spectypes.BNRoleAttester, index: 10, slot: slot + 1, source: dutySourceStore},
+			{role: spectypes.BNRoleAttester, index: 11, slot: slot + 2, source: dutySourceStore},
+			{role: spectypes.BNRoleProposer, index: 10, slot: slot + 3, source: dutySourceStore},
+			{role: spectypes.BNRoleVoluntaryExit, index: 11, slot: nextSlot + 5, source: dutySourceStore},
+			{role: spectypes.BNRoleAttester, index: 10, slot: nextSlot + 7, source: dutySourceBeaconNode},
+			{role: spectypes.BNRoleProposer, index: 10, slot: nextSlot + 9, source: dutySourceBeaconNode},
+		}, got)
+		require.ElementsMatch(t, []string{"attester", "proposer", "sync_committee"}, beaconNode.calls)
+
+		require.Equal(t, period, *duties[0].SyncCommitteePeriod)
+		require.Equal(t, beaconNetwork.FirstEpochOfSyncPeriod(period+1)-1, *duties[0].EndEpoch)
+		require.Equal(t, []spectypes.OperatorID{1, 5, 6, 7}, duties[0].Committee)
+
+		// Epochs past the beacon node's lookahead are skipped.
+		beaconNode.calls = nil
+		duties, err = list(fmt.Sprintf("from_epoch=%d&to_epoch=%d&roles=ATTESTER,PROPOSER", epoch+2, epoch+3))
+		require.NoError(t, err)
+		require.Empty(t, duties)
+		require.Empty(t, beaconNode.calls)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		duties, err := list("roles=PROPOSER,ATTESTER&committees=1,2,3,4")
+		require.NoError(t, err)
+		require.Len(t, duties, 4)
+		for _, duty := range duties {
+			require.Equal(t, phase0.ValidatorIndex(10), duty.ValidatorIndex)
+		}
+
+		duties, err = list("indices=11&roles=VOLUNTARY_EXIT")
+		require.NoError(t, err)
+		require.Len(t, duties, 1)
+		require.Equal(t, api.Hex(otherCommittee.ValidatorPubKey[:]), duties[0].PubKey)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		for _, query := range []string{
+			"from_epoch=10&to_epoch=9",
+			"from_epoch=1&to_epoch=1000",
+			"roles=AGGREGATOR",
+		} {
+			_, err := list(query)
+			var errResponse *api.ErrorResponse
+			require.ErrorAs(t, err, &errResponse, query)
+			require.Equal(t, http.StatusBadRequest, errResponse.Code, query)
+		}
+	})
+}
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/networkconfig"
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	"github.com/ssvlabs/ssv/protocol/v2/types"
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+const (
+	exitStatusDryRun           = "dry_run"
+	exitStatusScheduled        = "scheduled"
+	exitStatusAlreadyRequested = "already_requested"
+)
+
+type ExitShares interface {
+	Get(txn basedb.Reader, pubKey []byte) (*types.SSVShare, bool)
+}
+
+type ExitRequests interface {
+	GetExitRequest(r basedb.Reader, pubKey phase0.BLSPubKey) (*operatorstorage.ExitRequest, bool, error)
+	SaveExitRequest(rw basedb.ReadWriter, request *operatorstorage.ExitRequest) error
+}
+
+type ValidatorExiter interface {
+	RequestValidatorExit(pubKey phase0.BLSPubKey, validatorIndex phase0.ValidatorIndex, slot phase0.Slot) error
+}
+
+// Exits starts voluntary exit duties of own validators, the same way a ValidatorExited contract event does.
+// Every operator of the validator's cluster must request the exit with the same epoch,
+// since the signed exit message is for the epoch of the duty.
+type Exits struct {
+	NetworkConfig networkconfig.NetworkConfig
+	Shares        ExitShares
+	Requests      ExitRequests
+	Exiter        ValidatorExiter
+	OperatorID    func() spectypes.OperatorID
+
+	mu sync.Mutex
+}
+
+type exitJSON struct {
+	PubKey         api.Hex               `json:"public_key"`
+	ValidatorIndex phase0.ValidatorIndex `json:"validator_index"`
+	Epoch          phase0.Epoch          `json:"epoch"`
+	Slot           phase0.Slot           `json:"slot"`
+	Message        *phase0.VoluntaryExit `json:"message"`
+	Status         string                `json:"status"`
+	RequestedAt    *time.Time            `json:"requested_at,omitempty"`
+}
+
+func (h *Exits) Exit(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		PubKeys api.HexSlice `json:"pubkeys" form:"pubkeys"`
+		// Epoch of the exit, defaults to the next epoch.
+		Epoch  uint64 `json:"epoch" form:"epoch"`
+		DryRun bool   `json:"dry_run" form:"dry_run"`
+	}
+	var response struct {
+		Data []*exitJSON `json:"data"`
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+	if len(request.PubKeys) == 0 {
+		return api.BadRequestError(fmt.Errorf("at least one validator public key is required"))
+	}
+
+	currentEpoch := h.NetworkConfig.Beacon.EstimatedCurrentEpoch()
+	epoch := phase0.Epoch(request.Epoch)
+	if epoch == 0 {
+		epoch = currentEpoch + 1
+	}
+	if epoch < currentEpoch {
+		return api.BadRequestError(fmt.Errorf("epoch %d has passed, the current epoch is %d", epoch, currentEpoch))
+	}
+	slot := h.NetworkConfig.Beacon.GetEpochFirstSlot(epoch)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// Validate all validators before scheduling any exit.
+	shares := make([]*types.SSVShare, len(request.PubKeys))
+	for i, pk := range request.PubKeys {
+		share, err := h.exitableShare(pk)
+		if err != nil {
+			return api.BadRequestError(err)
+		}
+		shares[i] = share
+	}
+
+	for _, share := range shares {
+		pubKey := phase0.BLSPubKey(share.ValidatorPubKey)
+
+		stored, found, err := h.Requests.GetExitRequest(nil, pubKey)
+		if err != nil {
+			return api.Error(fmt.Errorf("could not get exit request: %w", err))
+		}
+		if found && !stored.Expired(currentEpoch) {
+			response.Data = append(response.Data, exitFromRequest(stored, exitStatusAlreadyRequested))
+			continue
+		}
+
+		exitRequest := &operatorstorage.ExitRequest{
+			PubKey:         pubKey,
+			ValidatorIndex: share.ValidatorIndex,
+			Epoch:          epoch,
+			Slot:           slot,
+			RequestedAt:    time.Now(),
+		}
+		if request.DryRun {
+			exit := exitFromRequest(exitRequest, exitStatusDryRun)
+			exit.RequestedAt = nil
+			response.Data = append(response.Data, exit)
+			continue
+		}
+
+		if err := h.Exiter.RequestValidatorExit(pubKey, share.ValidatorIndex, slot); err != nil {
+			return api.Error(fmt.Errorf("could not schedule exit of %x: %w", pubKey[:], err))
+		}
+		if err := h.Requests.SaveExitRequest(nil, exitRequest); err != nil {
+			return api.Error(fmt.Errorf("could not save exit request of %x: %w", pubKey[:], err))
+		}
+		response.Data = append(response.Data, exitFromRequest(exitRequest, exitStatusScheduled))
+	}
+
+	return api.Render(w, r, response)
+}
+
+func (h *Exits) exitableShare(pk api.Hex) (*types.SSVShare, error) {
+	share, found := h.Shares.Get(nil, pk)
+	if !found {
+		return nil, fmt.Errorf("validator %x not found", []byte(pk))
+	}
+	if !share.BelongsToOperator(h.OperatorID()) {
+		return nil, fmt.Errorf("validator %x doesn't belong to this operator", []byte(pk))
+	}
+	if share.Liquidated {
+		return nil, fmt.Errorf("validator %x belongs to a liquidated cluster", []byte(pk))
+	}
+	if !share.HasBeaconMetadata() {
+		return nil, fmt.Errorf("validator %x has no beacon metadata yet", []byte(pk))
+	}
+	if share.Exiting() {
+		return nil, fmt.Errorf("validator %x is already exiting", []byte(pk))
+	}
+	return share, nil
+}
+
+func exitFromRequest(request *operatorstorage.ExitRequest, status string) *exitJSON {
+	requestedAt := request.RequestedAt
+	return &exitJSON{
+		PubKey:         request.PubKey[:],
+		ValidatorIndex: request.ValidatorIndex,
+		Epoch:          request.Epoch,
+		Slot:           request.Slot,
+		Message: &phase0.VoluntaryExit{
+			Epoch:          request.Epoch,
+			ValidatorIndex: request.ValidatorIndex,
+		},
+		Status:      status,
+		RequestedAt: &requestedAt,
+	}
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/networkconfig"
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	"github.com/ssvlabs/ssv/protocol/v2/types"
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+type fakeExitShares map[phase0.BLSPubKey]*types.SSVShare
+
+func (s fakeExitShares) Get(_ basedb.Reader, pubKey []byte) (*types.SSVShare, bool) {
+	share, ok := s[phase0.BLSPubKey(pubKey)]
+	return share, ok
+}
+
+type fakeExitRequests map[phase0.BLSPubKey]*operatorstorage.ExitRequest
+
+func (s fakeExitRequests) GetExitRequest(_ basedb.Reader, pubKey phase0.BLSPubKey) (*operatorstorage.ExitRequest, bool, error) {
+	request, ok := s[pubKey]
+	return request, ok, nil
+}
+
+func (s fakeExitRequests) SaveExitRequest(_ basedb.ReadWriter, request *operatorstorage.ExitRequest) error {
+	s[request.PubKey] = request
+	return nil
+}
+
+type fakeExiter struct {
+	exits map[phase0.BLSPubKey]phase0.Slot
+}
+
+func (e *fakeExiter) RequestValidatorExit(pubKey phase0.BLSPubKey, _ phase0.ValidatorIndex, slot phase0.Slot) error {
+	e.exits[pubKey] = slot
+	return nil
+}
+
+func exitShare(pubKey phase0.BLSPubKey, index phase0.ValidatorIndex, status eth2apiv1.ValidatorState, operatorIDs ...uint64) *types.SSVShare {
+	share := mockShare(operatorIDs...)
+	share.ValidatorPubKey = spectypes.ValidatorPK(pubKey)
+	share.ValidatorIndex = index
+	share.Status = status
+	return share
+}
+
+func TestExits(t *testing.T) {
+	active := phase0.BLSPubKey{1}
+	exited := phase0.BLSPubKey{2}
+	foreign := phase0.BLSPubKey{3}
+	unknown := phase0.BLSPubKey{4}
+
+	shares := fakeExitShares{
+		active:  exitShare(active, 10, eth2apiv1.ValidatorStateActiveOngoing, 1, 2, 3, 4),
+		exited:  exitShare(exited, 11, eth2apiv1.ValidatorStateExitedUnslashed, 1, 2, 3, 4),
+		foreign: exitShare(foreign, 12, eth2apiv1.ValidatorStateActiveOngoing, 5, 6, 7, 8),
+	}
+	requests := fakeExitRequests{}
+	exiter := &fakeExiter{exits: map[phase0.BLSPubKey]phase0.Slot{}}
+	h := &Exits{
+		NetworkConfig: networkconfig.TestNetwork,
+		Shares:        shares,
+		Requests:      requests,
+		Exiter:        exiter,
+		OperatorID:    func() spectypes.OperatorID { return 1 },
+	}
+
+	epoch := networkconfig.TestNetwork.Beacon.EstimatedCurrentEpoch() + 2
+	slot := networkconfig.TestNetwork.Beacon.GetEpochFirstSlot(epoch)
+
+	exit := func(pubKey phase0.BLSPubKey, epoch phase0.Epoch, dryRun bool) ([]*exitJSON, error) {
+		body, err := json.Marshal(map[string]any{
+			"pubkeys": []string{hex.EncodeToString(pubKey[:])},
+			"epoch":   uint64(epoch),
+			"dry_run": dryRun,
+		})
+		require.NoError(t, err)
+		r := httptest.NewRequest(http.MethodPost, "/v1/validators/exit", bytes.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		if err := h.Exit(w, r); err != nil {
+			return nil, err
+		}
+		var response struct {
+			Data []*exitJSON `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Data, nil
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		exits, err := exit(active, epoch, true)
+		require.NoError(t, err)
+		require.Len(t, exits, 1)
+		require.Equal(t, exitStatusDryRun, exits[0].Status)
+		require.Equal(t, phase0.ValidatorIndex(10), exits[0].Message.ValidatorIndex)
+		require.Equal(t, epoch, exits[0].Message.Epoch)
+		require.Equal(t, slot, exits[0].Slot)
+		require.Empty(t, exiter.exits)
+		require.Empty(t, requests)
+	})
+
+	t.Run("scheduled", func(t *testing.T) {
+		exits, err := exit(active, epoch, false)
+		require.NoError(t, err)
+		require.Len(t, exits, 1)
+		require.Equal(t, exitStatusScheduled, exits[0].Status)
+		require.Equal(t, slot, exiter.exits[active])
+		require.Contains(t, requests, active)
+	})
+
+	t.Run("already requested", func(t *testing.T) {
+		delete(exiter.exits, active)
+		exits, err := exit(active, epoch+1, false)
+		require.NoError(t, err)
+		require.Len(t, exits, 1)
+		require.Equal(t, exitStatusAlreadyRequested, exits[0].Status)
+		require.Equal(t, epoch, exits[0].Epoch)
+		require.Empty(t, exiter.exits)
+	})
+
+	t.Run("failed request is retried", func(t *testing.T) {
+		t.Cleanup(func() { delete(exiter.exits, active) })
+		currentEpoch := networkconfig.TestNetwork.Beacon.EstimatedCurrentEpoch()
+		requests[active].Epoch = currentEpoch - operatorstorage.ExitRequestRetryEpochs - 1
+		exits, err := exit(active, epoch+1, false)
+		require.NoError(t, err)
+		require.Len(t, exits, 1)
+		require.Equal(t, exitStatusScheduled, exits[0].Status)
+		require.Equal(t, epoch+1, exits[0].Epoch)
+		require.Equal(t, networkconfig.TestNetwork.Beacon.GetEpochFirstSlot(epoch+1), exiter.exits[active])
+		require.Equal(t, epoch+1, requests[active].Epoch)
+	})
+
+	t.Run("invalid validators", func(t *testing.T) {
+		for _, pubKey := range []phase0.BLSPubKey{exited, foreign, unknown} {
+			_, err := exit(pubKey, epoch, false)
+			var errResponse *api.ErrorResponse
+			require.ErrorAs(t, err, &errResponse)
+			require.Equal(t, http.StatusBadRequest, errResponse.Code)
+		}
+		require.Empty(t, exiter.exits)
+	})
+
+	t.Run("past epoch", func(t *testing.T) {
+		_, err := exit(phase0.BLSPubKey{5}, 1, false)
+		var errResponse *api.ErrorResponse
+		require.ErrorAs(t, err, &errResponse)
+		require.Equal(t, http.StatusBadRequest, errResponse.Code)
+	})
+}
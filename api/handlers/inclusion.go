@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/operator/inclusion"
+)
+
+type InclusionTracker interface {
+	Outcomes(from, to phase0.Epoch) []*inclusion.EpochOutcome
+}
+
+// Inclusion reports whether the duties of own validators made it on chain,
+// for the most recent epochs tracked since the node started.
+type Inclusion struct {
+	Tracker InclusionTracker
+}
+
+type inclusionSummaryJSON struct {
+	Attestations struct {
+		Expected      int `json:"expected"`
+		Included      int `json:"included"`
+		CorrectSource int `json:"correct_source"`
+		CorrectTarget int `json:"correct_target"`
+		CorrectHead   int `json:"correct_head"`
+		// AverageInclusionDelay is in slots, over the included attestations.
+		AverageInclusionDelay float64 `json:"average_inclusion_delay"`
+	} `json:"attestations"`
+	Proposals struct {
+		Expected  int `json:"expected"`
+		Canonical int `json:"canonical"`
+	} `json:"proposals"`
+	SyncCommittee struct {
+		Participated int `json:"participated"`
+		Missed       int `json:"missed"`
+	} `json:"sync_committee"`
+}
+
+type attestationOutcomeJSON struct {
+	ValidatorIndex phase0.ValidatorIndex `json:"validator_index"`
+	Slot           phase0.Slot           `json:"slot"`
+	Included       bool                  `json:"included"`
+	InclusionSlot  *phase0.Slot          `json:"inclusion_slot,omitempty"`
+	InclusionDelay *phase0.Slot          `json:"inclusion_delay,omitempty"`
+	CorrectSource  bool                  `json:"correct_source"`
+	CorrectTarget  bool                  `json:"correct_target"`
+	CorrectHead    bool                  `json:"correct_head"`
+}
+
+type proposalOutcomeJSON struct {
+	ValidatorIndex phase0.ValidatorIndex `json:"validator_index"`
+	Slot           phase0.Slot           `json:"slot"`
+	Canonical      bool                  `json:"canonical"`
+}
+
+type syncCommitteeOutcomeJSON struct {
+	ValidatorIndex phase0.ValidatorIndex `json:"validator_index"`
+	Participated   int                   `json:"participated"`
+	Missed         int                   `json:"missed"`
+}
+
+type epochOutcomeJSON struct {
+	Epoch         phase0.Epoch                `json:"epoch"`
+	Summary       inclusionSummaryJSON        `json:"summary"`
+	Attestations  []*attestationOutcomeJSON   `json:"attestations"`
+	Proposals     []*proposalOutcomeJSON      `json:"proposals"`
+	SyncCommittee []*syncCommitteeOutcomeJSON `json:"sync_committee"`
+}
+
+func (h *Inclusion) List(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		// FromEpoch and ToEpoch default to all tracked epochs.
+		FromEpoch uint64          `json:"from_epoch" form:"from_epoch"`
+		ToEpoch   uint64          `json:"to_epoch" form:"to_epoch"`
+		Indices   api.Uint64Slice `json:"indices" form:"indices"`
+	}
+	var response struct {
+		Data []*epochOutcomeJSON `json:"data"`
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+
+	from := phase0.Epoch(request.FromEpoch)
+	to := phase0.Epoch(request.ToEpoch)
+	if to == 0 {
+		to = math.MaxUint64
+	}
+	if from > to {
+		return api.BadRequestError(fmt.Errorf("'from_epoch' must be less than or equal to 'to_epoch'"))
+	}
+
+	selected := func(index phase0.ValidatorIndex) bool {
+		return len(request.Indices) == 0 || slices.Contains(request.Indices, uint64(index))
+	}
+
+	response.Data = []*epochOutcomeJSON{}
+	for _, outcome := range h.Tracker.Outcomes(from, to) {
+		filtered := &inclusion.EpochOutcome{Epoch: outcome.Epoch}
+		for _, attestation := range outcome.Attestations {
+			if selected(attestation.ValidatorIndex) {
+				filtered.Attestations = append(filtered.Attestations, attestation)
+			}
+		}
+		for _, proposal := range outcome.Proposals {
+			if selected(proposal.ValidatorIndex) {
+				filtered.Proposals = append(filtered.Proposals, proposal)
+			}
+		}
+		for _, syncCommittee := range outcome.SyncCommittee {
+			if selected(syncCommittee.ValidatorIndex) {
+				filtered.SyncCommittee = append(filtered.SyncCommittee, syncCommittee)
+			}
+		}
+		response.Data = append(response.Data, epochOutcomeToJSON(filtered))
+	}
+	return api.Render(w, r, response)
+}
+
+func epochOutcomeToJSON(outcome *inclusion.EpochOutcome) *epochOutcomeJSON {
+	summary := outcome.Summary()
+	resp := &epochOutcomeJSON{
+		Epoch:         outcome.Epoch,
+		Attestations:  []*attestationOutcomeJSON{},
+		Proposals:     []*proposalOutcomeJSON{},
+		SyncCommittee: []*syncCommitteeOutcomeJSON{},
+	}
+
+	resp.Summary.Attestations.Expected = summary.Attestations.Expected
+	resp.Summary.Attestations.Included = summary.Attestations.Included
+	resp.Summary.Attestations.CorrectSource = summary.Attestations.CorrectSource
+	resp.Summary.Attestations.CorrectTarget = summary.Attestations.CorrectTarget
+	resp.Summary.Attestations.CorrectHead = summary.Attestations.CorrectHead
+	if summary.Attestations.Included > 0 {
+		resp.Summary.Attestations.AverageInclusionDelay = float64(summary.Attestations.TotalInclusionDelay) / float64(summary.Attestations.Included)
+	}
+	resp.Summary.Proposals.Expected = summary.Proposals.Expected
+	resp.Summary.Proposals.Canonical = summary.Proposals.Canonical
+	resp.Summary.SyncCommittee.Participated = summary.SyncCommittee.Participated
+	resp.Summary.SyncCommittee.Missed = summary.SyncCommittee.Missed
+
+	for _, attestation := range outcome.Attestations {
+		attestationJSON := &attestationOutcomeJSON{
+			ValidatorIndex: attestation.ValidatorIndex,
+			Slot:           attestation.Slot,
+			Included:       attestation.Included,
+			CorrectSource:  attestation.CorrectSource,
+			CorrectTarget:  attestation.CorrectTarget,
+			CorrectHead:    attestation.CorrectHead,
+		}
+		if attestation.Included {
+			inclusionSlot, inclusionDelay := attestation.InclusionSlot, attestation.InclusionDelay()
+			attestationJSON.InclusionSlot = &inclusionSlot
+			attestationJSON.InclusionDelay = &inclusionDelay
+		}
+		resp.Attestations = append(resp.Attestations, attestationJSON)
+	}
+	for _, proposal := range outcome.Proposals {
+		resp.Proposals = append(resp.Proposals, &proposalOutcomeJSON{
+			ValidatorIndex: proposal.ValidatorIndex,
+			Slot:           proposal.Slot,
+			Canonical:      proposal.Canonical,
+		})
+	}
+	for _, syncCommittee := range outcome.SyncCommittee {
+		resp.SyncCommittee = append(resp.SyncCommittee, &syncCommitteeOutcomeJSON{
+			ValidatorIndex: syncCommittee.ValidatorIndex,
+			Participated:   syncCommittee.Participated,
+			Missed:         syncCommittee.Missed,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/operator/inclusion"
+)
+
+type fakeInclusionTracker []*inclusion.EpochOutcome
+
+func (t fakeInclusionTracker) Outcomes(from, to phase0.Epoch) []*inclusion.EpochOutcome {
+	var outcomes []*inclusion.EpochOutcome
+	for _, outcome := range t {
+		if outcome.Epoch >= from && outcome.Epoch <= to {
+			outcomes = append(outcomes, outcome)
+		}
+	}
+	return outcomes
+}
+
+func TestInclusion(t *testing.T) {
+	h := &Inclusion{
+		Tracker: fakeInclusionTracker{
+			{
+				Epoch: 10,
+				Attestations: []*inclusion.AttestationOutcome{
+					{ValidatorIndex: 1, Slot: 320, Included: true, InclusionSlot: 321, CorrectSource: true, CorrectTarget: true, CorrectHead: true},
+					{ValidatorIndex: 2, Slot: 322, Included: true, InclusionSlot: 325, CorrectSource: true, CorrectTarget: true},
+					{ValidatorIndex: 3, Slot: 323},
+				},
+				Proposals: []*inclusion.ProposalOutcome{
+					{ValidatorIndex: 2, Slot: 330, Canonical: true},
+				},
+				SyncCommittee: []*inclusion.SyncCommitteeOutcome{
+					{ValidatorIndex: 3, Participated: 30, Missed: 2},
+				},
+			},
+			{Epoch: 11},
+		},
+	}
+
+	list := func(query string) ([]*epochOutcomeJSON, error) {
+		r := httptest.NewRequest(http.MethodGet, "/v1/inclusion?"+query, nil)
+		w := httptest.NewRecorder()
+		if err := h.List(w, r); err != nil {
+			return nil, err
+		}
+		var response struct {
+			Data []*epochOutcomeJSON `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Data, nil
+	}
+
+	t.Run("all epochs", func(t *testing.T) {
+		outcomes, err := list("")
+		require.NoError(t, err)
+		require.Len(t, outcomes, 2)
+
+		summary := outcomes[0].Summary
+		require.Equal(t, 3, summary.Attestations.Expected)
+		require.Equal(t, 2, summary.Attestations.Included)
+		require.Equal(t, 1, summary.Attestations.CorrectHead)
+		require.Equal(t, 2.0, summary.Attestations.AverageInclusionDelay)
+		require.Equal(t, 1, summary.Proposals.Canonical)
+		require.Equal(t, 30, summary.SyncCommittee.Participated)
+
+		require.Equal(t, phase0.Slot(3), *outcomes[0].Attestations[1].InclusionDelay)
+		require.Nil(t, outcomes[0].Attestations[2].InclusionSlot)
+
+		require.Equal(t, phase0.Epoch(11), outcomes[1].Epoch)
+		require.Empty(t, outcomes[1].Attestations)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		outcomes, err := list("from_epoch=10&to_epoch=10&indices=2")
+		require.NoError(t, err)
+		require.Len(t, outcomes, 1)
+		require.Len(t, outcomes[0].Attestations, 1)
+		require.Equal(t, phase0.ValidatorIndex(2), outcomes[0].Attestations[0].ValidatorIndex)
+		require.Len(t, outcomes[0].Proposals, 1)
+		require.Empty(t, outcomes[0].SyncCommittee)
+		require.Equal(t, 3.0, outcomes[0].Summary.Attestations.AverageInclusionDelay)
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		_, err := list("from_epoch=11&to_epoch=10")
+		var errResponse *api.ErrorResponse
+		require.ErrorAs(t, err, &errResponse)
+		require.Equal(t, http.StatusBadRequest, errResponse.Code)
+	})
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/operator/maintenance"
+)
+
+const defaultMaintenanceWindows = 3
+
+// Maintenance finds windows in which the node can be restarted without missing proposals of own validators.
+// Proposers are only known until the end of the epoch after the current one, which bounds the windows,
+// and those of the next epoch may still change, so windows reaching into it are marked provisional.
+type Maintenance struct {
+	Duties *Duties
+}
+
+type maintenanceWindowJSON struct {
+	StartSlot           phase0.Slot `json:"start_slot"`
+	EndSlot             phase0.Slot `json:"end_slot"`
+	StartTime           time.Time   `json:"start_time"`
+	EndTime             time.Time   `json:"end_time"`
+	AttestationDuties   int         `json:"attestation_duties"`
+	SyncCommitteeDuties int         `json:"sync_committee_duties"`
+	Provisional         bool        `json:"provisional"`
+}
+
+func (h *Maintenance) Windows(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		// Duration is the minimum length of the window, such as "5m".
+		Duration string `json:"duration" form:"duration"`
+		Limit    uint64 `json:"limit" form:"limit"`
+	}
+	var response struct {
+		Data struct {
+			// HorizonSlot is the first slot past the known proposers.
+			HorizonSlot phase0.Slot `json:"horizon_slot"`
+			// ProvisionalSlot is the first slot whose proposers may still change.
+			ProvisionalSlot phase0.Slot              `json:"provisional_slot"`
+			Next            *maintenanceWindowJSON   `json:"next"`
+			Windows         []*maintenanceWindowJSON `json:"windows"`
+		} `json:"data"`
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+	duration, err := time.ParseDuration(request.Duration)
+	if err != nil || duration <= 0 {
+		return api.BadRequestError(fmt.Errorf("a positive duration such as \"5m\" is required"))
+	}
+	limit := int(request.Limit)
+	if limit == 0 {
+		limit = defaultMaintenanceWindows
+	}
+
+	beaconNetwork := h.Duties.NetworkConfig.Beacon
+	slotDuration := beaconNetwork.SlotDurationSec()
+	length := int((duration + slotDuration - 1) / slotDuration)
+
+	// Windows start with the next slot and end before the first slot with unknown proposers.
+	epoch := beaconNetwork.EstimatedCurrentEpoch()
+	lastEpoch := epoch + proposerDutiesLookahead
+	firstSlot := beaconNetwork.EstimatedCurrentSlot() + 1
+	horizonSlot := beaconNetwork.FirstSlotAtEpoch(lastEpoch + 1)
+	provisionalSlot := beaconNetwork.FirstSlotAtEpoch(epoch + 1)
+
+	roles := []spectypes.BeaconRole{spectypes.BNRoleAttester, spectypes.BNRoleProposer, spectypes.BNRoleSyncCommittee}
+	duties, err := h.Duties.collect(r.Context(), roles, h.Duties.ownShares(nil, nil, nil), epoch, lastEpoch)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	slots := make([]maintenance.SlotDuties, 0, horizonSlot-firstSlot)
+	for slot := firstSlot; slot < horizonSlot; slot++ {
+		slots = append(slots, maintenance.SlotDuties{Slot: slot})
+	}
+	for _, duty := range duties {
+		switch spectypes.BeaconRole(duty.Role) {
+		case spectypes.BNRoleAttester, spectypes.BNRoleProposer:
+			if *duty.Slot < firstSlot {
+				continue
+			}
+			slotDuties := &slots[*duty.Slot-firstSlot]
+			if spectypes.BeaconRole(duty.Role) == spectypes.BNRoleProposer {
+				slotDuties.Proposals++
+			} else {
+				slotDuties.Attestations++
+			}
+		case spectypes.BNRoleSyncCommittee:
+			// Sync committee members have a duty in every slot of the period.
+			for i := range slots {
+				slotEpoch := beaconNetwork.EstimatedEpochAtSlot(slots[i].Slot)
+				if slotEpoch >= duty.Epoch && slotEpoch <= *duty.EndEpoch {
+					slots[i].SyncCommittee++
+				}
+			}
+		}
+	}
+
+	windowJSON := func(window maintenance.Window) *maintenanceWindowJSON {
+		return &maintenanceWindowJSON{
+			StartSlot:           window.StartSlot,
+			EndSlot:             window.EndSlot,
+			StartTime:           beaconNetwork.GetSlotStartTime(window.StartSlot).UTC(),
+			EndTime:             beaconNetwork.GetSlotStartTime(window.EndSlot).UTC(),
+			AttestationDuties:   window.Attestations,
+			SyncCommitteeDuties: window.SyncCommittee,
+			Provisional:         window.EndSlot > provisionalSlot,
+		}
+	}
+
+	response.Data.HorizonSlot = horizonSlot
+	response.Data.ProvisionalSlot = provisionalSlot
+	if next, ok := maintenance.NextWindow(slots, length); ok {
+		response.Data.Next = windowJSON(next)
+	}
+	response.Data.Windows = []*maintenanceWindowJSON{}
+	for _, window := range maintenance.FindWindows(slots, length, limit) {
+		response.Data.Windows = append(response.Data.Windows, windowJSON(window))
+	}
+	return api.Render(w, r, response)
+}
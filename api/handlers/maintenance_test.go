@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/operator/duties/dutystore"
+)
+
+func TestMaintenanceWindows(t *testing.T) {
+	beaconNetwork := networkconfig.TestNetwork.Beacon
+	epoch := beaconNetwork.EstimatedCurrentEpoch()
+	nextSlot := beaconNetwork.FirstSlotAtEpoch(epoch + 1)
+	proposalSlot := nextSlot + 10
+
+	store := dutystore.New()
+	store.Proposer.Set(epoch, nil)
+	store.Proposer.Set(epoch+1, []dutystore.StoreDuty[eth2apiv1.ProposerDuty]{
+		{Slot: proposalSlot, ValidatorIndex: 10, Duty: &eth2apiv1.ProposerDuty{Slot: proposalSlot, ValidatorIndex: 10}, InCommittee: true},
+	})
+	// Attestations in every slot except for two.
+	for e := epoch; e <= epoch+1; e++ {
+		var attesterDuties []dutystore.StoreDuty[eth2apiv1.AttesterDuty]
+		for slot := beaconNetwork.FirstSlotAtEpoch(e); slot < beaconNetwork.FirstSlotAtEpoch(e+1); slot++ {
+			if slot >= nextSlot+20 && slot < nextSlot+22 {
+				continue
+			}
+			attesterDuties = append(attesterDuties, dutystore.StoreDuty[eth2apiv1.AttesterDuty]{
+				Slot: slot, ValidatorIndex: 10, Duty: &eth2apiv1.AttesterDuty{Slot: slot, ValidatorIndex: 10}, InCommittee: true,
+			})
+		}
+		store.Attester.Set(e, attesterDuties)
+	}
+	store.SyncCommittee.Set(beaconNetwork.EstimatedSyncCommitteePeriodAtEpoch(epoch), []dutystore.StoreSyncCommitteeDuty{
+		{ValidatorIndex: 10, Duty: &eth2apiv1.SyncCommitteeDuty{ValidatorIndex: 10}, InCommittee: true},
+	})
+
+	h := &Maintenance{
+		Duties: &Duties{
+			NetworkConfig: networkconfig.TestNetwork,
+			Store:         store,
+			BeaconNode:    &fakeDutiesBeaconNode{},
+			Shares:        fakeDutiesShares{exitShare(phase0.BLSPubKey{1}, 10, eth2apiv1.ValidatorStateActiveOngoing, 1, 2, 3, 4)},
+			OperatorID:    func() spectypes.OperatorID { return 1 },
+		},
+	}
+
+	windows := func(query string) (phase0.Slot, *maintenanceWindowJSON, []*maintenanceWindowJSON, error) {
+		r := httptest.NewRequest(http.MethodGet, "/v1/maintenance/windows?"+query, nil)
+		w := httptest.NewRecorder()
+		if err := h.Windows(w, r); err != nil {
+			return 0, nil, nil, err
+		}
+		var response struct {
+			Data struct {
+				HorizonSlot phase0.Slot              `json:"horizon_slot"`
+				Next        *maintenanceWindowJSON   `json:"next"`
+				Windows     []*maintenanceWindowJSON `json:"windows"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Data.HorizonSlot, response.Data.Next, response.Data.Windows, nil
+	}
+
+	t.Run("windows avoid proposals", func(t *testing.T) {
+		duration := 2 * beaconNetwork.SlotDurationSec()
+		horizon, next, ranked, err := windows("duration=" + duration.String() + "&limit=5")
+		require.NoError(t, err)
+		require.Equal(t, beaconNetwork.FirstSlotAtEpoch(epoch+2), horizon)
+
+		require.NotNil(t, next)
+		require.Equal(t, beaconNetwork.EstimatedCurrentSlot()+1, next.StartSlot)
+		require.Len(t, ranked, 5)
+		require.Equal(t, nextSlot+20, ranked[0].StartSlot)
+		require.Equal(t, nextSlot+22, ranked[0].EndSlot)
+		require.Zero(t, ranked[0].AttestationDuties)
+		require.Equal(t, beaconNetwork.GetSlotStartTime(nextSlot+22).UTC(), ranked[0].EndTime)
+		require.True(t, ranked[0].Provisional)
+
+		for _, window := range append(ranked, next) {
+			require.Equal(t, phase0.Slot(2), window.EndSlot-window.StartSlot)
+			require.False(t, proposalSlot >= window.StartSlot && proposalSlot < window.EndSlot)
+			// Proposers of the next epoch may still change.
+			require.Equal(t, window.EndSlot > nextSlot, window.Provisional)
+		}
+	})
+
+	t.Run("window longer than the horizon", func(t *testing.T) {
+		_, next, ranked, err := windows("duration=24h")
+		require.NoError(t, err)
+		require.Nil(t, next)
+		require.Empty(t, ranked)
+	})
+
+	t.Run("invalid duration", func(t *testing.T) {
+		for _, query := range []string{"", "duration=soon", "duration=-1m"} {
+			_, _, _, err := windows(query)
+			var errResponse *api.ErrorResponse
+			require.ErrorAs(t, err, &errResponse, query)
+			require.Equal(t, http.StatusBadRequest, errResponse.Code, query)
+		}
+	})
+}
@@ -11,7 +11,10 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/beacon/goclient"
 	networkpeers "github.com/ssvlabs/ssv/network/peers"
+	"github.com/ssvlabs/ssv/network/peers/connections"
+	"github.com/ssvlabs/ssv/network/topics"
 	"github.com/ssvlabs/ssv/nodeprobe"
 )
 
@@ -24,6 +27,27 @@ type TopicIndex interface {
 	PeersByTopic() map[string][]peer.ID
 }
 
+// PeerAccess manages the peers allow and deny lists at runtime.
+type PeerAccess interface {
+	AccessList() *connections.AccessList
+	DisconnectDeniedPeers() int
+}
+
+// ConfigReloader re-reads the node config and applies the changes of the fields which can be changed at runtime.
+type ConfigReloader interface {
+	ReloadConfig() (applied []string, err error)
+}
+
+// PeerScores provides the gossipsub score breakdown of peers.
+type PeerScores interface {
+	PeerScore(id peer.ID) (*topics.PeerScoreBreakdown, bool)
+}
+
+// BeaconNodeScores provides the health scores of the beacon nodes.
+type BeaconNodeScores interface {
+	BeaconNodeScores() []goclient.NodeScore
+}
+
 type AllPeersAndTopicsJSON struct {
 	AllPeers     []peer.ID        `json:"all_peers"`
 	PeersByTopic []topicIndexJSON `json:"peers_by_topic"`
@@ -40,12 +64,13 @@ type connectionJSON struct {
 }
 
 type peerJSON struct {
-	ID            peer.ID          `json:"id"`
-	Addresses     []string         `json:"addresses"`
-	Connections   []connectionJSON `json:"connections"`
-	Connectedness string           `json:"connectedness"`
-	Subnets       string           `json:"subnets"`
-	Version       string           `json:"version"`
+	ID            peer.ID                    `json:"id"`
+	Addresses     []string                   `json:"addresses"`
+	Connections   []connectionJSON           `json:"connections"`
+	Connectedness string                     `json:"connectedness"`
+	Subnets       string                     `json:"subnets"`
+	Version       string                     `json:"version"`
+	Score         *topics.PeerScoreBreakdown `json:"score,omitempty"`
 }
 
 type identityJSON struct {
@@ -55,6 +80,11 @@ type identityJSON struct {
 	Version   string   `json:"version"`
 }
 
+type peerAccessJSON struct {
+	Allow []string `json:"allow"`
+	Deny  []string `json:"deny"`
+}
+
 type healthStatus struct {
 	err error
 }
@@ -72,10 +102,11 @@ type healthCheckJSON struct {
 	ExecutionNode healthStatus `json:"execution_node"`
 	EventSyncer   healthStatus `json:"event_syncer"`
 	Advanced      struct {
-		Peers           int      `json:"peers"`
-		InboundConns    int      `json:"inbound_conns"`
-		OutboundConns   int      `json:"outbound_conns"`
-		ListenAddresses []string `json:"p2p_listen_addresses"`
+		Peers           int                  `json:"peers"`
+		InboundConns    int                  `json:"inbound_conns"`
+		OutboundConns   int                  `json:"outbound_conns"`
+		ListenAddresses []string             `json:"p2p_listen_addresses"`
+		BeaconNodes     []goclient.NodeScore `json:"beacon_nodes,omitempty"`
 	} `json:"advanced"`
 }
 
@@ -91,8 +122,12 @@ type Node struct {
 	ListenAddresses []string
 	PeersIndex      networkpeers.Index
 	TopicIndex      TopicIndex
+	PeerAccess      PeerAccess
+	PeerScores      PeerScores
 	Network         network.Network
 	NodeProber      *nodeprobe.Prober
+	ConfigReloader  ConfigReloader
+	BeaconNodes     BeaconNodeScores
 }
 
 func (h *Node) Identity(w http.ResponseWriter, r *http.Request) error {
@@ -128,6 +163,44 @@ func (h *Node) Topics(w http.ResponseWriter, r *http.Request) error {
 	return api.Render(w, r, resp)
 }
 
+func (h *Node) PeerAccessList(w http.ResponseWriter, r *http.Request) error {
+	allow, deny := h.PeerAccess.AccessList().Rules()
+	return api.Render(w, r, peerAccessJSON{Allow: allow, Deny: deny})
+}
+
+func (h *Node) UpdatePeerAccessList(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		Allow  []string `json:"allow"`
+		Deny   []string `json:"deny"`
+		Remove []string `json:"remove"`
+	}
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+
+	accessList := h.PeerAccess.AccessList()
+	if err := accessList.Update(request.Allow, request.Deny, request.Remove); err != nil {
+		return api.BadRequestError(err)
+	}
+	h.PeerAccess.DisconnectDeniedPeers()
+
+	allow, deny := accessList.Rules()
+	return api.Render(w, r, peerAccessJSON{Allow: allow, Deny: deny})
+}
+
+func (h *Node) ReloadConfig(w http.ResponseWriter, r *http.Request) error {
+	applied, err := h.ConfigReloader.ReloadConfig()
+	if err != nil {
+		return api.BadRequestError(err)
+	}
+	if applied == nil {
+		applied = []string{}
+	}
+	return api.Render(w, r, struct {
+		Applied []string `json:"applied"`
+	}{Applied: applied})
+}
+
 func (h *Node) Health(w http.ResponseWriter, r *http.Request) error {
 	ctx := context.Background()
 	var resp healthCheckJSON
@@ -163,6 +236,9 @@ func (h *Node) Health(w http.ResponseWriter, r *http.Request) error {
 	resp.BeaconNode = healthStatus{h.NodeProber.CheckBeaconNodeHealth(ctx)}
 	resp.ExecutionNode = healthStatus{h.NodeProber.CheckExecutionNodeHealth(ctx)}
 	resp.EventSyncer = healthStatus{h.NodeProber.CheckEventSyncerHealth(ctx)}
+	if h.BeaconNodes != nil {
+		resp.Advanced.BeaconNodes = h.BeaconNodes.BeaconNodeScores()
+	}
 
 	return api.Render(w, r, resp)
 }
@@ -188,6 +264,12 @@ func (h *Node) peers(peers []peer.ID) []peerJSON {
 			})
 		}
 
+		if h.PeerScores != nil {
+			if score, ok := h.PeerScores.PeerScore(id); ok {
+				resp[i].Score = score
+			}
+		}
+
 		nodeInfo := h.PeersIndex.NodeInfo(id)
 		if nodeInfo == nil {
 			continue
@@ -156,11 +156,21 @@ func (c *requestClusters) Bind(value string) error {
 	return nil
 }
 
+const (
+	// exitReasonExit is a voluntary exit or an execution layer withdrawal request (EIP-7002),
+	// which can't be told apart on the beacon chain.
+	exitReasonExit = "exit"
+	// exitReasonConsolidation is a consolidation into another validator (EIP-7251).
+	exitReasonConsolidation = "consolidation"
+)
+
 type validatorJSON struct {
 	PubKey          api.Hex                `json:"public_key"`
 	Index           phase0.ValidatorIndex  `json:"index"`
 	Status          string                 `json:"status"`
 	ActivationEpoch phase0.Epoch           `json:"activation_epoch"`
+	ExitEpoch       *phase0.Epoch          `json:"exit_epoch,omitempty"`
+	ExitReason      string                 `json:"exit_reason,omitempty"`
 	Owner           api.Hex                `json:"owner"`
 	Committee       []spectypes.OperatorID `json:"committee"`
 	Quorum          uint64                 `json:"quorum"`
@@ -188,5 +198,13 @@ func validatorFromShare(share *types.SSVShare) *validatorJSON {
 		v.Status = share.Status.String()
 		v.ActivationEpoch = share.ActivationEpoch
 	}
+	if share.ExitInitiated() {
+		exitEpoch := share.ExitEpoch
+		v.ExitEpoch = &exitEpoch
+		v.ExitReason = exitReasonExit
+		if share.Consolidating {
+			v.ExitReason = exitReasonConsolidation
+		}
+	}
 	return v
 }
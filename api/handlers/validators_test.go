@@ -1,8 +1,14 @@
 package handlers
 
 import (
+	"encoding/json"
+	"math"
 	"testing"
 
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
 	spectypes "github.com/ssvlabs/ssv-spec/types"
 	"github.com/ssvlabs/ssv/protocol/v2/types"
 )
@@ -92,3 +98,52 @@ func TestByClusters(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatorFromShare_Exit(t *testing.T) {
+	testCases := []struct {
+		name           string
+		share          *types.SSVShare
+		expectedEpoch  *phase0.Epoch
+		expectedReason string
+		expectedJSON   string
+	}{
+		{
+			name:  "Not Exiting",
+			share: &types.SSVShare{Status: eth2apiv1.ValidatorStateActiveOngoing, ExitEpoch: phase0.Epoch(math.MaxUint64)},
+		},
+		{
+			name:  "Unknown Exit Epoch",
+			share: &types.SSVShare{Status: eth2apiv1.ValidatorStateActiveOngoing},
+		},
+		{
+			name:           "Exit",
+			share:          &types.SSVShare{Status: eth2apiv1.ValidatorStateActiveExiting, ExitEpoch: 300},
+			expectedEpoch:  func() *phase0.Epoch { e := phase0.Epoch(300); return &e }(),
+			expectedReason: exitReasonExit,
+			expectedJSON:   `"exit_epoch":"300","exit_reason":"exit"`,
+		},
+		{
+			name:           "Consolidation",
+			share:          &types.SSVShare{Status: eth2apiv1.ValidatorStateActiveExiting, ExitEpoch: 300, Consolidating: true},
+			expectedEpoch:  func() *phase0.Epoch { e := phase0.Epoch(300); return &e }(),
+			expectedReason: exitReasonConsolidation,
+			expectedJSON:   `"exit_epoch":"300","exit_reason":"consolidation"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v := validatorFromShare(tc.share)
+			require.Equal(t, tc.expectedEpoch, v.ExitEpoch)
+			require.Equal(t, tc.expectedReason, v.ExitReason)
+
+			encoded, err := json.Marshal(v)
+			require.NoError(t, err)
+			if tc.expectedJSON == "" {
+				require.NotContains(t, string(encoded), "exit_")
+			} else {
+				require.Contains(t, string(encoded), tc.expectedJSON)
+			}
+		})
+	}
+}
@@ -1,12 +1,15 @@
 package server
 
 import (
+	"crypto/subtle"
 	"net/http"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
 	"go.uber.org/zap"
 
 	"github.com/ssvlabs/ssv/api"
@@ -17,25 +20,44 @@ import (
 type Server struct {
 	logger *zap.Logger
 	addr   string
+	// authToken is the bearer token required by the endpoints which act on the node, they are disabled if it's empty.
+	authToken string
 
-	node       *handlers.Node
-	validators *handlers.Validators
-	exporter   *handlers.Exporter
+	node        *handlers.Node
+	validators  *handlers.Validators
+	exporter    *handlers.Exporter
+	exits       *handlers.Exits
+	duties      *handlers.Duties
+	maintenance *handlers.Maintenance
+	inclusion   *handlers.Inclusion
+	balances    *handlers.Balances
 }
 
 func New(
 	logger *zap.Logger,
 	addr string,
+	authToken string,
 	node *handlers.Node,
 	validators *handlers.Validators,
 	exporter *handlers.Exporter,
+	exits *handlers.Exits,
+	duties *handlers.Duties,
+	maintenance *handlers.Maintenance,
+	inclusion *handlers.Inclusion,
+	balances *handlers.Balances,
 ) *Server {
 	return &Server{
-		logger:     logger,
-		addr:       addr,
-		node:       node,
-		validators: validators,
-		exporter:   exporter,
+		logger:      logger,
+		addr:        addr,
+		authToken:   authToken,
+		node:        node,
+		validators:  validators,
+		exporter:    exporter,
+		exits:       exits,
+		duties:      duties,
+		maintenance: maintenance,
+		inclusion:   inclusion,
+		balances:    balances,
 	}
 }
 
@@ -49,12 +71,21 @@ func (s *Server) Run() error {
 
 	router.Get("/v1/node/identity", api.Handler(s.node.Identity))
 	router.Get("/v1/node/peers", api.Handler(s.node.Peers))
+	router.Get("/v1/node/peers/access", api.Handler(s.node.PeerAccessList))
+	router.With(middlewareAuth(s.authToken)).Post("/v1/node/peers/access", api.Handler(s.node.UpdatePeerAccessList))
 	router.Get("/v1/node/topics", api.Handler(s.node.Topics))
 	router.Get("/v1/node/health", api.Handler(s.node.Health))
 	router.Get("/v1/validators", api.Handler(s.validators.List))
+	router.Get("/v1/duties", api.Handler(s.duties.List))
+	router.Get("/v1/maintenance/windows", api.Handler(s.maintenance.Windows))
+	router.Get("/v1/inclusion", api.Handler(s.inclusion.List))
+	router.Get("/v1/balances", api.Handler(s.balances.List))
+	router.Get("/v1/balances/negative", api.Handler(s.balances.Negative))
 	// We kept both GET and POST methods to ensure compatibility and avoid breaking changes for clients that may rely on either method
 	router.Get("/v1/exporter/decideds", api.Handler(s.exporter.Decideds))
 	router.Post("/v1/exporter/decideds", api.Handler(s.exporter.Decideds))
+	router.With(middlewareAuth(s.authToken)).Post("/v1/node/config/reload", api.Handler(s.node.ReloadConfig))
+	router.With(middlewareAuth(s.authToken)).Post("/v1/validators/exit", api.Handler(s.exits.Exit))
 
 	s.logger.Info("Serving SSV API", zap.String("addr", s.addr))
 
@@ -90,6 +121,25 @@ func middlewareLogger(logger *zap.Logger) func(next http.Handler) http.Handler {
 	}
 }
 
+// middlewareAuth requires the given bearer token, rejecting all requests if it's empty.
+func middlewareAuth(token string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token == "" {
+				err := &api.ErrorResponse{Code: http.StatusForbidden, Status: http.StatusText(http.StatusForbidden), Message: "endpoint requires SSVAPIToken to be configured"}
+				_ = render.Render(w, r, err)
+				return
+			}
+			got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+				_ = render.Render(w, r, api.ErrUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 func middlewareNodeVersion(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("X-SSV-Node-Version", commons.GetNodeVersion())
@@ -303,6 +303,7 @@ func (gc *GoClient) fetchWeightedAttestationData(ctx context.Context,
 	})
 
 	recordRequestDuration(ctx, "AttestationData", addr, http.MethodGet, time.Since(attDataReqStart), err)
+	gc.health.recordRequest(addr, time.Since(attDataReqStart), err)
 
 	if err != nil {
 		logger.Error(clResponseErrMsg, zap.Error(err))
@@ -0,0 +1,75 @@
+package goclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"go.uber.org/zap"
+)
+
+// SignedBeaconBlock returns the canonical block of the given slot, or nil if the slot has no block.
+func (gc *GoClient) SignedBeaconBlock(ctx context.Context, slot phase0.Slot) (*spec.VersionedSignedBeaconBlock, error) {
+	start := time.Now()
+	resp, err := gc.multiClient.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+		Block: fmt.Sprint(slot),
+	})
+	recordRequestDuration(gc.ctx, "SignedBeaconBlock", gc.multiClient.Address(), http.MethodGet, time.Since(start), err)
+
+	var apiErr *api.Error
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		gc.log.Error(clResponseErrMsg,
+			zap.String("api", "SignedBeaconBlock"),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to obtain signed beacon block: %w", err)
+	}
+	if resp == nil {
+		gc.log.Error(clNilResponseErrMsg,
+			zap.String("api", "SignedBeaconBlock"),
+		)
+		return nil, fmt.Errorf("signed beacon block response is nil")
+	}
+	if resp.Data == nil {
+		gc.log.Error(clNilResponseDataErrMsg,
+			zap.String("api", "SignedBeaconBlock"),
+		)
+		return nil, fmt.Errorf("signed beacon block data is nil")
+	}
+
+	return resp.Data, nil
+}
+
+// BeaconCommittees returns the beacon committees of all slots of the given epoch.
+func (gc *GoClient) BeaconCommittees(ctx context.Context, epoch phase0.Epoch) ([]*apiv1.BeaconCommittee, error) {
+	start := time.Now()
+	resp, err := gc.multiClient.BeaconCommittees(ctx, &api.BeaconCommitteesOpts{
+		State: "head",
+		Epoch: &epoch,
+	})
+	recordRequestDuration(gc.ctx, "BeaconCommittees", gc.multiClient.Address(), http.MethodGet, time.Since(start), err)
+	if err != nil {
+		gc.log.Error(clResponseErrMsg,
+			zap.String("api", "BeaconCommittees"),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to obtain beacon committees: %w", err)
+	}
+	if resp == nil {
+		gc.log.Error(clNilResponseErrMsg,
+			zap.String("api", "BeaconCommittees"),
+		)
+		return nil, fmt.Errorf("beacon committees response is nil")
+	}
+
+	return resp.Data, nil
+}
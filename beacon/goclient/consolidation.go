@@ -0,0 +1,98 @@
+package goclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"go.uber.org/zap"
+)
+
+// pendingConsolidationsPath is the Electra endpoint of the pending consolidations of a state,
+// which go-eth2-client doesn't support yet.
+const pendingConsolidationsPath = "/eth/v1/beacon/states/head/pending_consolidations"
+
+// PendingConsolidations returns the consolidations (EIP-7251) of the head state. The source validator
+// of a pending consolidation is exiting, and its balance moves to the target validator once it's withdrawable.
+// There are none before Electra. The consolidations are fetched once per epoch and mustn't be modified.
+func (gc *GoClient) PendingConsolidations(ctx context.Context) ([]*electra.PendingConsolidation, error) {
+	gc.ForkLock.RLock()
+	electraEpoch := gc.ForkEpochElectra
+	gc.ForkLock.RUnlock()
+	epoch := gc.network.EstimatedCurrentEpoch()
+	if epoch < electraEpoch {
+		return nil, nil
+	}
+
+	gc.pendingConsolidationsMu.Lock()
+	defer gc.pendingConsolidationsMu.Unlock()
+	if gc.pendingConsolidationsFetched && gc.pendingConsolidationsEpoch == epoch {
+		return gc.pendingConsolidations, nil
+	}
+
+	var errs error
+	for i, client := range gc.clients {
+		start := time.Now()
+		consolidations, err := gc.pendingConsolidationsFrom(ctx, gc.httpClients[i], gc.addresses[i])
+		recordRequestDuration(gc.ctx, "PendingConsolidations", client.Address(), http.MethodGet, time.Since(start), err)
+		gc.health.recordRequest(client.Address(), time.Since(start), err)
+		if err == nil {
+			gc.pendingConsolidations = consolidations
+			gc.pendingConsolidationsEpoch = epoch
+			gc.pendingConsolidationsFetched = true
+			return consolidations, nil
+		}
+		gc.log.Error(clResponseErrMsg,
+			zap.String("address", client.Address()),
+			zap.String("api", "PendingConsolidations"),
+			zap.Error(err),
+		)
+		errs = errors.Join(errs, err)
+	}
+	return nil, fmt.Errorf("failed to obtain pending consolidations: %w", errs)
+}
+
+func (gc *GoClient) pendingConsolidationsFrom(ctx context.Context, httpClient *http.Client, address string) ([]*electra.PendingConsolidation, error) {
+	if !strings.HasPrefix(address, "http") {
+		address = fmt.Sprintf("http://%s", address)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, gc.longTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(address, "/")+pendingConsolidationsPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, body)
+	}
+
+	var response struct {
+		Data []*electra.PendingConsolidation `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+	return response.Data, nil
+}
@@ -0,0 +1,100 @@
+package goclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/stretchr/testify/require"
+)
+
+func createPendingConsolidationsServer(t *testing.T, status int, requests *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if responseBody, ok := beaconEndpointResponses[r.URL.Path]; ok {
+			_, _ = w.Write(responseBody)
+			return
+		}
+		if r.URL.Path != pendingConsolidationsPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		requests.Add(1)
+		w.WriteHeader(status)
+		if status != http.StatusOK {
+			_, _ = w.Write([]byte(`{"code": 500, "message": "internal error"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{
+			"version": "electra",
+			"execution_optimistic": false,
+			"finalized": false,
+			"data": [
+				{"source_index": "1", "target_index": "2"},
+				{"source_index": "5", "target_index": "2"}
+			]
+		}`))
+	}))
+}
+
+func TestGoClient_PendingConsolidations(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var failingRequests, healthyRequests atomic.Int32
+	failing := createPendingConsolidationsServer(t, http.StatusInternalServerError, &failingRequests)
+	defer failing.Close()
+	healthy := createPendingConsolidationsServer(t, http.StatusOK, &healthyRequests)
+	defer healthy.Close()
+
+	client := createSyncCommitteeClient(t, ctx, failing, healthy)
+
+	t.Run("before electra", func(t *testing.T) {
+		client.ForkLock.Lock()
+		client.ForkEpochElectra = FarFutureEpoch
+		client.ForkLock.Unlock()
+
+		consolidations, err := client.PendingConsolidations(ctx)
+		require.NoError(t, err)
+		require.Empty(t, consolidations)
+		require.Zero(t, failingRequests.Load())
+		require.Zero(t, healthyRequests.Load())
+	})
+
+	t.Run("falls back to next client", func(t *testing.T) {
+		client.ForkLock.Lock()
+		client.ForkEpochElectra = 0
+		client.ForkLock.Unlock()
+
+		consolidations, err := client.PendingConsolidations(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []*electra.PendingConsolidation{
+			{SourceIndex: 1, TargetIndex: 2},
+			{SourceIndex: 5, TargetIndex: 2},
+		}, consolidations)
+		require.EqualValues(t, 1, failingRequests.Load())
+		require.EqualValues(t, 1, healthyRequests.Load())
+	})
+
+	t.Run("cached for the epoch", func(t *testing.T) {
+		consolidations, err := client.PendingConsolidations(ctx)
+		require.NoError(t, err)
+		require.Len(t, consolidations, 2)
+		require.EqualValues(t, 1, failingRequests.Load())
+		require.EqualValues(t, 1, healthyRequests.Load())
+	})
+
+	t.Run("all clients fail", func(t *testing.T) {
+		client := createSyncCommitteeClient(t, ctx, failing)
+		client.ForkLock.Lock()
+		client.ForkEpochElectra = 0
+		client.ForkLock.Unlock()
+
+		_, err := client.PendingConsolidations(ctx)
+		require.ErrorContains(t, err, "failed to obtain pending consolidations")
+	})
+}
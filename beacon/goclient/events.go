@@ -14,7 +14,7 @@ import (
 )
 
 type event interface {
-	*apiv1.HeadEvent
+	*apiv1.HeadEvent | *apiv1.ChainReorgEvent | *apiv1.FinalizedCheckpointEvent
 }
 
 type subscriber[T event] struct {
@@ -23,32 +23,73 @@ type subscriber[T event] struct {
 }
 
 func (gc *GoClient) SubscribeToHeadEvents(ctx context.Context, subscriberIdentifier string, ch chan<- *apiv1.HeadEvent) error {
-	logger := gc.log.With(zap.String("subscriber_identifier", subscriberIdentifier))
+	return subscribe(gc, &gc.headEventSubscribers, EventTopicHead, "HeadEventTopic", subscriberIdentifier, ch)
+}
 
-	if !slices.Contains(gc.supportedTopics, EventTopicHead) {
-		logger.Warn("the list of supported topics did not contain 'HeadEventTopic', cannot add new subscriber")
-		return fmt.Errorf("the list of supported topics did not contain 'HeadEventTopic', cannot add new subscriber")
-	}
+// SubscribeToChainReorgEvents subscribes to the reorgs reported by the beacon nodes.
+func (gc *GoClient) SubscribeToChainReorgEvents(ctx context.Context, subscriberIdentifier string, ch chan<- *apiv1.ChainReorgEvent) error {
+	return subscribe(gc, &gc.chainReorgEventSubscribers, EventTopicChainReorg, "ChainReorgEventTopic", subscriberIdentifier, ch)
+}
 
-	logger.Info("adding 'head' event subscriber")
+// SubscribeToFinalizedCheckpointEvents subscribes to the finalized checkpoints reported by the beacon nodes.
+// Subscribers receive every finalized checkpoint once, in increasing epoch order.
+//
+// Finality drives the pruning of the duty store and, on exporters, of the participant stores.
+// Doppelganger protection and event sync don't subscribe: doppelganger protection decides by the liveness of
+// validators over a fixed number of epochs, and event sync follows the execution layer by block number,
+// to which a finalized checkpoint doesn't map without an extra lookup, so neither uses finality yet.
+// Slashing protection keeps only the highest attestation and proposal of each validator, so it has nothing to prune.
+func (gc *GoClient) SubscribeToFinalizedCheckpointEvents(ctx context.Context, subscriberIdentifier string, ch chan<- *apiv1.FinalizedCheckpointEvent) error {
+	return subscribe(gc, &gc.finalizedCheckpointEventSubscribers, EventTopicFinalizedCheckpoint, "FinalizedCheckpointEventTopic", subscriberIdentifier, ch)
+}
 
-	headEventSubscriber := subscriber[*apiv1.HeadEvent]{
-		Identifier: subscriberIdentifier,
-		Channel:    ch,
+// FinalizedCheckpoint returns the latest finalized checkpoint reported by the beacon nodes, if any was reported yet.
+func (gc *GoClient) FinalizedCheckpoint() (phase0.Checkpoint, bool) {
+	gc.finalizedCheckpointLock.RLock()
+	defer gc.finalizedCheckpointLock.RUnlock()
+
+	return gc.finalizedCheckpoint, gc.finalizedCheckpoint.Root != phase0.Root{}
+}
+
+func subscribe[T event](gc *GoClient, subscribers *[]subscriber[T], topic EventTopic, topicName, subscriberIdentifier string, ch chan<- T) error {
+	logger := gc.log.With(zap.String("subscriber_identifier", subscriberIdentifier))
+
+	if !slices.Contains(gc.supportedTopics, topic) {
+		logger.Warn(fmt.Sprintf("the list of supported topics did not contain '%s', cannot add new subscriber", topicName))
+		return fmt.Errorf("the list of supported topics did not contain '%s', cannot add new subscriber", topicName)
 	}
 
+	logger.Info(fmt.Sprintf("adding '%s' event subscriber", topic))
+
 	gc.subscribersLock.Lock()
 	defer gc.subscribersLock.Unlock()
 
-	gc.headEventSubscribers = append(gc.headEventSubscribers, headEventSubscriber)
+	*subscribers = append(*subscribers, subscriber[T]{
+		Identifier: subscriberIdentifier,
+		Channel:    ch,
+	})
 
 	logger.
-		With(zap.Int("head_event_subscribers_len", len(gc.headEventSubscribers))).
-		Info("subscribed to head events")
+		With(zap.Int(fmt.Sprintf("%s_event_subscribers_len", topic), len(*subscribers))).
+		Info(fmt.Sprintf("subscribed to %s events", topic))
 
 	return nil
 }
 
+// broadcast must be called with gc.subscribersLock held.
+func broadcast[T event](logger *zap.Logger, subscribers []subscriber[T], data T) {
+	for _, sub := range subscribers {
+		logger := logger.With(zap.String("subscriber_identifier", sub.Identifier))
+
+		select {
+		case sub.Channel <- data:
+			logger.Info("event broadcasted")
+		default:
+			logger.Warn("subscriber channel full, dropping the message")
+		}
+	}
+}
+
 func (gc *GoClient) startEventListener(ctx context.Context) error {
 	if len(gc.supportedTopics) == 0 {
 		gc.log.Warn("the list of supported topics was empty, won't launch event listener")
@@ -83,7 +124,8 @@ func (gc *GoClient) startEventListener(ctx context.Context) error {
 	logger.Info("subscribing to events")
 	if gc.withWeightedAttestationData {
 		for _, client := range gc.clients {
-			if err := client.Events(ctx, strTopics, gc.eventHandler); err != nil {
+			gc.health.trackEvents(client.Address())
+			if err := client.Events(ctx, strTopics, gc.clientEventHandler(client.Address(), gc.eventHandler)); err != nil {
 				logger.Error(clResponseErrMsg, zap.String("api", "Events"), zap.Error(err))
 				return err
 			}
@@ -93,6 +135,17 @@ func (gc *GoClient) startEventListener(ctx context.Context) error {
 			logger.Error(clResponseErrMsg, zap.String("api", "Events"), zap.Error(err))
 			return err
 		}
+
+		// Head events from every node are needed to score the nodes by their head lag and missed events.
+		if len(gc.clients) > 1 {
+			for _, client := range gc.clients {
+				gc.health.trackEvents(client.Address())
+				if err := client.Events(ctx, []string{string(EventTopicHead)}, gc.clientEventHandler(client.Address(), nil)); err != nil {
+					logger.Error(clResponseErrMsg, zap.String("api", "Events"), zap.Error(err))
+					return err
+				}
+			}
+		}
 	}
 
 	logger.Debug("subscribed to events")
@@ -100,6 +153,20 @@ func (gc *GoClient) startEventListener(ctx context.Context) error {
 	return nil
 }
 
+// clientEventHandler records the head events of the node for its health score and passes the events on to next, if set.
+func (gc *GoClient) clientEventHandler(addr string, next func(e *apiv1.Event)) func(e *apiv1.Event) {
+	return func(e *apiv1.Event) {
+		if e != nil && EventTopic(e.Topic) == EventTopicHead {
+			if head, ok := e.Data.(*apiv1.HeadEvent); ok && head != nil {
+				gc.health.recordHead(addr, head.Slot)
+			}
+		}
+		if next != nil {
+			next(e)
+		}
+	}
+}
+
 func (gc *GoClient) eventHandler(e *apiv1.Event) {
 	if e == nil {
 		gc.log.Warn("event was nil, skipping")
@@ -137,16 +204,7 @@ func (gc *GoClient) eventHandler(e *apiv1.Event) {
 
 		gc.subscribersLock.RLock()
 		defer gc.subscribersLock.RUnlock()
-		for _, sub := range gc.headEventSubscribers {
-			logger = logger.With(zap.String("subscriber_identifier", sub.Identifier))
-
-			select {
-			case sub.Channel <- eventData:
-				logger.Info("event broadcasted")
-			default:
-				logger.Warn("subscriber channel full, dropping the message")
-			}
-		}
+		broadcast(logger, gc.headEventSubscribers, eventData)
 	case EventTopicBlock:
 		eventData, ok := e.Data.(*apiv1.BlockEvent)
 		if !ok {
@@ -162,6 +220,58 @@ func (gc *GoClient) eventHandler(e *apiv1.Event) {
 				With(fields.BlockRoot(eventData.Block)).
 				Info("block root to slot cache updated")
 		}
+	case EventTopicChainReorg:
+		eventData, ok := e.Data.(*apiv1.ChainReorgEvent)
+		if !ok {
+			logger.Warn("could not type assert")
+			return
+		}
+
+		logger = logger.With(
+			fields.Slot(eventData.Slot),
+			zap.Uint64("depth", eventData.Depth),
+			zap.String("old_head_block", eventData.OldHeadBlock.String()),
+			zap.String("new_head_block", eventData.NewHeadBlock.String()),
+		)
+
+		// With multiple beacon nodes, the same reorg is usually reported by several of them.
+		gc.lastChainReorgLock.Lock()
+		if gc.lastChainReorg.Slot == eventData.Slot && gc.lastChainReorg.NewHeadBlock == eventData.NewHeadBlock {
+			gc.lastChainReorgLock.Unlock()
+			logger.Debug("chain reorg was already processed")
+			return
+		}
+		gc.lastChainReorg = *eventData
+		gc.lastChainReorgLock.Unlock()
+
+		logger.Info("chain reorg reported")
+
+		gc.subscribersLock.RLock()
+		defer gc.subscribersLock.RUnlock()
+		broadcast(logger, gc.chainReorgEventSubscribers, eventData)
+	case EventTopicFinalizedCheckpoint:
+		eventData, ok := e.Data.(*apiv1.FinalizedCheckpointEvent)
+		if !ok {
+			logger.Warn("could not type assert")
+			return
+		}
+
+		logger = logger.With(fields.Epoch(eventData.Epoch), fields.BlockRoot(eventData.Block))
+
+		gc.finalizedCheckpointLock.Lock()
+		if eventData.Epoch <= gc.finalizedCheckpoint.Epoch && gc.finalizedCheckpoint.Root != (phase0.Root{}) {
+			gc.finalizedCheckpointLock.Unlock()
+			logger.Debug("finalized checkpoint is not newer than the last processed one")
+			return
+		}
+		gc.finalizedCheckpoint = phase0.Checkpoint{Epoch: eventData.Epoch, Root: eventData.Block}
+		gc.finalizedCheckpointLock.Unlock()
+
+		logger.Info("finalized checkpoint updated")
+
+		gc.subscribersLock.RLock()
+		defer gc.subscribersLock.RUnlock()
+		broadcast(logger, gc.finalizedCheckpointEventSubscribers, eventData)
 	default:
 		gc.log.
 			With(zap.String("topic", e.Topic)).
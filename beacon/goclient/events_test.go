@@ -9,6 +9,7 @@ import (
 	"time"
 
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ssvlabs/ssv-spec/types"
 	"github.com/ssvlabs/ssv/beacon/goclient/tests"
 	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
@@ -41,9 +42,11 @@ func TestSubscribeToHeadEvents(t *testing.T) {
 		for {
 			select {
 			case <-eventsEndpointSubscribedCh:
-				assert.Len(t, subscribedTopics, 2)
+				assert.Len(t, subscribedTopics, 4)
 				assert.Contains(t, subscribedTopics, "block")
 				assert.Contains(t, subscribedTopics, "head")
+				assert.Contains(t, subscribedTopics, "chain_reorg")
+				assert.Contains(t, subscribedTopics, "finalized_checkpoint")
 				return
 			case <-time.After(time.Second * 5):
 				t.Fatalf("timed out waiting for events endpoint to be subscribed")
@@ -91,3 +94,47 @@ func eventsTestClient(t *testing.T, serverURL string) *GoClient {
 	require.NoError(t, err)
 	return server
 }
+
+func TestChainReorgAndFinalizedCheckpointEvents(t *testing.T) {
+	client := &GoClient{
+		log:             zap.NewNop(),
+		supportedTopics: []EventTopic{EventTopicHead, EventTopicBlock, EventTopicChainReorg, EventTopicFinalizedCheckpoint},
+	}
+
+	t.Run("Should broadcast each chain reorg once", func(t *testing.T) {
+		ch := make(chan *apiv1.ChainReorgEvent, 4)
+		require.NoError(t, client.SubscribeToChainReorgEvents(context.Background(), "test_caller", ch))
+
+		reorg := &apiv1.ChainReorgEvent{Slot: 100, Depth: 2, NewHeadBlock: phase0.Root{1}}
+		client.eventHandler(&apiv1.Event{Topic: string(EventTopicChainReorg), Data: reorg})
+		client.eventHandler(&apiv1.Event{Topic: string(EventTopicChainReorg), Data: reorg})
+		client.eventHandler(&apiv1.Event{Topic: string(EventTopicChainReorg), Data: &apiv1.ChainReorgEvent{Slot: 100, Depth: 1, NewHeadBlock: phase0.Root{2}}})
+
+		require.Len(t, ch, 2)
+		require.Equal(t, reorg, <-ch)
+		require.Equal(t, phase0.Root{2}, (<-ch).NewHeadBlock)
+	})
+
+	t.Run("Should broadcast only newer finalized checkpoints", func(t *testing.T) {
+		_, ok := client.FinalizedCheckpoint()
+		require.False(t, ok)
+
+		ch := make(chan *apiv1.FinalizedCheckpointEvent, 4)
+		require.NoError(t, client.SubscribeToFinalizedCheckpointEvents(context.Background(), "test_caller", ch))
+
+		for _, epoch := range []phase0.Epoch{10, 10, 9, 11} {
+			client.eventHandler(&apiv1.Event{
+				Topic: string(EventTopicFinalizedCheckpoint),
+				Data:  &apiv1.FinalizedCheckpointEvent{Epoch: epoch, Block: phase0.Root{byte(epoch)}},
+			})
+		}
+
+		require.Len(t, ch, 2)
+		require.Equal(t, phase0.Epoch(10), (<-ch).Epoch)
+		require.Equal(t, phase0.Epoch(11), (<-ch).Epoch)
+
+		checkpoint, ok := client.FinalizedCheckpoint()
+		require.True(t, ok)
+		require.Equal(t, phase0.Checkpoint{Epoch: 11, Root: phase0.Root{11}}, checkpoint)
+	})
+}
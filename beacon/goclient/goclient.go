@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"net"
+	"net/http"
 	"strings"
 	"sync"
 	"time"
@@ -14,6 +16,7 @@ import (
 	eth2clienthttp "github.com/attestantio/go-eth2-client/http"
 	eth2clientmulti "github.com/attestantio/go-eth2-client/multi"
 	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/electra"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/jellydator/ttlcache/v3"
 	"github.com/pkg/errors"
@@ -108,6 +111,9 @@ type MultiClient interface {
 	eth2client.ValidatorRegistrationsSubmitter
 	eth2client.VoluntaryExitSubmitter
 	eth2client.ValidatorLivenessProvider
+	eth2client.SignedBeaconBlockProvider
+	eth2client.BeaconCommitteesProvider
+	eth2client.ValidatorBalancesProvider
 }
 
 type operatorDataStore interface {
@@ -117,17 +123,26 @@ type operatorDataStore interface {
 type EventTopic string
 
 const (
-	EventTopicHead  EventTopic = "head"
-	EventTopicBlock EventTopic = "block"
+	EventTopicHead                EventTopic = "head"
+	EventTopicBlock               EventTopic = "block"
+	EventTopicChainReorg          EventTopic = "chain_reorg"
+	EventTopicFinalizedCheckpoint EventTopic = "finalized_checkpoint"
 )
 
 // GoClient implementing Beacon struct
 type GoClient struct {
-	log         *zap.Logger
-	ctx         context.Context
-	network     beaconprotocol.Network
-	clients     []Client
+	log     *zap.Logger
+	ctx     context.Context
+	network beaconprotocol.Network
+	clients []Client
+	// addresses are the configured addresses of clients, in the same order. Unlike Client.Address(),
+	// they keep any credentials, so they can be used for requests which go-eth2-client doesn't support.
+	addresses []string
+	// httpClients are the HTTP clients of clients, in the same order, shared with requests
+	// which go-eth2-client doesn't support.
+	httpClients []*http.Client
 	multiClient MultiClient
+	health      *healthTracker
 	specssv.VersionCalls
 
 	syncDistanceTolerance phase0.Slot
@@ -155,6 +170,13 @@ type GoClient struct {
 	// intended for cases where some objects within the application may need to fetch attestation data for more than one slot.
 	blockRootToSlotCache *ttlcache.Cache[phase0.Root, phase0.Slot]
 
+	// pendingConsolidations are cached for the epoch they were fetched in, since the metadata syncer
+	// requests them for every batch of validators.
+	pendingConsolidationsMu      sync.Mutex
+	pendingConsolidationsEpoch   phase0.Epoch
+	pendingConsolidationsFetched bool
+	pendingConsolidations        []*electra.PendingConsolidation
+
 	commonTimeout time.Duration
 	longTimeout   time.Duration
 
@@ -162,13 +184,29 @@ type GoClient struct {
 
 	withParallelSubmissions bool
 
-	subscribersLock      sync.RWMutex
-	headEventSubscribers []subscriber[*apiv1.HeadEvent]
-	supportedTopics      []EventTopic
+	withWeightedSyncCommitteeData bool
+	syncCommitteeDataSoftTimeout  time.Duration
+	syncCommitteeDataHardTimeout  time.Duration
+
+	withHighestValueProposal bool
+	proposalSoftTimeout      time.Duration
+	proposalHardTimeout      time.Duration
+
+	subscribersLock                     sync.RWMutex
+	headEventSubscribers                []subscriber[*apiv1.HeadEvent]
+	chainReorgEventSubscribers          []subscriber[*apiv1.ChainReorgEvent]
+	finalizedCheckpointEventSubscribers []subscriber[*apiv1.FinalizedCheckpointEvent]
+	supportedTopics                     []EventTopic
 
 	lastProcessedEventSlotLock sync.Mutex
 	lastProcessedEventSlot     phase0.Slot
 
+	lastChainReorgLock sync.Mutex
+	lastChainReorg     apiv1.ChainReorgEvent
+
+	finalizedCheckpointLock sync.RWMutex
+	finalizedCheckpoint     phase0.Checkpoint
+
 	genesisForkVersion phase0.Version
 	ForkLock           sync.RWMutex
 	ForkEpochElectra   phase0.Epoch
@@ -215,9 +253,15 @@ func New(
 		longTimeout:                        longTimeout,
 		withWeightedAttestationData:        opt.WithWeightedAttestationData,
 		withParallelSubmissions:            opt.WithParallelSubmissions,
+		withWeightedSyncCommitteeData:      opt.WithWeightedSyncCommitteeData,
+		syncCommitteeDataSoftTimeout:       time.Duration(float64(commonTimeout) / 2.5),
+		syncCommitteeDataHardTimeout:       commonTimeout,
+		withHighestValueProposal:           opt.WithHighestValueProposal,
+		proposalSoftTimeout:                time.Duration(float64(commonTimeout) / 2.5),
+		proposalHardTimeout:                commonTimeout,
 		weightedAttestationDataSoftTimeout: time.Duration(float64(commonTimeout) / 2.5),
 		weightedAttestationDataHardTimeout: commonTimeout,
-		supportedTopics:                    []EventTopic{EventTopicHead, EventTopicBlock},
+		supportedTopics:                    []EventTopic{EventTopicHead, EventTopicBlock, EventTopicChainReorg, EventTopicFinalizedCheckpoint},
 		genesisForkVersion:                 phase0.Version(opt.Network.ForkVersion()),
 		// Initialize forks with FAR_FUTURE_EPOCH.
 		ForkEpochAltair:    math.MaxUint64,
@@ -238,6 +282,8 @@ func New(
 		}
 	}
 
+	client.health = newHealthTracker(client.clients, commonTimeout)
+
 	err := client.initMultiClient(opt.Context)
 	if err != nil {
 		logger.Error("Consensus multi client initialization failed",
@@ -251,6 +297,7 @@ func New(
 	client.nodeSyncingFn = client.nodeSyncing
 
 	go client.registrationSubmitter(slotTickerProvider)
+	go client.healthReporter(slotTickerProvider)
 	// Start automatic expired item deletion for attestationDataCache.
 	go client.attestationDataCache.Start()
 
@@ -279,10 +326,28 @@ func (gc *GoClient) initMultiClient(ctx context.Context) error {
 	}
 
 	gc.multiClient = multiClient.(*eth2clientmulti.Service)
+	if len(gc.clients) > 1 {
+		// Route requests to the healthiest node first instead of the multi client's fixed order.
+		gc.multiClient = newHealthRouter(gc.multiClient, gc.health)
+	}
 	return nil
 }
 
 func (gc *GoClient) addSingleClient(ctx context.Context, addr string) error {
+	// The same transport go-eth2-client uses by default, which is shared with requests it doesn't support.
+	sharedHTTPClient := &http.Client{
+		Transport: &http.Transport{
+			DialContext: (&net.Dialer{
+				Timeout:   gc.commonTimeout,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			MaxIdleConns:        64,
+			MaxConnsPerHost:     64,
+			MaxIdleConnsPerHost: 64,
+			IdleConnTimeout:     600 * time.Second,
+		},
+	}
+
 	httpClient, err := eth2clienthttp.New(
 		ctx,
 		// WithAddress supplies the address of the beacon node, in host:port format.
@@ -290,6 +355,7 @@ func (gc *GoClient) addSingleClient(ctx context.Context, addr string) error {
 		// LogLevel supplies the level of logging to carry out.
 		eth2clienthttp.WithLogLevel(zerolog.DebugLevel),
 		eth2clienthttp.WithTimeout(gc.commonTimeout),
+		eth2clienthttp.WithHTTPClient(sharedHTTPClient),
 		eth2clienthttp.WithReducedMemoryUsage(true),
 		eth2clienthttp.WithAllowDelayedStart(true),
 		eth2clienthttp.WithHooks(gc.singleClientHooks()),
@@ -304,6 +370,8 @@ func (gc *GoClient) addSingleClient(ctx context.Context, addr string) error {
 	}
 
 	gc.clients = append(gc.clients, httpClient.(*eth2clienthttp.Service))
+	gc.addresses = append(gc.addresses, addr)
+	gc.httpClients = append(gc.httpClients, sharedHTTPClient)
 
 	return nil
 }
@@ -464,6 +532,20 @@ func (gc *GoClient) Healthy(ctx context.Context) error {
 	return nil
 }
 
+// ClientVersion returns the version string reported by the first consensus client which responds.
+func (gc *GoClient) ClientVersion(ctx context.Context) (string, error) {
+	lastErr := fmt.Errorf("no consensus clients")
+	for _, client := range gc.clients {
+		resp, err := client.NodeVersion(ctx, &api.NodeVersionOpts{})
+		if err != nil {
+			lastErr = fmt.Errorf("failed to obtain node version from %s: %w", client.Address(), err)
+			continue
+		}
+		return resp.Data, nil
+	}
+	return "", lastErr
+}
+
 // GetBeaconNetwork returns the beacon network the node is on
 func (gc *GoClient) GetBeaconNetwork() spectypes.BeaconNetwork {
 	return gc.network.BeaconNetwork
@@ -0,0 +1,284 @@
+package goclient
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/operator/slotticker"
+)
+
+const (
+	// healthWindow is the number of the most recent requests and head events a beacon node is scored by.
+	healthWindow = 128
+
+	// maxHeadLag is the head lag (in slots) at which a beacon node gets the full head lag penalty.
+	maxHeadLag = 4
+
+	// Weights of the penalties subtracted from the perfect score of 1.
+	errorRateWeight    = 0.4
+	latencyWeight      = 0.2
+	headLagWeight      = 0.2
+	missedEventsWeight = 0.2
+)
+
+// NodeScore is the health score of a beacon node, between 0 (unusable) and 1 (perfect),
+// along with the measurements it's derived from.
+type NodeScore struct {
+	Address          string  `json:"address"`
+	Score            float64 `json:"score"`
+	Synced           bool    `json:"synced"`
+	LatencyP50Millis int64   `json:"latency_p50_ms"`
+	LatencyP95Millis int64   `json:"latency_p95_ms"`
+	ErrorRate        float64 `json:"error_rate"`
+	HeadLag          uint64  `json:"head_lag"`
+	MissedEventsRate float64 `json:"missed_events_rate"`
+}
+
+// window is a fixed-size ring of the most recent samples.
+type window[T any] struct {
+	samples []T
+	next    int
+}
+
+func (w *window[T]) add(sample T) {
+	if len(w.samples) < healthWindow {
+		w.samples = append(w.samples, sample)
+		return
+	}
+	w.samples[w.next] = sample
+	w.next = (w.next + 1) % healthWindow
+}
+
+// clientHealth holds the recent measurements of a single beacon node.
+type clientHealth struct {
+	latencies window[time.Duration]
+	failures  window[bool]
+	// missedHeads records, for every slot the head advanced past, whether the node failed to deliver that head.
+	missedHeads  window[bool]
+	tracksEvents bool
+	headSlot     phase0.Slot
+}
+
+// healthTracker scores beacon nodes by their latency percentiles, error rate, head lag and missed head events,
+// and orders them from the healthiest to the least healthy.
+type healthTracker struct {
+	mu       sync.Mutex
+	clients  []Client
+	health   map[string]*clientHealth
+	timeout  time.Duration
+	hasHead  bool
+	headSlot phase0.Slot
+}
+
+func newHealthTracker(clients []Client, timeout time.Duration) *healthTracker {
+	t := &healthTracker{
+		clients: clients,
+		health:  make(map[string]*clientHealth, len(clients)),
+		timeout: timeout,
+	}
+	for _, client := range clients {
+		t.health[client.Address()] = &clientHealth{}
+	}
+	return t
+}
+
+// recordRequest records the outcome of a request to the beacon node.
+// Errors caused by the request itself or by the caller aren't held against the node.
+func (t *healthTracker) recordRequest(addr string, duration time.Duration, err error) {
+	var apiErr *api.Error
+	if errors.Is(err, context.Canceled) || (errors.As(err, &apiErr) && apiErr.StatusCode/100 == 4) {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	h, ok := t.health[addr]
+	if !ok {
+		return
+	}
+	h.failures.add(err != nil)
+	if err == nil {
+		h.latencies.add(duration)
+	}
+}
+
+// trackEvents marks the beacon node as subscribed to head events, so it's scored by the head events it misses.
+func (t *healthTracker) trackEvents(addr string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if h, ok := t.health[addr]; ok {
+		h.tracksEvents = true
+	}
+}
+
+// recordHead records a head event received from the beacon node.
+func (t *healthTracker) recordHead(addr string, slot phase0.Slot) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	h, ok := t.health[addr]
+	if !ok {
+		return
+	}
+	h.headSlot = max(h.headSlot, slot)
+
+	if t.hasHead && slot <= t.headSlot {
+		return
+	}
+	// The head advanced, so by now every node should have delivered the previous head.
+	if t.hasHead {
+		for _, other := range t.health {
+			if other.tracksEvents {
+				other.missedHeads.add(other.headSlot < t.headSlot)
+			}
+		}
+	}
+	t.hasHead = true
+	t.headSlot = slot
+}
+
+// Scores returns the scores of the beacon nodes in the configured order.
+func (t *healthTracker) Scores() []NodeScore {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	scores := make([]NodeScore, 0, len(t.clients))
+	for _, client := range t.clients {
+		scores = append(scores, t.score(client))
+	}
+	return scores
+}
+
+// ordered returns the clients from the healthiest to the least healthy. Synced clients always come first,
+// and clients with equal scores keep their configured order.
+func (t *healthTracker) ordered() []Client {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	type scoredClient struct {
+		client Client
+		score  NodeScore
+	}
+	scored := make([]scoredClient, 0, len(t.clients))
+	for _, client := range t.clients {
+		scored = append(scored, scoredClient{client: client, score: t.score(client)})
+	}
+	slices.SortStableFunc(scored, func(a, b scoredClient) int {
+		if a.score.Synced != b.score.Synced {
+			if a.score.Synced {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Compare(b.score.Score, a.score.Score)
+	})
+
+	clients := make([]Client, 0, len(scored))
+	for _, s := range scored {
+		clients = append(clients, s.client)
+	}
+	return clients
+}
+
+// score must be called with t.mu held.
+func (t *healthTracker) score(client Client) NodeScore {
+	addr := client.Address()
+	h := t.health[addr]
+
+	s := NodeScore{
+		Address: addr,
+		Synced:  client.IsSynced(),
+	}
+
+	if len(h.latencies.samples) > 0 {
+		latencies := slices.Clone(h.latencies.samples)
+		slices.Sort(latencies)
+		s.LatencyP50Millis = percentile(latencies, 0.5).Milliseconds()
+		s.LatencyP95Millis = percentile(latencies, 0.95).Milliseconds()
+	}
+	s.ErrorRate = rate(h.failures.samples)
+	s.MissedEventsRate = rate(h.missedHeads.samples)
+	if h.tracksEvents && t.headSlot > h.headSlot {
+		s.HeadLag = uint64(t.headSlot - h.headSlot)
+	}
+
+	if !s.Synced {
+		return s
+	}
+
+	latencyPenalty := 0.0
+	if t.timeout > 0 {
+		latencyPenalty = math.Min(float64(s.LatencyP95Millis)/float64(t.timeout.Milliseconds()), 1)
+	}
+	headLagPenalty := math.Min(float64(s.HeadLag)/maxHeadLag, 1)
+
+	s.Score = 1 -
+		errorRateWeight*s.ErrorRate -
+		latencyWeight*latencyPenalty -
+		headLagWeight*headLagPenalty -
+		missedEventsWeight*s.MissedEventsRate
+	s.Score = math.Max(s.Score, 0)
+	return s
+}
+
+// percentile returns the p-th percentile of the sorted samples.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	i := int(math.Ceil(p*float64(len(sorted)))) - 1
+	return sorted[max(i, 0)]
+}
+
+// rate returns the share of true samples.
+func rate(samples []bool) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	n := 0
+	for _, s := range samples {
+		if s {
+			n++
+		}
+	}
+	return float64(n) / float64(len(samples))
+}
+
+// BeaconNodeScores returns the health scores of the configured beacon nodes.
+func (gc *GoClient) BeaconNodeScores() []NodeScore {
+	return gc.health.Scores()
+}
+
+// healthReporter publishes the beacon node scores every slot and logs when the healthiest node changes.
+func (gc *GoClient) healthReporter(slotTickerProvider slotticker.Provider) {
+	var healthiest string
+
+	ticker := slotTickerProvider()
+	for {
+		select {
+		case <-gc.ctx.Done():
+			return
+		case <-ticker.Next():
+			scores := gc.health.Scores()
+			for _, score := range scores {
+				recordBeaconNodeScore(gc.ctx, score.Address, score.Score)
+			}
+
+			if addr := gc.health.ordered()[0].Address(); addr != healthiest {
+				healthiest = addr
+				gc.log.Info("healthiest consensus client changed",
+					zap.String("address", healthiest),
+					zap.Any("scores", scores),
+				)
+			}
+		}
+	}
+}
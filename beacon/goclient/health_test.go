@@ -0,0 +1,199 @@
+package goclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/beacon/goclient/tests"
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+)
+
+type healthTestClient struct {
+	Client
+	addr   string
+	synced bool
+}
+
+func (c *healthTestClient) Address() string { return c.addr }
+
+func (c *healthTestClient) IsSynced() bool { return c.synced }
+
+func healthTestClients(synced ...bool) []Client {
+	clients := make([]Client, 0, len(synced))
+	for i, s := range synced {
+		clients = append(clients, &healthTestClient{addr: fmt.Sprintf("node-%d", i), synced: s})
+	}
+	return clients
+}
+
+func orderedAddresses(t *healthTracker) []string {
+	var addrs []string
+	for _, client := range t.ordered() {
+		addrs = append(addrs, client.Address())
+	}
+	return addrs
+}
+
+func TestHealthTracker_Ordering(t *testing.T) {
+	t.Run("keeps configured order when scores are equal", func(t *testing.T) {
+		tracker := newHealthTracker(healthTestClients(true, true, true), time.Second)
+		require.Equal(t, []string{"node-0", "node-1", "node-2"}, orderedAddresses(tracker))
+	})
+
+	t.Run("unsynced nodes come last", func(t *testing.T) {
+		tracker := newHealthTracker(healthTestClients(false, true), time.Second)
+		require.Equal(t, []string{"node-1", "node-0"}, orderedAddresses(tracker))
+		require.Zero(t, tracker.Scores()[0].Score)
+	})
+
+	t.Run("errors lower the score", func(t *testing.T) {
+		tracker := newHealthTracker(healthTestClients(true, true), time.Second)
+		tracker.recordRequest("node-0", 10*time.Millisecond, fmt.Errorf("connection refused"))
+		tracker.recordRequest("node-1", 10*time.Millisecond, nil)
+
+		require.Equal(t, []string{"node-1", "node-0"}, orderedAddresses(tracker))
+		require.Equal(t, 1.0, tracker.Scores()[0].ErrorRate)
+	})
+
+	t.Run("request errors don't lower the score", func(t *testing.T) {
+		tracker := newHealthTracker(healthTestClients(true, true), time.Second)
+		tracker.recordRequest("node-0", 10*time.Millisecond, &api.Error{StatusCode: http.StatusBadRequest})
+		tracker.recordRequest("node-0", 10*time.Millisecond, context.Canceled)
+
+		require.Equal(t, []string{"node-0", "node-1"}, orderedAddresses(tracker))
+		require.Zero(t, tracker.Scores()[0].ErrorRate)
+	})
+
+	t.Run("latency lowers the score", func(t *testing.T) {
+		tracker := newHealthTracker(healthTestClients(true, true), time.Second)
+		for i := 0; i < 20; i++ {
+			tracker.recordRequest("node-0", 800*time.Millisecond, nil)
+			tracker.recordRequest("node-1", 50*time.Millisecond, nil)
+		}
+
+		require.Equal(t, []string{"node-1", "node-0"}, orderedAddresses(tracker))
+		scores := tracker.Scores()
+		require.EqualValues(t, 800, scores[0].LatencyP50Millis)
+		require.EqualValues(t, 800, scores[0].LatencyP95Millis)
+	})
+
+	t.Run("head lag and missed events lower the score", func(t *testing.T) {
+		tracker := newHealthTracker(healthTestClients(true, true), time.Second)
+		tracker.trackEvents("node-0")
+		tracker.trackEvents("node-1")
+		for slot := phase0.Slot(1); slot <= 10; slot++ {
+			tracker.recordHead("node-1", slot)
+			if slot%2 == 0 {
+				tracker.recordHead("node-0", slot-1)
+			}
+		}
+
+		require.Equal(t, []string{"node-1", "node-0"}, orderedAddresses(tracker))
+		scores := tracker.Scores()
+		require.EqualValues(t, 1, scores[0].HeadLag)
+		require.Greater(t, scores[0].MissedEventsRate, 0.0)
+		require.Zero(t, scores[1].HeadLag)
+		require.Zero(t, scores[1].MissedEventsRate)
+	})
+}
+
+func TestHealthRouter(t *testing.T) {
+	const blockRootPath = "/eth/v1/beacon/blocks/head/root"
+
+	responses := map[string]json.RawMessage{
+		blockRootPath: json.RawMessage(`{
+			"execution_optimistic": false,
+			"finalized": false,
+			"data": {"root": "0x1662a3d288b0338436d74083b4ce68908a0ece0661aa236acd95c8a4c3f6e8fc"}
+		}`),
+	}
+	for path, resp := range beaconEndpointResponses {
+		responses[path] = resp
+	}
+
+	var (
+		failing           atomic.Bool
+		firstNodeRequests atomic.Int64
+		lastNodeRequests  atomic.Int64
+	)
+	failing.Store(true)
+
+	firstNode := tests.MockServerWithResponses(maps.Clone(responses), func(r *http.Request, resp json.RawMessage) (json.RawMessage, error) {
+		if r.URL.Path == blockRootPath {
+			firstNodeRequests.Add(1)
+			if failing.Load() {
+				return nil, fmt.Errorf("internal error")
+			}
+		}
+		return resp, nil
+	})
+	defer firstNode.Close()
+
+	lastNode := tests.MockServerWithResponses(maps.Clone(responses), func(r *http.Request, resp json.RawMessage) (json.RawMessage, error) {
+		if r.URL.Path == blockRootPath {
+			lastNodeRequests.Add(1)
+		}
+		return resp, nil
+	})
+	defer lastNode.Close()
+
+	client, err := New(zap.NewNop(), beacon.Options{
+		Context:        context.Background(),
+		Network:        beacon.NewNetwork(types.MainNetwork),
+		BeaconNodeAddr: strings.Join([]string{firstNode.URL, lastNode.URL}, ";"),
+		CommonTimeout:  time.Second,
+		LongTimeout:    time.Second,
+	},
+		tests.MockDataStore{},
+		tests.MockSlotTickerProvider)
+	require.NoError(t, err)
+	require.IsType(t, &healthRouter{}, client.multiClient)
+
+	getBlockRoot := func() {
+		resp, err := client.multiClient.BeaconBlockRoot(context.Background(), &api.BeaconBlockRootOpts{Block: "head"})
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+	}
+
+	// The first node is tried first and fails over to the last node.
+	getBlockRoot()
+	require.EqualValues(t, 1, firstNodeRequests.Load())
+	require.EqualValues(t, 1, lastNodeRequests.Load())
+
+	// Having failed, the first node is routed to last.
+	require.Equal(t, lastNode.URL, client.multiClient.Address())
+	getBlockRoot()
+	require.EqualValues(t, 1, firstNodeRequests.Load())
+	require.EqualValues(t, 2, lastNodeRequests.Load())
+
+	scores := client.BeaconNodeScores()
+	require.Len(t, scores, 2)
+	require.Equal(t, firstNode.URL, scores[0].Address)
+	require.Greater(t, scores[1].Score, scores[0].Score)
+
+	// Once the first node recovers and the last node starts failing, the first node is healthier again.
+	failing.Store(false)
+	for i := 0; i < 3; i++ {
+		client.health.recordRequest(firstNode.URL, time.Millisecond, nil)
+	}
+	for i := 0; i < 2; i++ {
+		client.health.recordRequest(lastNode.URL, time.Millisecond, fmt.Errorf("connection refused"))
+	}
+	require.Equal(t, firstNode.URL, client.multiClient.Address())
+	getBlockRoot()
+	require.EqualValues(t, 2, firstNodeRequests.Load())
+	require.EqualValues(t, 2, lastNodeRequests.Load())
+}
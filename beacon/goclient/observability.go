@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/api"
@@ -48,6 +49,24 @@ var (
 			metricName("sync.distance"),
 			metric.WithUnit("{block}"),
 			metric.WithDescription("consensus client syncing distance which is a delta between highest and current blocks")))
+
+	beaconNodeScoreGauge = observability.NewMetric(
+		meter.Float64Gauge(
+			metricName("health.score"),
+			metric.WithDescription("beacon node health score, from 0 (unusable) to 1 (perfect)")))
+
+	proposalSelectedCounter = observability.NewMetric(
+		meter.Int64Counter(
+			metricName("proposal.selected"),
+			metric.WithUnit("{proposal}"),
+			metric.WithDescription("number of highest value proposals selected per consensus client")))
+
+	proposalValueSpreadHistogram = observability.NewMetric(
+		meter.Float64Histogram(
+			metricName("proposal.value.spread"),
+			metric.WithUnit("Gwei"),
+			metric.WithDescription("difference in value between the highest and the lowest proposal received from consensus clients"),
+			metric.WithExplicitBucketBoundaries(0, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9)))
 )
 
 func metricName(name string) string {
@@ -80,6 +99,10 @@ func recordSyncDistance(ctx context.Context, distance phase0.Slot, serverAddr st
 	observability.RecordUint64Value(ctx, uint64(distance), syncDistanceGauge.Record, metric.WithAttributes(semconv.ServerAddress(serverAddr)))
 }
 
+func recordBeaconNodeScore(ctx context.Context, serverAddr string, score float64) {
+	beaconNodeScoreGauge.Record(ctx, score, metric.WithAttributes(semconv.ServerAddress(serverAddr)))
+}
+
 func recordBeaconClientStatus(ctx context.Context, status beaconNodeStatus, serverAddr string) {
 	resetBeaconClientStatusGauge(ctx, serverAddr)
 
@@ -102,3 +125,19 @@ func beaconClientStatusAttribute(value beaconNodeStatus) attribute.KeyValue {
 	eventNameAttrName := fmt.Sprintf("%s.sync.status", observabilityNamespace)
 	return attribute.String(eventNameAttrName, string(value))
 }
+
+// recordProposalSelection records the consensus client whose proposal was selected and,
+// when there was more than one proposal to choose from, the spread of their values.
+func recordProposalSelection(ctx context.Context, serverAddr string, blinded bool, spread *big.Int, proposals int) {
+	proposalSelectedCounter.Add(ctx, 1,
+		metric.WithAttributes(
+			semconv.ServerAddress(serverAddr),
+			attribute.Bool(fmt.Sprintf("%s.proposal.blinded", observabilityNamespace), blinded),
+		))
+
+	if proposals < 2 {
+		return
+	}
+	spreadGwei, _ := new(big.Float).Quo(new(big.Float).SetInt(spread), big.NewFloat(1e9)).Float64()
+	proposalValueSpreadHistogram.Record(ctx, spreadGwei)
+}
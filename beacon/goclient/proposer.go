@@ -3,6 +3,7 @@ package goclient
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"net/http"
 	"time"
 
@@ -63,13 +64,32 @@ func (gc *GoClient) GetBeaconBlock(slot phase0.Slot, graffitiBytes, randao []byt
 	graffiti := [32]byte{}
 	copy(graffiti[:], graffitiBytes[:])
 
-	reqStart := time.Now()
-	proposalResp, err := gc.multiClient.Proposal(gc.ctx, &api.ProposalOpts{
+	opts := &api.ProposalOpts{
 		Slot:                   slot,
 		RandaoReveal:           sig,
 		Graffiti:               graffiti,
 		SkipRandaoVerification: false,
-	})
+	}
+
+	var (
+		proposal *api.VersionedProposal
+		err      error
+	)
+	if gc.withHighestValueProposal && len(gc.clients) > 1 {
+		proposal, err = gc.highestValueProposal(opts)
+	} else {
+		proposal, err = gc.simpleProposal(opts)
+	}
+	if err != nil {
+		return nil, DataVersionNil, err
+	}
+
+	return proposalBlock(proposal)
+}
+
+func (gc *GoClient) simpleProposal(opts *api.ProposalOpts) (*api.VersionedProposal, error) {
+	reqStart := time.Now()
+	proposalResp, err := gc.multiClient.Proposal(gc.ctx, opts)
 	recordRequestDuration(gc.ctx, "Proposal", gc.multiClient.Address(), http.MethodGet, time.Since(reqStart), err)
 
 	if err != nil {
@@ -77,22 +97,26 @@ func (gc *GoClient) GetBeaconBlock(slot phase0.Slot, graffitiBytes, randao []byt
 			zap.String("api", "Proposal"),
 			zap.Error(err),
 		)
-		return nil, DataVersionNil, fmt.Errorf("failed to get proposal: %w", err)
+		return nil, fmt.Errorf("failed to get proposal: %w", err)
 	}
 	if proposalResp == nil {
 		gc.log.Error(clNilResponseErrMsg,
 			zap.String("api", "Proposal"),
 		)
-		return nil, DataVersionNil, fmt.Errorf("proposal response is nil")
+		return nil, fmt.Errorf("proposal response is nil")
 	}
 	if proposalResp.Data == nil {
 		gc.log.Error(clNilResponseDataErrMsg,
 			zap.String("api", "Proposal"),
 		)
-		return nil, DataVersionNil, fmt.Errorf("proposal data is nil")
+		return nil, fmt.Errorf("proposal data is nil")
 	}
 
-	beaconBlock := proposalResp.Data
+	return proposalResp.Data, nil
+}
+
+// proposalBlock returns the (possibly blinded) block of the proposal, checking that it's complete.
+func proposalBlock(beaconBlock *api.VersionedProposal) (ssz.Marshaler, spec.DataVersion, error) {
 
 	if beaconBlock.Blinded {
 		switch beaconBlock.Version {
@@ -179,6 +203,185 @@ func (gc *GoClient) GetBeaconBlock(slot phase0.Slot, graffitiBytes, randao []byt
 	}
 }
 
+type (
+	proposalResponse struct {
+		clientIndex int
+		clientAddr  string
+		proposal    *api.VersionedProposal
+		value       *big.Int
+	}
+
+	proposalError struct {
+		clientAddr string
+		err        error
+	}
+)
+
+// highestValueProposal requests the proposal from all clients in parallel and returns the most valuable one.
+// Like weightedAttestationData, it has a soft and a hard timeout: after the soft timeout it returns
+// as soon as it has any response, and at the hard timeout it returns unconditionally.
+func (gc *GoClient) highestValueProposal(opts *api.ProposalOpts) (*api.VersionedProposal, error) {
+	logger := gc.log.With(fields.Slot(opts.Slot))
+
+	ctx, cancel := context.WithTimeout(gc.ctx, gc.proposalHardTimeout)
+	defer cancel()
+
+	softCtx, softCancel := context.WithTimeout(ctx, gc.proposalSoftTimeout)
+	defer softCancel()
+
+	started := time.Now()
+
+	numberOfRequests := len(gc.clients)
+	respCh := make(chan *proposalResponse, numberOfRequests)
+	errCh := make(chan *proposalError, numberOfRequests)
+
+	for i, client := range gc.clients {
+		go gc.fetchProposal(ctx, i, client, opts, respCh, errCh, logger)
+	}
+
+	var (
+		succeeded, errored int
+		best               *proposalResponse
+		lowestValue        *big.Int
+	)
+
+	// Until the soft timeout, wait for all clients. After it, return as soon as there is any proposal.
+	softDone := softCtx.Done()
+	hardTimedOut := false
+	for !hardTimedOut && succeeded+errored != numberOfRequests && (softDone != nil || succeeded == 0) {
+		select {
+		case resp := <-respCh:
+			succeeded++
+			logger.With(
+				zap.Duration("elapsed", time.Since(started)),
+				zap.String("client_addr", resp.clientAddr),
+				zap.Stringer("value", resp.value),
+				zap.Bool("blinded", resp.proposal.Blinded),
+			).Debug("proposal received")
+
+			if best == nil || betterProposal(resp, best) {
+				best = resp
+			}
+			if lowestValue == nil || resp.value.Cmp(lowestValue) < 0 {
+				lowestValue = resp.value
+			}
+		case err := <-errCh:
+			errored++
+			logger.With(
+				zap.Duration("elapsed", time.Since(started)),
+				zap.String("client_addr", err.clientAddr),
+				zap.Error(err.err),
+			).Error("error received fetching proposal")
+		case <-softDone:
+			softDone = nil
+			logger.With(
+				zap.Duration("elapsed", time.Since(started)),
+				zap.Int("succeeded", succeeded),
+				zap.Int("errored", errored),
+			).Debug("soft timeout reached")
+		case <-ctx.Done():
+			hardTimedOut = true
+			logger.With(
+				zap.Duration("elapsed", time.Since(started)),
+				zap.Int("succeeded", succeeded),
+				zap.Int("errored", errored),
+			).Error("hard timeout reached")
+		}
+	}
+
+	resultLogger := logger.With(
+		zap.Duration("elapsed", time.Since(started)),
+		zap.Int("succeeded", succeeded),
+		zap.Int("errored", errored),
+		zap.Int("timed_out", numberOfRequests-succeeded-errored),
+		zap.Bool("with_highest_value_proposal", true),
+	)
+	if best == nil {
+		resultLogger.Error("no proposals received")
+		return nil, fmt.Errorf("no proposals received")
+	}
+
+	spread := new(big.Int).Sub(best.value, lowestValue)
+	recordProposalSelection(gc.ctx, best.clientAddr, best.proposal.Blinded, spread, succeeded)
+
+	resultLogger.With(
+		zap.String("client_addr", best.clientAddr),
+		zap.Stringer("value", best.value),
+		zap.Stringer("value_spread", spread),
+		zap.Bool("blinded", best.proposal.Blinded),
+	).Debug("selected highest value proposal")
+
+	return best.proposal, nil
+}
+
+func (gc *GoClient) fetchProposal(
+	ctx context.Context,
+	clientIndex int,
+	client Client,
+	opts *api.ProposalOpts,
+	respCh chan *proposalResponse,
+	errCh chan *proposalError,
+	logger *zap.Logger,
+) {
+	addr := client.Address()
+	reqStart := time.Now()
+
+	logger.Debug("fetching proposal", zap.String("client_addr", addr))
+	resp, err := client.Proposal(ctx, opts)
+	recordRequestDuration(ctx, "Proposal", addr, http.MethodGet, time.Since(reqStart), err)
+	gc.health.recordRequest(addr, time.Since(reqStart), err)
+
+	switch {
+	case err != nil:
+	case resp == nil:
+		err = fmt.Errorf("response is nil")
+	case resp.Data == nil:
+		err = fmt.Errorf("proposal data is nil")
+	default:
+		// Reject incomplete proposals here, so that they don't win the selection.
+		_, _, err = proposalBlock(resp.Data)
+	}
+	if err != nil {
+		errCh <- &proposalError{
+			clientAddr: addr,
+			err:        err,
+		}
+		return
+	}
+
+	respCh <- &proposalResponse{
+		clientIndex: clientIndex,
+		clientAddr:  addr,
+		proposal:    resp.Data,
+		value:       proposalValue(resp.Data),
+	}
+}
+
+// proposalValue returns the total value of the proposal in Wei: the consensus rewards of the block
+// and the execution value, which is the builder's bid for blinded proposals and the fees of the local payload otherwise.
+func proposalValue(proposal *api.VersionedProposal) *big.Int {
+	value := new(big.Int)
+	if proposal.ConsensusValue != nil {
+		value.Add(value, proposal.ConsensusValue)
+	}
+	if proposal.ExecutionValue != nil {
+		value.Add(value, proposal.ExecutionValue)
+	}
+	return value
+}
+
+// betterProposal returns whether the candidate should be preferred over the current best proposal.
+// Ties prefer unblinded proposals, which don't depend on a relay to be published, and then the first configured client.
+func betterProposal(candidate, best *proposalResponse) bool {
+	if cmp := candidate.value.Cmp(best.value); cmp != 0 {
+		return cmp > 0
+	}
+	if candidate.proposal.Blinded != best.proposal.Blinded {
+		return !candidate.proposal.Blinded
+	}
+	return candidate.clientIndex < best.clientIndex
+}
+
 func (gc *GoClient) SubmitBlindedBeaconBlock(block *api.VersionedBlindedProposal, sig phase0.BLSSignature) error {
 	signedBlock := &api.VersionedSignedBlindedProposal{
 		Version: block.Version,
@@ -0,0 +1,56 @@
+package goclient
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProposalValue(t *testing.T) {
+	require.Zero(t, proposalValue(&api.VersionedProposal{}).Sign())
+
+	value := proposalValue(&api.VersionedProposal{
+		ConsensusValue: big.NewInt(3),
+		ExecutionValue: big.NewInt(40),
+	})
+	require.Equal(t, big.NewInt(43), value)
+
+	value = proposalValue(&api.VersionedProposal{ExecutionValue: big.NewInt(40)})
+	require.Equal(t, big.NewInt(40), value)
+}
+
+func TestBetterProposal(t *testing.T) {
+	response := func(clientIndex int, value int64, blinded bool) *proposalResponse {
+		return &proposalResponse{
+			clientIndex: clientIndex,
+			proposal:    &api.VersionedProposal{Blinded: blinded},
+			value:       big.NewInt(value),
+		}
+	}
+
+	t.Run("higher value wins", func(t *testing.T) {
+		require.True(t, betterProposal(response(1, 100, true), response(0, 99, false)))
+		require.False(t, betterProposal(response(0, 99, false), response(1, 100, true)))
+	})
+
+	t.Run("unblinded wins a tie", func(t *testing.T) {
+		require.True(t, betterProposal(response(1, 100, false), response(0, 100, true)))
+		require.False(t, betterProposal(response(0, 100, true), response(1, 100, false)))
+	})
+
+	t.Run("first client wins a tie", func(t *testing.T) {
+		require.True(t, betterProposal(response(0, 100, false), response(1, 100, false)))
+		require.False(t, betterProposal(response(1, 100, false), response(0, 100, false)))
+	})
+}
+
+func TestProposalBlock_Incomplete(t *testing.T) {
+	_, _, err := proposalBlock(&api.VersionedProposal{Version: spec.DataVersionDeneb})
+	require.Error(t, err)
+
+	_, _, err = proposalBlock(&api.VersionedProposal{Version: spec.DataVersionCapella, Blinded: true})
+	require.Error(t, err)
+}
@@ -0,0 +1,218 @@
+package goclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// healthRouter routes requests to the healthiest beacon node first, failing over to the next healthiest
+// one on errors the same way the go-eth2-client multi client does for its fixed order of nodes.
+// The multi client still serves the static data (spec, genesis and domains) and the event stream.
+type healthRouter struct {
+	MultiClient
+	health *healthTracker
+}
+
+func newHealthRouter(multiClient MultiClient, health *healthTracker) *healthRouter {
+	return &healthRouter{
+		MultiClient: multiClient,
+		health:      health,
+	}
+}
+
+// Address returns the address of the healthiest beacon node.
+func (r *healthRouter) Address() string {
+	return r.health.ordered()[0].Address()
+}
+
+// routeCall calls the beacon nodes from the healthiest to the least healthy until one succeeds.
+// Like the multi client, it doesn't fail over on errors caused by the request or by the caller.
+func routeCall[T any](ctx context.Context, r *healthRouter, call func(client Client) (T, error)) (T, error) {
+	var (
+		res T
+		err error
+	)
+	for _, client := range r.health.ordered() {
+		start := time.Now()
+		res, err = call(client)
+		r.health.recordRequest(client.Address(), time.Since(start), err)
+		if err == nil {
+			return res, nil
+		}
+
+		var apiErr *api.Error
+		switch {
+		case errors.As(err, &apiErr) && apiErr.StatusCode/100 == 4,
+			errors.Is(err, context.Canceled),
+			errors.Is(err, context.DeadlineExceeded) && ctx.Err() != nil:
+			return res, err
+		}
+	}
+	return res, fmt.Errorf("all beacon nodes failed: %w", err)
+}
+
+// routeSubmit is routeCall for calls which return only an error.
+func routeSubmit(ctx context.Context, r *healthRouter, call func(client Client) error) error {
+	_, err := routeCall(ctx, r, func(client Client) (struct{}, error) {
+		return struct{}{}, call(client)
+	})
+	return err
+}
+
+func (r *healthRouter) AttestationData(ctx context.Context, opts *api.AttestationDataOpts) (*api.Response[*phase0.AttestationData], error) {
+	return routeCall(ctx, r, func(client Client) (*api.Response[*phase0.AttestationData], error) {
+		return client.AttestationData(ctx, opts)
+	})
+}
+
+func (r *healthRouter) SubmitAttestations(ctx context.Context, opts *api.SubmitAttestationsOpts) error {
+	return routeSubmit(ctx, r, func(client Client) error {
+		return client.SubmitAttestations(ctx, opts)
+	})
+}
+
+func (r *healthRouter) AggregateAttestation(ctx context.Context, opts *api.AggregateAttestationOpts) (*api.Response[*spec.VersionedAttestation], error) {
+	return routeCall(ctx, r, func(client Client) (*api.Response[*spec.VersionedAttestation], error) {
+		return client.AggregateAttestation(ctx, opts)
+	})
+}
+
+func (r *healthRouter) SubmitAggregateAttestations(ctx context.Context, opts *api.SubmitAggregateAttestationsOpts) error {
+	return routeSubmit(ctx, r, func(client Client) error {
+		return client.SubmitAggregateAttestations(ctx, opts)
+	})
+}
+
+func (r *healthRouter) SubmitBeaconCommitteeSubscriptions(ctx context.Context, subscriptions []*apiv1.BeaconCommitteeSubscription) error {
+	return routeSubmit(ctx, r, func(client Client) error {
+		return client.SubmitBeaconCommitteeSubscriptions(ctx, subscriptions)
+	})
+}
+
+func (r *healthRouter) SubmitSyncCommitteeSubscriptions(ctx context.Context, subscriptions []*apiv1.SyncCommitteeSubscription) error {
+	return routeSubmit(ctx, r, func(client Client) error {
+		return client.SubmitSyncCommitteeSubscriptions(ctx, subscriptions)
+	})
+}
+
+func (r *healthRouter) AttesterDuties(ctx context.Context, opts *api.AttesterDutiesOpts) (*api.Response[[]*apiv1.AttesterDuty], error) {
+	return routeCall(ctx, r, func(client Client) (*api.Response[[]*apiv1.AttesterDuty], error) {
+		return client.AttesterDuties(ctx, opts)
+	})
+}
+
+func (r *healthRouter) ProposerDuties(ctx context.Context, opts *api.ProposerDutiesOpts) (*api.Response[[]*apiv1.ProposerDuty], error) {
+	return routeCall(ctx, r, func(client Client) (*api.Response[[]*apiv1.ProposerDuty], error) {
+		return client.ProposerDuties(ctx, opts)
+	})
+}
+
+func (r *healthRouter) SyncCommitteeDuties(ctx context.Context, opts *api.SyncCommitteeDutiesOpts) (*api.Response[[]*apiv1.SyncCommitteeDuty], error) {
+	return routeCall(ctx, r, func(client Client) (*api.Response[[]*apiv1.SyncCommitteeDuty], error) {
+		return client.SyncCommitteeDuties(ctx, opts)
+	})
+}
+
+func (r *healthRouter) NodeSyncing(ctx context.Context, opts *api.NodeSyncingOpts) (*api.Response[*apiv1.SyncState], error) {
+	return routeCall(ctx, r, func(client Client) (*api.Response[*apiv1.SyncState], error) {
+		return client.NodeSyncing(ctx, opts)
+	})
+}
+
+func (r *healthRouter) Proposal(ctx context.Context, opts *api.ProposalOpts) (*api.Response[*api.VersionedProposal], error) {
+	return routeCall(ctx, r, func(client Client) (*api.Response[*api.VersionedProposal], error) {
+		return client.Proposal(ctx, opts)
+	})
+}
+
+func (r *healthRouter) SubmitProposal(ctx context.Context, opts *api.SubmitProposalOpts) error {
+	return routeSubmit(ctx, r, func(client Client) error {
+		return client.SubmitProposal(ctx, opts)
+	})
+}
+
+func (r *healthRouter) SubmitSyncCommitteeMessages(ctx context.Context, messages []*altair.SyncCommitteeMessage) error {
+	return routeSubmit(ctx, r, func(client Client) error {
+		return client.SubmitSyncCommitteeMessages(ctx, messages)
+	})
+}
+
+func (r *healthRouter) BeaconBlockRoot(ctx context.Context, opts *api.BeaconBlockRootOpts) (*api.Response[*phase0.Root], error) {
+	return routeCall(ctx, r, func(client Client) (*api.Response[*phase0.Root], error) {
+		return client.BeaconBlockRoot(ctx, opts)
+	})
+}
+
+func (r *healthRouter) SyncCommitteeContribution(ctx context.Context, opts *api.SyncCommitteeContributionOpts) (*api.Response[*altair.SyncCommitteeContribution], error) {
+	return routeCall(ctx, r, func(client Client) (*api.Response[*altair.SyncCommitteeContribution], error) {
+		return client.SyncCommitteeContribution(ctx, opts)
+	})
+}
+
+func (r *healthRouter) SubmitSyncCommitteeContributions(ctx context.Context, contributionAndProofs []*altair.SignedContributionAndProof) error {
+	return routeSubmit(ctx, r, func(client Client) error {
+		return client.SubmitSyncCommitteeContributions(ctx, contributionAndProofs)
+	})
+}
+
+func (r *healthRouter) BeaconBlockHeader(ctx context.Context, opts *api.BeaconBlockHeaderOpts) (*api.Response[*apiv1.BeaconBlockHeader], error) {
+	return routeCall(ctx, r, func(client Client) (*api.Response[*apiv1.BeaconBlockHeader], error) {
+		return client.BeaconBlockHeader(ctx, opts)
+	})
+}
+
+func (r *healthRouter) Validators(ctx context.Context, opts *api.ValidatorsOpts) (*api.Response[map[phase0.ValidatorIndex]*apiv1.Validator], error) {
+	return routeCall(ctx, r, func(client Client) (*api.Response[map[phase0.ValidatorIndex]*apiv1.Validator], error) {
+		return client.Validators(ctx, opts)
+	})
+}
+
+func (r *healthRouter) SubmitProposalPreparations(ctx context.Context, preparations []*apiv1.ProposalPreparation) error {
+	return routeSubmit(ctx, r, func(client Client) error {
+		return client.SubmitProposalPreparations(ctx, preparations)
+	})
+}
+
+func (r *healthRouter) SubmitValidatorRegistrations(ctx context.Context, registrations []*api.VersionedSignedValidatorRegistration) error {
+	return routeSubmit(ctx, r, func(client Client) error {
+		return client.SubmitValidatorRegistrations(ctx, registrations)
+	})
+}
+
+func (r *healthRouter) SubmitVoluntaryExit(ctx context.Context, voluntaryExit *phase0.SignedVoluntaryExit) error {
+	return routeSubmit(ctx, r, func(client Client) error {
+		return client.SubmitVoluntaryExit(ctx, voluntaryExit)
+	})
+}
+
+func (r *healthRouter) ValidatorLiveness(ctx context.Context, opts *api.ValidatorLivenessOpts) (*api.Response[[]*apiv1.ValidatorLiveness], error) {
+	return routeCall(ctx, r, func(client Client) (*api.Response[[]*apiv1.ValidatorLiveness], error) {
+		return client.ValidatorLiveness(ctx, opts)
+	})
+}
+
+func (r *healthRouter) SignedBeaconBlock(ctx context.Context, opts *api.SignedBeaconBlockOpts) (*api.Response[*spec.VersionedSignedBeaconBlock], error) {
+	return routeCall(ctx, r, func(client Client) (*api.Response[*spec.VersionedSignedBeaconBlock], error) {
+		return client.SignedBeaconBlock(ctx, opts)
+	})
+}
+
+func (r *healthRouter) BeaconCommittees(ctx context.Context, opts *api.BeaconCommitteesOpts) (*api.Response[[]*apiv1.BeaconCommittee], error) {
+	return routeCall(ctx, r, func(client Client) (*api.Response[[]*apiv1.BeaconCommittee], error) {
+		return client.BeaconCommittees(ctx, opts)
+	})
+}
+
+func (r *healthRouter) ValidatorBalances(ctx context.Context, opts *api.ValidatorBalancesOpts) (*api.Response[map[phase0.ValidatorIndex]phase0.Gwei], error) {
+	return routeCall(ctx, r, func(client Client) (*api.Response[map[phase0.ValidatorIndex]phase0.Gwei], error) {
+		return client.ValidatorBalances(ctx, opts)
+	})
+}
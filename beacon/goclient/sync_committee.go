@@ -71,6 +71,119 @@ func (gc *GoClient) GetSyncMessageBlockRoot(slot phase0.Slot) (phase0.Root, spec
 	return *resp.Data, spec.DataVersionAltair, nil
 }
 
+type syncCommitteeDataResponse[T any] struct {
+	clientIndex int
+	clientAddr  string
+	data        T
+	score       float64
+}
+
+// weightedSyncCommitteeData requests sync committee data from all clients in parallel and returns the data
+// with the highest score, preferring the first configured client on ties. Like highestValueProposal,
+// it waits for all clients until the soft timeout, after which it returns as soon as it has any response,
+// and at the hard timeout it returns unconditionally.
+func weightedSyncCommitteeData[T any](
+	gc *GoClient,
+	logger *zap.Logger,
+	apiName string,
+	fetch func(ctx context.Context, client Client) (data T, score float64, err error),
+) (T, error) {
+	ctx, cancel := context.WithTimeout(gc.ctx, gc.syncCommitteeDataHardTimeout)
+	defer cancel()
+
+	softCtx, softCancel := context.WithTimeout(ctx, gc.syncCommitteeDataSoftTimeout)
+	defer softCancel()
+
+	started := time.Now()
+
+	numberOfRequests := len(gc.clients)
+	respCh := make(chan *syncCommitteeDataResponse[T], numberOfRequests)
+	errCh := make(chan error, numberOfRequests)
+
+	for i, client := range gc.clients {
+		go func() {
+			addr := client.Address()
+			reqStart := time.Now()
+			data, score, err := fetch(ctx, client)
+			recordRequestDuration(ctx, apiName, addr, http.MethodGet, time.Since(reqStart), err)
+			gc.health.recordRequest(addr, time.Since(reqStart), err)
+			if err != nil {
+				errCh <- fmt.Errorf("client %s: %w", addr, err)
+				return
+			}
+			respCh <- &syncCommitteeDataResponse[T]{
+				clientIndex: i,
+				clientAddr:  addr,
+				data:        data,
+				score:       score,
+			}
+		}()
+	}
+
+	var (
+		succeeded, errored int
+		best               *syncCommitteeDataResponse[T]
+	)
+
+	softDone := softCtx.Done()
+	hardTimedOut := false
+	for !hardTimedOut && succeeded+errored != numberOfRequests && (softDone != nil || succeeded == 0) {
+		select {
+		case resp := <-respCh:
+			succeeded++
+			logger.With(
+				zap.Duration("elapsed", time.Since(started)),
+				zap.String("client_addr", resp.clientAddr),
+				zap.Float64("score", resp.score),
+			).Debug("response received")
+
+			if best == nil || resp.score > best.score || (resp.score == best.score && resp.clientIndex < best.clientIndex) {
+				best = resp
+			}
+		case err := <-errCh:
+			errored++
+			logger.With(
+				zap.Duration("elapsed", time.Since(started)),
+				zap.Error(err),
+			).Error("error received")
+		case <-softDone:
+			softDone = nil
+			logger.With(
+				zap.Duration("elapsed", time.Since(started)),
+				zap.Int("succeeded", succeeded),
+				zap.Int("errored", errored),
+			).Debug("soft timeout reached")
+		case <-ctx.Done():
+			hardTimedOut = true
+			logger.With(
+				zap.Duration("elapsed", time.Since(started)),
+				zap.Int("succeeded", succeeded),
+				zap.Int("errored", errored),
+			).Error("hard timeout reached")
+		}
+	}
+
+	resultLogger := logger.With(
+		zap.Duration("elapsed", time.Since(started)),
+		zap.Int("succeeded", succeeded),
+		zap.Int("errored", errored),
+		zap.Int("timed_out", numberOfRequests-succeeded-errored),
+		zap.Bool("with_weighted_sync_committee_data", true),
+	)
+	if best == nil {
+		resultLogger.Error("no responses received")
+		var zero T
+		return zero, fmt.Errorf("no %s responses received", apiName)
+	}
+
+	resultLogger.With(
+		zap.String("client_addr", best.clientAddr),
+		zap.Float64("score", best.score),
+	).Debug("selected sync committee data")
+
+	return best.data, nil
+}
+
 // SubmitSyncMessages submits a signed sync committee msg
 func (gc *GoClient) SubmitSyncMessages(msgs []*altair.SyncCommitteeMessage) error {
 	if gc.withParallelSubmissions {
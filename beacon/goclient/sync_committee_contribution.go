@@ -1,6 +1,7 @@
 package goclient
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/binary"
 	"fmt"
@@ -15,6 +16,8 @@ import (
 	spectypes "github.com/ssvlabs/ssv-spec/types"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
+
+	"github.com/ssvlabs/ssv/logging/fields"
 )
 
 // IsSyncCommitteeAggregator returns tru if aggregator
@@ -44,6 +47,57 @@ func (gc *GoClient) GetSyncCommitteeContribution(slot phase0.Slot, selectionProo
 
 	gc.waitForOneThirdSlotDuration(slot)
 
+	var (
+		blockRoot phase0.Root
+		err       error
+	)
+	if gc.withWeightedSyncCommitteeData && len(gc.clients) > 1 {
+		blockRoot, err = gc.weightedContributionBlockRoot(slot)
+	} else {
+		blockRoot, err = gc.simpleContributionBlockRoot(slot)
+	}
+	if err != nil {
+		return nil, DataVersionNil, err
+	}
+
+	gc.waitToSlotTwoThirds(slot)
+
+	// Fetch sync committee contributions for each subnet in parallel.
+	var (
+		contributions = make(spectypes.Contributions, len(subnetIDs))
+		g             errgroup.Group
+	)
+	for i := range subnetIDs {
+		index := i
+		g.Go(func() error {
+			var (
+				contribution *altair.SyncCommitteeContribution
+				err          error
+			)
+			if gc.withWeightedSyncCommitteeData && len(gc.clients) > 1 {
+				contribution, err = gc.weightedSyncCommitteeContribution(slot, subnetIDs[index], blockRoot)
+			} else {
+				contribution, err = gc.simpleSyncCommitteeContribution(slot, subnetIDs[index], blockRoot)
+			}
+			if err != nil {
+				return err
+			}
+
+			contributions[index] = &spectypes.Contribution{
+				SelectionProofSig: selectionProofs[index],
+				Contribution:      *contribution,
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, DataVersionNil, err
+	}
+
+	return &contributions, spec.DataVersionAltair, nil
+}
+
+func (gc *GoClient) simpleContributionBlockRoot(slot phase0.Slot) (phase0.Root, error) {
 	scDataReqStart := time.Now()
 	beaconBlockRootResp, err := gc.multiClient.BeaconBlockRoot(gc.ctx, &api.BeaconBlockRootOpts{
 		Block: fmt.Sprint(slot),
@@ -54,73 +108,98 @@ func (gc *GoClient) GetSyncCommitteeContribution(slot phase0.Slot, selectionProo
 			zap.String("api", "BeaconBlockRoot"),
 			zap.Error(err),
 		)
-		return nil, DataVersionNil, fmt.Errorf("failed to obtain beacon block root: %w", err)
+		return phase0.Root{}, fmt.Errorf("failed to obtain beacon block root: %w", err)
 	}
 	if beaconBlockRootResp == nil {
 		gc.log.Error(clNilResponseErrMsg,
 			zap.String("api", "BeaconBlockRoot"),
 		)
-		return nil, DataVersionNil, fmt.Errorf("beacon block root response is nil")
+		return phase0.Root{}, fmt.Errorf("beacon block root response is nil")
 	}
 	if beaconBlockRootResp.Data == nil {
 		gc.log.Error(clNilResponseDataErrMsg,
 			zap.String("api", "BeaconBlockRoot"),
 		)
-		return nil, DataVersionNil, fmt.Errorf("beacon block root data is nil")
+		return phase0.Root{}, fmt.Errorf("beacon block root data is nil")
 	}
 
-	blockRoot := beaconBlockRootResp.Data
+	return *beaconBlockRootResp.Data, nil
+}
 
-	gc.waitToSlotTwoThirds(slot)
+// weightedContributionBlockRoot requests the block root of the slot from all clients.
+// Clients which haven't received the block yet fail the request, so any root received is usable
+// and all of them score the same.
+func (gc *GoClient) weightedContributionBlockRoot(slot phase0.Slot) (phase0.Root, error) {
+	logger := gc.log.With(fields.Slot(slot), zap.String("api", "BeaconBlockRoot"))
 
-	// Fetch sync committee contributions for each subnet in parallel.
-	var (
-		contributions = make(spectypes.Contributions, 0, len(subnetIDs))
-		g             errgroup.Group
-	)
-	for i := range subnetIDs {
-		index := i
-		g.Go(func() error {
-			start := time.Now()
-			syncCommitteeContrResp, err := gc.multiClient.SyncCommitteeContribution(gc.ctx, &api.SyncCommitteeContributionOpts{
-				Slot:              slot,
-				SubcommitteeIndex: subnetIDs[index],
-				BeaconBlockRoot:   *blockRoot,
-			})
-			recordRequestDuration(gc.ctx, "SyncCommitteeContribution", gc.multiClient.Address(), http.MethodGet, time.Since(start), err)
-			if err != nil {
-				gc.log.Error(clResponseErrMsg,
-					zap.String("api", "SyncCommitteeContribution"),
-					zap.Error(err),
-				)
-				return fmt.Errorf("failed to obtain sync committee contribution: %w", err)
-			}
-			if syncCommitteeContrResp == nil {
-				gc.log.Error(clNilResponseErrMsg,
-					zap.String("api", "SyncCommitteeContribution"),
-				)
-				return fmt.Errorf("sync committee contribution response is nil")
-			}
-			if syncCommitteeContrResp.Data == nil {
-				gc.log.Error(clNilResponseDataErrMsg,
-					zap.String("api", "SyncCommitteeContribution"),
-				)
-				return fmt.Errorf("sync committee contribution data is nil")
-			}
-
-			contribution := syncCommitteeContrResp.Data
-			contributions = append(contributions, &spectypes.Contribution{
-				SelectionProofSig: selectionProofs[index],
-				Contribution:      *contribution,
-			})
-			return nil
+	return weightedSyncCommitteeData(gc, logger, "BeaconBlockRoot", func(ctx context.Context, client Client) (phase0.Root, float64, error) {
+		resp, err := client.BeaconBlockRoot(ctx, &api.BeaconBlockRootOpts{
+			Block: fmt.Sprint(slot),
 		})
+		if err != nil {
+			return phase0.Root{}, 0, err
+		}
+		if resp == nil || resp.Data == nil {
+			return phase0.Root{}, 0, fmt.Errorf("beacon block root response is nil")
+		}
+		return *resp.Data, 0, nil
+	})
+}
+
+func (gc *GoClient) simpleSyncCommitteeContribution(slot phase0.Slot, subnetID uint64, blockRoot phase0.Root) (*altair.SyncCommitteeContribution, error) {
+	start := time.Now()
+	syncCommitteeContrResp, err := gc.multiClient.SyncCommitteeContribution(gc.ctx, &api.SyncCommitteeContributionOpts{
+		Slot:              slot,
+		SubcommitteeIndex: subnetID,
+		BeaconBlockRoot:   blockRoot,
+	})
+	recordRequestDuration(gc.ctx, "SyncCommitteeContribution", gc.multiClient.Address(), http.MethodGet, time.Since(start), err)
+	if err != nil {
+		gc.log.Error(clResponseErrMsg,
+			zap.String("api", "SyncCommitteeContribution"),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to obtain sync committee contribution: %w", err)
 	}
-	if err := g.Wait(); err != nil {
-		return nil, DataVersionNil, err
+	if syncCommitteeContrResp == nil {
+		gc.log.Error(clNilResponseErrMsg,
+			zap.String("api", "SyncCommitteeContribution"),
+		)
+		return nil, fmt.Errorf("sync committee contribution response is nil")
+	}
+	if syncCommitteeContrResp.Data == nil {
+		gc.log.Error(clNilResponseDataErrMsg,
+			zap.String("api", "SyncCommitteeContribution"),
+		)
+		return nil, fmt.Errorf("sync committee contribution data is nil")
 	}
 
-	return &contributions, spec.DataVersionAltair, nil
+	return syncCommitteeContrResp.Data, nil
+}
+
+// weightedSyncCommitteeContribution requests the contribution from all clients and returns the one
+// aggregating the most sync committee messages, since the reward grows with the number of participants.
+func (gc *GoClient) weightedSyncCommitteeContribution(slot phase0.Slot, subnetID uint64, blockRoot phase0.Root) (*altair.SyncCommitteeContribution, error) {
+	logger := gc.log.With(
+		fields.Slot(slot),
+		zap.Uint64("subnet_id", subnetID),
+		zap.String("api", "SyncCommitteeContribution"),
+	)
+
+	return weightedSyncCommitteeData(gc, logger, "SyncCommitteeContribution", func(ctx context.Context, client Client) (*altair.SyncCommitteeContribution, float64, error) {
+		resp, err := client.SyncCommitteeContribution(ctx, &api.SyncCommitteeContributionOpts{
+			Slot:              slot,
+			SubcommitteeIndex: subnetID,
+			BeaconBlockRoot:   blockRoot,
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		if resp == nil || resp.Data == nil {
+			return nil, 0, fmt.Errorf("sync committee contribution response is nil")
+		}
+		return resp.Data, float64(resp.Data.AggregationBits.Count()), nil
+	})
 }
 
 // SubmitSignedContributionAndProof broadcasts to the network
@@ -0,0 +1,152 @@
+package goclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	operatordatastore "github.com/ssvlabs/ssv/operator/datastore"
+	"github.com/ssvlabs/ssv/operator/slotticker"
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+)
+
+type syncCommitteeServerOptions struct {
+	// aggregationBits is the hex encoded participation of the returned contributions.
+	aggregationBits string
+	// withoutBlock makes the server fail the block root and contribution requests, as if it hadn't received the block yet.
+	withoutBlock bool
+}
+
+// syncCommitteeServer is a beacon node serving sync committee contributions,
+// it records the requested block roots to be checked by the test rather than in the handler goroutine.
+type syncCommitteeServer struct {
+	*httptest.Server
+
+	mu                  sync.Mutex
+	requestedBlockRoots []string
+}
+
+func (s *syncCommitteeServer) RequestedBlockRoots() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requestedBlockRoots)
+}
+
+func createSyncCommitteeServer(t *testing.T, options syncCommitteeServerOptions) *syncCommitteeServer {
+	s := &syncCommitteeServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if responseBody, ok := beaconEndpointResponses[r.URL.Path]; ok {
+			_, _ = w.Write(responseBody)
+			return
+		}
+
+		var resp string
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/eth/v1/beacon/blocks/") && strings.HasSuffix(r.URL.Path, "/root"):
+			if options.withoutBlock {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"code": 404, "message": "block not found"}`))
+				return
+			}
+			resp = fmt.Sprintf(`{"data": {"root": "%s"}}`, roots[1])
+		case r.URL.Path == "/eth/v1/validator/sync_committee_contribution":
+			if options.withoutBlock {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"code": 404, "message": "contribution not found"}`))
+				return
+			}
+			s.mu.Lock()
+			s.requestedBlockRoots = append(s.requestedBlockRoots, r.URL.Query().Get("beacon_block_root"))
+			s.mu.Unlock()
+			resp = fmt.Sprintf(`{
+				"data": {
+					"slot": "%s",
+					"beacon_block_root": "%s",
+					"subcommittee_index": "%s",
+					"aggregation_bits": "%s",
+					"signature": "0x%s"
+				}
+			}`, r.URL.Query().Get("slot"), roots[1], r.URL.Query().Get("subcommittee_index"), options.aggregationBits, strings.Repeat("00", 96))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(resp))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func createSyncCommitteeClient(t *testing.T, ctx context.Context, servers ...*httptest.Server) *GoClient {
+	var addresses []string
+	for _, server := range servers {
+		addresses = append(addresses, server.URL)
+	}
+	client, err := New(zap.NewNop(),
+		beacon.Options{
+			Context:                       ctx,
+			Network:                       beacon.NewNetwork(spectypes.MainNetwork),
+			BeaconNodeAddr:                strings.Join(addresses, ";"),
+			CommonTimeout:                 defaultHardTimeout,
+			LongTimeout:                   time.Second,
+			WithWeightedSyncCommitteeData: true,
+		},
+		operatordatastore.New(&registrystorage.OperatorData{ID: 1}),
+		func() slotticker.SlotTicker {
+			return slotticker.New(zap.NewNop(), slotticker.Config{
+				SlotDuration: 12 * time.Second,
+				GenesisTime:  time.Now(),
+			})
+		},
+	)
+	require.NoError(t, err)
+	return client
+}
+
+func TestGoClient_GetSyncCommitteeContribution_Weighted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The server with the most participants doesn't have the block, so it can't provide contributions.
+	withoutBlock := createSyncCommitteeServer(t, syncCommitteeServerOptions{aggregationBits: "0xffffffffffffffffffffffffffffffff", withoutBlock: true})
+	fewer := createSyncCommitteeServer(t, syncCommitteeServerOptions{aggregationBits: "0x01000000000000000000000000000000"})
+	more := createSyncCommitteeServer(t, syncCommitteeServerOptions{aggregationBits: "0x07000000000000000000000000000000"})
+
+	client := createSyncCommitteeClient(t, ctx, fewer.Server, more.Server)
+
+	selectionProofs := []phase0.BLSSignature{{1}, {2}}
+	subnetIDs := []uint64{0, 3}
+	marshaler, _, err := client.GetSyncCommitteeContribution(100, selectionProofs, subnetIDs)
+	require.NoError(t, err)
+
+	contributions := *marshaler.(*spectypes.Contributions)
+	require.Len(t, contributions, 2)
+	for i, contribution := range contributions {
+		require.EqualValues(t, selectionProofs[i], contribution.SelectionProofSig)
+		require.Equal(t, subnetIDs[i], contribution.Contribution.SubcommitteeIndex)
+		require.Equal(t, roots[1], contribution.Contribution.BeaconBlockRoot.String())
+		require.Equal(t, uint64(3), contribution.Contribution.AggregationBits.Count())
+	}
+	for _, server := range []*syncCommitteeServer{fewer, more} {
+		require.Equal(t, []string{roots[1], roots[1]}, server.RequestedBlockRoots())
+	}
+
+	client = createSyncCommitteeClient(t, ctx, withoutBlock.Server, fewer.Server)
+	marshaler, _, err = client.GetSyncCommitteeContribution(100, selectionProofs[:1], subnetIDs[:1])
+	require.NoError(t, err)
+	contribution := (*marshaler.(*spectypes.Contributions))[0].Contribution
+	require.Equal(t, uint64(1), contribution.AggregationBits.Count())
+}
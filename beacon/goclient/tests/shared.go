@@ -34,6 +34,12 @@ func MockServer(onRequestFn requestCallback) *httptest.Server {
 		panic(fmt.Sprintf("couldn't decode json file: %v", err))
 	}
 
+	return MockServerWithResponses(mockResponses, onRequestFn)
+}
+
+// MockServerWithResponses is like MockServer, but serves the given responses by path.
+// An error returned by onRequestFn is served as an internal server error.
+func MockServerWithResponses(mockResponses map[string]json.RawMessage, onRequestFn requestCallback) *httptest.Server {
 	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		resp, ok := mockResponses[r.URL.Path]
 		if !ok {
@@ -44,7 +50,8 @@ func MockServer(onRequestFn requestCallback) *httptest.Server {
 		if onRequestFn != nil {
 			resp, err = onRequestFn(r, resp)
 			if err != nil {
-				panic(fmt.Sprintf("onRequestFn returned error: %v", err))
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
 			}
 		}
 
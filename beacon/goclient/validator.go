@@ -1,6 +1,7 @@
 package goclient
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -36,3 +37,29 @@ func (gc *GoClient) GetValidatorData(validatorPubKeys []phase0.BLSPubKey) (map[p
 
 	return resp.Data, nil
 }
+
+// ValidatorBalances returns the balances of the given validators at the first slot of the given epoch.
+func (gc *GoClient) ValidatorBalances(ctx context.Context, epoch phase0.Epoch, indices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]phase0.Gwei, error) {
+	start := time.Now()
+	resp, err := gc.multiClient.ValidatorBalances(ctx, &api.ValidatorBalancesOpts{
+		State:   fmt.Sprint(gc.network.FirstSlotAtEpoch(epoch)),
+		Indices: indices,
+		Common:  api.CommonOpts{Timeout: gc.longTimeout},
+	})
+	recordRequestDuration(gc.ctx, "ValidatorBalances", gc.multiClient.Address(), http.MethodPost, time.Since(start), err)
+	if err != nil {
+		gc.log.Error(clResponseErrMsg,
+			zap.String("api", "ValidatorBalances"),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to obtain validator balances: %w", err)
+	}
+	if resp == nil {
+		gc.log.Error(clNilResponseErrMsg,
+			zap.String("api", "ValidatorBalances"),
+		)
+		return nil, fmt.Errorf("validator balances response is nil")
+	}
+
+	return resp.Data, nil
+}
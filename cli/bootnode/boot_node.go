@@ -3,8 +3,13 @@ package bootnode
 import (
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/observability"
 	"github.com/ssvlabs/ssv/utils/commons"
 
 	"github.com/ssvlabs/ssv/logging"
@@ -56,15 +61,35 @@ var StartBootNodeCmd = &cobra.Command{
 
 		logger.Info(fmt.Sprintf("starting %v", commons.GetBuildData()))
 
-		networkConfig, err := networkconfig.GetNetworkConfigByName(cfg.Options.Network)
+		observabilityShutdown, err := observability.Initialize(
+			cmd.Parent().Short,
+			cmd.Parent().Version,
+			observability.WithMetrics())
 		if err != nil {
-			logger.Fatal("failed to get network config", zap.Error(err))
+			logger.Fatal("could not initialize observability configuration", zap.Error(err))
 		}
-		bootNode, err := bootnode.New(networkConfig, cfg.Options)
+		defer func() {
+			if err := observabilityShutdown(cmd.Context()); err != nil {
+				logger.Error("could not shutdown observability object", zap.Error(err))
+			}
+		}()
+
+		var networkConfigs []networkconfig.NetworkConfig
+		for _, name := range cfg.Options.NetworkNames() {
+			networkConfig, err := networkconfig.GetNetworkConfigByName(strings.TrimSpace(name))
+			if err != nil {
+				logger.Fatal("failed to get network config", zap.Error(err))
+			}
+			networkConfigs = append(networkConfigs, networkConfig)
+		}
+		bootNode, err := bootnode.New(networkConfigs, cfg.Options)
 		if err != nil {
 			logger.Fatal("failed to set up boot node", zap.Error(err))
 		}
-		if err := bootNode.Start(cmd.Context(), logger); err != nil {
+
+		ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+		if err := bootNode.Start(ctx, logger); err != nil {
 			logger.Fatal("failed to start boot node", zap.Error(err))
 		}
 	},
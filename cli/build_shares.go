@@ -0,0 +1,251 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"path/filepath"
+	"strings"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/spf13/cobra"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/eth/contract"
+	"github.com/ssvlabs/ssv/eth/sharesdata"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/operator/keys"
+	"github.com/ssvlabs/ssv/utils/threshold"
+)
+
+// buildSharesOperator is an entry of the operators file.
+type buildSharesOperator struct {
+	ID uint64 `json:"id"`
+	// PublicKey is the base64 encoded PEM public key, as registered in the contract.
+	PublicKey string `json:"publicKey"`
+}
+
+type buildSharesShare struct {
+	OperatorID   uint64        `json:"operatorId"`
+	PublicKey    hexutil.Bytes `json:"publicKey"`
+	EncryptedKey hexutil.Bytes `json:"encryptedKey"`
+}
+
+// clusterJSON is the cluster snapshot argument of the registry contract calls.
+type clusterJSON struct {
+	ValidatorCount  uint32 `json:"validatorCount"`
+	NetworkFeeIndex uint64 `json:"networkFeeIndex"`
+	Index           uint64 `json:"index"`
+	Active          bool   `json:"active"`
+	Balance         string `json:"balance"`
+}
+
+type registerValidatorCall struct {
+	Method      string        `json:"method"`
+	PublicKey   hexutil.Bytes `json:"publicKey"`
+	OperatorIDs []uint64      `json:"operatorIds"`
+	Shares      hexutil.Bytes `json:"shares"`
+	Amount      string        `json:"amount"`
+	Cluster     clusterJSON   `json:"cluster"`
+	// Calldata is the ABI encoded call, to be sent to the SSV network contract.
+	Calldata hexutil.Bytes `json:"calldata"`
+}
+
+type buildSharesOutput struct {
+	PublicKey         hexutil.Bytes         `json:"publicKey"`
+	OwnerAddress      ethcommon.Address     `json:"ownerAddress"`
+	OwnerNonce        uint64                `json:"ownerNonce"`
+	OperatorIDs       []uint64              `json:"operatorIds"`
+	SharesData        hexutil.Bytes         `json:"sharesData"`
+	Shares            []buildSharesShare    `json:"shares"`
+	RegisterValidator registerValidatorCall `json:"registerValidator"`
+}
+
+// buildSharesCmd splits a validator key between operators and builds the payload to register it
+var buildSharesCmd = &cobra.Command{
+	Use:   "build-shares",
+	Short: "Splits a validator keystore into encrypted operator shares and builds the registerValidator payload",
+	Long: `Splits the validator key between the operators, encrypts each share with its operator public key
+and signs the owner address and nonce, producing the shares data the node expects in the ValidatorAdded event.
+The operators file is a JSON list of {"id": <operator ID>, "publicKey": "<base64 public key>"}.
+The nonce must be the number of validators the owner has registered so far, otherwise the nodes reject the shares.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := logging.SetGlobalLogger("info", "capital", "console", nil); err != nil {
+			log.Fatal(err)
+		}
+		logger := zap.L().Named(logging.NameBuildShares)
+
+		keystorePath, _ := cmd.Flags().GetString("keystore")
+		passwordFile, _ := cmd.Flags().GetString("password-file")
+		operatorsFile, _ := cmd.Flags().GetString("operators-file")
+		ownerAddress, _ := cmd.Flags().GetString("owner-address")
+		ownerNonce, _ := cmd.Flags().GetUint64("owner-nonce")
+		amountString, _ := cmd.Flags().GetString("amount")
+		clusterString, _ := cmd.Flags().GetString("cluster")
+		outputPath, _ := cmd.Flags().GetString("output")
+
+		if !ethcommon.IsHexAddress(ownerAddress) {
+			logger.Fatal("invalid owner address", zap.String("owner_address", ownerAddress))
+		}
+		owner := ethcommon.HexToAddress(ownerAddress)
+		amount, ok := new(big.Int).SetString(amountString, 10)
+		if !ok {
+			logger.Fatal("invalid amount", zap.String("amount", amountString))
+		}
+		var cluster clusterJSON
+		if err := json.Unmarshal([]byte(clusterString), &cluster); err != nil {
+			logger.Fatal("invalid cluster", zap.Error(err))
+		}
+		clusterBalance, ok := new(big.Int).SetString(cluster.Balance, 10)
+		if !ok {
+			logger.Fatal("invalid cluster balance", zap.String("balance", cluster.Balance))
+		}
+
+		threshold.Init()
+		validatorKey, err := readValidatorKeystore(keystorePath, passwordFile)
+		if err != nil {
+			logger.Fatal("could not read validator keystore", zap.Error(err))
+		}
+		operators, err := readOperators(operatorsFile)
+		if err != nil {
+			logger.Fatal("could not read operators", zap.Error(err))
+		}
+
+		shares, err := sharesdata.Build(validatorKey, operators, owner, ownerNonce)
+		if err != nil {
+			logger.Fatal("could not build shares", zap.Error(err))
+		}
+
+		out := buildSharesOutput{
+			PublicKey:    shares.ValidatorPublicKey,
+			OwnerAddress: owner,
+			OwnerNonce:   ownerNonce,
+			OperatorIDs:  shares.OperatorIDs(),
+			SharesData:   shares.Bytes(),
+			RegisterValidator: registerValidatorCall{
+				Method:      "registerValidator",
+				PublicKey:   shares.ValidatorPublicKey,
+				OperatorIDs: shares.OperatorIDs(),
+				Shares:      shares.Bytes(),
+				Amount:      amount.String(),
+				Cluster:     cluster,
+			},
+		}
+		for _, share := range shares.Shares {
+			out.Shares = append(out.Shares, buildSharesShare{
+				OperatorID:   share.OperatorID,
+				PublicKey:    share.PublicKey,
+				EncryptedKey: share.EncryptedKey,
+			})
+		}
+
+		contractABI, err := contract.ContractMetaData.GetAbi()
+		if err != nil {
+			logger.Fatal("could not parse contract ABI", zap.Error(err))
+		}
+		out.RegisterValidator.Calldata, err = contractABI.Pack("registerValidator",
+			shares.ValidatorPublicKey,
+			shares.OperatorIDs(),
+			shares.Bytes(),
+			amount,
+			contract.ISSVNetworkCoreCluster{
+				ValidatorCount:  cluster.ValidatorCount,
+				NetworkFeeIndex: cluster.NetworkFeeIndex,
+				Index:           cluster.Index,
+				Active:          cluster.Active,
+				Balance:         clusterBalance,
+			},
+		)
+		if err != nil {
+			logger.Fatal("could not encode registerValidator call", zap.Error(err))
+		}
+
+		data, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			logger.Fatal("could not encode output", zap.Error(err))
+		}
+		if outputPath == "" {
+			fmt.Println(string(data))
+			return
+		}
+		if err := writeFile(outputPath, data); err != nil {
+			logger.Fatal("could not write output", zap.Error(err))
+		}
+		logger.Info("built shares",
+			zap.String("validator", hexutil.Encode(shares.ValidatorPublicKey)),
+			zap.Uint64s("operator_ids", shares.OperatorIDs()),
+			zap.String("output", outputPath))
+	},
+}
+
+// readValidatorKeystore decrypts an EIP-2335 validator keystore.
+func readValidatorKeystore(keystorePath, passwordFile string) (*bls.SecretKey, error) {
+	keystoreJSON, err := readFile(keystorePath)
+	if err != nil {
+		return nil, err
+	}
+	password, err := readFile(passwordFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not read password file: %w", err)
+	}
+
+	var keystore struct {
+		Crypto map[string]any `json:"crypto"`
+	}
+	if err := json.Unmarshal(keystoreJSON, &keystore); err != nil {
+		return nil, fmt.Errorf("could not parse keystore: %w", err)
+	}
+	if keystore.Crypto == nil {
+		return nil, fmt.Errorf("keystore has no crypto section")
+	}
+	secret, err := keystorev4.New().Decrypt(keystore.Crypto, strings.TrimRight(string(password), "\r\n"))
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt keystore: %w", err)
+	}
+
+	sk := &bls.SecretKey{}
+	if err := sk.Deserialize(secret); err != nil {
+		return nil, fmt.Errorf("could not deserialize validator key: %w", err)
+	}
+	return sk, nil
+}
+
+func readOperators(operatorsFile string) ([]sharesdata.Operator, error) {
+	data, err := readFile(filepath.Clean(operatorsFile))
+	if err != nil {
+		return nil, err
+	}
+	var entries []buildSharesOperator
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("could not parse operators: %w", err)
+	}
+	operators := make([]sharesdata.Operator, len(entries))
+	for i, entry := range entries {
+		pubKey, err := keys.PublicKeyFromString(entry.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key of operator %d: %w", entry.ID, err)
+		}
+		operators[i] = sharesdata.Operator{ID: entry.ID, PublicKey: pubKey}
+	}
+	return operators, nil
+}
+
+func init() {
+	buildSharesCmd.Flags().String("keystore", "", "Path to the EIP-2335 validator keystore")
+	buildSharesCmd.Flags().String("password-file", "", "Path to the validator keystore password file")
+	buildSharesCmd.Flags().String("operators-file", "", "Path to a JSON file with the IDs and public keys of the operators")
+	buildSharesCmd.Flags().String("owner-address", "", "Address of the validator owner, which sends the registerValidator transaction")
+	buildSharesCmd.Flags().Uint64("owner-nonce", 0, "Number of validators the owner has registered so far")
+	buildSharesCmd.Flags().String("amount", "0", "SSV amount in wei to deposit into the cluster")
+	buildSharesCmd.Flags().String("cluster", `{"validatorCount":0,"networkFeeIndex":0,"index":0,"active":true,"balance":"0"}`, "Current cluster snapshot as JSON, defaults to a new cluster")
+	buildSharesCmd.Flags().String("output", "", "File to write the output JSON into, printed if not set")
+	_ = buildSharesCmd.MarkFlagRequired("keystore")
+	_ = buildSharesCmd.MarkFlagRequired("password-file")
+	_ = buildSharesCmd.MarkFlagRequired("operators-file")
+	_ = buildSharesCmd.MarkFlagRequired("owner-address")
+	RootCmd.AddCommand(buildSharesCmd)
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Change is a config field whose value differs between two configs.
+type Change struct {
+	// Field is the path of the field, made of the yaml names of the field and its parents.
+	Field string
+	// Reloadable is whether the field is tagged with `reload:"true"`, meaning it can be changed at runtime.
+	Reloadable bool
+}
+
+// Diff returns the fields whose values differ between two configs of the same struct type.
+// Only fields with a yaml tag are compared, the others are set at runtime.
+func Diff(old, new any) []Change {
+	var changes []Change
+	diff(reflect.Indirect(reflect.ValueOf(old)), reflect.Indirect(reflect.ValueOf(new)), "", &changes)
+	return changes
+}
+
+func diff(old, new reflect.Value, prefix string, changes *[]Change) {
+	for i := 0; i < old.NumField(); i++ {
+		field := old.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		path := prefix + name
+		reloadable := field.Tag.Get("reload") == "true"
+
+		if field.Type.Kind() == reflect.Struct && !reloadable {
+			diff(old.Field(i), new.Field(i), path+".", changes)
+			continue
+		}
+		if !reflect.DeepEqual(old.Field(i).Interface(), new.Field(i).Interface()) {
+			*changes = append(*changes, Change{Field: path, Reloadable: reloadable})
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging"
+	p2pcommons "github.com/ssvlabs/ssv/network/commons"
+	"github.com/ssvlabs/ssv/network/crawler"
+	"github.com/ssvlabs/ssv/networkconfig"
+)
+
+const (
+	crawlFormatJSON = "json"
+	crawlFormatCSV  = "csv"
+	// censusFileName is the file in the output directory the census of every snapshot is appended to
+	censusFileName = "census.jsonl"
+)
+
+// crawlCmd crawls the network and writes snapshots of the discovered nodes
+var crawlCmd = &cobra.Command{
+	Use:   "crawl",
+	Short: "Crawls the SSV network over discv5, handshakes with the discovered nodes and reports subnet coverage and client diversity",
+	Run: func(cmd *cobra.Command, args []string) {
+		logLevel, _ := cmd.Flags().GetString("log-level")
+		if err := logging.SetGlobalLogger(logLevel, "capital", "console", nil); err != nil {
+			log.Fatal(err)
+		}
+		logger := zap.L().Named(logging.NameCrawler)
+
+		networkName, _ := cmd.Flags().GetString("network")
+		bootnodes, _ := cmd.Flags().GetStringSlice("bootnodes")
+		udpPort, _ := cmd.Flags().GetUint16("udp-port")
+		tcpPort, _ := cmd.Flags().GetUint16("tcp-port")
+		outputDir, _ := cmd.Flags().GetString("output-dir")
+		format, _ := cmd.Flags().GetString("format")
+		snapshotInterval, _ := cmd.Flags().GetDuration("snapshot-interval")
+		duration, _ := cmd.Flags().GetDuration("duration")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		handshakeTimeout, _ := cmd.Flags().GetDuration("handshake-timeout")
+		recrawlInterval, _ := cmd.Flags().GetDuration("recrawl-interval")
+
+		if format != crawlFormatJSON && format != crawlFormatCSV {
+			logger.Fatal("unknown snapshot format", zap.String("format", format))
+		}
+		if snapshotInterval <= 0 {
+			logger.Fatal("snapshot interval must be positive")
+		}
+
+		networkConfig, err := networkconfig.GetNetworkConfigByName(networkName)
+		if err != nil {
+			logger.Fatal("failed to get network config", zap.Error(err))
+		}
+		if err := os.MkdirAll(outputDir, 0750); err != nil {
+			logger.Fatal("failed to create output directory", zap.Error(err))
+		}
+		networkKey, err := p2pcommons.GenNetworkKey()
+		if err != nil {
+			logger.Fatal("failed to generate network key", zap.Error(err))
+		}
+
+		ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+		if duration > 0 {
+			ctx, cancel = context.WithTimeout(ctx, duration)
+			defer cancel()
+		}
+
+		c, err := crawler.New(ctx, logger, crawler.Options{
+			NetworkConfig:    networkConfig,
+			NetworkKey:       networkKey,
+			UDPPort:          udpPort,
+			TCPPort:          tcpPort,
+			Bootnodes:        bootnodes,
+			HandshakeTimeout: handshakeTimeout,
+			Concurrency:      concurrency,
+			RecrawlInterval:  recrawlInterval,
+		})
+		if err != nil {
+			logger.Fatal("failed to create crawler", zap.Error(err))
+		}
+		go c.Start()
+
+		logger.Info("crawling network",
+			zap.String("network", networkConfig.Name),
+			zap.String("output_dir", outputDir),
+			zap.Duration("snapshot_interval", snapshotInterval))
+
+		ticker := time.NewTicker(snapshotInterval)
+		defer ticker.Stop()
+		for done := false; !done; {
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				done = true
+			}
+			if err := writeCrawlSnapshot(logger, c.Snapshot(), outputDir, format); err != nil {
+				logger.Error("failed to write snapshot", zap.Error(err))
+			}
+		}
+
+		if err := c.Close(); err != nil {
+			logger.Warn("failed to close crawler", zap.Error(err))
+		}
+	},
+}
+
+// writeCrawlSnapshot writes the snapshot into its own file, appends its census to the census file and logs it.
+func writeCrawlSnapshot(logger *zap.Logger, snapshot *crawler.Snapshot, outputDir, format string) error {
+	fileName := filepath.Join(outputDir, fmt.Sprintf("snapshot-%s.%s", snapshot.Time.UTC().Format("20060102T150405Z"), format))
+	f, err := os.Create(filepath.Clean(fileName))
+	if err != nil {
+		return err
+	}
+	if format == crawlFormatCSV {
+		err = snapshot.WriteCSV(f)
+	} else {
+		err = snapshot.WriteJSON(f)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("could not write %s: %w", fileName, err)
+	}
+
+	census := snapshot.Census()
+	line, err := json.Marshal(census)
+	if err != nil {
+		return err
+	}
+	cf, err := os.OpenFile(filepath.Join(outputDir, censusFileName), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = cf.Write(append(line, '\n'))
+	if closeErr := cf.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("could not append census: %w", err)
+	}
+
+	logger.Info("crawl snapshot",
+		zap.String("file", fileName),
+		zap.Int("discovered", census.Discovered),
+		zap.Int("handshaked", census.Handshaked),
+		zap.Int("covered_subnets", census.CoveredSubnets),
+		zap.Strings("weakest_subnets", census.WeakestSubnets(5)),
+		zap.Any("node_versions", census.NodeVersions),
+		zap.Any("execution_clients", census.ExecutionClients),
+		zap.Any("consensus_clients", census.ConsensusClients),
+	)
+	return nil
+}
+
+func init() {
+	crawlCmd.Flags().String("network", "mainnet", "Network to crawl")
+	crawlCmd.Flags().StringSlice("bootnodes", nil, "Bootnodes to start crawling from, defaults to the network bootnodes")
+	crawlCmd.Flags().Uint16("udp-port", p2pcommons.DefaultUDP, "Local UDP port for discovery")
+	crawlCmd.Flags().Uint16("tcp-port", p2pcommons.DefaultTCP, "Local TCP port for handshakes")
+	crawlCmd.Flags().String("output-dir", "./crawl", "Directory to write snapshots and the census into")
+	crawlCmd.Flags().String("format", crawlFormatJSON, "Snapshot format: json or csv")
+	crawlCmd.Flags().Duration("snapshot-interval", 10*time.Minute, "Interval between snapshots")
+	crawlCmd.Flags().Duration("duration", 0, "Crawling duration, crawls until interrupted if 0")
+	crawlCmd.Flags().Int("concurrency", 16, "Number of parallel handshakes")
+	crawlCmd.Flags().Duration("handshake-timeout", 10*time.Second, "Timeout for connecting to and handshaking with a node")
+	crawlCmd.Flags().Duration("recrawl-interval", 30*time.Minute, "Time to wait before handshaking with an already crawled node again")
+	crawlCmd.Flags().String("log-level", "info", "Log level")
+	RootCmd.AddCommand(crawlCmd)
+}
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging"
+)
+
+// exitValidatorsCmd requests voluntary exits of validators from a running node through its SSV API
+var exitValidatorsCmd = &cobra.Command{
+	Use:   "exit-validators [validator public keys...]",
+	Short: "Starts voluntary exit duties of validators on a running node through its SSV API",
+	Long: `Starts voluntary exit duties of the given validators on a running node, the same way a ValidatorExited contract event does.
+Every operator of the validators' cluster must request the exit with the same epoch, so pass an explicit --epoch
+when coordinating between operators. Exits are recorded by the node, so repeating a request doesn't repeat the exit.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := logging.SetGlobalLogger("info", "capital", "console", nil); err != nil {
+			log.Fatal(err)
+		}
+		logger := zap.L().Named(logging.NameExitValidators)
+
+		apiURL, _ := cmd.Flags().GetString("api")
+		tokenFile, _ := cmd.Flags().GetString("token-file")
+		epoch, _ := cmd.Flags().GetUint64("epoch")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		token := os.Getenv("SSV_API_TOKEN")
+		if tokenFile != "" {
+			data, err := readFile(tokenFile)
+			if err != nil {
+				logger.Fatal("could not read token file", zap.Error(err))
+			}
+			token = strings.TrimSpace(string(data))
+		}
+		if token == "" {
+			logger.Fatal("an API token is required, set --token-file or SSV_API_TOKEN")
+		}
+
+		body, err := json.Marshal(map[string]any{
+			"pubkeys": args,
+			"epoch":   epoch,
+			"dry_run": dryRun,
+		})
+		if err != nil {
+			logger.Fatal("could not encode request", zap.Error(err))
+		}
+		req, err := http.NewRequestWithContext(cmd.Context(), http.MethodPost, strings.TrimSuffix(apiURL, "/")+"/v1/validators/exit", bytes.NewReader(body))
+		if err != nil {
+			logger.Fatal("could not create request", zap.Error(err))
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		client := &http.Client{Timeout: 30 * time.Second}
+		resp, err := client.Do(req)
+		if err != nil {
+			logger.Fatal("could not send request", zap.Error(err))
+		}
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			logger.Fatal("could not read response", zap.Error(err))
+		}
+		if resp.StatusCode != http.StatusOK {
+			logger.Fatal("exit request failed", zap.Int("status", resp.StatusCode), zap.String("response", string(respBody)))
+		}
+
+		var response struct {
+			Data []struct {
+				PubKey         string          `json:"public_key"`
+				ValidatorIndex uint64          `json:"validator_index"`
+				Epoch          uint64          `json:"epoch"`
+				Slot           uint64          `json:"slot"`
+				Message        json.RawMessage `json:"message"`
+				Status         string          `json:"status"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(respBody, &response); err != nil {
+			logger.Fatal("could not decode response", zap.Error(err))
+		}
+
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "VALIDATOR\tINDEX\tEPOCH\tSLOT\tSTATUS\tMESSAGE")
+		for _, exit := range response.Data {
+			_, _ = fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%s\t%s\n", exit.PubKey, exit.ValidatorIndex, exit.Epoch, exit.Slot, exit.Status, exit.Message)
+		}
+		_ = tw.Flush()
+	},
+}
+
+func init() {
+	exitValidatorsCmd.Flags().String("api", "http://localhost:16000", "SSV API URL of the node")
+	exitValidatorsCmd.Flags().String("token-file", "", "File containing the SSV API token, defaults to the SSV_API_TOKEN environment variable")
+	exitValidatorsCmd.Flags().Uint64("epoch", 0, "Epoch of the exit, defaults to the next epoch")
+	exitValidatorsCmd.Flags().Bool("dry-run", false, "Show the exit messages without starting the exit duties")
+	RootCmd.AddCommand(exitValidatorsCmd)
+}
@@ -4,12 +4,14 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/ssvlabs/ssv/operator/keystore"
 
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 
+	"github.com/ssvlabs/ssv/cli/operator"
 	"github.com/ssvlabs/ssv/logging"
 	"github.com/ssvlabs/ssv/operator/keys"
 )
@@ -26,12 +28,19 @@ var generateOperatorKeysCmd = &cobra.Command{
 		passwordFilePath, _ := cmd.Flags().GetString("password-file")
 		privateKeyFilePath, _ := cmd.Flags().GetString("operator-key-file")
 
-		privKey, err := keys.GeneratePrivateKey()
-		if err != nil {
-			logger.Fatal("Failed to generate keys", zap.Error(err))
-		}
-
-		if privateKeyFilePath != "" {
+		newMnemonic, _ := cmd.Flags().GetBool("new-mnemonic")
+		mnemonicFilePath, _ := cmd.Flags().GetString("mnemonic-file")
+		passphraseFilePath, _ := cmd.Flags().GetString("mnemonic-passphrase-file")
+		keyIndex, _ := cmd.Flags().GetUint64("key-index")
+
+		var privKey keys.OperatorPrivateKey
+		var err error
+		switch {
+		case newMnemonic && mnemonicFilePath != "":
+			logger.Fatal("new-mnemonic and mnemonic-file can't be used together")
+		case privateKeyFilePath != "" && (newMnemonic || mnemonicFilePath != ""):
+			logger.Fatal("operator-key-file can't be used with a mnemonic")
+		case privateKeyFilePath != "":
 			keyBytes, err := readFile(privateKeyFilePath)
 			if err != nil {
 				logger.Fatal("Failed to read private key from file", zap.Error(err))
@@ -41,6 +50,45 @@ var generateOperatorKeysCmd = &cobra.Command{
 			if err != nil {
 				logger.Fatal("Failed to parse private key", zap.Error(err))
 			}
+		case newMnemonic || mnemonicFilePath != "":
+			var mnemonic string
+			if newMnemonic {
+				mnemonic, err = keys.GenerateMnemonic()
+				if err != nil {
+					logger.Fatal("Failed to generate mnemonic", zap.Error(err))
+				}
+			} else {
+				mnemonicBytes, err := readFile(mnemonicFilePath)
+				if err != nil {
+					logger.Fatal("Failed to read mnemonic file", zap.Error(err))
+				}
+				mnemonic = strings.Join(strings.Fields(string(mnemonicBytes)), " ")
+			}
+
+			var passphrase string
+			if passphraseFilePath != "" {
+				passphraseBytes, err := readFile(passphraseFilePath)
+				if err != nil {
+					logger.Fatal("Failed to read mnemonic passphrase file", zap.Error(err))
+				}
+				passphrase = strings.TrimRight(string(passphraseBytes), "\r\n")
+			}
+
+			privKey, err = keys.PrivateKeyFromMnemonic(mnemonic, passphrase, keyIndex)
+			if err != nil {
+				logger.Fatal("Failed to derive private key from mnemonic", zap.Error(err))
+			}
+			if newMnemonic {
+				logger.Info("generated mnemonic, write it down and keep it offline as anyone with it can recreate the operator key",
+					zap.String("mnemonic", mnemonic),
+					zap.Uint64("key_index", keyIndex),
+				)
+			}
+		default:
+			privKey, err = keys.GeneratePrivateKey()
+			if err != nil {
+				logger.Fatal("Failed to generate keys", zap.Error(err))
+			}
 		}
 
 		pubKeyBase64, err := privKey.Public().Base64()
@@ -89,5 +137,10 @@ func readFile(filePath string) ([]byte, error) {
 func init() {
 	generateOperatorKeysCmd.Flags().StringP("password-file", "p", "", "File path to the password used to encrypt the private key")
 	generateOperatorKeysCmd.Flags().StringP("operator-key-file", "o", "", "File path to the operator private key")
+	generateOperatorKeysCmd.Flags().Bool("new-mnemonic", false, "Generate a new BIP-39 mnemonic and derive the private key from it, so that the key can be recreated from the mnemonic")
+	generateOperatorKeysCmd.Flags().String("mnemonic-file", "", "File path to a BIP-39 mnemonic to recreate the private key from")
+	generateOperatorKeysCmd.Flags().String("mnemonic-passphrase-file", "", "File path to the optional BIP-39 passphrase of the mnemonic")
+	generateOperatorKeysCmd.Flags().Uint64("key-index", 0, "Index of the private key derived from the mnemonic")
+	generateOperatorKeysCmd.AddCommand(operator.VerifyOperatorKeyCmd)
 	RootCmd.AddCommand(generateOperatorKeysCmd)
 }
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging"
+)
+
+// maintenanceWindowCmd finds windows for restarting a running node through its SSV API
+var maintenanceWindowCmd = &cobra.Command{
+	Use:   "maintenance-window",
+	Short: "Finds windows in which none of the operator's validators propose, through the SSV API of a running node",
+	Long: `Finds windows of at least --duration in which none of the operator's validators propose a block,
+ranked by the fewest attestation duties and then the fewest sync committee duties in the window.
+Proposers are only known until the end of the next epoch, so longer windows can't be found,
+and those of the next epoch may still change, so windows reaching into it are marked provisional.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := logging.SetGlobalLogger("info", "capital", "console", nil); err != nil {
+			log.Fatal(err)
+		}
+		logger := zap.L().Named(logging.NameMaintenanceWindow)
+
+		apiURL, _ := cmd.Flags().GetString("api")
+		duration, _ := cmd.Flags().GetDuration("duration")
+		limit, _ := cmd.Flags().GetUint64("limit")
+
+		query := url.Values{}
+		query.Set("duration", duration.String())
+		query.Set("limit", fmt.Sprint(limit))
+		req, err := http.NewRequestWithContext(cmd.Context(), http.MethodGet, strings.TrimSuffix(apiURL, "/")+"/v1/maintenance/windows?"+query.Encode(), nil)
+		if err != nil {
+			logger.Fatal("could not create request", zap.Error(err))
+		}
+		req.Header.Set("Accept", "application/json")
+
+		client := &http.Client{Timeout: 30 * time.Second}
+		resp, err := client.Do(req)
+		if err != nil {
+			logger.Fatal("could not send request", zap.Error(err))
+		}
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			logger.Fatal("could not read response", zap.Error(err))
+		}
+		if resp.StatusCode != http.StatusOK {
+			logger.Fatal("maintenance window request failed", zap.Int("status", resp.StatusCode), zap.String("response", string(respBody)))
+		}
+
+		type window struct {
+			StartSlot           uint64    `json:"start_slot"`
+			EndSlot             uint64    `json:"end_slot"`
+			StartTime           time.Time `json:"start_time"`
+			EndTime             time.Time `json:"end_time"`
+			AttestationDuties   int       `json:"attestation_duties"`
+			SyncCommitteeDuties int       `json:"sync_committee_duties"`
+			Provisional         bool      `json:"provisional"`
+		}
+		var response struct {
+			Data struct {
+				HorizonSlot uint64    `json:"horizon_slot"`
+				Next        *window   `json:"next"`
+				Windows     []*window `json:"windows"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(respBody, &response); err != nil {
+			logger.Fatal("could not decode response", zap.Error(err))
+		}
+
+		if response.Data.Next == nil {
+			fmt.Printf("No window of %s without proposals before slot %d\n", duration, response.Data.HorizonSlot)
+			return
+		}
+
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "\tSTART\tEND\tSLOTS\tATTESTATIONS\tSYNC COMMITTEE\tPROVISIONAL")
+		printWindow := func(label string, w *window) {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%d-%d\t%d\t%d\t%t\n", label, w.StartTime.Local().Format(time.TimeOnly), w.EndTime.Local().Format(time.TimeOnly), w.StartSlot, w.EndSlot-1, w.AttestationDuties, w.SyncCommitteeDuties, w.Provisional)
+		}
+		printWindow("next", response.Data.Next)
+		for i, w := range response.Data.Windows {
+			printWindow(fmt.Sprintf("#%d", i+1), w)
+		}
+		_ = tw.Flush()
+	},
+}
+
+func init() {
+	maintenanceWindowCmd.Flags().String("api", "http://localhost:16000", "SSV API URL of the node")
+	maintenanceWindowCmd.Flags().Duration("duration", 5*time.Minute, "Minimum length of the window")
+	maintenanceWindowCmd.Flags().Uint64("limit", 3, "Number of ranked windows to show")
+	RootCmd.AddCommand(maintenanceWindowCmd)
+}
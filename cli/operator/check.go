@@ -0,0 +1,351 @@
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/beacon/goclient"
+	networkcommons "github.com/ssvlabs/ssv/network/commons"
+	"github.com/ssvlabs/ssv/network/discovery"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/nodeprobe"
+	operatordatastore "github.com/ssvlabs/ssv/operator/datastore"
+	"github.com/ssvlabs/ssv/operator/graffiti"
+	"github.com/ssvlabs/ssv/operator/keys"
+	"github.com/ssvlabs/ssv/operator/keys/external"
+	"github.com/ssvlabs/ssv/operator/slotticker"
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+// checkTimeout bounds the time spent connecting to and probing the Ethereum nodes.
+const checkTimeout = time.Minute
+
+type checkStatus string
+
+const (
+	checkOK   checkStatus = "ok"
+	checkWarn checkStatus = "warn"
+	checkFail checkStatus = "fail"
+)
+
+// checkItem is the outcome of a single start-node --check item.
+type checkItem struct {
+	Name   string      `json:"name"`
+	Status checkStatus `json:"status"`
+	Detail string      `json:"detail,omitempty"`
+}
+
+// checkReport is the outcome of start-node --check.
+type checkReport struct {
+	Items []checkItem `json:"items"`
+}
+
+// add adds an item which failed if err isn't nil, otherwise passed with the given detail.
+func (r *checkReport) add(name string, err error, detail string) {
+	if err != nil {
+		r.Items = append(r.Items, checkItem{Name: name, Status: checkFail, Detail: err.Error()})
+		return
+	}
+	r.Items = append(r.Items, checkItem{Name: name, Status: checkOK, Detail: detail})
+}
+
+func (r *checkReport) warn(name, detail string) {
+	r.Items = append(r.Items, checkItem{Name: name, Status: checkWarn, Detail: detail})
+}
+
+func (r *checkReport) failed() bool {
+	for _, item := range r.Items {
+		if item.Status == checkFail {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *checkReport) print(w io.Writer, format string) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	case "text", "":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "CHECK\tSTATUS\tDETAIL")
+		for _, item := range r.Items {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", item.Name, item.Status, item.Detail)
+		}
+		return tw.Flush()
+	default:
+		return fmt.Errorf("unknown check output format %q", format)
+	}
+}
+
+// runChecks validates the loaded configuration the way start-node would use it,
+// without writing to the DB or joining the network.
+func runChecks(ctx context.Context, logger *zap.Logger) *checkReport {
+	report := &checkReport{}
+
+	networkConfig, err := setupSSVNetwork(logger)
+	report.add("network", err, networkConfig.Name)
+	networkOK := err == nil
+
+	operatorKey, operatorKeyText, err := checkOperatorKey()
+	if err != nil {
+		report.add("operator key", err, "")
+	} else {
+		pubKey, err := operatorKey.Public().Base64()
+		report.add("operator key", err, string(pubKey))
+	}
+
+	report.add("subnets", checkSubnets(cfg.P2pNetworkConfig.Subnets), cfg.P2pNetworkConfig.Subnets)
+
+	if networkOK {
+		cfg.P2pNetworkConfig.Network = networkConfig
+	}
+	bootnodes := cfg.P2pNetworkConfig.TransformBootnodes()
+	_, err = discovery.ParseENR(nil, false, bootnodes...)
+	report.add("bootnodes", err, fmt.Sprintf("%d bootnodes", len(bootnodes)))
+
+	report.add("graffiti", graffiti.Validate(cfg.Graffiti), cfg.Graffiti)
+	if cfg.GraffitiOverridesFile != "" {
+		overrides, err := graffiti.LoadOverrides(cfg.GraffitiOverridesFile)
+		report.add("graffiti overrides", err, fmt.Sprintf("%d validators", len(overrides)))
+	}
+
+	if cfg.LocalEventsPath != "" {
+		_, err := os.Stat(cfg.LocalEventsPath)
+		report.add("local events", err, cfg.LocalEventsPath)
+	}
+
+	report.Items = append(report.Items, checkPorts(configuredPorts())...)
+
+	if networkOK {
+		checkDB(logger, report, cfg.DBOptions.Path, &operatorstorage.ConfigLock{
+			NetworkName:      networkConfig.NetworkName(),
+			UsingLocalEvents: cfg.LocalEventsPath != "",
+		}, operatorKey, operatorKeyText)
+
+		ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+		defer cancel()
+		checkEthereumNodes(ctx, logger, report, networkConfig)
+	}
+
+	return report
+}
+
+// checkOperatorKey loads the configured operator key, closing it if it's an external key.
+// The key text is empty for external keys.
+func checkOperatorKey() (keys.OperatorKey, string, error) {
+	if cfg.ExternalOperatorKey.Enabled() {
+		externalKey, err := external.New(cfg.ExternalOperatorKey)
+		if err != nil {
+			return nil, "", fmt.Errorf("could not open external operator key: %w", err)
+		}
+		if err := externalKey.Close(); err != nil {
+			return nil, "", fmt.Errorf("could not close external operator key: %w", err)
+		}
+		return externalKey, "", nil
+	}
+	return readOperatorPrivateKey()
+}
+
+func checkSubnets(subnets string) error {
+	if subnets == "" {
+		return nil
+	}
+	parsed, err := networkcommons.FromString(subnets)
+	if err != nil {
+		return err
+	}
+	if len(parsed) != networkcommons.SubnetsCount {
+		return fmt.Errorf("expected %d subnets, got %d", networkcommons.SubnetsCount, len(parsed))
+	}
+	return nil
+}
+
+// configuredPort is a port the node listens on.
+type configuredPort struct {
+	name    string
+	network string
+	port    int
+}
+
+func configuredPorts() []configuredPort {
+	ports := []configuredPort{
+		{name: "p2p", network: "tcp", port: int(cfg.P2pNetworkConfig.TCPPort)},
+		{name: "discovery", network: "udp", port: int(cfg.P2pNetworkConfig.UDPPort)},
+		{name: "metrics API", network: "tcp", port: cfg.MetricsAPIPort},
+		{name: "SSV API", network: "tcp", port: cfg.SSVAPIPort},
+		{name: "websocket API", network: "tcp", port: cfg.WsAPIPort},
+	}
+	enabled := ports[:0]
+	for _, p := range ports {
+		if p.port > 0 {
+			enabled = append(enabled, p)
+		}
+	}
+	return enabled
+}
+
+// checkPorts checks that the ports are neither used twice nor taken by another process.
+func checkPorts(ports []configuredPort) []checkItem {
+	items := make([]checkItem, 0, len(ports))
+	seen := make(map[string]string)
+	for _, p := range ports {
+		name := fmt.Sprintf("port %s/%d", p.network, p.port)
+		key := fmt.Sprintf("%s/%d", p.network, p.port)
+		if other, ok := seen[key]; ok {
+			items = append(items, checkItem{Name: name, Status: checkFail, Detail: fmt.Sprintf("%s port is already used by %s", p.name, other)})
+			continue
+		}
+		seen[key] = p.name
+
+		if err := checkPortFree(p.network, p.port); err != nil {
+			items = append(items, checkItem{Name: name, Status: checkFail, Detail: fmt.Sprintf("%s port is not free: %v", p.name, err)})
+			continue
+		}
+		items = append(items, checkItem{Name: name, Status: checkOK, Detail: p.name})
+	}
+	return items
+}
+
+func checkPortFree(network string, port int) error {
+	addr := fmt.Sprintf(":%d", port)
+	if network == "udp" {
+		conn, err := net.ListenPacket(network, addr)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+	l, err := net.Listen(network, addr)
+	if err != nil {
+		return err
+	}
+	return l.Close()
+}
+
+// checkDB opens the DB read-only and checks that it was created with the current config and operator key.
+// operatorKey is nil if it couldn't be loaded.
+func checkDB(
+	logger *zap.Logger,
+	report *checkReport,
+	path string,
+	currentConfig *operatorstorage.ConfigLock,
+	operatorKey keys.OperatorKey,
+	operatorKeyText string,
+) {
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		report.warn("db", fmt.Sprintf("%s doesn't exist and will be created", path))
+		return
+	}
+
+	db, err := kv.New(logger, basedb.Options{Path: path, ReadOnly: true})
+	if err != nil {
+		report.add("db", fmt.Errorf("could not open DB read-only, make sure the node is stopped: %w", err), "")
+		return
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			logger.Warn("could not close DB", zap.Error(err))
+		}
+	}()
+	nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+	if err != nil {
+		report.add("db", fmt.Errorf("could not load node storage: %w", err), "")
+		return
+	}
+	report.add("db", nil, path)
+
+	storedConfig, found, err := nodeStorage.GetConfig(nil)
+	switch {
+	case err != nil:
+		report.add("db config lock", fmt.Errorf("could not get stored config: %w", err), "")
+	case !found:
+		report.warn("db config lock", "no stored config, it will be stored on start")
+	default:
+		report.add("db config lock", storedConfig.ValidateCompatibility(currentConfig), storedConfig.NetworkName)
+	}
+
+	if operatorKey == nil {
+		return
+	}
+	storedHash, found, err := nodeStorage.GetPrivateKeyHash()
+	if err != nil {
+		report.add("db operator key", fmt.Errorf("could not get stored private key hash: %w", err), "")
+		return
+	}
+	if !found {
+		report.warn("db operator key", "no stored key hash, it will be stored on start")
+		return
+	}
+	hash, legacyHash, err := operatorKeyHashes(operatorKey, operatorKeyText)
+	if err != nil {
+		report.add("db operator key", err, "")
+		return
+	}
+	if storedHash != hash && storedHash != legacyHash {
+		report.add("db operator key", errors.New("operator private key is not matching the one encrypted the storage"), "")
+		return
+	}
+	report.add("db operator key", nil, "matches the stored key hash")
+}
+
+// checkEthereumNodes connects to the execution and consensus clients and probes their health.
+func checkEthereumNodes(ctx context.Context, logger *zap.Logger, report *checkReport, networkConfig networkconfig.NetworkConfig) {
+	nodes := make(map[string]nodeprobe.Node)
+
+	executionClient, err := setupExecutionClient(ctx, logger, networkConfig)
+	if err != nil {
+		report.add("execution client", fmt.Errorf("could not connect: %w", err), "")
+	} else {
+		defer func() {
+			_ = executionClient.Close()
+		}()
+		nodes["execution client"] = executionClient
+	}
+
+	consensusOptions := cfg.ConsensusClient
+	consensusOptions.Context = ctx
+	consensusOptions.Network = networkConfig.Beacon.GetNetwork()
+	slotTickerProvider := func() slotticker.SlotTicker {
+		return slotticker.New(logger, slotticker.Config{
+			SlotDuration: networkConfig.SlotDurationSec(),
+			GenesisTime:  networkConfig.GetGenesisTime(),
+		})
+	}
+	consensusClient, err := goclient.New(logger, consensusOptions, operatordatastore.New(&registrystorage.OperatorData{}), slotTickerProvider)
+	if err != nil {
+		report.add("consensus client", fmt.Errorf("could not connect: %w", err), "")
+	} else {
+		nodes["consensus client"] = consensusClient
+	}
+
+	prober := nodeprobe.NewProber(logger, nil, nodes)
+	checks := map[string]func(context.Context) error{
+		"execution client": prober.CheckExecutionNodeHealth,
+		"consensus client": prober.CheckBeaconNodeHealth,
+	}
+	names := make([]string, 0, len(nodes))
+	for name := range nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		err := checks[name](ctx)
+		report.add(name, err, "healthy")
+	}
+}
@@ -0,0 +1,121 @@
+package operator
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/ssvlabs/ssv/operator/keys"
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func Test_checkPorts(t *testing.T) {
+	l, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	defer func() { _ = l.Close() }()
+	busyPort := l.Addr().(*net.TCPAddr).Port
+
+	items := checkPorts([]configuredPort{
+		{name: "metrics API", network: "tcp", port: busyPort},
+		{name: "SSV API", network: "tcp", port: busyPort},
+	})
+	require.Len(t, items, 2)
+	require.Equal(t, checkFail, items[0].Status)
+	require.Contains(t, items[0].Detail, "not free")
+	require.Equal(t, checkFail, items[1].Status)
+	require.Contains(t, items[1].Detail, "already used by metrics API")
+
+	require.NoError(t, l.Close())
+	items = checkPorts([]configuredPort{
+		{name: "p2p", network: "tcp", port: busyPort},
+		{name: "discovery", network: "udp", port: busyPort},
+	})
+	require.Equal(t, checkOK, items[0].Status)
+	require.Equal(t, checkOK, items[1].Status)
+}
+
+func Test_checkSubnets(t *testing.T) {
+	require.NoError(t, checkSubnets(""))
+	require.NoError(t, checkSubnets("0xffffffffffffffffffffffffffffffff"))
+	require.Error(t, checkSubnets("0xffff"))
+	require.Error(t, checkSubnets("0xzz"))
+}
+
+func Test_checkDB(t *testing.T) {
+	logger := zap.New(zapcore.NewNopCore())
+	path := filepath.Join(t.TempDir(), "db")
+
+	key, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+	keyText := base64.StdEncoding.EncodeToString(key.Bytes())
+	otherKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	report := &checkReport{}
+	checkDB(logger, report, path, &operatorstorage.ConfigLock{NetworkName: "holesky"}, key, keyText)
+	require.Len(t, report.Items, 1)
+	require.Equal(t, checkWarn, report.Items[0].Status)
+
+	db, err := kv.New(logger, basedb.Options{Path: path})
+	require.NoError(t, err)
+	nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+	require.NoError(t, err)
+	require.NoError(t, nodeStorage.SaveConfig(nil, &operatorstorage.ConfigLock{NetworkName: "holesky"}))
+	hash, err := key.StorageHash()
+	require.NoError(t, err)
+	require.NoError(t, nodeStorage.SavePrivateKeyHash(hash))
+
+	t.Run("open elsewhere", func(t *testing.T) {
+		report := &checkReport{}
+		checkDB(logger, report, path, &operatorstorage.ConfigLock{NetworkName: "holesky"}, key, keyText)
+		require.True(t, report.failed())
+		require.Contains(t, report.Items[0].Detail, "make sure the node is stopped")
+	})
+
+	require.NoError(t, db.Close())
+
+	t.Run("compatible", func(t *testing.T) {
+		report := &checkReport{}
+		checkDB(logger, report, path, &operatorstorage.ConfigLock{NetworkName: "holesky"}, key, keyText)
+		require.False(t, report.failed(), report.Items)
+		require.Len(t, report.Items, 3)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		report := &checkReport{}
+		checkDB(logger, report, path, &operatorstorage.ConfigLock{NetworkName: "mainnet", UsingLocalEvents: true}, otherKey, "")
+		require.Len(t, report.Items, 3)
+		require.Equal(t, checkOK, report.Items[0].Status)
+		require.Equal(t, checkFail, report.Items[1].Status)
+		require.Contains(t, report.Items[1].Detail, "network mismatch")
+		require.Equal(t, checkFail, report.Items[2].Status)
+	})
+}
+
+func Test_checkReport_print(t *testing.T) {
+	report := &checkReport{}
+	report.add("network", nil, "holesky")
+	report.warn("db", "will be created")
+	require.False(t, report.failed())
+
+	var text bytes.Buffer
+	require.NoError(t, report.print(&text, "text"))
+	require.Contains(t, text.String(), "network  ok      holesky")
+
+	var decoded checkReport
+	var jsonOut bytes.Buffer
+	require.NoError(t, report.print(&jsonOut, "json"))
+	require.NoError(t, json.Unmarshal(jsonOut.Bytes(), &decoded))
+	require.Equal(t, report, &decoded)
+
+	require.Error(t, report.print(&text, "yaml"))
+}
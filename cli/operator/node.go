@@ -10,10 +10,13 @@ import (
 	"math/big"
 	"net/http"
 	"os"
+	"os/signal"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ilyakaznacheev/cleanenv"
@@ -44,13 +47,18 @@ import (
 	"github.com/ssvlabs/ssv/network"
 	networkcommons "github.com/ssvlabs/ssv/network/commons"
 	p2pv1 "github.com/ssvlabs/ssv/network/p2p"
+	"github.com/ssvlabs/ssv/network/records"
 	"github.com/ssvlabs/ssv/networkconfig"
 	"github.com/ssvlabs/ssv/nodeprobe"
 	"github.com/ssvlabs/ssv/observability"
 	"github.com/ssvlabs/ssv/operator"
+	"github.com/ssvlabs/ssv/operator/balances"
 	operatordatastore "github.com/ssvlabs/ssv/operator/datastore"
 	"github.com/ssvlabs/ssv/operator/duties/dutystore"
+	"github.com/ssvlabs/ssv/operator/graffiti"
+	"github.com/ssvlabs/ssv/operator/inclusion"
 	"github.com/ssvlabs/ssv/operator/keys"
+	"github.com/ssvlabs/ssv/operator/keys/external"
 	"github.com/ssvlabs/ssv/operator/keystore"
 	"github.com/ssvlabs/ssv/operator/slotticker"
 	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
@@ -82,7 +90,9 @@ type config struct {
 	ConsensusClient              beaconprotocol.Options           `yaml:"eth2"` // TODO: consensus_client in yaml
 	P2pNetworkConfig             p2pv1.Config                     `yaml:"p2p"`
 	KeyStore                     KeyStore                         `yaml:"KeyStore"`
-	Graffiti                     string                           `yaml:"Graffiti" env:"GRAFFITI" env-description:"Custom graffiti for block proposals." env-default:"ssv.network" `
+	ExternalOperatorKey          external.Config                  `yaml:"ExternalOperatorKey"`
+	Graffiti                     string                           `yaml:"Graffiti" env:"GRAFFITI" env-description:"Custom graffiti template for block proposals, supports {committee}, the sorted operator IDs of the validator's committee." env-default:"ssv.network" reload:"true"`
+	GraffitiOverridesFile        string                           `yaml:"GraffitiOverridesFile" env:"GRAFFITI_OVERRIDES_FILE" env-description:"Path to a YAML file mapping validator public keys to graffiti templates which override Graffiti." reload:"true"`
 	OperatorPrivateKey           string                           `yaml:"OperatorPrivateKey" env:"OPERATOR_KEY" env-description:"Operator private key, used to decrypt contract events"`
 	MetricsAPIPort               int                              `yaml:"MetricsAPIPort" env:"METRICS_API_PORT" env-description:"Port to listen on for the metrics API."`
 	EnableProfile                bool                             `yaml:"EnableProfile" env:"ENABLE_PROFILE" env-description:"flag that indicates whether go profiling tools are enabled"`
@@ -90,14 +100,25 @@ type config struct {
 	WsAPIPort                    int                              `yaml:"WebSocketAPIPort" env:"WS_API_PORT" env-description:"Port to listen on for the websocket API."`
 	WithPing                     bool                             `yaml:"WithPing" env:"WITH_PING" env-description:"Whether to send websocket ping messages'"`
 	SSVAPIPort                   int                              `yaml:"SSVAPIPort" env:"SSV_API_PORT" env-description:"Port to listen on for the SSV API."`
+	SSVAPIToken                  string                           `yaml:"SSVAPIToken" env:"SSV_API_TOKEN" env-description:"Bearer token required by the SSV API endpoints which act on the node, such as validator exits. They are disabled if not set."`
 	LocalEventsPath              string                           `yaml:"LocalEventsPath" env:"EVENTS_PATH" env-description:"path to local events"`
-	EnableDoppelgangerProtection bool                             `yaml:"EnableDoppelgangerProtection" env:"ENABLE_DOPPELGANGER_PROTECTION" env-description:"Flag to enable Doppelganger protection for validators."`
+	GracefulShutdown             bool                             `yaml:"GracefulShutdown" env:"GRACEFUL_SHUTDOWN" env-description:"Stop starting new duties and wait for in-flight committee and proposer duties to finish before exiting on SIGINT or SIGTERM."`
+	GracefulShutdownTimeout      time.Duration                    `yaml:"GracefulShutdownTimeout" env:"GRACEFUL_SHUTDOWN_TIMEOUT" env-default:"30s" env-description:"Maximum time to wait for in-flight duties when shutting down gracefully."`
+	BalanceRetentionEpochs       uint64                           `yaml:"BalanceRetentionEpochs" env:"BALANCE_RETENTION_EPOCHS" env-default:"1575" env-description:"Number of most recent epochs whose balances of own validators are kept in the database."`
+	NegativeBalanceEpochs        uint64                           `yaml:"NegativeBalanceEpochs" env:"NEGATIVE_BALANCE_EPOCHS" env-default:"3" env-description:"Number of consecutive epochs with a decreasing balance after which a validator is flagged."`
+	EnableDoppelgangerProtection bool                             `yaml:"EnableDoppelgangerProtection" env:"ENABLE_DOPPELGANGER_PROTECTION" env-description:"Flag to enable Doppelganger protection for validators. It can be disabled and re-enabled at runtime only if it was enabled at startup." reload:"true"`
 }
 
 var cfg config
 
 var globalArgs global_config.Args
 
+// checkOnly makes start-node check the configuration and exit instead of starting the node.
+var checkOnly bool
+
+// checkOutput is the format of the --check report.
+var checkOutput string
+
 // StartNodeCmd is the command to start SSV node
 var StartNodeCmd = &cobra.Command{
 	Use:   "start-node",
@@ -112,8 +133,27 @@ var StartNodeCmd = &cobra.Command{
 
 		defer logging.CapturePanic(logger)
 
+		// The node's context is cancelled to shut it down, e.g. by shutdownGracefully.
+		ctx, cancel := context.WithCancel(cmd.Context())
+		defer cancel()
+		cmd.SetContext(ctx)
+
+		if checkOnly {
+			report := runChecks(cmd.Context(), logger)
+			if err := report.print(os.Stdout, checkOutput); err != nil {
+				logger.Fatal("could not print check report", zap.Error(err))
+			}
+			if report.failed() {
+				os.Exit(1)
+			}
+			return
+		}
+
 		logger.Info(fmt.Sprintf("starting %v", commons.GetBuildData()))
 
+		// Keep the config as loaded to compare it with reloaded configs, as cfg is changed below.
+		loadedConfig := cfg
+
 		observabilityShutdown, err := observability.Initialize(
 			cmd.Parent().Short,
 			cmd.Parent().Version,
@@ -137,38 +177,27 @@ var StartNodeCmd = &cobra.Command{
 		if err != nil {
 			logger.Fatal("could not setup db", zap.Error(err))
 		}
-
-		var operatorPrivKey keys.OperatorPrivateKey
-		var operatorPrivKeyText string
-		if cfg.KeyStore.PrivateKeyFile != "" {
-			// nolint: gosec
-			encryptedJSON, err := os.ReadFile(cfg.KeyStore.PrivateKeyFile)
-			if err != nil {
-				logger.Fatal("could not read PEM file", zap.Error(err))
-			}
-
-			// nolint: gosec
-			keyStorePassword, err := os.ReadFile(cfg.KeyStore.PasswordFile)
-			if err != nil {
-				logger.Fatal("could not read password file", zap.Error(err))
+		defer func() {
+			if err := db.Close(); err != nil {
+				logger.Error("could not close db", zap.Error(err))
 			}
+		}()
 
-			decryptedKeystore, err := keystore.DecryptKeystore(encryptedJSON, string(keyStorePassword))
-			if err != nil {
-				logger.Fatal("could not decrypt operator private key keystore", zap.Error(err))
-			}
-			operatorPrivKey, err = keys.PrivateKeyFromBytes(decryptedKeystore)
+		var operatorPrivKey keys.OperatorKey
+		var operatorPrivKeyText string
+		if cfg.ExternalOperatorKey.Enabled() {
+			externalKey, err := external.New(cfg.ExternalOperatorKey)
 			if err != nil {
-				logger.Fatal("could not extract operator private key from file", zap.Error(err))
+				logger.Fatal("could not open external operator key", zap.Error(err))
 			}
-
-			operatorPrivKeyText = base64.StdEncoding.EncodeToString(decryptedKeystore)
+			defer func() {
+				if err := externalKey.Close(); err != nil {
+					logger.Warn("could not close external operator key", zap.Error(err))
+				}
+			}()
+			operatorPrivKey = externalKey
 		} else {
-			operatorPrivKey, err = keys.PrivateKeyFromString(cfg.OperatorPrivateKey)
-			if err != nil {
-				logger.Fatal("could not decode operator private key", zap.Error(err))
-			}
-			operatorPrivKeyText = cfg.OperatorPrivateKey
+			operatorPrivKey, operatorPrivKeyText = loadOperatorPrivateKey(logger)
 		}
 		cfg.P2pNetworkConfig.OperatorSigner = operatorPrivKey
 
@@ -205,49 +234,9 @@ var StartNodeCmd = &cobra.Command{
 
 		consensusClient := setupConsensusClient(logger, operatorDataStore, slotTickerProvider)
 
-		executionAddrList := strings.Split(cfg.ExecutionClient.Addr, ";") // TODO: Decide what symbol to use as a separator. Bootnodes are currently separated by ";". Deployment bot currently uses ",".
-		if len(executionAddrList) == 0 {
-			logger.Fatal("no execution node address provided")
-		}
-
-		var executionClient executionclient.Provider
-
-		if len(executionAddrList) == 1 {
-			ec, err := executionclient.New(
-				cmd.Context(),
-				executionAddrList[0],
-				ethcommon.HexToAddress(networkConfig.RegistryContractAddr),
-				executionclient.WithLogger(logger),
-				executionclient.WithFollowDistance(executionclient.DefaultFollowDistance),
-				executionclient.WithConnectionTimeout(cfg.ExecutionClient.ConnectionTimeout),
-				executionclient.WithReconnectionInitialInterval(executionclient.DefaultReconnectionInitialInterval),
-				executionclient.WithReconnectionMaxInterval(executionclient.DefaultReconnectionMaxInterval),
-				executionclient.WithHealthInvalidationInterval(executionclient.DefaultHealthInvalidationInterval),
-				executionclient.WithSyncDistanceTolerance(cfg.ExecutionClient.SyncDistanceTolerance),
-			)
-			if err != nil {
-				logger.Fatal("could not connect to execution client", zap.Error(err))
-			}
-
-			executionClient = ec
-		} else {
-			ec, err := executionclient.NewMulti(
-				cmd.Context(),
-				executionAddrList,
-				ethcommon.HexToAddress(networkConfig.RegistryContractAddr),
-				executionclient.WithLoggerMulti(logger),
-				executionclient.WithFollowDistanceMulti(executionclient.DefaultFollowDistance),
-				executionclient.WithConnectionTimeoutMulti(cfg.ExecutionClient.ConnectionTimeout),
-				executionclient.WithReconnectionInitialIntervalMulti(executionclient.DefaultReconnectionInitialInterval),
-				executionclient.WithReconnectionMaxIntervalMulti(executionclient.DefaultReconnectionMaxInterval),
-				executionclient.WithHealthInvalidationIntervalMulti(executionclient.DefaultHealthInvalidationInterval),
-				executionclient.WithSyncDistanceToleranceMulti(cfg.ExecutionClient.SyncDistanceTolerance),
-			)
-			if err != nil {
-				logger.Fatal("could not connect to execution client", zap.Error(err))
-			}
-
-			executionClient = ec
+		executionClient, err := setupExecutionClient(cmd.Context(), logger, networkConfig)
+		if err != nil {
+			logger.Fatal("could not connect to execution client", zap.Error(err))
 		}
 
 		cfg.P2pNetworkConfig.NodeStorage = nodeStorage
@@ -325,10 +314,25 @@ var StartNodeCmd = &cobra.Command{
 			retain := cfg.SSVOptions.ValidatorOptions.ExporterRetainSlots
 			threshold := cfg.SSVOptions.Network.Beacon.EstimatedCurrentSlot()
 			initSlotPruning(cmd.Context(), logger, storageMap, slotTickerProvider, threshold, retain)
+			prunedBelow := threshold - min(threshold, phase0.Slot(retain))
+			if err := initFinalityPruning(cmd.Context(), logger, storageMap, consensusClient, networkConfig.Beacon, prunedBelow, retain); err != nil {
+				logger.Fatal("failed to start finality pruning", zap.Error(err))
+			}
 		}
 
 		cfg.SSVOptions.ValidatorOptions.StorageMap = storageMap
-		cfg.SSVOptions.ValidatorOptions.Graffiti = []byte(cfg.Graffiti)
+		if err := graffiti.Validate(cfg.Graffiti); err != nil {
+			logger.Fatal("invalid graffiti", zap.Error(err))
+		}
+		graffitiProvider := graffiti.New(cfg.Graffiti)
+		if cfg.GraffitiOverridesFile != "" {
+			overrides, err := graffiti.LoadOverrides(cfg.GraffitiOverridesFile)
+			if err != nil {
+				logger.Fatal("failed to load graffiti overrides", zap.Error(err))
+			}
+			graffitiProvider.SetOverrides(overrides)
+		}
+		cfg.SSVOptions.ValidatorOptions.Graffiti = graffitiProvider
 		cfg.SSVOptions.ValidatorOptions.ValidatorStore = nodeStorage.ValidatorStore()
 		cfg.SSVOptions.ValidatorOptions.OperatorSigner = types.NewSsvOperatorSigner(operatorPrivKey, operatorDataStore.GetOperatorID)
 
@@ -355,14 +359,16 @@ var StartNodeCmd = &cobra.Command{
 		cfg.SSVOptions.ValidatorOptions.ValidatorSyncer = metadataSyncer
 
 		var doppelgangerHandler doppelganger.Provider
+		var doppelgangerToggle *doppelganger.Toggle
 		if cfg.EnableDoppelgangerProtection {
-			doppelgangerHandler = doppelganger.NewHandler(&doppelganger.Options{
+			doppelgangerToggle = doppelganger.NewToggle(doppelganger.NewHandler(&doppelganger.Options{
 				Network:            networkConfig,
 				BeaconNode:         consensusClient,
 				ValidatorProvider:  nodeStorage.ValidatorStore().WithOperatorID(operatorDataStore.GetOperatorID),
 				SlotTickerProvider: slotTickerProvider,
 				Logger:             logger,
-			})
+			}))
+			doppelgangerHandler = doppelgangerToggle
 			logger.Info("Doppelganger protection enabled.")
 		} else {
 			doppelgangerHandler = doppelganger.NoOpHandler{}
@@ -399,6 +405,10 @@ var StartNodeCmd = &cobra.Command{
 		nodeProber.Wait()
 		logger.Info("ethereum node(s) are healthy")
 
+		nodeMetadata := fetchNodeMetadata(cmd.Context(), logger, executionClient, consensusClient)
+		cfg.P2pNetworkConfig.ExecutionNodeVersion = nodeMetadata.ExecutionNode
+		cfg.P2pNetworkConfig.ConsensusNodeVersion = nodeMetadata.ConsensusNode
+
 		eventSyncer := syncContractEvents(
 			cmd.Context(),
 			logger,
@@ -460,17 +470,59 @@ var StartNodeCmd = &cobra.Command{
 			}
 		}
 
+		configReloader := &configReloader{
+			logger:       logger,
+			loaded:       loadedConfig,
+			p2p:          p2pNetwork.(reloadableP2P),
+			graffiti:     graffitiProvider,
+			doppelganger: doppelgangerToggle,
+		}
+		reloadOnSighup(cmd.Context(), logger, configReloader)
+
+		inclusionTracker := inclusion.New(&inclusion.Options{
+			Network:            networkConfig,
+			BeaconNode:         consensusClient,
+			DutyStore:          dutyStore,
+			SlotTickerProvider: slotTickerProvider,
+			Logger:             logger,
+		})
+		go inclusionTracker.Start(cmd.Context())
+
+		balanceTracker := balances.New(&balances.Options{
+			Network:            networkConfig,
+			BeaconNode:         consensusClient,
+			ValidatorProvider:  nodeStorage.ValidatorStore().WithOperatorID(operatorDataStore.GetOperatorID),
+			DB:                 db,
+			SlotTickerProvider: slotTickerProvider,
+			Logger:             logger,
+			RetainedEpochs:     cfg.BalanceRetentionEpochs,
+			NegativeEpochs:     cfg.NegativeBalanceEpochs,
+		})
+		go balanceTracker.Start(cmd.Context())
+
 		if cfg.SSVAPIPort > 0 {
+			dutiesHandler := &handlers.Duties{
+				NetworkConfig: networkConfig,
+				Store:         dutyStore,
+				BeaconNode:    consensusClient,
+				Shares:        nodeStorage.Shares(),
+				OperatorID:    operatorDataStore.GetOperatorID,
+			}
 			apiServer := apiserver.New(
 				logger,
 				fmt.Sprintf(":%d", cfg.SSVAPIPort),
+				cfg.SSVAPIToken,
 				&handlers.Node{
 					// TODO: replace with narrower interface! (instead of accessing the entire PeersIndex)
 					ListenAddresses: []string{fmt.Sprintf("tcp://%s:%d", cfg.P2pNetworkConfig.HostAddress, cfg.P2pNetworkConfig.TCPPort), fmt.Sprintf("udp://%s:%d", cfg.P2pNetworkConfig.HostAddress, cfg.P2pNetworkConfig.UDPPort)},
 					PeersIndex:      p2pNetwork.(p2pv1.PeersIndexProvider).PeersIndex(),
 					Network:         p2pNetwork.(p2pv1.HostProvider).Host().Network(),
 					TopicIndex:      p2pNetwork.(handlers.TopicIndex),
+					PeerAccess:      p2pNetwork.(handlers.PeerAccess),
+					PeerScores:      p2pNetwork.(handlers.PeerScores),
 					NodeProber:      nodeProber,
+					ConfigReloader:  configReloader,
+					BeaconNodes:     consensusClient,
 				},
 				&handlers.Validators{
 					Shares: nodeStorage.Shares(),
@@ -479,6 +531,23 @@ var StartNodeCmd = &cobra.Command{
 					NetworkConfig:     networkConfig,
 					ParticipantStores: storageMap,
 				},
+				&handlers.Exits{
+					NetworkConfig: networkConfig,
+					Shares:        nodeStorage.Shares(),
+					Requests:      nodeStorage,
+					Exiter:        validatorCtrl,
+					OperatorID:    operatorDataStore.GetOperatorID,
+				},
+				dutiesHandler,
+				&handlers.Maintenance{
+					Duties: dutiesHandler,
+				},
+				&handlers.Inclusion{
+					Tracker: inclusionTracker,
+				},
+				&handlers.Balances{
+					Tracker: balanceTracker,
+				},
 			)
 			go func() {
 				err := apiServer.Run()
@@ -487,6 +556,9 @@ var StartNodeCmd = &cobra.Command{
 				}
 			}()
 		}
+		if cfg.GracefulShutdown {
+			go shutdownGracefully(logger, operatorNode, validatorCtrl, networkConfig.Beacon, cfg.GracefulShutdownTimeout, cancel)
+		}
 		if err := operatorNode.Start(logger); err != nil {
 			logger.Fatal("failed to start SSV node", zap.Error(err))
 		}
@@ -520,19 +592,29 @@ func validateConfig(nodeStorage operatorstorage.Storage, networkName string, usi
 
 func init() {
 	global_config.ProcessArgs(&cfg, &globalArgs, StartNodeCmd)
+	StartNodeCmd.Flags().BoolVar(&checkOnly, "check", false, "Validate the configuration, the DB and the Ethereum nodes, print a report and exit without starting the node")
+	StartNodeCmd.Flags().StringVar(&checkOutput, "check-output", "text", "Format of the --check report: text or json")
 }
 
-func setupGlobal() (*zap.Logger, error) {
+// readConfig reads the config and share config files into c.
+func readConfig(c *config) error {
 	if globalArgs.ConfigPath != "" {
-		if err := cleanenv.ReadConfig(globalArgs.ConfigPath, &cfg); err != nil {
-			return nil, fmt.Errorf("could not read config: %w", err)
+		if err := cleanenv.ReadConfig(globalArgs.ConfigPath, c); err != nil {
+			return fmt.Errorf("could not read config: %w", err)
 		}
 	}
 	if globalArgs.ShareConfigPath != "" {
-		if err := cleanenv.ReadConfig(globalArgs.ShareConfigPath, &cfg); err != nil {
-			return nil, fmt.Errorf("could not read share config: %w", err)
+		if err := cleanenv.ReadConfig(globalArgs.ShareConfigPath, c); err != nil {
+			return fmt.Errorf("could not read share config: %w", err)
 		}
 	}
+	return nil
+}
+
+func setupGlobal() (*zap.Logger, error) {
+	if err := readConfig(&cfg); err != nil {
+		return nil, err
+	}
 
 	err := logging.SetGlobalLogger(
 		cfg.LogLevel,
@@ -602,32 +684,88 @@ func setupDB(logger *zap.Logger, eth2Network beaconprotocol.Network) (*kv.Badger
 	return db, nil
 }
 
-func setupOperatorStorage(logger *zap.Logger, db basedb.Database, configPrivKey keys.OperatorPrivateKey, configPrivKeyText string) (operatorstorage.Storage, *registrystorage.OperatorData) {
-	nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+// loadOperatorPrivateKey loads the operator private key from the keystore file if configured,
+// otherwise from the configured base64 text. It returns the key and its base64 text.
+func loadOperatorPrivateKey(logger *zap.Logger) (keys.OperatorPrivateKey, string) {
+	privKey, privKeyText, err := readOperatorPrivateKey()
 	if err != nil {
-		logger.Fatal("failed to create node storage", zap.Error(err))
+		logger.Fatal("could not load operator private key", zap.Error(err))
 	}
+	return privKey, privKeyText
+}
 
-	storedPrivKeyHash, found, err := nodeStorage.GetPrivateKeyHash()
+func readOperatorPrivateKey() (keys.OperatorPrivateKey, string, error) {
+	if cfg.KeyStore.PrivateKeyFile == "" {
+		privKey, err := keys.PrivateKeyFromString(cfg.OperatorPrivateKey)
+		if err != nil {
+			return nil, "", fmt.Errorf("could not decode operator private key: %w", err)
+		}
+		return privKey, cfg.OperatorPrivateKey, nil
+	}
+
+	// nolint: gosec
+	encryptedJSON, err := os.ReadFile(cfg.KeyStore.PrivateKeyFile)
 	if err != nil {
-		logger.Fatal("could not get hashed private key", zap.Error(err))
+		return nil, "", fmt.Errorf("could not read PEM file: %w", err)
 	}
 
-	configStoragePrivKeyHash, err := configPrivKey.StorageHash()
+	// nolint: gosec
+	keyStorePassword, err := os.ReadFile(cfg.KeyStore.PasswordFile)
 	if err != nil {
-		logger.Fatal("could not hash private key", zap.Error(err))
+		return nil, "", fmt.Errorf("could not read password file: %w", err)
 	}
 
-	// Backwards compatibility for the old hashing method,
-	// which was hashing the text from the configuration directly,
-	// whereas StorageHash re-encodes with PEM format.
-	cliPrivKeyDecoded, err := base64.StdEncoding.DecodeString(configPrivKeyText)
+	decryptedKeystore, err := keystore.DecryptKeystore(encryptedJSON, string(keyStorePassword))
 	if err != nil {
-		logger.Fatal("could not decode private key", zap.Error(err))
+		return nil, "", fmt.Errorf("could not decrypt operator private key keystore: %w", err)
 	}
-	configStoragePrivKeyLegacyHash, err := rsaencryption.HashRsaKey(cliPrivKeyDecoded)
+	privKey, err := keys.PrivateKeyFromBytes(decryptedKeystore)
 	if err != nil {
-		logger.Fatal("could not hash private key", zap.Error(err))
+		return nil, "", fmt.Errorf("could not extract operator private key from file: %w", err)
+	}
+
+	return privKey, base64.StdEncoding.EncodeToString(decryptedKeystore), nil
+}
+
+// operatorKeyHashes returns the storage hash of the operator key and, if the key text is given,
+// its legacy hash which was hashing the text from the configuration directly,
+// whereas StorageHash re-encodes with PEM format.
+func operatorKeyHashes(key keys.OperatorKey, keyText string) (hash string, legacyHash string, err error) {
+	hash, err = key.StorageHash()
+	if err != nil {
+		return "", "", fmt.Errorf("could not hash private key: %w", err)
+	}
+	if keyText == "" {
+		return hash, "", nil
+	}
+	keyDecoded, err := base64.StdEncoding.DecodeString(keyText)
+	if err != nil {
+		return "", "", fmt.Errorf("could not decode private key: %w", err)
+	}
+	legacyHash, err = rsaencryption.HashRsaKey(keyDecoded)
+	if err != nil {
+		return "", "", fmt.Errorf("could not hash private key: %w", err)
+	}
+	return hash, legacyHash, nil
+}
+
+// setupOperatorStorage checks that the operator key matches the one the storage was created with.
+// configPrivKeyText is the private key text from the configuration, it's empty for external operator keys.
+func setupOperatorStorage(logger *zap.Logger, db basedb.Database, configPrivKey keys.OperatorKey, configPrivKeyText string) (operatorstorage.Storage, *registrystorage.OperatorData) {
+	nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+	if err != nil {
+		logger.Fatal("failed to create node storage", zap.Error(err))
+	}
+
+	storedPrivKeyHash, found, err := nodeStorage.GetPrivateKeyHash()
+	if err != nil {
+		logger.Fatal("could not get hashed private key", zap.Error(err))
+	}
+
+	// Backwards compatibility for the old hashing method.
+	configStoragePrivKeyHash, configStoragePrivKeyLegacyHash, err := operatorKeyHashes(configPrivKey, configPrivKeyText)
+	if err != nil {
+		logger.Fatal("could not hash operator key", zap.Error(err))
 	}
 
 	if !found {
@@ -663,9 +801,24 @@ func setupOperatorStorage(logger *zap.Logger, db basedb.Database, configPrivKey
 }
 
 func setupSSVNetwork(logger *zap.Logger) (networkconfig.NetworkConfig, error) {
-	networkConfig, err := networkconfig.GetNetworkConfigByName(cfg.SSVOptions.NetworkName)
-	if err != nil {
-		return networkconfig.NetworkConfig{}, err
+	var networkConfig networkconfig.NetworkConfig
+	var err error
+	if cfg.SSVOptions.NetworkConfigFile != "" {
+		networkConfig, err = networkconfig.LoadCustomNetwork(context.Background(), cfg.SSVOptions.NetworkConfigFile, cfg.ConsensusClient.BeaconNodeAddr)
+		if err != nil {
+			return networkconfig.NetworkConfig{}, fmt.Errorf("could not load custom network: %w", err)
+		}
+		logger.Info("running with custom network",
+			zap.String("file", cfg.SSVOptions.NetworkConfigFile),
+			zap.Int64("genesisTime", networkConfig.Beacon.MinGenesisTime()),
+			zap.Duration("slotDuration", networkConfig.SlotDurationSec()),
+			zap.String("forkVersion", fmt.Sprintf("%x", networkConfig.ForkVersion())),
+		)
+	} else {
+		networkConfig, err = networkconfig.GetNetworkConfigByName(cfg.SSVOptions.NetworkName)
+		if err != nil {
+			return networkconfig.NetworkConfig{}, err
+		}
 	}
 
 	if cfg.SSVOptions.CustomDomainType != "" {
@@ -713,6 +866,7 @@ func setupP2P(logger *zap.Logger, db basedb.Database) network.P2PNetwork {
 		logger.Fatal("failed to setup network private key", zap.Error(err))
 	}
 	cfg.P2pNetworkConfig.NetworkPrivateKey = netPrivKey
+	cfg.P2pNetworkConfig.DB = db
 
 	n, err := p2pv1.New(logger, &cfg.P2pNetworkConfig)
 	if err != nil {
@@ -735,6 +889,74 @@ func setupConsensusClient(
 	return cl
 }
 
+// fetchNodeMetadata returns the versions of this node and its Ethereum nodes.
+// A version which can't be fetched is left empty.
+func fetchNodeMetadata(
+	ctx context.Context,
+	logger *zap.Logger,
+	executionClient executionclient.Provider,
+	consensusClient *goclient.GoClient,
+) records.NodeMetadata {
+	metadata := records.NodeMetadata{NodeVersion: commons.GetNodeVersion()}
+
+	executionVersion, err := executionClient.ClientVersion(ctx)
+	if err != nil {
+		logger.Warn("could not get execution client version", zap.Error(err))
+	}
+	metadata.ExecutionNode = executionVersion
+
+	consensusVersion, err := consensusClient.ClientVersion(ctx)
+	if err != nil {
+		logger.Warn("could not get consensus client version", zap.Error(err))
+	}
+	metadata.ConsensusNode = consensusVersion
+
+	return metadata
+}
+
+func setupExecutionClient(ctx context.Context, logger *zap.Logger, networkConfig networkconfig.NetworkConfig) (executionclient.Provider, error) {
+	executionAddrList := strings.Split(cfg.ExecutionClient.Addr, ";") // TODO: Decide what symbol to use as a separator. Bootnodes are currently separated by ";". Deployment bot currently uses ",".
+	if len(executionAddrList) == 0 {
+		return nil, errors.New("no execution node address provided")
+	}
+
+	if len(executionAddrList) == 1 {
+		ec, err := executionclient.New(
+			ctx,
+			executionAddrList[0],
+			ethcommon.HexToAddress(networkConfig.RegistryContractAddr),
+			executionclient.WithLogger(logger),
+			executionclient.WithFollowDistance(executionclient.DefaultFollowDistance),
+			executionclient.WithConnectionTimeout(cfg.ExecutionClient.ConnectionTimeout),
+			executionclient.WithReconnectionInitialInterval(executionclient.DefaultReconnectionInitialInterval),
+			executionclient.WithReconnectionMaxInterval(executionclient.DefaultReconnectionMaxInterval),
+			executionclient.WithHealthInvalidationInterval(executionclient.DefaultHealthInvalidationInterval),
+			executionclient.WithSyncDistanceTolerance(cfg.ExecutionClient.SyncDistanceTolerance),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return ec, nil
+	}
+
+	ec, err := executionclient.NewMulti(
+		ctx,
+		executionAddrList,
+		ethcommon.HexToAddress(networkConfig.RegistryContractAddr),
+		executionclient.WithLoggerMulti(logger),
+		executionclient.WithFollowDistanceMulti(executionclient.DefaultFollowDistance),
+		executionclient.WithConnectionTimeoutMulti(cfg.ExecutionClient.ConnectionTimeout),
+		executionclient.WithReconnectionInitialIntervalMulti(executionclient.DefaultReconnectionInitialInterval),
+		executionclient.WithReconnectionMaxIntervalMulti(executionclient.DefaultReconnectionMaxInterval),
+		executionclient.WithHealthInvalidationIntervalMulti(executionclient.DefaultHealthInvalidationInterval),
+		executionclient.WithSyncDistanceToleranceMulti(cfg.ExecutionClient.SyncDistanceTolerance),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return ec, nil
+}
+
 // syncContractEvents blocks until historical events are synced and then spawns a goroutine syncing ongoing events.
 func syncContractEvents(
 	ctx context.Context,
@@ -891,3 +1113,86 @@ func initSlotPruning(ctx context.Context, logger *zap.Logger, stores *ibftstorag
 		return nil
 	})
 }
+
+// initFinalityPruning removes the slots retained before every finalized checkpoint,
+// catching up on the slots the per-tick pruning missed (e.g. while the node was busy or the ticker skipped slots).
+// Only the slots between the previous threshold, initially the one pruned at startup, and the new one are removed.
+func initFinalityPruning(ctx context.Context, logger *zap.Logger, stores *ibftstorage.ParticipantStores, consensusClient *goclient.GoClient, beaconNetwork beaconprotocol.BeaconNetwork, prunedBelow phase0.Slot, retain uint64) error {
+	finalizedCh := make(chan *eth2apiv1.FinalizedCheckpointEvent, 8)
+	if err := consensusClient.SubscribeToFinalizedCheckpointEvents(ctx, "participant_pruning", finalizedCh); err != nil {
+		return fmt.Errorf("subscribe to finalized checkpoint events: %w", err)
+	}
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-finalizedCh:
+				finalizedSlot := beaconNetwork.FirstSlotAtEpoch(event.Epoch)
+				if uint64(finalizedSlot) <= retain {
+					continue
+				}
+				threshold := finalizedSlot - phase0.Slot(retain)
+				if threshold <= prunedBelow {
+					continue
+				}
+				from := prunedBelow
+				_ = stores.Each(func(_ spectypes.BeaconRole, store qbftstorage.ParticipantStore) error {
+					store.PruneRange(ctx, logger, from, threshold)
+					return nil
+				})
+				prunedBelow = threshold
+			}
+		}
+	}()
+
+	return nil
+}
+
+// shutdownGracefully shuts the node down on SIGINT or SIGTERM: it stops executing new duties and cancels the node's
+// context once no committee or proposer duty which started before the signal, in its slot or the previous one,
+// is running, or once the timeout passes. Duties of older slots have missed their deadline, so they aren't waited for.
+// A second signal stops waiting, and any further one gets the default handling.
+func shutdownGracefully(logger *zap.Logger, node *operator.Node, validatorCtrl validator.Controller, beaconNetwork beaconprotocol.BeaconNetwork, timeout time.Duration, cancel context.CancelFunc) {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	defer cancel()
+
+	sig := <-signals
+	node.StopDuties()
+
+	fromSlot := beaconNetwork.EstimatedCurrentSlot()
+	if fromSlot > 0 {
+		fromSlot--
+	}
+	logger.Info("shutting down gracefully, waiting for in-flight duties",
+		zap.String("signal", sig.String()),
+		fields.Slot(fromSlot),
+		zap.Duration("timeout", timeout))
+
+	deadline := time.After(timeout)
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		inFlight := validatorCtrl.InFlightDuties(fromSlot)
+		if inFlight == 0 {
+			logger.Info("no in-flight duties, shutting down")
+			return
+		}
+
+		select {
+		case <-ticker.C:
+		case sig := <-signals:
+			logger.Warn("received another signal, shutting down without waiting for in-flight duties",
+				zap.String("signal", sig.String()),
+				zap.Int("in_flight_duties", inFlight))
+			return
+		case <-deadline:
+			logger.Warn("timed out waiting for in-flight duties, shutting down",
+				zap.Int("in_flight_duties", inFlight))
+			return
+		}
+	}
+}
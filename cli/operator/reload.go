@@ -0,0 +1,147 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	global_config "github.com/ssvlabs/ssv/cli/config"
+	"github.com/ssvlabs/ssv/doppelganger"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/operator/graffiti"
+)
+
+// reloadableP2P is the part of the p2p network which can be reconfigured at runtime.
+type reloadableP2P interface {
+	SetTrustedPeers(addrs []string) error
+	SetMaxPeers(maxPeers int)
+}
+
+// configReloader re-reads the config files and applies the changes of the fields tagged as reloadable
+// to the running components. A reload changing any other field is rejected as a whole.
+type configReloader struct {
+	mu     sync.Mutex
+	logger *zap.Logger
+	// loaded is the config as last read from the files, without the changes made to it at startup.
+	loaded       config
+	p2p          reloadableP2P
+	graffiti     *graffiti.Provider
+	doppelganger *doppelganger.Toggle // nil if the protection was disabled at startup
+}
+
+// ReloadConfig re-reads the config files and returns the reloadable fields it applied.
+func (r *configReloader) ReloadConfig() ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var next config
+	if err := readConfig(&next); err != nil {
+		return nil, err
+	}
+
+	changes := global_config.Diff(&r.loaded, &next)
+	var fixed, applied []string
+	for _, change := range changes {
+		if !change.Reloadable {
+			fixed = append(fixed, change.Field)
+			continue
+		}
+		applied = append(applied, change.Field)
+	}
+	if len(fixed) > 0 {
+		return nil, fmt.Errorf("can't reload %s, restart the node to change them", strings.Join(fixed, ", "))
+	}
+	if err := r.validate(&next, applied); err != nil {
+		return nil, err
+	}
+	// The overrides file is re-read on every reload, so that its content can be changed without changing its path.
+	var graffitiOverrides map[spectypes.ValidatorPK]string
+	if next.GraffitiOverridesFile != "" {
+		var err error
+		if graffitiOverrides, err = graffiti.LoadOverrides(next.GraffitiOverridesFile); err != nil {
+			return nil, err
+		}
+	}
+
+	// SetTrustedPeers is the only change which may fail, so it's applied first to keep the reload atomic.
+	for _, field := range applied {
+		if field == "p2p.TrustedPeers" {
+			if err := r.p2p.SetTrustedPeers(next.P2pNetworkConfig.TrustedPeers); err != nil {
+				return nil, fmt.Errorf("could not set trusted peers: %w", err)
+			}
+		}
+	}
+	for _, field := range applied {
+		switch field {
+		case "global.LogLevel":
+			_ = logging.SetGlobalLogLevel(next.LogLevel)
+		case "p2p.MaxPeers":
+			r.p2p.SetMaxPeers(next.P2pNetworkConfig.MaxPeers)
+		case "Graffiti":
+			r.graffiti.Set(next.Graffiti)
+		case "EnableDoppelgangerProtection":
+			r.doppelganger.SetEnabled(next.EnableDoppelgangerProtection)
+		}
+	}
+	r.graffiti.SetOverrides(graffitiOverrides)
+	r.loaded = next
+
+	if len(applied) > 0 {
+		r.logger.Info("reloaded config", zap.Strings("fields", applied))
+	}
+	return applied, nil
+}
+
+// validate checks the new values of the changed fields, so that they can be applied without failing.
+func (r *configReloader) validate(next *config, changed []string) error {
+	for _, field := range changed {
+		switch field {
+		case "global.LogLevel":
+			if _, err := zapcore.ParseLevel(next.LogLevel); err != nil {
+				return fmt.Errorf("invalid LogLevel: %w", err)
+			}
+		case "p2p.MaxPeers":
+			if next.P2pNetworkConfig.MaxPeers <= 0 {
+				return errors.New("MaxPeers must be positive")
+			}
+		case "Graffiti":
+			if err := graffiti.Validate(next.Graffiti); err != nil {
+				return fmt.Errorf("invalid Graffiti: %w", err)
+			}
+		case "EnableDoppelgangerProtection":
+			if r.doppelganger == nil {
+				return errors.New("doppelganger protection was disabled at startup, restart the node to enable it")
+			}
+		}
+	}
+	return nil
+}
+
+// reloadOnSighup reloads the config whenever the process receives SIGHUP, until the context is done.
+func reloadOnSighup(ctx context.Context, logger *zap.Logger, reloader *configReloader) {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	go func() {
+		defer signal.Stop(sighup)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sighup:
+				logger.Info("received SIGHUP, reloading config")
+				if _, err := reloader.ReloadConfig(); err != nil {
+					logger.Error("could not reload config", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,187 @@
+package operator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/ssvlabs/ssv/doppelganger"
+	"github.com/ssvlabs/ssv/operator/graffiti"
+)
+
+type fakeReloadableP2P struct {
+	trustedPeers []string
+	maxPeers     int
+}
+
+func (f *fakeReloadableP2P) SetTrustedPeers(addrs []string) error {
+	f.trustedPeers = addrs
+	return nil
+}
+
+func (f *fakeReloadableP2P) SetMaxPeers(maxPeers int) {
+	f.maxPeers = maxPeers
+}
+
+const testTrustedPeer = "/ip4/127.0.0.1/tcp/13001/p2p/16Uiu2HAmNz6Qfh1JUVrJHbhHc6KvPHkPFhUUTfJFMjgzaDTWHPRV"
+
+func Test_configReloader(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig := func(content string) {
+		content += `
+eth1:
+  ETH1Addr: ws://localhost:8546
+eth2:
+  BeaconNodeAddr: http://localhost:5052
+`
+		require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+	}
+	previousArgs := globalArgs
+	globalArgs.ConfigPath = configPath
+	globalArgs.ShareConfigPath = ""
+	defer func() { globalArgs = previousArgs }()
+
+	writeConfig(`
+db:
+  Path: ./data/db
+p2p:
+  MaxPeers: 60
+Graffiti: before
+EnableDoppelgangerProtection: true
+`)
+	var loaded config
+	require.NoError(t, readConfig(&loaded))
+
+	p2p := &fakeReloadableP2P{}
+	graffitiProvider := graffiti.New(loaded.Graffiti)
+	toggle := doppelganger.NewToggle(doppelganger.NoOpHandler{})
+	reloader := &configReloader{
+		logger:       zap.New(zapcore.NewNopCore()),
+		loaded:       loaded,
+		p2p:          p2p,
+		graffiti:     graffitiProvider,
+		doppelganger: toggle,
+	}
+
+	t.Run("no changes", func(t *testing.T) {
+		applied, err := reloader.ReloadConfig()
+		require.NoError(t, err)
+		require.Empty(t, applied)
+	})
+
+	t.Run("reloadable fields", func(t *testing.T) {
+		writeConfig(`
+global:
+  LogLevel: debug
+db:
+  Path: ./data/db
+p2p:
+  MaxPeers: 90
+  TrustedPeers: ["` + testTrustedPeer + `"]
+Graffiti: after
+EnableDoppelgangerProtection: false
+`)
+		applied, err := reloader.ReloadConfig()
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{
+			"global.LogLevel",
+			"p2p.TrustedPeers",
+			"p2p.MaxPeers",
+			"Graffiti",
+			"EnableDoppelgangerProtection",
+		}, applied)
+		require.Equal(t, 90, p2p.maxPeers)
+		require.Equal(t, []string{testTrustedPeer}, p2p.trustedPeers)
+		require.Equal(t, []byte("after"), graffitiProvider.Graffiti(&spectypes.Share{}))
+		require.False(t, toggle.Enabled())
+	})
+
+	t.Run("graffiti overrides", func(t *testing.T) {
+		var pubKey spectypes.ValidatorPK
+		pubKey[0] = 0xaa
+		overridesPath := filepath.Join(t.TempDir(), "graffiti.yaml")
+		require.NoError(t, os.WriteFile(overridesPath, []byte(`"`+hexutil.Encode(pubKey[:])+`": "custom {committee}"`), 0600))
+
+		writeConfig(`
+global:
+  LogLevel: debug
+db:
+  Path: ./data/db
+p2p:
+  MaxPeers: 90
+  TrustedPeers: ["` + testTrustedPeer + `"]
+Graffiti: op {committee}
+GraffitiOverridesFile: ` + overridesPath + `
+`)
+		applied, err := reloader.ReloadConfig()
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"Graffiti", "GraffitiOverridesFile"}, applied)
+		committee := []*spectypes.ShareMember{{Signer: 7}, {Signer: 3}}
+		require.Equal(t, []byte("custom 3-7"), graffitiProvider.Graffiti(&spectypes.Share{ValidatorPubKey: pubKey, Committee: committee}))
+		require.Equal(t, []byte("op 3-7"), graffitiProvider.Graffiti(&spectypes.Share{Committee: committee}))
+
+		// The file is re-read even if its path didn't change.
+		require.NoError(t, os.WriteFile(overridesPath, []byte(`"`+hexutil.Encode(pubKey[:])+`": "changed"`), 0600))
+		applied, err = reloader.ReloadConfig()
+		require.NoError(t, err)
+		require.Empty(t, applied)
+		require.Equal(t, []byte("changed"), graffitiProvider.Graffiti(&spectypes.Share{ValidatorPubKey: pubKey}))
+	})
+
+	t.Run("non-reloadable field", func(t *testing.T) {
+		writeConfig(`
+global:
+  LogLevel: debug
+db:
+  Path: ./other/db
+p2p:
+  MaxPeers: 100
+  TrustedPeers: ["` + testTrustedPeer + `"]
+Graffiti: after
+`)
+		_, err := reloader.ReloadConfig()
+		require.ErrorContains(t, err, "can't reload db.Path")
+		// Nothing was applied.
+		require.Equal(t, 90, p2p.maxPeers)
+		require.False(t, toggle.Enabled())
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		writeConfig(`
+global:
+  LogLevel: debug
+db:
+  Path: ./data/db
+p2p:
+  MaxPeers: 100
+  TrustedPeers: ["` + testTrustedPeer + `"]
+Graffiti: this graffiti is way too long to fit into a block
+`)
+		_, err := reloader.ReloadConfig()
+		require.ErrorContains(t, err, "at most 32 are allowed")
+		require.Equal(t, 90, p2p.maxPeers)
+	})
+
+	t.Run("doppelganger disabled at startup", func(t *testing.T) {
+		reloader.doppelganger = nil
+		writeConfig(`
+global:
+  LogLevel: debug
+db:
+  Path: ./data/db
+p2p:
+  MaxPeers: 90
+  TrustedPeers: ["` + testTrustedPeer + `"]
+Graffiti: after
+EnableDoppelgangerProtection: true
+`)
+		_, err := reloader.ReloadConfig()
+		require.ErrorContains(t, err, "restart the node to enable it")
+	})
+}
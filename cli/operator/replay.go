@@ -0,0 +1,261 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/spf13/cobra"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	global_config "github.com/ssvlabs/ssv/cli/config"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/message/signatureverifier"
+	"github.com/ssvlabs/ssv/message/validation"
+	p2pv1 "github.com/ssvlabs/ssv/network/p2p"
+	"github.com/ssvlabs/ssv/operator/duties/dutystore"
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	"github.com/ssvlabs/ssv/protocol/v2/ssv/queue"
+	"github.com/ssvlabs/ssv/utils/commons"
+)
+
+const (
+	replaySpeedFlag            = "speed"
+	replayElectraForkEpochFlag = "electra-fork-epoch"
+)
+
+// ReplayCmd is the command to replay recorded pubsub messages offline.
+var ReplayCmd = &cobra.Command{
+	Use:   "replay [record files...]",
+	Short: "Replays recorded pubsub messages through message validation",
+	Long: `Replays files written by the p2p message recorder (see p2p.MessageRecordPath).
+Messages are validated in the recorded order and timing, at their recorded receive time, against the registry data
+in the configured DB, which should be a copy of the node's DB.
+
+Only message validation is replayed: accepted messages are counted by duty, but they aren't processed by an offline
+committee, so no consensus or signing takes place. Replaying through validators and committees isn't supported,
+as it requires the operator's shares and a beacon node serving the recorded slots.
+
+The beacon duties of the recorded epochs aren't known offline, so proposer, sync committee contribution and
+voluntary exit messages, which are validated against them, are skipped rather than replayed, and committee
+messages are limited as if none of their validators were in the sync committee.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		commons.SetBuildData(cmd.Parent().Short, cmd.Parent().Version)
+
+		logger, err := setupGlobal()
+		if err != nil {
+			log.Fatal("could not create logger ", err)
+		}
+		logger = logger.Named(logging.NameReplay)
+
+		speed, _ := cmd.Flags().GetFloat64(replaySpeedFlag)
+		electraForkEpoch, _ := cmd.Flags().GetUint64(replayElectraForkEpochFlag)
+
+		records, err := loadMessageRecords(args)
+		if err != nil {
+			logger.Fatal("could not load message records", zap.Error(err))
+		}
+		if len(records) == 0 {
+			logger.Fatal("no message records found")
+		}
+
+		networkConfig, err := setupSSVNetwork(logger)
+		if err != nil {
+			logger.Fatal("could not setup network", zap.Error(err))
+		}
+		cfg.DBOptions.Ctx = cmd.Context()
+		db, err := setupDB(logger, networkConfig.Beacon.GetNetwork())
+		if err != nil {
+			logger.Fatal("could not setup db", zap.Error(err))
+		}
+		defer func() {
+			if err := db.Close(); err != nil {
+				logger.Error("could not close db", zap.Error(err))
+			}
+		}()
+
+		nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+		if err != nil {
+			logger.Fatal("failed to create node storage", zap.Error(err))
+		}
+
+		replayer := &messageReplayer{
+			logger: logger,
+			speed:  speed,
+		}
+		replayer.validator = validation.New(
+			networkConfig,
+			nodeStorage.ValidatorStore(),
+			dutystore.New(), // beacon duties aren't known offline, see unreplayableRoles
+
+			signatureverifier.NewSignatureVerifier(nodeStorage),
+			phase0.Epoch(electraForkEpoch),
+			validation.WithLogger(logger),
+			validation.WithClock(replayer.now),
+		)
+
+		logger.Info("replaying recorded messages",
+			zap.Int("records", len(records)),
+			zap.Time("from", records[0].ReceivedAt),
+			zap.Time("to", records[len(records)-1].ReceivedAt),
+			zap.Float64("speed", speed),
+		)
+
+		if err := replayer.Replay(cmd.Context(), records); err != nil {
+			logger.Fatal("failed to replay messages", zap.Error(err))
+		}
+		replayer.Report()
+	},
+}
+
+// loadMessageRecords reads all records from the given files and sorts them by receive time,
+// so that rotated record files can be passed in any order.
+func loadMessageRecords(paths []string) ([]*p2pv1.MessageRecord, error) {
+	var records []*p2pv1.MessageRecord
+	for _, path := range paths {
+		f, err := os.Open(filepath.Clean(path))
+		if err != nil {
+			return nil, fmt.Errorf("could not open %s: %w", path, err)
+		}
+		reader := p2pv1.NewMessageRecordReader(f)
+		for {
+			record, err := reader.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				_ = f.Close()
+				return nil, fmt.Errorf("could not read %s: %w", path, err)
+			}
+			records = append(records, record)
+		}
+		if err := f.Close(); err != nil {
+			return nil, fmt.Errorf("could not close %s: %w", path, err)
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].ReceivedAt.Before(records[j].ReceivedAt)
+	})
+	return records, nil
+}
+
+// unreplayableRoles are the roles whose messages are validated against beacon duties,
+// which the replay's empty duty store doesn't have.
+var unreplayableRoles = map[spectypes.RunnerRole]struct{}{
+	spectypes.RoleProposer:                  {},
+	spectypes.RoleSyncCommitteeContribution: {},
+	spectypes.RoleVoluntaryExit:             {},
+}
+
+// messageReplayer feeds recorded messages through message validation
+// and counts the accepted messages of each duty by their message ID, as the validator controller routes them.
+type messageReplayer struct {
+	logger    *zap.Logger
+	validator validation.MessageValidator
+	speed     float64
+
+	current  time.Time
+	accepted map[spectypes.MessageID]int
+	results  map[pubsub.ValidationResult]int
+	skipped  int
+	failures int
+}
+
+// now returns the receive time of the message being replayed.
+func (r *messageReplayer) now() time.Time {
+	return r.current
+}
+
+// Replay replays the given records, which must be sorted by receive time.
+// Gaps between records are reproduced divided by speed, a speed of 0 replays as fast as possible.
+func (r *messageReplayer) Replay(ctx context.Context, records []*p2pv1.MessageRecord) error {
+	r.results = make(map[pubsub.ValidationResult]int)
+	r.accepted = make(map[spectypes.MessageID]int)
+	for i, record := range records {
+		if i > 0 && r.speed > 0 {
+			gap := record.ReceivedAt.Sub(records[i-1].ReceivedAt)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(float64(gap) / r.speed)):
+			}
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		pmsg, err := record.PubsubMessage()
+		if err != nil {
+			r.failures++
+			r.logger.Debug("could not rebuild recorded message", zap.Error(err))
+			continue
+		}
+		if !replayable(pmsg) {
+			r.skipped++
+			continue
+		}
+
+		r.current = record.ReceivedAt
+		result := r.validator.Validate(ctx, pmsg.ReceivedFrom, pmsg)
+		r.results[result]++
+		if result != pubsub.ValidationAccept {
+			continue
+		}
+
+		msg, ok := pmsg.ValidatorData.(*queue.SSVMessage)
+		if !ok {
+			r.failures++
+			continue
+		}
+		r.accepted[msg.GetID()]++
+	}
+	return nil
+}
+
+// replayable returns false for messages of unreplayableRoles,
+// messages which can't be decoded are replayed for message validation to reject them.
+func replayable(pmsg *pubsub.Message) bool {
+	signedSSVMessage := &spectypes.SignedSSVMessage{}
+	if err := signedSSVMessage.Decode(pmsg.GetData()); err != nil || signedSSVMessage.SSVMessage == nil {
+		return true
+	}
+	_, unreplayable := unreplayableRoles[signedSSVMessage.SSVMessage.GetID().GetRoleType()]
+	return !unreplayable
+}
+
+// Report logs the replay summary.
+func (r *messageReplayer) Report() {
+	for id, accepted := range r.accepted {
+		r.logger.Info("replayed duty messages",
+			fields.MessageID(id),
+			fields.Role(id.GetRoleType()),
+			zap.Int("accepted", accepted),
+		)
+	}
+	r.logger.Info("replay finished",
+		zap.Int("accepted", r.results[pubsub.ValidationAccept]),
+		zap.Int("ignored", r.results[pubsub.ValidationIgnore]),
+		zap.Int("rejected", r.results[pubsub.ValidationReject]),
+		zap.Int("skipped", r.skipped),
+		zap.Int("duties", len(r.accepted)),
+		zap.Int("failures", r.failures),
+	)
+}
+
+func init() {
+	global_config.ProcessArgs(&cfg, &globalArgs, ReplayCmd)
+	ReplayCmd.Flags().Float64(replaySpeedFlag, 1, "Replay speed relative to the recorded timing, 0 replays as fast as possible")
+	ReplayCmd.Flags().Uint64(replayElectraForkEpochFlag, math.MaxUint64, "Electra fork epoch of the recorded network")
+}
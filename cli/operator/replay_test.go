@@ -0,0 +1,85 @@
+package operator
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	p2pv1 "github.com/ssvlabs/ssv/network/p2p"
+	"github.com/ssvlabs/ssv/protocol/v2/ssv/queue"
+)
+
+type acceptingValidator struct {
+	validated []time.Time
+	now       func() time.Time
+}
+
+func (v *acceptingValidator) ValidatorForTopic(string) func(ctx context.Context, p peer.ID, pmsg *pubsub.Message) pubsub.ValidationResult {
+	return v.Validate
+}
+
+func (v *acceptingValidator) Validate(_ context.Context, _ peer.ID, pmsg *pubsub.Message) pubsub.ValidationResult {
+	v.validated = append(v.validated, v.now())
+	signedSSVMessage := &spectypes.SignedSSVMessage{}
+	if err := signedSSVMessage.Decode(pmsg.GetData()); err != nil {
+		return pubsub.ValidationReject
+	}
+	pmsg.ValidatorData = &queue.SSVMessage{SignedSSVMessage: signedSSVMessage, SSVMessage: signedSSVMessage.SSVMessage}
+	return pubsub.ValidationAccept
+}
+
+func TestMessageReplayerSkipsUnreplayableRoles(t *testing.T) {
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	peerID, err := peer.IDFromPrivateKey(key)
+	require.NoError(t, err)
+
+	start := time.Unix(1700000000, 0)
+	record := func(i int, role spectypes.RunnerRole) *p2pv1.MessageRecord {
+		msg := &spectypes.SignedSSVMessage{
+			Signatures:  [][]byte{make([]byte, 256)},
+			OperatorIDs: []spectypes.OperatorID{1},
+			SSVMessage: &spectypes.SSVMessage{
+				MsgType: spectypes.SSVPartialSignatureMsgType,
+				MsgID:   spectypes.NewMsgID(spectypes.DomainType{}, make([]byte, 48), role),
+				Data:    []byte{1},
+			},
+		}
+		data, err := msg.Encode()
+		require.NoError(t, err)
+		return &p2pv1.MessageRecord{
+			ReceivedAt: start.Add(time.Duration(i) * time.Second),
+			Topic:      "ssv.v2.1",
+			Peer:       peerID.String(),
+			Data:       data,
+		}
+	}
+	records := []*p2pv1.MessageRecord{
+		record(0, spectypes.RoleCommittee),
+		record(1, spectypes.RoleProposer),
+		record(2, spectypes.RoleAggregator),
+		record(3, spectypes.RoleSyncCommitteeContribution),
+		record(4, spectypes.RoleVoluntaryExit),
+		{ReceivedAt: start.Add(5 * time.Second), Topic: "ssv.v2.1", Peer: peerID.String(), Data: []byte("malformed")},
+	}
+
+	replayer := &messageReplayer{logger: zap.NewNop()}
+	validator := &acceptingValidator{now: replayer.now}
+	replayer.validator = validator
+	require.NoError(t, replayer.Replay(context.Background(), records))
+
+	// Messages are validated at their receive time, and malformed messages are left for validation to reject.
+	require.Equal(t, []time.Time{records[0].ReceivedAt, records[2].ReceivedAt, records[5].ReceivedAt}, validator.validated)
+	require.Equal(t, 3, replayer.skipped)
+	require.Equal(t, 2, replayer.results[pubsub.ValidationAccept])
+	require.Equal(t, 1, replayer.results[pubsub.ValidationReject])
+	require.Len(t, replayer.accepted, 2)
+}
@@ -0,0 +1,275 @@
+package operator
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	global_config "github.com/ssvlabs/ssv/cli/config"
+	"github.com/ssvlabs/ssv/ekm"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/operator/keys"
+	"github.com/ssvlabs/ssv/operator/keystore"
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/utils/commons"
+)
+
+const (
+	rotateNewKeystoreFlag     = "new-keystore"
+	rotateNewPasswordFileFlag = "new-password-file"
+	rotateOutputFlag          = "output"
+	rotateBackupFlag          = "backup"
+)
+
+// RotateOperatorKeyCmd is the command to rotate the operator key of a stopped node.
+var RotateOperatorKeyCmd = &cobra.Command{
+	Use:   "rotate-operator-key",
+	Short: "Rotates the operator key of the node",
+	Long: `Rotates the operator key configured for the node (KeyStore or OperatorPrivateKey) to a new key.
+The new key is generated and written to an encrypted keystore, unless an existing keystore is given with --new-keystore.
+The signer storage is then re-encrypted with the new key and the stored key hash is updated in a single DB transaction,
+so a failure leaves the DB untouched and the written keystore is removed.
+
+The node must be stopped. Once rotated, point KeyStore.PrivateKeyFile at the new keystore and register the printed public key on-chain.
+To roll back, point the config at the new keystore and rotate again with --new-keystore set to the previous keystore.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		commons.SetBuildData(cmd.Parent().Short, cmd.Parent().Version)
+
+		logger, err := setupGlobal()
+		if err != nil {
+			log.Fatal("could not create logger ", err)
+		}
+		logger = logger.Named(logging.NameKeyRotation)
+
+		newKeystorePath, _ := cmd.Flags().GetString(rotateNewKeystoreFlag)
+		newPasswordFile, _ := cmd.Flags().GetString(rotateNewPasswordFileFlag)
+		outputPath, _ := cmd.Flags().GetString(rotateOutputFlag)
+		backupPath, _ := cmd.Flags().GetString(rotateBackupFlag)
+
+		if cfg.ExternalOperatorKey.Enabled() {
+			logger.Fatal("rotation of an external operator key isn't supported, rotate it in the external signer instead")
+		}
+		if newPasswordFile == "" {
+			newPasswordFile = cfg.KeyStore.PasswordFile
+		}
+		if newPasswordFile == "" {
+			logger.Fatal("a password file for the new keystore is required")
+		}
+		// nolint: gosec
+		newPassword, err := os.ReadFile(newPasswordFile)
+		if err != nil {
+			logger.Fatal("could not read new password file", zap.Error(err))
+		}
+
+		currentKey, currentKeyText := loadOperatorPrivateKey(logger)
+
+		var newKey keys.OperatorPrivateKey
+		if newKeystorePath != "" {
+			newKey, err = readKeystore(newKeystorePath, string(newPassword))
+			if err != nil {
+				logger.Fatal("could not read new keystore", zap.Error(err))
+			}
+		} else {
+			newKey, err = keys.GeneratePrivateKey()
+			if err != nil {
+				logger.Fatal("could not generate new operator key", zap.Error(err))
+			}
+		}
+
+		networkConfig, err := setupSSVNetwork(logger)
+		if err != nil {
+			logger.Fatal("could not setup network", zap.Error(err))
+		}
+		cfg.DBOptions.Ctx = cmd.Context()
+		db, err := setupDB(logger, networkConfig.Beacon.GetNetwork())
+		if err != nil {
+			logger.Fatal("could not setup db, is the node stopped?", zap.Error(err))
+		}
+		defer func() {
+			if err := db.Close(); err != nil {
+				logger.Error("could not close db", zap.Error(err))
+			}
+		}()
+
+		// Keystores are written before the DB is updated, so that a key which the DB is encrypted with is never lost.
+		var written []string
+		rollbackFiles := func() {
+			for _, path := range written {
+				if err := os.Remove(path); err != nil {
+					logger.Error("could not remove keystore, remove it manually", zap.String("path", path), zap.Error(err))
+				}
+			}
+		}
+		if newKeystorePath == "" {
+			if err := writeKeystore(outputPath, newKey, string(newPassword)); err != nil {
+				logger.Fatal("could not write new keystore", zap.Error(err))
+			}
+			written = append(written, outputPath)
+			newKeystorePath = outputPath
+		}
+		previousKeystorePath, previousPasswordFile := cfg.KeyStore.PrivateKeyFile, cfg.KeyStore.PasswordFile
+		if previousKeystorePath == "" {
+			// The current key only exists in the config text, keep it in a keystore for rolling back.
+			if err := writeKeystore(backupPath, currentKey, string(newPassword)); err != nil {
+				rollbackFiles()
+				logger.Fatal("could not write backup keystore of the current key", zap.Error(err))
+			}
+			written = append(written, backupPath)
+			previousKeystorePath, previousPasswordFile = backupPath, newPasswordFile
+		}
+
+		accounts, err := rotateOperatorKey(logger, db, networkConfig, currentKey, currentKeyText, newKey)
+		if err != nil {
+			rollbackFiles()
+			logger.Fatal("could not rotate operator key, the DB is left unchanged", zap.Error(err))
+		}
+
+		pubKey, err := newKey.Public().Base64()
+		if err != nil {
+			logger.Fatal("could not encode new public key", zap.Error(err))
+		}
+		logger.Info("rotated operator key",
+			zap.Int("reencrypted_accounts", accounts),
+			zap.String("new_keystore", newKeystorePath),
+			zap.String("previous_keystore", previousKeystorePath),
+		)
+		fmt.Printf("New operator public key, register it on-chain:\n%s\n\n", pubKey)
+		fmt.Printf("Update the config to KeyStore.PrivateKeyFile: %s\n", newKeystorePath)
+		fmt.Printf("To roll back, rotate again with --%s=%s --%s=%s\n",
+			rotateNewKeystoreFlag, previousKeystorePath, rotateNewPasswordFileFlag, previousPasswordFile)
+	},
+}
+
+// rotateOperatorKey re-encrypts the signer storage with the EKM hash of newKey and stores the hash of newKey,
+// both in a single transaction. It fails if currentKey isn't the key the storage was created with.
+// It returns the number of re-encrypted accounts.
+func rotateOperatorKey(
+	logger *zap.Logger,
+	db basedb.Database,
+	networkConfig networkconfig.NetworkConfig,
+	currentKey keys.OperatorPrivateKey,
+	currentKeyText string,
+	newKey keys.OperatorPrivateKey,
+) (int, error) {
+	nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+	if err != nil {
+		return 0, fmt.Errorf("could not create node storage: %w", err)
+	}
+
+	storedHash, found, err := nodeStorage.GetPrivateKeyHash()
+	if err != nil {
+		return 0, fmt.Errorf("could not get stored key hash: %w", err)
+	}
+	if !found {
+		return 0, errors.New("no operator key is stored, there is nothing to rotate")
+	}
+	currentHash, currentLegacyHash, err := operatorKeyHashes(currentKey, currentKeyText)
+	if err != nil {
+		return 0, err
+	}
+	if storedHash != currentHash && storedHash != currentLegacyHash {
+		return 0, errors.New("current operator key is not matching the one encrypted the storage")
+	}
+	newHash, err := newKey.StorageHash()
+	if err != nil {
+		return 0, fmt.Errorf("could not hash new key: %w", err)
+	}
+	if newHash == currentHash {
+		return 0, errors.New("new operator key is the current one")
+	}
+
+	currentEKMHash, err := currentKey.EKMHash()
+	if err != nil {
+		return 0, fmt.Errorf("could not get current EKM hash: %w", err)
+	}
+	newEKMHash, err := newKey.EKMHash()
+	if err != nil {
+		return 0, fmt.Errorf("could not get new EKM hash: %w", err)
+	}
+
+	signerStorage := ekm.NewSignerStorage(db, networkConfig.Beacon, logger)
+	if err := signerStorage.SetEncryptionKey(currentEKMHash); err != nil {
+		return 0, err
+	}
+	newSignerStorage := ekm.NewSignerStorage(db, networkConfig.Beacon, logger)
+	if err := newSignerStorage.SetEncryptionKey(newEKMHash); err != nil {
+		return 0, err
+	}
+
+	txn := db.Begin()
+	defer txn.Discard()
+
+	count, err := signerStorage.ReEncryptAccountsTxn(txn, newEKMHash)
+	if err != nil {
+		return 0, fmt.Errorf("could not re-encrypt signer storage: %w", err)
+	}
+	// Check that the accounts are readable with the new key before committing.
+	accounts, err := newSignerStorage.ListAccountsTxn(txn)
+	if err != nil {
+		return 0, fmt.Errorf("could not read re-encrypted signer storage: %w", err)
+	}
+	if len(accounts) != count {
+		return 0, fmt.Errorf("read %d re-encrypted accounts, expected %d", len(accounts), count)
+	}
+	if err := nodeStorage.SavePrivateKeyHashTxn(txn, newHash); err != nil {
+		return 0, fmt.Errorf("could not save new key hash: %w", err)
+	}
+	if err := txn.Commit(); err != nil {
+		return 0, fmt.Errorf("could not commit: %w", err)
+	}
+	return count, nil
+}
+
+func readKeystore(path, password string) (keys.OperatorPrivateKey, error) {
+	encryptedJSON, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	decrypted, err := keystore.DecryptKeystore(encryptedJSON, password)
+	if err != nil {
+		return nil, err
+	}
+	return keys.PrivateKeyFromBytes(decrypted)
+}
+
+// writeKeystore writes the key into a new keystore file, it never overwrites an existing file.
+func writeKeystore(path string, key keys.OperatorPrivateKey, password string) error {
+	pubKey, err := key.Public().Base64()
+	if err != nil {
+		return err
+	}
+	encryptedJSON, err := keystore.EncryptKeystore(key.Bytes(), string(pubKey), password)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Clean(path), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(encryptedJSON)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+	}
+	return err
+}
+
+func init() {
+	global_config.ProcessArgs(&cfg, &globalArgs, RotateOperatorKeyCmd)
+	RotateOperatorKeyCmd.Flags().String(rotateNewKeystoreFlag, "", "Existing keystore of the new operator key, a new key is generated if not set")
+	RotateOperatorKeyCmd.Flags().String(rotateNewPasswordFileFlag, "", "Password file of the new keystore, defaults to KeyStore.PasswordFile")
+	RotateOperatorKeyCmd.Flags().String(rotateOutputFlag, "./encrypted_private_key.rotated.json", "File to write the keystore of the generated key into")
+	RotateOperatorKeyCmd.Flags().String(rotateBackupFlag, "./encrypted_private_key.previous.json", "File to write a keystore of the current key into when it's configured as OperatorPrivateKey")
+}
@@ -0,0 +1,104 @@
+package operator
+
+import (
+	"testing"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/ssvlabs/ssv/ekm"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/operator/keys"
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+	"github.com/ssvlabs/ssv/utils/threshold"
+)
+
+func Test_rotateOperatorKey(t *testing.T) {
+	threshold.Init()
+	logger := zap.New(zapcore.NewNopCore())
+	network := networkconfig.TestNetwork
+
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+	require.NoError(t, err)
+
+	currentKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+	newKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+	otherKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	currentHash, err := currentKey.StorageHash()
+	require.NoError(t, err)
+	require.NoError(t, nodeStorage.SavePrivateKeyHash(currentHash))
+
+	currentEKMHash, err := currentKey.EKMHash()
+	require.NoError(t, err)
+	km, err := ekm.NewETHKeyManagerSigner(logger, db, network, currentEKMHash)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		sk := &bls.SecretKey{}
+		sk.SetByCSPRNG()
+		require.NoError(t, km.AddShare(sk))
+	}
+
+	listAccounts := func(key keys.OperatorPrivateKey) (int, error) {
+		ekmHash, err := key.EKMHash()
+		require.NoError(t, err)
+		s := ekm.NewSignerStorage(db, network.Beacon, logger)
+		require.NoError(t, s.SetEncryptionKey(ekmHash))
+		accounts, err := s.ListAccounts()
+		return len(accounts), err
+	}
+
+	t.Run("wrong current key", func(t *testing.T) {
+		_, err := rotateOperatorKey(logger, db, network, otherKey, "", newKey)
+		require.ErrorContains(t, err, "not matching")
+
+		n, err := listAccounts(currentKey)
+		require.NoError(t, err)
+		require.Equal(t, 3, n)
+	})
+
+	t.Run("same key", func(t *testing.T) {
+		_, err := rotateOperatorKey(logger, db, network, currentKey, "", currentKey)
+		require.Error(t, err)
+	})
+
+	t.Run("rotate and roll back", func(t *testing.T) {
+		n, err := rotateOperatorKey(logger, db, network, currentKey, "", newKey)
+		require.NoError(t, err)
+		require.Equal(t, 3, n)
+
+		storedHash, _, err := nodeStorage.GetPrivateKeyHash()
+		require.NoError(t, err)
+		newHash, err := newKey.StorageHash()
+		require.NoError(t, err)
+		require.Equal(t, newHash, storedHash)
+
+		n, err = listAccounts(newKey)
+		require.NoError(t, err)
+		require.Equal(t, 3, n)
+		_, err = listAccounts(currentKey)
+		require.Error(t, err)
+
+		n, err = rotateOperatorKey(logger, db, network, newKey, "", currentKey)
+		require.NoError(t, err)
+		require.Equal(t, 3, n)
+
+		storedHash, _, err = nodeStorage.GetPrivateKeyHash()
+		require.NoError(t, err)
+		require.Equal(t, currentHash, storedHash)
+		n, err = listAccounts(currentKey)
+		require.NoError(t, err)
+		require.Equal(t, 3, n)
+	})
+}
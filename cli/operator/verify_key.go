@@ -0,0 +1,169 @@
+package operator
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/operator/keys"
+	"github.com/ssvlabs/ssv/operator/keystore"
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+// VerifyOperatorKeyCmd is the command to verify an operator keystore against a public key or the node's DB.
+var VerifyOperatorKeyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verifies an operator keystore and prints the key hashes the node would use",
+	Long: `Decrypts an operator keystore and verifies its public key against the given --public-key and/or the
+OperatorAdded data and private key hash in the node DB at --db-path, which requires the node to be stopped.
+The storage hash identifies the key in the node DB and the EKM hash encrypts the share keys in it.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := logging.SetGlobalLogger("info", "capital", "console", nil); err != nil {
+			log.Fatal(err)
+		}
+		logger := zap.L().Named(logging.NameExportKeys)
+
+		keystorePath, _ := cmd.Flags().GetString("keystore")
+		passwordFile, _ := cmd.Flags().GetString("password-file")
+		expectedPubKey, _ := cmd.Flags().GetString("public-key")
+		dbPath, _ := cmd.Flags().GetString("db-path")
+
+		encryptedJSON, err := os.ReadFile(filepath.Clean(keystorePath))
+		if err != nil {
+			logger.Fatal("could not read keystore", zap.Error(err))
+		}
+		password, err := os.ReadFile(filepath.Clean(passwordFile))
+		if err != nil {
+			logger.Fatal("could not read password file", zap.Error(err))
+		}
+		decrypted, err := keystore.DecryptKeystore(encryptedJSON, string(password))
+		if err != nil {
+			logger.Fatal("could not decrypt keystore", zap.Error(err))
+		}
+		key, err := keys.PrivateKeyFromBytes(decrypted)
+		if err != nil {
+			logger.Fatal("could not parse keystore private key", zap.Error(err))
+		}
+
+		var nodeStorage operatorstorage.Storage
+		if dbPath != "" {
+			db, err := kv.New(logger, basedb.Options{Ctx: cmd.Context(), Path: dbPath})
+			if err != nil {
+				logger.Fatal("could not open node DB, make sure the node is stopped", zap.Error(err))
+			}
+			defer func() {
+				_ = db.Close()
+			}()
+			nodeStorage, err = operatorstorage.NewNodeStorage(logger, db)
+			if err != nil {
+				logger.Fatal("could not create node storage", zap.Error(err))
+			}
+		}
+
+		report, err := verifyOperatorKey(key, base64.StdEncoding.EncodeToString(decrypted), expectedPubKey, nodeStorage)
+		if report != nil {
+			report.print()
+		}
+		if err != nil {
+			logger.Fatal("operator key verification failed", zap.Error(err))
+		}
+	},
+}
+
+// operatorKeyReport is what the verify command reports about an operator key.
+type operatorKeyReport struct {
+	PublicKey         string
+	StorageHash       string
+	LegacyStorageHash string
+	EKMHash           string
+	// OperatorID is set if the key was found in the node DB.
+	OperatorID *spectypes.OperatorID
+	// StoredHash is the private key hash found in the node DB, if any.
+	StoredHash string
+}
+
+func (r *operatorKeyReport) print() {
+	fmt.Println("Public key (base64):", r.PublicKey)
+	fmt.Println("Storage hash:", r.StorageHash)
+	fmt.Println("Legacy storage hash:", r.LegacyStorageHash)
+	fmt.Println("EKM hash:", r.EKMHash)
+	if r.OperatorID != nil {
+		fmt.Println("Operator ID:", *r.OperatorID)
+	}
+	if r.StoredHash != "" {
+		fmt.Println("Stored hash:", r.StoredHash)
+	}
+}
+
+// verifyOperatorKey checks the key's public key against expectedPubKey, if given,
+// and against the OperatorAdded data and the private key hash in the node storage, if given.
+// keyText is the base64 encoded decrypted keystore, as used by the node for the legacy hash.
+func verifyOperatorKey(key keys.OperatorPrivateKey, keyText, expectedPubKey string, nodeStorage operatorstorage.Storage) (*operatorKeyReport, error) {
+	pubKey, err := key.Public().Base64()
+	if err != nil {
+		return nil, fmt.Errorf("could not encode public key: %w", err)
+	}
+	hash, legacyHash, err := operatorKeyHashes(key, keyText)
+	if err != nil {
+		return nil, err
+	}
+	ekmHash, err := key.EKMHash()
+	if err != nil {
+		return nil, fmt.Errorf("could not get EKM hash: %w", err)
+	}
+	report := &operatorKeyReport{
+		PublicKey:         string(pubKey),
+		StorageHash:       hash,
+		LegacyStorageHash: legacyHash,
+		EKMHash:           ekmHash,
+	}
+
+	if expectedPubKey != "" && expectedPubKey != report.PublicKey {
+		return report, errors.New("public key doesn't match the given public key")
+	}
+
+	if nodeStorage == nil {
+		return report, nil
+	}
+	operatorData, found, err := nodeStorage.GetOperatorDataByPubKey(nil, pubKey)
+	if err != nil {
+		return report, fmt.Errorf("could not get operator data: %w", err)
+	}
+	if found {
+		report.OperatorID = &operatorData.ID
+	}
+	storedHash, storedHashFound, err := nodeStorage.GetPrivateKeyHash()
+	if err != nil {
+		return report, fmt.Errorf("could not get stored private key hash: %w", err)
+	}
+	if storedHashFound {
+		report.StoredHash = storedHash
+	}
+
+	if storedHashFound && storedHash != hash && storedHash != legacyHash {
+		return report, errors.New("key doesn't match the key the node DB was created with")
+	}
+	if !found {
+		return report, errors.New("no OperatorAdded event with this public key in the node DB")
+	}
+	return report, nil
+}
+
+func init() {
+	VerifyOperatorKeyCmd.Flags().String("keystore", "", "Path of the operator keystore")
+	VerifyOperatorKeyCmd.Flags().String("password-file", "", "Path of the keystore password file")
+	VerifyOperatorKeyCmd.Flags().String("public-key", "", "Expected base64 public key, as registered on-chain")
+	VerifyOperatorKeyCmd.Flags().String("db-path", "", "Path of the node DB to verify the key against")
+	_ = VerifyOperatorKeyCmd.MarkFlagRequired("keystore")
+	_ = VerifyOperatorKeyCmd.MarkFlagRequired("password-file")
+}
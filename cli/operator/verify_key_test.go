@@ -0,0 +1,77 @@
+package operator
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/ssvlabs/ssv/operator/keys"
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func Test_verifyOperatorKey(t *testing.T) {
+	logger := zap.New(zapcore.NewNopCore())
+
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+	require.NoError(t, err)
+
+	key, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+	keyText := base64.StdEncoding.EncodeToString(key.Bytes())
+	pubKey, err := key.Public().Base64()
+	require.NoError(t, err)
+	otherKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+	otherPubKey, err := otherKey.Public().Base64()
+	require.NoError(t, err)
+
+	t.Run("public key", func(t *testing.T) {
+		report, err := verifyOperatorKey(key, keyText, string(pubKey), nil)
+		require.NoError(t, err)
+		require.Equal(t, string(pubKey), report.PublicKey)
+
+		hash, err := key.StorageHash()
+		require.NoError(t, err)
+		require.Equal(t, hash, report.StorageHash)
+		ekmHash, err := key.EKMHash()
+		require.NoError(t, err)
+		require.Equal(t, ekmHash, report.EKMHash)
+		require.NotEmpty(t, report.LegacyStorageHash)
+
+		_, err = verifyOperatorKey(key, keyText, string(otherPubKey), nil)
+		require.ErrorContains(t, err, "doesn't match the given public key")
+	})
+
+	t.Run("node DB", func(t *testing.T) {
+		_, err := verifyOperatorKey(key, keyText, "", nodeStorage)
+		require.ErrorContains(t, err, "no OperatorAdded event")
+
+		_, err = nodeStorage.SaveOperatorData(nil, &registrystorage.OperatorData{ID: 7, PublicKey: pubKey})
+		require.NoError(t, err)
+		report, err := verifyOperatorKey(key, keyText, "", nodeStorage)
+		require.NoError(t, err)
+		require.NotNil(t, report.OperatorID)
+		require.EqualValues(t, 7, *report.OperatorID)
+
+		// The node DB was created with the legacy hash of the key.
+		require.NoError(t, nodeStorage.SavePrivateKeyHash(report.LegacyStorageHash))
+		report, err = verifyOperatorKey(key, keyText, "", nodeStorage)
+		require.NoError(t, err)
+		require.Equal(t, report.LegacyStorageHash, report.StoredHash)
+
+		otherHash, err := otherKey.StorageHash()
+		require.NoError(t, err)
+		require.NoError(t, nodeStorage.SavePrivateKeyHash(otherHash))
+		_, err = verifyOperatorKey(key, keyText, "", nodeStorage)
+		require.ErrorContains(t, err, "doesn't match the key the node DB was created with")
+	})
+}
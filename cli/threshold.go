@@ -18,7 +18,7 @@ import (
 // createThreshold is the command to create threshold based on the given private key
 var createThresholdCmd = &cobra.Command{
 	Use:   "create-threshold",
-	Short: "Turns a private key into a threshold key. For testing usage only",
+	Short: "Turns a private key into a threshold key. For testing usage only, see build-shares for registering validators",
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := logging.SetGlobalLogger("debug", "capital", "console", nil); err != nil {
 			log.Fatal(err)
@@ -53,7 +53,6 @@ var createThresholdCmd = &cobra.Command{
 			logger.Fatal("failed to turn a private key into a threshold key", zap.Error(err))
 		}
 
-		// TODO: export to json file
 		fmt.Println("Generating threshold keys for validator", baseKey.GetPublicKey().SerializeToHexStr())
 		for i, pk := range privKeys {
 			fmt.Println()
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/network/topics"
+)
+
+// traceCmd groups the commands for inspecting pubsub trace files.
+var traceCmd = &cobra.Command{
+	Use:   "trace",
+	Short: "Inspect pubsub trace files",
+}
+
+// traceSummarizeCmd summarizes pubsub trace files written by the node (see PubSubTraceFile).
+var traceSummarizeCmd = &cobra.Command{
+	Use:   "summarize [trace files...]",
+	Short: "Summarizes pubsub trace files per topic: mesh churn, duplicate rates, IHAVE/IWANT volumes and delivery latency",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := logging.SetGlobalLogger("info", "capital", "console", nil); err != nil {
+			log.Fatal(err)
+		}
+		logger := zap.L().Named(logging.NameTrace)
+
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			logger.Fatal("failed to get format flag value", zap.Error(err))
+		}
+
+		summarizer := topics.NewTraceSummarizer()
+		for _, fileName := range args {
+			events, err := readTraceFile(fileName, format, summarizer)
+			if err != nil {
+				logger.Fatal("failed to read trace file", zap.String("file", fileName), zap.Error(err))
+			}
+			logger.Info("read trace file", zap.String("file", fileName), zap.Int("events", events))
+		}
+
+		printTraceSummary(os.Stdout, summarizer.Summary())
+	},
+}
+
+func readTraceFile(fileName, format string, summarizer *topics.TraceSummarizer) (int, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	reader, err := topics.NewTraceReader(f, format)
+	if err != nil {
+		return 0, err
+	}
+	events := 0
+	for {
+		evt, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return events, nil
+		}
+		if err != nil {
+			return events, err
+		}
+		summarizer.Add(evt)
+		events++
+	}
+}
+
+func printTraceSummary(w io.Writer, summary []*topics.TopicTraceSummary) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "TOPIC\tDELIVERED\tDUPLICATES\tDUP RATE\tREJECTED\tPUBLISHED\tGRAFTS\tPRUNES\tCHURN\tIHAVE SENT\tIHAVE RECV\tIWANT SENT\tIWANT RECV\tLATENCY P50\tLATENCY P99")
+	for _, s := range summary {
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%d\t%.2f%%\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t%s\t%s\n",
+			s.Topic,
+			s.Delivered,
+			s.Duplicates,
+			s.DuplicateRate()*100,
+			s.Rejected,
+			s.Published,
+			s.Grafts,
+			s.Prunes,
+			s.MeshChurn(),
+			s.IHaveSent,
+			s.IHaveReceived,
+			s.IWantSent,
+			s.IWantReceived,
+			s.DeliveryLatency(50),
+			s.DeliveryLatency(99),
+		)
+	}
+	_ = tw.Flush()
+}
+
+func init() {
+	traceSummarizeCmd.Flags().String("format", topics.TraceFormatPB, "Format of the trace files: pb or json")
+	traceCmd.AddCommand(traceSummarizeCmd)
+	RootCmd.AddCommand(traceCmd)
+}
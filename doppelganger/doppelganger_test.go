@@ -50,6 +50,24 @@ func TestMarkAsSafe(t *testing.T) {
 	require.True(t, dg.validatorsState[1].safe())
 }
 
+func TestToggle(t *testing.T) {
+	dg := newTestDoppelgangerHandler(t)
+	dg.validatorsState[1] = &doppelgangerState{remainingEpochs: 2}
+	toggle := NewToggle(dg)
+
+	require.True(t, toggle.Enabled())
+	require.False(t, toggle.CanSign(1))
+
+	toggle.SetEnabled(false)
+	require.False(t, toggle.Enabled())
+	require.True(t, toggle.CanSign(1))
+
+	// The wrapped handler keeps tracking the state while disabled.
+	toggle.ReportQuorum(1)
+	toggle.SetEnabled(true)
+	require.True(t, toggle.CanSign(1))
+}
+
 func TestUpdateDoppelgangerState(t *testing.T) {
 	dg := newTestDoppelgangerHandler(t)
 
@@ -0,0 +1,38 @@
+package doppelganger
+
+import (
+	"sync/atomic"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Toggle is a Provider which can be disabled and re-enabled at runtime.
+// While disabled, all validators can sign, but the wrapped Provider keeps tracking their state
+// so that re-enabling doesn't start the detection over.
+type Toggle struct {
+	Provider
+	disabled atomic.Bool
+}
+
+// NewToggle returns an enabled Toggle of the given Provider.
+func NewToggle(provider Provider) *Toggle {
+	return &Toggle{Provider: provider}
+}
+
+// SetEnabled enables or disables the protection.
+func (t *Toggle) SetEnabled(enabled bool) {
+	t.disabled.Store(!enabled)
+}
+
+// Enabled returns whether the protection is enabled.
+func (t *Toggle) Enabled() bool {
+	return !t.disabled.Load()
+}
+
+// CanSign returns true if the protection is disabled, otherwise whether the wrapped Provider allows signing.
+func (t *Toggle) CanSign(validatorIndex phase0.ValidatorIndex) bool {
+	if t.disabled.Load() {
+		return true
+	}
+	return t.Provider.CanSign(validatorIndex)
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"go.uber.org/zap"
+
+	beaconproxy "github.com/ssvlabs/ssv/e2e/beacon_proxy"
+	"github.com/ssvlabs/ssv/e2e/beacon_proxy/intercept"
+	"github.com/ssvlabs/ssv/e2e/beacon_proxy/intercept/slashinginterceptor"
+	fakebeacon "github.com/ssvlabs/ssv/e2e/fake_beacon"
+)
+
+type FakeBeaconCmd struct {
+	Gateways          []string `required:"" env:"GATEWAYS"            help:"Names of the gateways to provide."`
+	BasePort          int      `            env:"BASE_PORT"           help:"Base port for the gateways."                            default:"6631"`
+	SyncCommitteeSize uint64   `            env:"SYNC_COMMITTEE_SIZE" help:"Number of validators in the sync committee."            default:"4"`
+	BlindedBlocks     bool     `            env:"BLINDED_BLOCKS"      help:"Produce blinded blocks instead of full blocks."`
+	Interceptor       string   `            env:"INTERCEPTOR"         help:"Interceptor of the gateways, as in the beacon proxy."   default:"none" enum:"none,slashing"`
+}
+
+type FakeBeaconJSON struct {
+	Validators map[phase0.ValidatorIndex]string `json:"fake_beacon"` // index => public key
+}
+
+// fakeValidators returns the active validators with the given indices and public keys.
+func fakeValidators(validators map[phase0.ValidatorIndex]string) ([]*v1.Validator, error) {
+	result := make([]*v1.Validator, 0, len(validators))
+	for index, pubKeyHex := range validators {
+		pubKey, err := hex.DecodeString(strings.TrimPrefix(pubKeyHex, "0x"))
+		if err != nil || len(pubKey) != len(phase0.BLSPubKey{}) {
+			return nil, fmt.Errorf("invalid public key of validator %d: %q", index, pubKeyHex)
+		}
+		result = append(result, &v1.Validator{
+			Index:   index,
+			Balance: 32_000_000_000,
+			Status:  v1.ValidatorStateActiveOngoing,
+			Validator: &phase0.Validator{
+				PublicKey:                  phase0.BLSPubKey(pubKey),
+				WithdrawalCredentials:      make([]byte, 32),
+				EffectiveBalance:           32_000_000_000,
+				ActivationEligibilityEpoch: 0,
+				ActivationEpoch:            0,
+				ExitEpoch:                  math.MaxUint64,
+				WithdrawableEpoch:          math.MaxUint64,
+			},
+		})
+	}
+	return result, nil
+}
+
+func (cmd *FakeBeaconCmd) Run(logger *zap.Logger, globals Globals) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	contents, err := os.ReadFile(globals.ValidatorsFile)
+	if err != nil {
+		return fmt.Errorf("failed to read file contents: %s, %w", globals.ValidatorsFile, err)
+	}
+
+	var fakeBeaconJSON FakeBeaconJSON
+	if err = json.Unmarshal(contents, &fakeBeaconJSON); err != nil {
+		return fmt.Errorf("error parsing json file: %s, %w", globals.ValidatorsFile, err)
+	}
+
+	validators, err := fakeValidators(fakeBeaconJSON.Validators)
+	if err != nil {
+		return err
+	}
+
+	// The chain follows the network of the SSV nodes, so that they agree on the slots and the fork versions.
+	networkCfg, err := networkconfig.GetNetworkConfigByName(globals.NetworkName)
+	if err != nil {
+		return err
+	}
+
+	var interceptor intercept.Interceptor
+	if cmd.Interceptor == "slashing" {
+		const startEpochDelay = 1
+		startEpoch := networkCfg.Beacon.EstimatedCurrentEpoch() + startEpochDelay
+
+		slashingInterceptor := slashinginterceptor.New(logger, networkCfg.Beacon.GetNetwork(), startEpoch, true, validators)
+		go slashingInterceptor.WatchSubmissions()
+		interceptor = slashingInterceptor
+	}
+
+	gateways := make([]beaconproxy.Gateway, len(cmd.Gateways))
+	for i, gw := range cmd.Gateways {
+		gateways[i] = beaconproxy.Gateway{
+			Name:        gw,
+			Port:        cmd.BasePort + i,
+			Interceptor: interceptor,
+		}
+	}
+
+	fake, err := fakebeacon.New(logger, fakebeacon.Config{
+		GenesisTime:        networkCfg.GetGenesisTime(),
+		GenesisForkVersion: networkCfg.ForkVersion(),
+		SlotDuration:       networkCfg.SlotDurationSec(),
+		SlotsPerEpoch:      networkCfg.SlotsPerEpoch(),
+		SyncCommitteeSize:  cmd.SyncCommitteeSize,
+		Validators:         validators,
+		BlindedBlocks:      cmd.BlindedBlocks,
+	}, gateways)
+	if err != nil {
+		return fmt.Errorf("fake beacon creation error: %w", err)
+	}
+
+	logger.Info("Starting fake beacon node",
+		zap.Int("validators", len(validators)),
+		zap.String("interceptor", cmd.Interceptor),
+		zap.Uint64("slot", uint64(fake.Clock().CurrentSlot())),
+	)
+	return fake.Run(ctx)
+}
@@ -21,6 +21,7 @@ type Globals struct {
 type CLI struct {
 	Globals
 	BeaconProxy BeaconProxyCmd `cmd:""`
+	FakeBeacon  FakeBeaconCmd  `cmd:""`
 	LogsCatcher LogsCatcherCmd `cmd:""`
 	ShareUpdate ShareUpdateCmd `cmd:""`
 }
@@ -0,0 +1,113 @@
+package fakebeacon
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"go.uber.org/zap"
+)
+
+func (b *FakeBeacon) handleAttestationData(w http.ResponseWriter, r *http.Request) {
+	logger, gateway := b.requestContext(r)
+
+	// Parse request.
+	slot, err := parseUintParam(r, "slot")
+	if err != nil {
+		b.error(r, w, http.StatusBadRequest, err)
+		return
+	}
+	committeeIndex, err := parseUintParam(r, "committee_index")
+	if err != nil {
+		b.error(r, w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Obtain attestation data.
+	data := b.chain.attestationData(phase0.Slot(slot), phase0.CommitteeIndex(committeeIndex))
+
+	// Intercept.
+	if gateway.Interceptor != nil {
+		data, err = gateway.Interceptor.InterceptAttestationData(r.Context(), phase0.Slot(slot), phase0.CommitteeIndex(committeeIndex), data)
+		if err != nil {
+			b.error(r, w, http.StatusInternalServerError, fmt.Errorf("failed to intercept attestation data: %w", err))
+			return
+		}
+	}
+
+	// Respond.
+	b.respond(r, w, data, nil)
+
+	logger.Info("served attestation data",
+		zap.Uint64("slot", slot),
+		zap.Uint64("committee_index", committeeIndex),
+	)
+}
+
+func (b *FakeBeacon) handleSubmitAttestations(w http.ResponseWriter, r *http.Request) {
+	logger, gateway := b.requestContext(r)
+
+	// Parse request.
+	var attestations []*phase0.Attestation
+	if err := json.NewDecoder(r.Body).Decode(&attestations); err != nil {
+		b.error(r, w, http.StatusBadRequest, fmt.Errorf("failed to parse request: %w", err))
+		return
+	}
+
+	// Intercept.
+	if gateway.Interceptor != nil {
+		var err error
+		attestations, err = gateway.Interceptor.InterceptSubmitAttestations(r.Context(), attestations)
+		if err != nil {
+			b.error(r, w, http.StatusInternalServerError, fmt.Errorf("failed to intercept attestations: %w", err))
+			return
+		}
+	}
+
+	// Submit.
+	for _, attestation := range attestations {
+		if err := b.chain.addAttestation(attestation); err != nil {
+			b.error(r, w, http.StatusBadRequest, fmt.Errorf("failed to add attestation: %w", err))
+			return
+		}
+	}
+
+	// Respond.
+	w.WriteHeader(http.StatusOK)
+
+	logger.Info("submitted attestations", zap.Int("count", len(attestations)))
+}
+
+// handleAggregateAttestation serves the union of the attestations submitted with the data root.
+func (b *FakeBeacon) handleAggregateAttestation(w http.ResponseWriter, r *http.Request) {
+	logger, _ := b.requestContext(r)
+
+	// Parse request.
+	slot, err := parseUintParam(r, "slot")
+	if err != nil {
+		b.error(r, w, http.StatusBadRequest, err)
+		return
+	}
+	dataRoot, err := parseRootParam(r, "attestation_data_root")
+	if err != nil {
+		b.error(r, w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Obtain aggregate.
+	aggregate, ok := b.chain.aggregateAttestation(dataRoot)
+	if !ok || aggregate.Data.Slot != phase0.Slot(slot) {
+		b.error(r, w, http.StatusNotFound, fmt.Errorf("no attestations with data root %#x at slot %d", dataRoot, slot))
+		return
+	}
+
+	// Respond.
+	b.respondVersioned(r, w, spec.DataVersionDeneb, aggregate)
+
+	logger.Info("served aggregate attestation",
+		zap.Uint64("slot", slot),
+		zap.Uint64("participants", aggregate.AggregationBits.Count()),
+	)
+}
@@ -0,0 +1,380 @@
+package fakebeacon
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sort"
+	"sync"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+// retainSlots is the number of recent slots for which blocks and attestations are kept.
+const retainSlots = 256
+
+// chain is the deterministic state of the fake beacon chain: every value is derived from the slot
+// and the validator set, except for the blocks and attestations submitted to it.
+type chain struct {
+	clock             *Clock
+	syncCommitteeSize uint64
+	validators        []*v1.Validator // sorted by index
+	byIndex           map[phase0.ValidatorIndex]*v1.Validator
+	byPubKey          map[phase0.BLSPubKey]*v1.Validator
+
+	mu sync.RWMutex
+	// blocks are the roots of submitted blocks by slot.
+	blocks map[phase0.Slot]phase0.Root
+	// payloads are the execution payloads of produced blocks by slot, used to unblind submitted blinded blocks.
+	payloads map[phase0.Slot]*deneb.ExecutionPayload
+	// attestations are the submitted attestations by the root of their data.
+	attestations map[phase0.Root][]*phase0.Attestation
+	// syncMessages are the submitted sync committee messages by slot.
+	syncMessages map[phase0.Slot][]*syncMessage
+	// live are the validators which submitted anything by epoch.
+	live map[phase0.Epoch]map[phase0.ValidatorIndex]struct{}
+}
+
+type syncMessage struct {
+	validatorIndex  phase0.ValidatorIndex
+	beaconBlockRoot phase0.Root
+	signature       phase0.BLSSignature
+}
+
+func newChain(clock *Clock, validators []*v1.Validator, syncCommitteeSize uint64) *chain {
+	c := &chain{
+		clock:             clock,
+		syncCommitteeSize: syncCommitteeSize,
+		validators:        append([]*v1.Validator(nil), validators...),
+		byIndex:           make(map[phase0.ValidatorIndex]*v1.Validator, len(validators)),
+		byPubKey:          make(map[phase0.BLSPubKey]*v1.Validator, len(validators)),
+		blocks:            make(map[phase0.Slot]phase0.Root),
+		payloads:          make(map[phase0.Slot]*deneb.ExecutionPayload),
+		attestations:      make(map[phase0.Root][]*phase0.Attestation),
+		syncMessages:      make(map[phase0.Slot][]*syncMessage),
+		live:              make(map[phase0.Epoch]map[phase0.ValidatorIndex]struct{}),
+	}
+	sort.Slice(c.validators, func(i, j int) bool {
+		return c.validators[i].Index < c.validators[j].Index
+	})
+	for _, v := range c.validators {
+		c.byIndex[v.Index] = v
+		c.byPubKey[v.Validator.PublicKey] = v
+	}
+	return c
+}
+
+// deterministicRoot returns a root derived from the label and the number.
+func deterministicRoot(label string, n uint64) phase0.Root {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], n)
+	return sha256.Sum256(append([]byte(label), buf[:]...))
+}
+
+// syntheticBlockRoot is the root of the block at the slot if none was submitted.
+func syntheticBlockRoot(slot phase0.Slot) phase0.Root {
+	return deterministicRoot("block", uint64(slot))
+}
+
+// blockRoot returns the root of the block at the slot.
+func (c *chain) blockRoot(slot phase0.Slot) phase0.Root {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if root, ok := c.blocks[slot]; ok {
+		return root
+	}
+	return syntheticBlockRoot(slot)
+}
+
+// stateRoot returns the root of the state after the slot.
+func (c *chain) stateRoot(slot phase0.Slot) phase0.Root {
+	return deterministicRoot("state", uint64(slot))
+}
+
+// slotOfRoot returns the slot of a known block root.
+func (c *chain) slotOfRoot(root phase0.Root, maxSlot phase0.Slot) (phase0.Slot, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for slot, r := range c.blocks {
+		if r == root {
+			return slot, true
+		}
+	}
+	for slot := maxSlot; slot+retainSlots > maxSlot; slot-- {
+		if syntheticBlockRoot(slot) == root {
+			return slot, true
+		}
+		if slot == 0 {
+			break
+		}
+	}
+	return 0, false
+}
+
+// dependentRoot returns the root on which the duties of the epoch depend:
+// the block of the last slot before the epoch, or the genesis block.
+func (c *chain) dependentRoot(epoch phase0.Epoch) phase0.Root {
+	if epoch == 0 {
+		return syntheticBlockRoot(0)
+	}
+	return syntheticBlockRoot(c.clock.FirstSlot(epoch) - 1)
+}
+
+// checkpoint returns the checkpoint of the epoch.
+func (c *chain) checkpoint(epoch phase0.Epoch) *phase0.Checkpoint {
+	return &phase0.Checkpoint{
+		Epoch: epoch,
+		Root:  c.blockRoot(c.clock.FirstSlot(epoch)),
+	}
+}
+
+// proposer returns the proposer of the slot, going round-robin over the validators.
+func (c *chain) proposer(slot phase0.Slot) *v1.Validator {
+	return c.validators[uint64(slot)%uint64(len(c.validators))]
+}
+
+// attesterSlot returns the slot at which the validator at the position attests in the epoch.
+// Validators are spread evenly over the slots of the epoch, one committee per slot.
+func (c *chain) attesterSlot(epoch phase0.Epoch, position int) phase0.Slot {
+	return c.clock.FirstSlot(epoch) + phase0.Slot(uint64(position)%c.clock.SlotsPerEpoch())
+}
+
+// attesterDuties returns the attester duties of the validators in the epoch.
+func (c *chain) attesterDuties(epoch phase0.Epoch, indices []phase0.ValidatorIndex) []*v1.AttesterDuty {
+	slotsPerEpoch := c.clock.SlotsPerEpoch()
+	wanted := make(map[phase0.ValidatorIndex]struct{}, len(indices))
+	for _, index := range indices {
+		wanted[index] = struct{}{}
+	}
+
+	duties := make([]*v1.AttesterDuty, 0, len(indices))
+	for position, v := range c.validators {
+		if _, ok := wanted[v.Index]; !ok {
+			continue
+		}
+		// The committee of a slot holds the validators at the positions equal to the slot modulo slotsPerEpoch.
+		committeeLength := uint64(len(c.validators)) / slotsPerEpoch
+		if uint64(position)%slotsPerEpoch < uint64(len(c.validators))%slotsPerEpoch {
+			committeeLength++
+		}
+		duties = append(duties, &v1.AttesterDuty{
+			PubKey:                  v.Validator.PublicKey,
+			Slot:                    c.attesterSlot(epoch, position),
+			ValidatorIndex:          v.Index,
+			CommitteeIndex:          0,
+			CommitteeLength:         committeeLength,
+			CommitteesAtSlot:        1,
+			ValidatorCommitteeIndex: uint64(position) / slotsPerEpoch,
+		})
+	}
+	return duties
+}
+
+// proposerDuties returns the proposer duties of the epoch.
+func (c *chain) proposerDuties(epoch phase0.Epoch) []*v1.ProposerDuty {
+	slotsPerEpoch := c.clock.SlotsPerEpoch()
+	duties := make([]*v1.ProposerDuty, 0, slotsPerEpoch)
+	for slot := c.clock.FirstSlot(epoch); slot < c.clock.FirstSlot(epoch+1); slot++ {
+		proposer := c.proposer(slot)
+		duties = append(duties, &v1.ProposerDuty{
+			PubKey:         proposer.Validator.PublicKey,
+			Slot:           slot,
+			ValidatorIndex: proposer.Index,
+		})
+	}
+	return duties
+}
+
+// syncCommitteePosition returns the position of the validator in the sync committee.
+// The first syncCommitteeSize validators form the sync committee of every period.
+func (c *chain) syncCommitteePosition(index phase0.ValidatorIndex) (uint64, bool) {
+	for position, v := range c.validators {
+		if uint64(position) >= c.syncCommitteeSize {
+			break
+		}
+		if v.Index == index {
+			return uint64(position), true
+		}
+	}
+	return 0, false
+}
+
+// syncCommitteeDuties returns the sync committee duties of the validators.
+func (c *chain) syncCommitteeDuties(indices []phase0.ValidatorIndex) []*v1.SyncCommitteeDuty {
+	var duties []*v1.SyncCommitteeDuty
+	for _, index := range indices {
+		position, ok := c.syncCommitteePosition(index)
+		if !ok {
+			continue
+		}
+		duties = append(duties, &v1.SyncCommitteeDuty{
+			PubKey:                        c.byIndex[index].Validator.PublicKey,
+			ValidatorIndex:                index,
+			ValidatorSyncCommitteeIndices: []phase0.CommitteeIndex{phase0.CommitteeIndex(position)},
+		})
+	}
+	return duties
+}
+
+// attestationData returns the attestation data of the slot.
+func (c *chain) attestationData(slot phase0.Slot, committeeIndex phase0.CommitteeIndex) *phase0.AttestationData {
+	epoch := c.clock.EpochOf(slot)
+	source := &phase0.Checkpoint{}
+	if epoch > 0 {
+		source = c.checkpoint(epoch - 1)
+	}
+	return &phase0.AttestationData{
+		Slot:            slot,
+		Index:           committeeIndex,
+		BeaconBlockRoot: c.blockRoot(slot),
+		Source:          source,
+		Target:          c.checkpoint(epoch),
+	}
+}
+
+// addBlock records the root of a submitted block.
+func (c *chain) addBlock(slot phase0.Slot, root phase0.Root, proposer phase0.ValidatorIndex) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.blocks[slot] = root
+	c.markLive(c.clock.EpochOf(slot), proposer)
+	c.prune(slot)
+}
+
+// addPayload records the execution payload of a produced block.
+func (c *chain) addPayload(slot phase0.Slot, payload *deneb.ExecutionPayload) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.payloads[slot] = payload
+}
+
+// payload returns the execution payload of the block produced at the slot.
+func (c *chain) payload(slot phase0.Slot) (*deneb.ExecutionPayload, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	payload, ok := c.payloads[slot]
+	return payload, ok
+}
+
+// addAttestation records a submitted attestation.
+func (c *chain) addAttestation(attestation *phase0.Attestation) error {
+	dataRoot, err := attestation.Data.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.attestations[dataRoot] = append(c.attestations[dataRoot], attestation)
+
+	// The attester is the validator at the set bit of the slot's committee.
+	epoch := c.clock.EpochOf(attestation.Data.Slot)
+	slotsPerEpoch := c.clock.SlotsPerEpoch()
+	for position, v := range c.validators {
+		if c.attesterSlot(epoch, position) != attestation.Data.Slot {
+			continue
+		}
+		committeePosition := uint64(position) / slotsPerEpoch
+		if committeePosition < attestation.AggregationBits.Len() && attestation.AggregationBits.BitAt(committeePosition) {
+			c.markLive(epoch, v.Index)
+		}
+	}
+	c.prune(attestation.Data.Slot)
+	return nil
+}
+
+// aggregateAttestation returns the union of the submitted attestations with the data root.
+// The signature is the one of the first attestation, since the fake doesn't verify signatures.
+func (c *chain) aggregateAttestation(dataRoot phase0.Root) (*phase0.Attestation, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	attestations := c.attestations[dataRoot]
+	if len(attestations) == 0 {
+		return nil, false
+	}
+	aggregate := &phase0.Attestation{
+		AggregationBits: bitfield.NewBitlist(attestations[0].AggregationBits.Len()),
+		Data:            attestations[0].Data,
+		Signature:       attestations[0].Signature,
+	}
+	for _, attestation := range attestations {
+		for i := uint64(0); i < attestation.AggregationBits.Len() && i < aggregate.AggregationBits.Len(); i++ {
+			if attestation.AggregationBits.BitAt(i) {
+				aggregate.AggregationBits.SetBitAt(i, true)
+			}
+		}
+	}
+	return aggregate, true
+}
+
+// addSyncMessage records a submitted sync committee message.
+func (c *chain) addSyncMessage(slot phase0.Slot, message *syncMessage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.syncMessages[slot] = append(c.syncMessages[slot], message)
+	c.markLive(c.clock.EpochOf(slot), message.validatorIndex)
+	c.prune(slot)
+}
+
+// syncMessagesAt returns the sync committee messages submitted for the slot and block root.
+func (c *chain) syncMessagesAt(slot phase0.Slot, root phase0.Root) []*syncMessage {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var messages []*syncMessage
+	for _, message := range c.syncMessages[slot] {
+		if message.beaconBlockRoot == root {
+			messages = append(messages, message)
+		}
+	}
+	return messages
+}
+
+// isLive returns whether the validator submitted anything in the epoch.
+func (c *chain) isLive(epoch phase0.Epoch, index phase0.ValidatorIndex) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, ok := c.live[epoch][index]
+	return ok
+}
+
+func (c *chain) markLive(epoch phase0.Epoch, index phase0.ValidatorIndex) {
+	if c.live[epoch] == nil {
+		c.live[epoch] = make(map[phase0.ValidatorIndex]struct{})
+	}
+	c.live[epoch][index] = struct{}{}
+}
+
+// prune drops the state older than retainSlots before the slot. Must be called with mu locked.
+func (c *chain) prune(slot phase0.Slot) {
+	if slot < retainSlots {
+		return
+	}
+	oldest := slot - retainSlots
+	for s := range c.blocks {
+		if s < oldest {
+			delete(c.blocks, s)
+		}
+	}
+	for s := range c.payloads {
+		if s < oldest {
+			delete(c.payloads, s)
+		}
+	}
+	for s := range c.syncMessages {
+		if s < oldest {
+			delete(c.syncMessages, s)
+		}
+	}
+	for root, attestations := range c.attestations {
+		if attestations[0].Data.Slot < oldest {
+			delete(c.attestations, root)
+		}
+	}
+	for epoch := range c.live {
+		if c.clock.FirstSlot(epoch+1) < oldest {
+			delete(c.live, epoch)
+		}
+	}
+}
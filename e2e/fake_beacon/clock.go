@@ -0,0 +1,107 @@
+package fakebeacon
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Clock is the virtual clock of the fake beacon node. It follows the wall clock from the genesis time,
+// but can be moved forward to skip slots without waiting for them.
+type Clock struct {
+	genesis       time.Time
+	slotDuration  time.Duration
+	slotsPerEpoch uint64
+
+	mu     sync.RWMutex
+	offset time.Duration
+	now    func() time.Time
+}
+
+// NewClock returns a clock of a chain started at genesis.
+func NewClock(genesis time.Time, slotDuration time.Duration, slotsPerEpoch uint64) *Clock {
+	return &Clock{
+		genesis:       genesis,
+		slotDuration:  slotDuration,
+		slotsPerEpoch: slotsPerEpoch,
+		now:           time.Now,
+	}
+}
+
+// Now returns the virtual time.
+func (c *Clock) Now() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.now().Add(c.offset)
+}
+
+// Advance moves the virtual time forward.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.offset += d
+}
+
+// Genesis returns the genesis time.
+func (c *Clock) Genesis() time.Time {
+	return c.genesis
+}
+
+// SlotDuration returns the duration of a slot.
+func (c *Clock) SlotDuration() time.Duration {
+	return c.slotDuration
+}
+
+// SlotsPerEpoch returns the number of slots in an epoch.
+func (c *Clock) SlotsPerEpoch() uint64 {
+	return c.slotsPerEpoch
+}
+
+// CurrentSlot returns the slot of the virtual time, or 0 before genesis.
+func (c *Clock) CurrentSlot() phase0.Slot {
+	since := c.Now().Sub(c.genesis)
+	if since < 0 {
+		return 0
+	}
+	return phase0.Slot(since / c.slotDuration)
+}
+
+// CurrentEpoch returns the epoch of the virtual time.
+func (c *Clock) CurrentEpoch() phase0.Epoch {
+	return c.EpochOf(c.CurrentSlot())
+}
+
+// EpochOf returns the epoch of the slot.
+func (c *Clock) EpochOf(slot phase0.Slot) phase0.Epoch {
+	return phase0.Epoch(uint64(slot) / c.slotsPerEpoch)
+}
+
+// FirstSlot returns the first slot of the epoch.
+func (c *Clock) FirstSlot(epoch phase0.Epoch) phase0.Slot {
+	return phase0.Slot(uint64(epoch) * c.slotsPerEpoch)
+}
+
+// SlotStart returns the virtual time at which the slot starts.
+func (c *Clock) SlotStart(slot phase0.Slot) time.Time {
+	return c.genesis.Add(time.Duration(slot) * c.slotDuration)
+}
+
+// WaitForSlot blocks until the slot starts in virtual time or the context is done.
+func (c *Clock) WaitForSlot(ctx context.Context, slot phase0.Slot) error {
+	for {
+		wait := c.SlotStart(slot).Sub(c.Now())
+		if wait <= 0 {
+			return nil
+		}
+		// Re-check at least every slot, in case the clock was advanced meanwhile.
+		timer := time.NewTimer(min(wait, c.slotDuration))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package fakebeacon
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"go.uber.org/zap"
+)
+
+func (b *FakeBeacon) handleAttesterDuties(w http.ResponseWriter, r *http.Request) {
+	logger, gateway := b.requestContext(r)
+
+	// Parse request.
+	epoch, err := parseUintParam(r, "epoch")
+	if err != nil {
+		b.error(r, w, http.StatusBadRequest, err)
+		return
+	}
+	indices, err := parseIndices(r)
+	if err != nil {
+		b.error(r, w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Obtain duties.
+	duties := b.chain.attesterDuties(phase0.Epoch(epoch), indices)
+
+	// Intercept.
+	if gateway.Interceptor != nil {
+		duties, err = gateway.Interceptor.InterceptAttesterDuties(r.Context(), phase0.Epoch(epoch), indices, duties)
+		if err != nil {
+			b.error(r, w, http.StatusInternalServerError, fmt.Errorf("failed to intercept attester duties: %w", err))
+			return
+		}
+	}
+
+	// Respond.
+	b.respondDuties(r, w, phase0.Epoch(epoch), duties)
+
+	logger.Info("served attester duties",
+		zap.Uint64("epoch", epoch),
+		zap.Int("indices", len(indices)),
+		zap.Int("duties", len(duties)),
+	)
+}
+
+func (b *FakeBeacon) handleProposerDuties(w http.ResponseWriter, r *http.Request) {
+	logger, gateway := b.requestContext(r)
+
+	// Parse request.
+	epoch, err := parseUintParam(r, "epoch")
+	if err != nil {
+		b.error(r, w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Obtain duties.
+	duties := b.chain.proposerDuties(phase0.Epoch(epoch))
+
+	// Intercept.
+	if gateway.Interceptor != nil {
+		duties, err = gateway.Interceptor.InterceptProposerDuties(r.Context(), phase0.Epoch(epoch), nil, duties)
+		if err != nil {
+			b.error(r, w, http.StatusInternalServerError, fmt.Errorf("failed to intercept proposer duties: %w", err))
+			return
+		}
+	}
+
+	// Respond.
+	b.respondDuties(r, w, phase0.Epoch(epoch), duties)
+
+	logger.Info("served proposer duties",
+		zap.Uint64("epoch", epoch),
+		zap.Int("duties", len(duties)),
+	)
+}
+
+func (b *FakeBeacon) handleSyncCommitteeDuties(w http.ResponseWriter, r *http.Request) {
+	logger, _ := b.requestContext(r)
+
+	// Parse request.
+	epoch, err := parseUintParam(r, "epoch")
+	if err != nil {
+		b.error(r, w, http.StatusBadRequest, err)
+		return
+	}
+	indices, err := parseIndices(r)
+	if err != nil {
+		b.error(r, w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Obtain and respond. The interceptors don't handle sync committee duties.
+	duties := b.chain.syncCommitteeDuties(indices)
+	b.respond(r, w, duties, map[string]any{"execution_optimistic": false})
+
+	logger.Info("served sync committee duties",
+		zap.Uint64("epoch", epoch),
+		zap.Int("indices", len(indices)),
+		zap.Int("duties", len(duties)),
+	)
+}
+
+func (b *FakeBeacon) respondDuties(r *http.Request, w http.ResponseWriter, epoch phase0.Epoch, duties any) {
+	b.respond(r, w, duties, map[string]any{
+		"dependent_root":       fmt.Sprintf("%#x", b.chain.dependentRoot(epoch)),
+		"execution_optimistic": false,
+	})
+}
+
+func (b *FakeBeacon) handleLiveness(w http.ResponseWriter, r *http.Request) {
+	epoch, err := parseUintParam(r, "epoch")
+	if err != nil {
+		b.error(r, w, http.StatusBadRequest, err)
+		return
+	}
+	indices, err := parseIndices(r)
+	if err != nil {
+		b.error(r, w, http.StatusBadRequest, err)
+		return
+	}
+	type validatorLiveness struct {
+		Index  string `json:"index"`
+		IsLive bool   `json:"is_live"`
+	}
+	liveness := make([]validatorLiveness, len(indices))
+	for i, index := range indices {
+		liveness[i] = validatorLiveness{
+			Index:  strconv.FormatUint(uint64(index), 10),
+			IsLive: b.chain.isLive(phase0.Epoch(epoch), index),
+		}
+	}
+	b.respond(r, w, liveness, nil)
+}
@@ -0,0 +1,144 @@
+package fakebeacon
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"go.uber.org/zap"
+)
+
+// subscriberBuffer is the number of events buffered per subscriber. Slow subscribers miss events.
+const subscriberBuffer = 64
+
+type event struct {
+	topic string
+	data  []byte
+}
+
+// eventFeed fans out the events to the subscribed event streams.
+type eventFeed struct {
+	mu          sync.Mutex
+	subscribers map[chan event]map[string]struct{}
+}
+
+func newEventFeed() *eventFeed {
+	return &eventFeed{
+		subscribers: make(map[chan event]map[string]struct{}),
+	}
+}
+
+func (f *eventFeed) subscribe(topics []string) chan event {
+	ch := make(chan event, subscriberBuffer)
+	set := make(map[string]struct{}, len(topics))
+	for _, topic := range topics {
+		set[topic] = struct{}{}
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.subscribers[ch] = set
+	return ch
+}
+
+func (f *eventFeed) unsubscribe(ch chan event) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.subscribers, ch)
+}
+
+// publish returns the number of subscribers the event was delivered to.
+func (f *eventFeed) publish(topic string, data any) (int, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return 0, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delivered := 0
+	for ch, topics := range f.subscribers {
+		if _, ok := topics[topic]; !ok {
+			continue
+		}
+		select {
+		case ch <- event{topic: topic, data: encoded}:
+			delivered++
+		default:
+		}
+	}
+	return delivered, nil
+}
+
+// publishHead publishes the head event of the slot.
+func (b *FakeBeacon) publishHead(slot phase0.Slot) {
+	epoch := b.clock.EpochOf(slot)
+	previousDependentRoot := b.chain.dependentRoot(epoch)
+	if epoch > 0 {
+		previousDependentRoot = b.chain.dependentRoot(epoch - 1)
+	}
+	head := &v1.HeadEvent{
+		Slot:                      slot,
+		Block:                     b.chain.blockRoot(slot),
+		State:                     b.chain.stateRoot(slot),
+		EpochTransition:           b.clock.FirstSlot(epoch) == slot,
+		CurrentDutyDependentRoot:  b.chain.dependentRoot(epoch),
+		PreviousDutyDependentRoot: previousDependentRoot,
+	}
+	if _, err := b.events.publish("head", head); err != nil {
+		b.logger.Error("failed to publish head event", zap.Uint64("slot", uint64(slot)), zap.Error(err))
+	}
+}
+
+// publishBlock publishes the block and head events of a submitted block.
+func (b *FakeBeacon) publishBlock(slot phase0.Slot, root phase0.Root) {
+	if _, err := b.events.publish("block", &v1.BlockEvent{Slot: slot, Block: root}); err != nil {
+		b.logger.Error("failed to publish block event", zap.Uint64("slot", uint64(slot)), zap.Error(err))
+	}
+	b.publishHead(slot)
+}
+
+// handleEvents streams the events of the requested topics as server-sent events.
+func (b *FakeBeacon) handleEvents(w http.ResponseWriter, r *http.Request) {
+	logger, _ := b.requestContext(r)
+
+	var topics []string
+	for _, topic := range r.URL.Query()["topics"] {
+		topics = append(topics, strings.Split(topic, ",")...)
+	}
+	if len(topics) == 0 {
+		b.error(r, w, http.StatusBadRequest, fmt.Errorf("no topics"))
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		b.error(r, w, http.StatusInternalServerError, fmt.Errorf("streaming not supported"))
+		return
+	}
+
+	ch := b.events.subscribe(topics)
+	defer b.events.unsubscribe(ch)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	logger.Debug("subscribed to events", zap.Strings("topics", topics))
+	for {
+		select {
+		case <-r.Context().Done():
+			logger.Debug("unsubscribed from events", zap.Strings("topics", topics))
+			return
+		case e := <-ch:
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.topic, e.data); err != nil {
+				logger.Debug("failed to write event", zap.Error(err))
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,330 @@
+package fakebeacon
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/go-chi/chi/v5"
+	"github.com/sourcegraph/conc/pool"
+	"go.uber.org/zap"
+
+	beaconproxy "github.com/ssvlabs/ssv/e2e/beacon_proxy"
+)
+
+// nodeVersion is reported by /eth/v1/node/version.
+const nodeVersion = "FakeBeacon/v0.0.1/ssv-e2e"
+
+// Config describes the chain served by the fake beacon node.
+type Config struct {
+	// GenesisTime is the start of slot 0.
+	GenesisTime time.Time
+	// GenesisForkVersion is the fork version of the network, the other fork versions are derived from it.
+	GenesisForkVersion phase0.Version
+	// SlotDuration can be shorter than on real networks to speed up tests,
+	// but must be in whole seconds since the Beacon API reports it in seconds.
+	SlotDuration  time.Duration
+	SlotsPerEpoch uint64
+	// SyncCommitteeSize is the number of validators in the sync committee, at most 512.
+	SyncCommitteeSize uint64
+	// EpochsPerSyncCommitteePeriod defaults to 256.
+	EpochsPerSyncCommitteePeriod uint64
+	// Validators are the active validators of the chain. Duties are assigned only to them.
+	Validators []*v1.Validator
+	// BlindedBlocks makes block production return blinded blocks.
+	BlindedBlocks bool
+}
+
+func (c *Config) validate() error {
+	if c.SlotDuration <= 0 || c.SlotDuration%time.Second != 0 {
+		return errors.New("slot duration must be a positive number of seconds")
+	}
+	if c.SlotsPerEpoch == 0 {
+		return errors.New("slots per epoch must be positive")
+	}
+	if len(c.Validators) == 0 {
+		return errors.New("no validators")
+	}
+	if c.SyncCommitteeSize > syncCommitteeSize {
+		return fmt.Errorf("sync committee size must be at most %d", syncCommitteeSize)
+	}
+	if c.EpochsPerSyncCommitteePeriod == 0 {
+		c.EpochsPerSyncCommitteePeriod = 256
+	}
+	return nil
+}
+
+// FakeBeacon is a self-contained beacon node serving the subset of the Beacon API used by the SSV node,
+// with deterministic duties and blocks. Like BeaconProxy, it serves a gateway per port, and each gateway
+// passes the duties, attestation data and blocks through its interceptor.
+type FakeBeacon struct {
+	logger   *zap.Logger
+	config   Config
+	clock    *Clock
+	chain    *chain
+	events   *eventFeed
+	gateways map[int]beaconproxy.Gateway
+}
+
+// New returns a fake beacon node serving the gateways. A gateway without an interceptor doesn't change anything.
+func New(logger *zap.Logger, config Config, gateways []beaconproxy.Gateway) (*FakeBeacon, error) {
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	clock := NewClock(config.GenesisTime, config.SlotDuration, config.SlotsPerEpoch)
+	b := &FakeBeacon{
+		logger:   logger,
+		config:   config,
+		clock:    clock,
+		chain:    newChain(clock, config.Validators, config.SyncCommitteeSize),
+		events:   newEventFeed(),
+		gateways: make(map[int]beaconproxy.Gateway),
+	}
+	for _, gateway := range gateways {
+		b.gateways[gateway.Port] = gateway
+	}
+	return b, nil
+}
+
+// Clock returns the virtual clock of the chain.
+func (b *FakeBeacon) Clock() *Clock {
+	return b.clock
+}
+
+// Run serves the gateways and produces the head events until the context is done.
+func (b *FakeBeacon) Run(ctx context.Context) error {
+	pool := pool.New().WithContext(ctx)
+	pool.Go(b.runSlots)
+	for port, gateway := range b.gateways {
+		b.logger.Debug("starting fake beacon server",
+			zap.String("gateway", gateway.Name),
+			zap.Int("port", port),
+		)
+		server := &http.Server{
+			Addr:        fmt.Sprintf(":%d", port),
+			ReadTimeout: 30 * time.Second,
+			IdleTimeout: 30 * time.Second,
+			Handler:     b.Handler(gateway),
+		}
+		pool.Go(func(ctx context.Context) error {
+			go func() {
+				<-ctx.Done()
+				_ = server.Close()
+			}()
+			if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		})
+	}
+	return pool.Wait()
+}
+
+// runSlots publishes the head of every slot when it starts.
+func (b *FakeBeacon) runSlots(ctx context.Context) error {
+	slot := b.clock.CurrentSlot()
+	for {
+		if err := b.clock.WaitForSlot(ctx, slot); err != nil {
+			return nil
+		}
+		b.publishHead(slot)
+		slot = max(slot+1, b.clock.CurrentSlot())
+	}
+}
+
+// Handler returns the HTTP handler of the gateway.
+func (b *FakeBeacon) Handler(gateway beaconproxy.Gateway) http.Handler {
+	r := chi.NewRouter()
+	r.Use(b.middleware(gateway))
+
+	// Node and chain configuration.
+	r.Get("/eth/v1/node/version", b.handleNodeVersion)
+	r.Get("/eth/v1/node/syncing", b.handleNodeSyncing)
+	r.Get("/eth/v1/node/health", b.handleNodeHealth)
+	r.Get("/eth/v1/beacon/genesis", b.handleGenesis)
+	r.Get("/eth/v1/config/spec", b.handleSpec)
+	r.Get("/eth/v1/config/fork_schedule", b.handleForkSchedule)
+	r.Get("/eth/v1/beacon/states/{state}/fork", b.handleStateFork)
+	r.Get("/eth/v1/beacon/states/{state}/validators", b.handleValidators)
+	r.Post("/eth/v1/beacon/states/{state}/validators", b.handleValidators)
+	r.Get("/eth/v1/beacon/blocks/{block}/root", b.handleBlockRoot)
+	r.Get("/eth/v1/beacon/headers/{block}", b.handleBlockHeader)
+	r.Get("/eth/v1/events", b.handleEvents)
+
+	// Duties.
+	r.Post("/eth/v1/validator/duties/attester/{epoch}", b.handleAttesterDuties)
+	r.Get("/eth/v1/validator/duties/proposer/{epoch}", b.handleProposerDuties)
+	r.Post("/eth/v1/validator/duties/sync/{epoch}", b.handleSyncCommitteeDuties)
+	r.Post("/eth/v1/validator/liveness/{epoch}", b.handleLiveness)
+
+	// Attestations.
+	r.Get("/eth/v1/validator/attestation_data", b.handleAttestationData)
+	r.Post("/eth/v1/beacon/pool/attestations", b.handleSubmitAttestations)
+	r.Post("/eth/v2/beacon/pool/attestations", b.handleSubmitAttestations)
+	r.Get("/eth/v1/validator/aggregate_attestation", b.handleAggregateAttestation)
+	r.Get("/eth/v2/validator/aggregate_attestation", b.handleAggregateAttestation)
+	r.Post("/eth/v1/validator/aggregate_and_proofs", b.handleAccept("aggregate and proofs"))
+	r.Post("/eth/v2/validator/aggregate_and_proofs", b.handleAccept("aggregate and proofs"))
+	r.Post("/eth/v1/validator/beacon_committee_subscriptions", b.handleAccept("beacon committee subscriptions"))
+
+	// Proposals.
+	r.Get("/eth/v3/validator/blocks/{slot}", b.handleBlockProposal)
+	r.Post("/eth/v1/beacon/blocks", b.handleSubmitBlockProposal)
+	r.Post("/eth/v2/beacon/blocks", b.handleSubmitBlockProposal)
+	r.Post("/eth/v1/beacon/blinded_blocks", b.handleSubmitBlindedBlockProposal)
+	r.Post("/eth/v2/beacon/blinded_blocks", b.handleSubmitBlindedBlockProposal)
+	r.Post("/eth/v1/validator/prepare_beacon_proposer", b.handleAccept("proposal preparations"))
+	r.Post("/eth/v1/validator/register_validator", b.handleAccept("validator registrations"))
+
+	// Sync committees.
+	r.Post("/eth/v1/beacon/pool/sync_committees", b.handleSubmitSyncCommitteeMessages)
+	r.Get("/eth/v1/validator/sync_committee_contribution", b.handleSyncCommitteeContribution)
+	r.Post("/eth/v1/validator/contribution_and_proofs", b.handleAccept("contribution and proofs"))
+	r.Post("/eth/v1/validator/sync_committee_subscriptions", b.handleAccept("sync committee subscriptions"))
+
+	// Exits.
+	r.Post("/eth/v1/beacon/pool/voluntary_exits", b.handleAccept("voluntary exit"))
+
+	r.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		b.error(r, w, http.StatusNotFound, fmt.Errorf("endpoint not supported by the fake beacon node"))
+	})
+
+	return r
+}
+
+// middleware stores the gateway, logger and start time in the request context
+// under the keys used by BeaconProxy, so that its interceptors work unchanged.
+func (b *FakeBeacon) middleware(gateway beaconproxy.Gateway) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), beaconproxy.GatewayKey{}, gateway)
+
+			logger := b.logger.With(
+				zap.String("gateway", gateway.Name),
+				zap.String("endpoint", fmt.Sprintf("%s %s", r.Method, r.URL.Path)),
+			)
+			ctx = context.WithValue(ctx, beaconproxy.LoggerKey{}, logger)
+
+			ctx = context.WithValue(ctx, beaconproxy.StartTimeKey{}, time.Now())
+
+			b.logger.Debug("received request",
+				zap.String("gateway", gateway.Name),
+				zap.String("method", r.Method),
+				zap.String("path", r.URL.Path),
+				zap.String("query", r.URL.Query().Encode()),
+			)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func (b *FakeBeacon) requestContext(r *http.Request) (*zap.Logger, beaconproxy.Gateway) {
+	return r.Context().Value(beaconproxy.LoggerKey{}).(*zap.Logger),
+		r.Context().Value(beaconproxy.GatewayKey{}).(beaconproxy.Gateway)
+}
+
+func (b *FakeBeacon) error(r *http.Request, w http.ResponseWriter, status int, err error) {
+	logger, _ := b.requestContext(r)
+	logger.Error("failed to handle request",
+		zap.Int("status", status),
+		zap.Duration("took", time.Since(r.Context().Value(beaconproxy.StartTimeKey{}).(time.Time))),
+		zap.Error(err),
+	)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}{
+		Code:    status,
+		Message: err.Error(),
+	})
+}
+
+// respond writes the data in the standard response envelope.
+func (b *FakeBeacon) respond(r *http.Request, w http.ResponseWriter, data any, extra map[string]any) {
+	response := map[string]any{"data": data}
+	for k, v := range extra {
+		response[k] = v
+	}
+	body, err := json.Marshal(response)
+	if err != nil {
+		b.error(r, w, http.StatusInternalServerError, fmt.Errorf("failed to encode response: %w", err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+// respondVersioned writes the data in the response envelope of versioned endpoints.
+func (b *FakeBeacon) respondVersioned(r *http.Request, w http.ResponseWriter, version spec.DataVersion, data any) {
+	w.Header().Set("Eth-Consensus-Version", version.String())
+	b.respond(r, w, data, map[string]any{
+		"version":              version.String(),
+		"execution_optimistic": false,
+		"finalized":            false,
+	})
+}
+
+// handleAccept accepts any JSON submission without acting on it.
+func (b *FakeBeacon) handleAccept(name string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger, _ := b.requestContext(r)
+		var body json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			b.error(r, w, http.StatusBadRequest, fmt.Errorf("failed to parse request: %w", err))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		logger.Debug("accepted " + name)
+	}
+}
+
+// parseIndices parses a JSON array of decimal validator indices.
+func parseIndices(r *http.Request) ([]phase0.ValidatorIndex, error) {
+	var indices []string
+	if err := json.NewDecoder(r.Body).Decode(&indices); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse indices: %w", err)
+	}
+	parsed := make([]phase0.ValidatorIndex, len(indices))
+	for i, index := range indices {
+		n, err := strconv.ParseUint(index, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse index %s: %w", index, err)
+		}
+		parsed[i] = phase0.ValidatorIndex(n)
+	}
+	return parsed, nil
+}
+
+func parseUintParam(r *http.Request, name string) (uint64, error) {
+	value := chi.URLParam(r, name)
+	if value == "" {
+		value = r.URL.Query().Get(name)
+	}
+	n, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", name, value)
+	}
+	return n, nil
+}
+
+func parseRootParam(r *http.Request, name string) (phase0.Root, error) {
+	value := r.URL.Query().Get(name)
+	decoded, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+	if err != nil || len(decoded) != len(phase0.Root{}) {
+		return phase0.Root{}, fmt.Errorf("invalid %s %q", name, value)
+	}
+	return phase0.Root(decoded), nil
+}
@@ -0,0 +1,325 @@
+package fakebeacon
+
+import (
+	"context"
+	"math"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	apiv1deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	"github.com/attestantio/go-eth2-client/http"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	beaconproxy "github.com/ssvlabs/ssv/e2e/beacon_proxy"
+	"github.com/ssvlabs/ssv/e2e/beacon_proxy/intercept"
+)
+
+const (
+	testValidators    = 8
+	testSlotsPerEpoch = 4
+)
+
+func testValidatorSet() []*v1.Validator {
+	validators := make([]*v1.Validator, testValidators)
+	for i := range validators {
+		var pubKey phase0.BLSPubKey
+		pubKey[0] = byte(i + 1)
+		validators[i] = &v1.Validator{
+			Index:  phase0.ValidatorIndex(i),
+			Status: v1.ValidatorStateActiveOngoing,
+			Validator: &phase0.Validator{
+				PublicKey:                  pubKey,
+				WithdrawalCredentials:      make([]byte, 32),
+				EffectiveBalance:           32_000_000_000,
+				ActivationEligibilityEpoch: 0,
+				ActivationEpoch:            0,
+				ExitEpoch:                  math.MaxUint64,
+				WithdrawableEpoch:          math.MaxUint64,
+			},
+		}
+	}
+	return validators
+}
+
+// dropAttestations passes everything through, except for the submitted attestations.
+type dropAttestations struct {
+	intercept.Interceptor
+}
+
+func (dropAttestations) InterceptSubmitAttestations(context.Context, []*phase0.Attestation) ([]*phase0.Attestation, error) {
+	return nil, nil
+}
+
+// setupFakeBeacon serves a fake beacon node with a chain which started 10 slots ago.
+func setupFakeBeacon(t *testing.T, blinded bool, interceptor intercept.Interceptor) (*FakeBeacon, eth2client.Service) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	b, err := New(zap.NewNop(), Config{
+		GenesisTime:       time.Now().Add(-10 * time.Second),
+		SlotDuration:      time.Second,
+		SlotsPerEpoch:     testSlotsPerEpoch,
+		SyncCommitteeSize: 4,
+		Validators:        testValidatorSet(),
+		BlindedBlocks:     blinded,
+	}, nil)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(b.Handler(beaconproxy.Gateway{Name: "test", Interceptor: interceptor}))
+	t.Cleanup(server.Close)
+
+	client, err := http.New(ctx,
+		http.WithAddress(server.URL),
+		http.WithLogLevel(zerolog.Disabled),
+		http.WithTimeout(5*time.Second),
+	)
+	require.NoError(t, err)
+	return b, client
+}
+
+func TestConfig(t *testing.T) {
+	_, err := New(zap.NewNop(), Config{SlotDuration: 500 * time.Millisecond, SlotsPerEpoch: 1, Validators: testValidatorSet()}, nil)
+	require.ErrorContains(t, err, "slot duration must be a positive number of seconds")
+	_, err = New(zap.NewNop(), Config{SlotDuration: time.Second, Validators: testValidatorSet()}, nil)
+	require.ErrorContains(t, err, "slots per epoch must be positive")
+	_, err = New(zap.NewNop(), Config{SlotDuration: time.Second, SlotsPerEpoch: 1}, nil)
+	require.ErrorContains(t, err, "no validators")
+}
+
+func TestChainConfig(t *testing.T) {
+	b, client := setupFakeBeacon(t, false, nil)
+	ctx := context.Background()
+
+	genesis, err := client.(eth2client.GenesisProvider).Genesis(ctx, &api.GenesisOpts{})
+	require.NoError(t, err)
+	require.Equal(t, b.clock.Genesis().Unix(), genesis.Data.GenesisTime.Unix())
+
+	slotDuration, err := client.(eth2client.SlotDurationProvider).SlotDuration(ctx)
+	require.NoError(t, err)
+	require.Equal(t, time.Second, slotDuration)
+
+	slotsPerEpoch, err := client.(eth2client.SlotsPerEpochProvider).SlotsPerEpoch(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, testSlotsPerEpoch, slotsPerEpoch)
+
+	version, err := client.(eth2client.NodeVersionProvider).NodeVersion(ctx, &api.NodeVersionOpts{})
+	require.NoError(t, err)
+	require.Equal(t, nodeVersion, version.Data)
+
+	validators, err := client.(eth2client.ValidatorsProvider).Validators(ctx, &api.ValidatorsOpts{
+		State:   "head",
+		Indices: []phase0.ValidatorIndex{1, 3, 100},
+	})
+	require.NoError(t, err)
+	require.Len(t, validators.Data, 2)
+	require.Contains(t, validators.Data, phase0.ValidatorIndex(3))
+}
+
+func TestDuties(t *testing.T) {
+	_, client := setupFakeBeacon(t, false, intercept.Chain())
+	ctx := context.Background()
+	indices := []phase0.ValidatorIndex{0, 1, 2, 3, 4, 5, 6, 7}
+
+	attesterDuties, err := client.(eth2client.AttesterDutiesProvider).AttesterDuties(ctx, &api.AttesterDutiesOpts{
+		Epoch:   2,
+		Indices: indices,
+	})
+	require.NoError(t, err)
+	require.Len(t, attesterDuties.Data, testValidators)
+	slots := make(map[phase0.Slot]int)
+	for _, duty := range attesterDuties.Data {
+		require.Equal(t, phase0.Epoch(2), phase0.Epoch(uint64(duty.Slot)/testSlotsPerEpoch))
+		require.EqualValues(t, testValidators/testSlotsPerEpoch, duty.CommitteeLength)
+		slots[duty.Slot]++
+	}
+	require.Len(t, slots, testSlotsPerEpoch)
+
+	// Duties are deterministic.
+	again, err := client.(eth2client.AttesterDutiesProvider).AttesterDuties(ctx, &api.AttesterDutiesOpts{
+		Epoch:   2,
+		Indices: indices,
+	})
+	require.NoError(t, err)
+	require.Equal(t, attesterDuties.Data, again.Data)
+
+	proposerDuties, err := client.(eth2client.ProposerDutiesProvider).ProposerDuties(ctx, &api.ProposerDutiesOpts{
+		Epoch: 2,
+	})
+	require.NoError(t, err)
+	require.Len(t, proposerDuties.Data, testSlotsPerEpoch)
+	require.Equal(t, phase0.Slot(8), proposerDuties.Data[0].Slot)
+	require.Equal(t, phase0.ValidatorIndex(0), proposerDuties.Data[0].ValidatorIndex)
+
+	syncDuties, err := client.(eth2client.SyncCommitteeDutiesProvider).SyncCommitteeDuties(ctx, &api.SyncCommitteeDutiesOpts{
+		Epoch:   2,
+		Indices: indices,
+	})
+	require.NoError(t, err)
+	require.Len(t, syncDuties.Data, 4)
+}
+
+func TestAttestations(t *testing.T) {
+	b, client := setupFakeBeacon(t, false, nil)
+	ctx := context.Background()
+	slot := b.clock.CurrentSlot()
+
+	data, err := client.(eth2client.AttestationDataProvider).AttestationData(ctx, &api.AttestationDataOpts{
+		Slot:           slot,
+		CommitteeIndex: 0,
+	})
+	require.NoError(t, err)
+	require.Equal(t, slot, data.Data.Slot)
+
+	// Both members of the slot's committee attest.
+	var attestations []*phase0.Attestation
+	for i := uint64(0); i < testValidators/testSlotsPerEpoch; i++ {
+		bits := bitfield.NewBitlist(testValidators / testSlotsPerEpoch)
+		bits.SetBitAt(i, true)
+		attestations = append(attestations, &phase0.Attestation{AggregationBits: bits, Data: data.Data})
+	}
+	require.NoError(t, client.(eth2client.AttestationsSubmitter).SubmitAttestations(ctx, attestations))
+
+	dataRoot, err := data.Data.HashTreeRoot()
+	require.NoError(t, err)
+	aggregate, err := client.(eth2client.AggregateAttestationProvider).AggregateAttestation(ctx, &api.AggregateAttestationOpts{
+		Slot:                slot,
+		AttestationDataRoot: dataRoot,
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, aggregate.Data.AggregationBits.Count())
+
+	// The attesters of the slot are live, the others aren't.
+	epoch := b.clock.EpochOf(slot)
+	require.True(t, b.chain.isLive(epoch, phase0.ValidatorIndex(uint64(slot)%testSlotsPerEpoch)))
+	require.True(t, b.chain.isLive(epoch, phase0.ValidatorIndex(uint64(slot)%testSlotsPerEpoch+testSlotsPerEpoch)))
+	require.False(t, b.chain.isLive(epoch, phase0.ValidatorIndex((uint64(slot)+1)%testSlotsPerEpoch)))
+}
+
+func TestInterceptor(t *testing.T) {
+	b, client := setupFakeBeacon(t, false, dropAttestations{intercept.Chain()})
+	ctx := context.Background()
+	slot := b.clock.CurrentSlot()
+
+	data, err := client.(eth2client.AttestationDataProvider).AttestationData(ctx, &api.AttestationDataOpts{Slot: slot})
+	require.NoError(t, err)
+	bits := bitfield.NewBitlist(testValidators / testSlotsPerEpoch)
+	bits.SetBitAt(0, true)
+	require.NoError(t, client.(eth2client.AttestationsSubmitter).SubmitAttestations(ctx, []*phase0.Attestation{
+		{AggregationBits: bits, Data: data.Data},
+	}))
+
+	dataRoot, err := data.Data.HashTreeRoot()
+	require.NoError(t, err)
+	_, err = client.(eth2client.AggregateAttestationProvider).AggregateAttestation(ctx, &api.AggregateAttestationOpts{
+		Slot:                slot,
+		AttestationDataRoot: dataRoot,
+	})
+	require.Error(t, err)
+}
+
+func TestProposal(t *testing.T) {
+	b, client := setupFakeBeacon(t, false, nil)
+	ctx := context.Background()
+	slot := b.clock.CurrentSlot() + 1
+	randao := phase0.BLSSignature{0x01}
+
+	proposal, err := client.(eth2client.ProposalProvider).Proposal(ctx, &api.ProposalOpts{
+		Slot:         slot,
+		RandaoReveal: randao,
+		Graffiti:     [32]byte{'s', 's', 'v'},
+	})
+	require.NoError(t, err)
+	require.Equal(t, spec.DataVersionDeneb, proposal.Data.Version)
+	require.False(t, proposal.Data.Blinded)
+	require.Equal(t, byte('s'), proposal.Data.Deneb.Block.Body.Graffiti[0])
+
+	require.NoError(t, client.(eth2client.ProposalSubmitter).SubmitProposal(ctx, &api.SubmitProposalOpts{
+		Proposal: &api.VersionedSignedProposal{
+			Version: spec.DataVersionDeneb,
+			Deneb: &apiv1deneb.SignedBlockContents{
+				SignedBlock: &deneb.SignedBeaconBlock{Message: proposal.Data.Deneb.Block},
+				KZGProofs:   []deneb.KZGProof{},
+				Blobs:       []deneb.Blob{},
+			},
+		},
+	}))
+
+	root, err := proposal.Data.Deneb.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, phase0.Root(root), b.chain.blockRoot(slot))
+	require.True(t, b.chain.isLive(b.clock.EpochOf(slot), proposal.Data.Deneb.Block.ProposerIndex))
+}
+
+func TestBlindedProposal(t *testing.T) {
+	b, client := setupFakeBeacon(t, true, nil)
+	ctx := context.Background()
+	slot := b.clock.CurrentSlot() + 1
+
+	proposal, err := client.(eth2client.ProposalProvider).Proposal(ctx, &api.ProposalOpts{
+		Slot:         slot,
+		RandaoReveal: phase0.BLSSignature{0x01},
+	})
+	require.NoError(t, err)
+	require.True(t, proposal.Data.Blinded)
+	require.NotNil(t, proposal.Data.DenebBlinded.Body.ExecutionPayloadHeader)
+
+	require.NoError(t, client.(eth2client.BlindedProposalSubmitter).SubmitBlindedProposal(ctx, &api.SubmitBlindedProposalOpts{
+		Proposal: &api.VersionedSignedBlindedProposal{
+			Version: spec.DataVersionDeneb,
+			Deneb:   &apiv1deneb.SignedBlindedBeaconBlock{Message: proposal.Data.DenebBlinded},
+		},
+	}))
+
+	// The submitted block is the unblinded one.
+	payload, ok := b.chain.payload(slot)
+	require.True(t, ok)
+	unblinded := unblindedBeaconBlock(&apiv1deneb.SignedBlindedBeaconBlock{Message: proposal.Data.DenebBlinded}, payload)
+	root, err := unblinded.Message.HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, phase0.Root(root), b.chain.blockRoot(slot))
+}
+
+func TestHeadEvents(t *testing.T) {
+	b, client := setupFakeBeacon(t, false, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() { _ = b.runSlots(ctx) }()
+
+	heads := make(chan *v1.HeadEvent, 16)
+	require.NoError(t, client.(eth2client.EventsProvider).Events(ctx, []string{"head"}, func(event *v1.Event) {
+		heads <- event.Data.(*v1.HeadEvent)
+	}))
+
+	select {
+	case head := <-heads:
+		require.Equal(t, b.chain.blockRoot(head.Slot), head.Block)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no head event")
+	}
+}
+
+func TestClock(t *testing.T) {
+	clock := NewClock(time.Now(), time.Second, testSlotsPerEpoch)
+	require.Equal(t, phase0.Slot(0), clock.CurrentSlot())
+
+	clock.Advance(9 * time.Second)
+	require.Equal(t, phase0.Slot(9), clock.CurrentSlot())
+	require.Equal(t, phase0.Epoch(2), clock.CurrentEpoch())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	require.NoError(t, clock.WaitForSlot(ctx, 9))
+	require.ErrorIs(t, clock.WaitForSlot(ctx, 100), context.DeadlineExceeded)
+}
@@ -0,0 +1,246 @@
+package fakebeacon
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/go-chi/chi/v5"
+)
+
+// The chain starts at Deneb, Electra isn't scheduled.
+var forkNames = []string{"ALTAIR", "BELLATRIX", "CAPELLA", "DENEB"}
+
+// forkVersion returns the version of the fork, counted from genesis,
+// derived from the genesis fork version like on the public testnets.
+func (b *FakeBeacon) forkVersion(fork int) phase0.Version {
+	version := b.config.GenesisForkVersion
+	version[0] += byte(fork)
+	return version
+}
+
+func (b *FakeBeacon) genesisValidatorsRoot() phase0.Root {
+	return deterministicRoot("genesis_validators", uint64(len(b.config.Validators)))
+}
+
+func (b *FakeBeacon) handleNodeVersion(w http.ResponseWriter, r *http.Request) {
+	b.respond(r, w, map[string]string{"version": nodeVersion}, nil)
+}
+
+func (b *FakeBeacon) handleNodeSyncing(w http.ResponseWriter, r *http.Request) {
+	b.respond(r, w, &v1.SyncState{
+		HeadSlot:     b.clock.CurrentSlot(),
+		SyncDistance: 0,
+		IsSyncing:    false,
+		IsOptimistic: false,
+	}, nil)
+}
+
+func (b *FakeBeacon) handleNodeHealth(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+func (b *FakeBeacon) handleGenesis(w http.ResponseWriter, r *http.Request) {
+	b.respond(r, w, &v1.Genesis{
+		GenesisTime:           b.clock.Genesis(),
+		GenesisValidatorsRoot: b.genesisValidatorsRoot(),
+		GenesisForkVersion:    b.config.GenesisForkVersion,
+	}, nil)
+}
+
+func (b *FakeBeacon) handleSpec(w http.ResponseWriter, r *http.Request) {
+	data := map[string]string{
+		"CONFIG_NAME":                              "fake",
+		"PRESET_BASE":                              "mainnet",
+		"SECONDS_PER_SLOT":                         strconv.FormatInt(int64(b.clock.SlotDuration().Seconds()), 10),
+		"SLOTS_PER_EPOCH":                          strconv.FormatUint(b.clock.SlotsPerEpoch(), 10),
+		"EPOCHS_PER_SYNC_COMMITTEE_PERIOD":         strconv.FormatUint(b.config.EpochsPerSyncCommitteePeriod, 10),
+		"SYNC_COMMITTEE_SIZE":                      strconv.Itoa(syncCommitteeSize),
+		"SYNC_COMMITTEE_SUBNET_COUNT":              strconv.Itoa(syncCommitteeSubnetCount),
+		"TARGET_AGGREGATORS_PER_COMMITTEE":         "16",
+		"TARGET_AGGREGATORS_PER_SYNC_SUBCOMMITTEE": "16",
+		"MAX_COMMITTEES_PER_SLOT":                  "64",
+		"MIN_GENESIS_TIME":                         strconv.FormatInt(b.clock.Genesis().Unix(), 10),
+		"GENESIS_FORK_VERSION":                     fmt.Sprintf("%#x", b.config.GenesisForkVersion),
+		"ELECTRA_FORK_VERSION":                     fmt.Sprintf("%#x", b.forkVersion(len(forkNames)+1)),
+		"ELECTRA_FORK_EPOCH":                       strconv.FormatUint(math.MaxUint64, 10),
+		"DOMAIN_BEACON_PROPOSER":                   "0x00000000",
+		"DOMAIN_BEACON_ATTESTER":                   "0x01000000",
+		"DOMAIN_RANDAO":                            "0x02000000",
+		"DOMAIN_DEPOSIT":                           "0x03000000",
+		"DOMAIN_VOLUNTARY_EXIT":                    "0x04000000",
+		"DOMAIN_SELECTION_PROOF":                   "0x05000000",
+		"DOMAIN_AGGREGATE_AND_PROOF":               "0x06000000",
+		"DOMAIN_SYNC_COMMITTEE":                    "0x07000000",
+		"DOMAIN_SYNC_COMMITTEE_SELECTION_PROOF":    "0x08000000",
+		"DOMAIN_CONTRIBUTION_AND_PROOF":            "0x09000000",
+		"DOMAIN_APPLICATION_MASK":                  "0x00000001",
+	}
+	for i, name := range forkNames {
+		data[name+"_FORK_VERSION"] = fmt.Sprintf("%#x", b.forkVersion(i+1))
+		data[name+"_FORK_EPOCH"] = "0"
+	}
+	b.respond(r, w, data, nil)
+}
+
+func (b *FakeBeacon) forkSchedule() []*phase0.Fork {
+	forks := make([]*phase0.Fork, 0, len(forkNames)+1)
+	previous := b.config.GenesisForkVersion
+	forks = append(forks, &phase0.Fork{PreviousVersion: previous, CurrentVersion: previous, Epoch: 0})
+	for i := range forkNames {
+		current := b.forkVersion(i + 1)
+		forks = append(forks, &phase0.Fork{PreviousVersion: previous, CurrentVersion: current, Epoch: 0})
+		previous = current
+	}
+	return forks
+}
+
+func (b *FakeBeacon) handleForkSchedule(w http.ResponseWriter, r *http.Request) {
+	b.respond(r, w, b.forkSchedule(), nil)
+}
+
+func (b *FakeBeacon) handleStateFork(w http.ResponseWriter, r *http.Request) {
+	forks := b.forkSchedule()
+	b.respond(r, w, forks[len(forks)-1], map[string]any{"execution_optimistic": false, "finalized": false})
+}
+
+// handleValidators serves the validators by index or public key, from the query or a POST body.
+func (b *FakeBeacon) handleValidators(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		IDs      []string `json:"ids"`
+		Statuses []string `json:"statuses"`
+	}
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+			b.error(r, w, http.StatusBadRequest, fmt.Errorf("failed to parse request: %w", err))
+			return
+		}
+	} else {
+		for _, id := range r.URL.Query()["id"] {
+			request.IDs = append(request.IDs, strings.Split(id, ",")...)
+		}
+		for _, status := range r.URL.Query()["status"] {
+			request.Statuses = append(request.Statuses, strings.Split(status, ",")...)
+		}
+	}
+
+	validators := b.chain.validators
+	if len(request.IDs) > 0 {
+		validators = nil
+		for _, id := range request.IDs {
+			if v, ok := b.chain.validatorByID(id); ok {
+				validators = append(validators, v)
+			}
+		}
+	}
+	if len(request.Statuses) > 0 {
+		filtered := validators[:0:0]
+		for _, v := range validators {
+			for _, status := range request.Statuses {
+				if status == v.Status.String() || (status == "active" && v.Status.IsActive()) {
+					filtered = append(filtered, v)
+					break
+				}
+			}
+		}
+		validators = filtered
+	}
+	b.respond(r, w, validators, map[string]any{"execution_optimistic": false, "finalized": false})
+}
+
+// validatorByID returns the validator by its decimal index or hex public key.
+func (c *chain) validatorByID(id string) (*v1.Validator, bool) {
+	if strings.HasPrefix(id, "0x") {
+		decoded, err := hex.DecodeString(strings.TrimPrefix(id, "0x"))
+		if err != nil || len(decoded) != len(phase0.BLSPubKey{}) {
+			return nil, false
+		}
+		v, ok := c.byPubKey[phase0.BLSPubKey(decoded)]
+		return v, ok
+	}
+	index, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return nil, false
+	}
+	v, ok := c.byIndex[phase0.ValidatorIndex(index)]
+	return v, ok
+}
+
+// blockSlot resolves a block ID (head, genesis, finalized, justified, a slot or a root) to a slot.
+func (b *FakeBeacon) blockSlot(id string) (phase0.Slot, error) {
+	current := b.clock.CurrentSlot()
+	switch id {
+	case "head":
+		return current, nil
+	case "genesis":
+		return 0, nil
+	case "finalized", "justified":
+		epoch := b.clock.EpochOf(current)
+		if epoch < 2 {
+			return 0, nil
+		}
+		return b.clock.FirstSlot(epoch - 2), nil
+	}
+	if strings.HasPrefix(id, "0x") {
+		decoded, err := hex.DecodeString(strings.TrimPrefix(id, "0x"))
+		if err != nil || len(decoded) != len(phase0.Root{}) {
+			return 0, fmt.Errorf("invalid block root %q", id)
+		}
+		slot, ok := b.chain.slotOfRoot(phase0.Root(decoded), current)
+		if !ok {
+			return 0, fmt.Errorf("unknown block root %s", id)
+		}
+		return slot, nil
+	}
+	slot, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid block ID %q", id)
+	}
+	if phase0.Slot(slot) > current {
+		return 0, fmt.Errorf("block at slot %d is in the future", slot)
+	}
+	return phase0.Slot(slot), nil
+}
+
+func (b *FakeBeacon) handleBlockRoot(w http.ResponseWriter, r *http.Request) {
+	slot, err := b.blockSlot(chi.URLParam(r, "block"))
+	if err != nil {
+		b.error(r, w, http.StatusNotFound, err)
+		return
+	}
+	b.respond(r, w, map[string]string{"root": fmt.Sprintf("%#x", b.chain.blockRoot(slot))},
+		map[string]any{"execution_optimistic": false, "finalized": false})
+}
+
+func (b *FakeBeacon) handleBlockHeader(w http.ResponseWriter, r *http.Request) {
+	slot, err := b.blockSlot(chi.URLParam(r, "block"))
+	if err != nil {
+		b.error(r, w, http.StatusNotFound, err)
+		return
+	}
+	var parentRoot phase0.Root
+	if slot > 0 {
+		parentRoot = b.chain.blockRoot(slot - 1)
+	}
+	b.respond(r, w, &v1.BeaconBlockHeader{
+		Root:      b.chain.blockRoot(slot),
+		Canonical: true,
+		Header: &phase0.SignedBeaconBlockHeader{
+			Message: &phase0.BeaconBlockHeader{
+				Slot:          slot,
+				ProposerIndex: b.chain.proposer(slot).Index,
+				ParentRoot:    parentRoot,
+				StateRoot:     b.chain.stateRoot(slot),
+				BodyRoot:      deterministicRoot("body", uint64(slot)),
+			},
+		},
+	}, map[string]any{"execution_optimistic": false, "finalized": false})
+}
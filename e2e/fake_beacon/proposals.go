@@ -0,0 +1,354 @@
+package fakebeacon
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	apiv1deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/holiman/uint256"
+	"github.com/prysmaticlabs/go-bitfield"
+	"go.uber.org/zap"
+)
+
+const gasLimit = 30_000_000
+
+// executionPayload returns the deterministic execution payload of the slot, without transactions.
+func (b *FakeBeacon) executionPayload(slot phase0.Slot) *deneb.ExecutionPayload {
+	var parentHash phase0.Hash32
+	if slot > 0 {
+		parentHash = phase0.Hash32(deterministicRoot("execution_block", uint64(slot-1)))
+	}
+	return &deneb.ExecutionPayload{
+		ParentHash:    parentHash,
+		StateRoot:     deterministicRoot("execution_state", uint64(slot)),
+		ReceiptsRoot:  deterministicRoot("execution_receipts", uint64(slot)),
+		PrevRandao:    deterministicRoot("randao", uint64(slot)),
+		BlockNumber:   uint64(slot),
+		GasLimit:      gasLimit,
+		Timestamp:     uint64(b.clock.SlotStart(slot).Unix()),
+		ExtraData:     []byte{},
+		BaseFeePerGas: uint256.NewInt(7),
+		BlockHash:     phase0.Hash32(deterministicRoot("execution_block", uint64(slot))),
+		Transactions:  []bellatrix.Transaction{},
+		Withdrawals:   []*capella.Withdrawal{},
+	}
+}
+
+// syncAggregate aggregates the sync committee messages submitted for the parent block.
+func (b *FakeBeacon) syncAggregate(slot phase0.Slot) *altair.SyncAggregate {
+	aggregate := &altair.SyncAggregate{
+		SyncCommitteeBits: bitfield.NewBitvector512(),
+	}
+	if slot == 0 {
+		return aggregate
+	}
+	for _, message := range b.chain.syncMessagesAt(slot-1, b.chain.blockRoot(slot-1)) {
+		position, _ := b.chain.syncCommitteePosition(message.validatorIndex)
+		if aggregate.SyncCommitteeBits.Count() == 0 {
+			aggregate.SyncCommitteeSignature = message.signature
+		}
+		aggregate.SyncCommitteeBits.SetBitAt(position, true)
+	}
+	return aggregate
+}
+
+// beaconBlock returns the block of the slot, empty apart from the execution payload and the sync aggregate.
+func (b *FakeBeacon) beaconBlock(slot phase0.Slot, randaoReveal phase0.BLSSignature, graffiti [32]byte) *deneb.BeaconBlock {
+	var parentRoot phase0.Root
+	if slot > 0 {
+		parentRoot = b.chain.blockRoot(slot - 1)
+	}
+	eth1BlockHash := deterministicRoot("eth1_block", 0)
+	return &deneb.BeaconBlock{
+		Slot:          slot,
+		ProposerIndex: b.chain.proposer(slot).Index,
+		ParentRoot:    parentRoot,
+		StateRoot:     b.chain.stateRoot(slot),
+		Body: &deneb.BeaconBlockBody{
+			RANDAOReveal: randaoReveal,
+			ETH1Data: &phase0.ETH1Data{
+				DepositRoot: deterministicRoot("deposits", 0),
+				BlockHash:   eth1BlockHash[:],
+			},
+			Graffiti:              graffiti,
+			ProposerSlashings:     []*phase0.ProposerSlashing{},
+			AttesterSlashings:     []*phase0.AttesterSlashing{},
+			Attestations:          []*phase0.Attestation{},
+			Deposits:              []*phase0.Deposit{},
+			VoluntaryExits:        []*phase0.SignedVoluntaryExit{},
+			SyncAggregate:         b.syncAggregate(slot),
+			ExecutionPayload:      b.executionPayload(slot),
+			BLSToExecutionChanges: []*capella.SignedBLSToExecutionChange{},
+			BlobKZGCommitments:    []deneb.KZGCommitment{},
+		},
+	}
+}
+
+// blindedBeaconBlock replaces the execution payload of the block with its header.
+// The transactions and withdrawals roots aren't computed, since nothing verifies them.
+func blindedBeaconBlock(block *deneb.BeaconBlock) *apiv1deneb.BlindedBeaconBlock {
+	payload := block.Body.ExecutionPayload
+	return &apiv1deneb.BlindedBeaconBlock{
+		Slot:          block.Slot,
+		ProposerIndex: block.ProposerIndex,
+		ParentRoot:    block.ParentRoot,
+		StateRoot:     block.StateRoot,
+		Body: &apiv1deneb.BlindedBeaconBlockBody{
+			RANDAOReveal:      block.Body.RANDAOReveal,
+			ETH1Data:          block.Body.ETH1Data,
+			Graffiti:          block.Body.Graffiti,
+			ProposerSlashings: block.Body.ProposerSlashings,
+			AttesterSlashings: block.Body.AttesterSlashings,
+			Attestations:      block.Body.Attestations,
+			Deposits:          block.Body.Deposits,
+			VoluntaryExits:    block.Body.VoluntaryExits,
+			SyncAggregate:     block.Body.SyncAggregate,
+			ExecutionPayloadHeader: &deneb.ExecutionPayloadHeader{
+				ParentHash:       payload.ParentHash,
+				FeeRecipient:     payload.FeeRecipient,
+				StateRoot:        payload.StateRoot,
+				ReceiptsRoot:     payload.ReceiptsRoot,
+				LogsBloom:        payload.LogsBloom,
+				PrevRandao:       payload.PrevRandao,
+				BlockNumber:      payload.BlockNumber,
+				GasLimit:         payload.GasLimit,
+				GasUsed:          payload.GasUsed,
+				Timestamp:        payload.Timestamp,
+				ExtraData:        payload.ExtraData,
+				BaseFeePerGas:    payload.BaseFeePerGas,
+				BlockHash:        payload.BlockHash,
+				TransactionsRoot: deterministicRoot("transactions", payload.BlockNumber),
+				WithdrawalsRoot:  deterministicRoot("withdrawals", payload.BlockNumber),
+				BlobGasUsed:      payload.BlobGasUsed,
+				ExcessBlobGas:    payload.ExcessBlobGas,
+			},
+			BLSToExecutionChanges: block.Body.BLSToExecutionChanges,
+			BlobKZGCommitments:    block.Body.BlobKZGCommitments,
+		},
+	}
+}
+
+// unblindedBeaconBlock replaces the execution payload header of the block with the payload it was produced with.
+func unblindedBeaconBlock(block *apiv1deneb.SignedBlindedBeaconBlock, payload *deneb.ExecutionPayload) *deneb.SignedBeaconBlock {
+	message := block.Message
+	return &deneb.SignedBeaconBlock{
+		Message: &deneb.BeaconBlock{
+			Slot:          message.Slot,
+			ProposerIndex: message.ProposerIndex,
+			ParentRoot:    message.ParentRoot,
+			StateRoot:     message.StateRoot,
+			Body: &deneb.BeaconBlockBody{
+				RANDAOReveal:          message.Body.RANDAOReveal,
+				ETH1Data:              message.Body.ETH1Data,
+				Graffiti:              message.Body.Graffiti,
+				ProposerSlashings:     message.Body.ProposerSlashings,
+				AttesterSlashings:     message.Body.AttesterSlashings,
+				Attestations:          message.Body.Attestations,
+				Deposits:              message.Body.Deposits,
+				VoluntaryExits:        message.Body.VoluntaryExits,
+				SyncAggregate:         message.Body.SyncAggregate,
+				ExecutionPayload:      payload,
+				BLSToExecutionChanges: message.Body.BLSToExecutionChanges,
+				BlobKZGCommitments:    message.Body.BlobKZGCommitments,
+			},
+		},
+		Signature: block.Signature,
+	}
+}
+
+func (b *FakeBeacon) handleBlockProposal(w http.ResponseWriter, r *http.Request) {
+	logger, gateway := b.requestContext(r)
+
+	// Parse request.
+	slot, err := parseUintParam(r, "slot")
+	if err != nil {
+		b.error(r, w, http.StatusBadRequest, err)
+		return
+	}
+	randaoReveal, err := hex.DecodeString(strings.TrimPrefix(r.URL.Query().Get("randao_reveal"), "0x"))
+	if err != nil || len(randaoReveal) != len(phase0.BLSSignature{}) {
+		b.error(r, w, http.StatusBadRequest, fmt.Errorf("invalid randao_reveal %q", r.URL.Query().Get("randao_reveal")))
+		return
+	}
+	var graffiti [32]byte
+	if value := r.URL.Query().Get("graffiti"); value != "" {
+		decoded, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+		if err != nil || len(decoded) > len(graffiti) {
+			b.error(r, w, http.StatusBadRequest, fmt.Errorf("invalid graffiti %q", value))
+			return
+		}
+		copy(graffiti[:], decoded)
+	}
+
+	// Produce block.
+	block := &spec.VersionedBeaconBlock{
+		Version: spec.DataVersionDeneb,
+		Deneb:   b.beaconBlock(phase0.Slot(slot), phase0.BLSSignature(randaoReveal), graffiti),
+	}
+
+	// Intercept.
+	if gateway.Interceptor != nil {
+		block, err = gateway.Interceptor.InterceptBlockProposal(
+			r.Context(),
+			phase0.Slot(slot),
+			phase0.BLSSignature(randaoReveal),
+			graffiti,
+			block,
+		)
+		if err != nil {
+			b.error(r, w, http.StatusInternalServerError, fmt.Errorf("failed to intercept block: %w", err))
+			return
+		}
+	}
+
+	// Respond.
+	var data any
+	switch {
+	case block.Version == spec.DataVersionDeneb && b.config.BlindedBlocks:
+		b.chain.addPayload(block.Deneb.Slot, block.Deneb.Body.ExecutionPayload)
+		data = blindedBeaconBlock(block.Deneb)
+	case block.Version == spec.DataVersionDeneb:
+		data = &apiv1deneb.BlockContents{
+			Block:     block.Deneb,
+			KZGProofs: []deneb.KZGProof{},
+			Blobs:     []deneb.Blob{},
+		}
+	case block.Version == spec.DataVersionCapella && !b.config.BlindedBlocks:
+		data = block.Capella
+	default:
+		b.error(r, w, http.StatusInternalServerError, fmt.Errorf("unsupported block version %s (blinded: %v)", block.Version, b.config.BlindedBlocks))
+		return
+	}
+	w.Header().Set("Eth-Execution-Payload-Blinded", fmt.Sprint(b.config.BlindedBlocks))
+	w.Header().Set("Eth-Execution-Payload-Value", "0")
+	w.Header().Set("Eth-Consensus-Block-Value", "0")
+	b.respondVersioned(r, w, block.Version, data)
+
+	logger.Info("produced block",
+		zap.Uint64("slot", slot),
+		zap.String("version", block.Version.String()),
+		zap.Bool("blinded", b.config.BlindedBlocks),
+		zap.String("graffiti", string(graffiti[:])),
+	)
+}
+
+func (b *FakeBeacon) handleSubmitBlockProposal(w http.ResponseWriter, r *http.Request) {
+	// Parse request.
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		b.error(r, w, http.StatusBadRequest, fmt.Errorf("failed to read request: %w", err))
+		return
+	}
+	ssz := strings.HasPrefix(r.Header.Get("Content-Type"), "application/octet-stream")
+	block := &spec.VersionedSignedBeaconBlock{}
+	switch version := r.Header.Get("Eth-Consensus-Version"); version {
+	case "capella":
+		block.Version = spec.DataVersionCapella
+		block.Capella = &capella.SignedBeaconBlock{}
+		if ssz {
+			err = block.Capella.UnmarshalSSZ(body)
+		} else {
+			err = json.Unmarshal(body, block.Capella)
+		}
+	case "deneb", "":
+		contents := &apiv1deneb.SignedBlockContents{}
+		if ssz {
+			err = contents.UnmarshalSSZ(body)
+		} else {
+			err = json.Unmarshal(body, contents)
+		}
+		block.Version = spec.DataVersionDeneb
+		block.Deneb = contents.SignedBlock
+	default:
+		err = fmt.Errorf("unsupported version %q", version)
+	}
+	if err != nil {
+		b.error(r, w, http.StatusBadRequest, fmt.Errorf("failed to parse request: %w", err))
+		return
+	}
+
+	b.submitBlock(w, r, block)
+}
+
+func (b *FakeBeacon) handleSubmitBlindedBlockProposal(w http.ResponseWriter, r *http.Request) {
+	// Parse request.
+	if version := r.Header.Get("Eth-Consensus-Version"); version != "" && version != "deneb" {
+		b.error(r, w, http.StatusBadRequest, fmt.Errorf("unsupported version %q", version))
+		return
+	}
+	var blindedBlock *apiv1deneb.SignedBlindedBeaconBlock
+	if err := json.NewDecoder(r.Body).Decode(&blindedBlock); err != nil {
+		b.error(r, w, http.StatusBadRequest, fmt.Errorf("failed to parse request: %w", err))
+		return
+	}
+
+	// Unblind with the payload the block was produced with, like a relay would.
+	payload, ok := b.chain.payload(blindedBlock.Message.Slot)
+	if !ok || payload.BlockHash != blindedBlock.Message.Body.ExecutionPayloadHeader.BlockHash {
+		b.error(r, w, http.StatusBadRequest, fmt.Errorf("unknown execution payload at slot %d", blindedBlock.Message.Slot))
+		return
+	}
+
+	b.submitBlock(w, r, &spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionDeneb,
+		Deneb:   unblindedBeaconBlock(blindedBlock, payload),
+	})
+}
+
+// submitBlock passes the block through the interceptor and makes it the head of its slot.
+func (b *FakeBeacon) submitBlock(w http.ResponseWriter, r *http.Request, block *spec.VersionedSignedBeaconBlock) {
+	logger, gateway := b.requestContext(r)
+
+	// Intercept.
+	if gateway.Interceptor != nil {
+		var err error
+		block, err = gateway.Interceptor.InterceptSubmitBlockProposal(r.Context(), block)
+		if err != nil {
+			b.error(r, w, http.StatusInternalServerError, fmt.Errorf("failed to intercept block: %w", err))
+			return
+		}
+		if block == nil {
+			// Don't submit.
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+
+	// Submit.
+	slot, err := block.Slot()
+	if err != nil {
+		b.error(r, w, http.StatusBadRequest, fmt.Errorf("failed to get block slot: %w", err))
+		return
+	}
+	proposer, err := block.ProposerIndex()
+	if err != nil {
+		b.error(r, w, http.StatusBadRequest, fmt.Errorf("failed to get block proposer: %w", err))
+		return
+	}
+	root, err := block.Root()
+	if err != nil {
+		b.error(r, w, http.StatusBadRequest, fmt.Errorf("failed to get block root: %w", err))
+		return
+	}
+	b.chain.addBlock(slot, root, proposer)
+	b.publishBlock(slot, root)
+
+	// Respond.
+	w.WriteHeader(http.StatusOK)
+
+	logger.Info("submitted block",
+		zap.Uint64("slot", uint64(slot)),
+		zap.Uint64("proposer", uint64(proposer)),
+		zap.String("root", fmt.Sprintf("%#x", root)),
+	)
+}
@@ -0,0 +1,106 @@
+package fakebeacon
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"go.uber.org/zap"
+)
+
+// The sync committee has the mainnet shape, even when only a few validators are members of it.
+const (
+	syncCommitteeSize        = 512
+	syncCommitteeSubnetCount = 4
+	syncSubcommitteeSize     = syncCommitteeSize / syncCommitteeSubnetCount
+)
+
+func (b *FakeBeacon) handleSubmitSyncCommitteeMessages(w http.ResponseWriter, r *http.Request) {
+	logger, _ := b.requestContext(r)
+
+	// Parse request.
+	var messages []*altair.SyncCommitteeMessage
+	if err := json.NewDecoder(r.Body).Decode(&messages); err != nil {
+		b.error(r, w, http.StatusBadRequest, fmt.Errorf("failed to parse request: %w", err))
+		return
+	}
+
+	// Submit.
+	for _, message := range messages {
+		if _, ok := b.chain.syncCommitteePosition(message.ValidatorIndex); !ok {
+			b.error(r, w, http.StatusBadRequest, fmt.Errorf("validator %d is not in the sync committee", message.ValidatorIndex))
+			return
+		}
+		b.chain.addSyncMessage(message.Slot, &syncMessage{
+			validatorIndex:  message.ValidatorIndex,
+			beaconBlockRoot: message.BeaconBlockRoot,
+			signature:       message.Signature,
+		})
+	}
+
+	// Respond.
+	w.WriteHeader(http.StatusOK)
+
+	logger.Info("submitted sync committee messages", zap.Int("count", len(messages)))
+}
+
+// handleSyncCommitteeContribution serves the contribution of the subcommittee
+// from the sync committee messages submitted for the slot and block root.
+func (b *FakeBeacon) handleSyncCommitteeContribution(w http.ResponseWriter, r *http.Request) {
+	logger, _ := b.requestContext(r)
+
+	// Parse request.
+	slot, err := parseUintParam(r, "slot")
+	if err != nil {
+		b.error(r, w, http.StatusBadRequest, err)
+		return
+	}
+	subcommitteeIndex, err := parseUintParam(r, "subcommittee_index")
+	if err != nil {
+		b.error(r, w, http.StatusBadRequest, err)
+		return
+	}
+	if subcommitteeIndex >= syncCommitteeSubnetCount {
+		b.error(r, w, http.StatusBadRequest, fmt.Errorf("subcommittee index %d is out of range", subcommitteeIndex))
+		return
+	}
+	root, err := parseRootParam(r, "beacon_block_root")
+	if err != nil {
+		b.error(r, w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Aggregate the messages of the subcommittee.
+	contribution := &altair.SyncCommitteeContribution{
+		Slot:              phase0.Slot(slot),
+		BeaconBlockRoot:   root,
+		SubcommitteeIndex: subcommitteeIndex,
+		AggregationBits:   bitfield.NewBitvector128(),
+	}
+	for _, message := range b.chain.syncMessagesAt(phase0.Slot(slot), root) {
+		position, _ := b.chain.syncCommitteePosition(message.validatorIndex)
+		if position/syncSubcommitteeSize != subcommitteeIndex {
+			continue
+		}
+		if contribution.AggregationBits.Count() == 0 {
+			contribution.Signature = message.signature
+		}
+		contribution.AggregationBits.SetBitAt(position%syncSubcommitteeSize, true)
+	}
+	if contribution.AggregationBits.Count() == 0 {
+		b.error(r, w, http.StatusNotFound, fmt.Errorf("no sync committee messages for block root %#x at slot %d", root, slot))
+		return
+	}
+
+	// Respond.
+	b.respond(r, w, contribution, nil)
+
+	logger.Info("served sync committee contribution",
+		zap.Uint64("slot", slot),
+		zap.Uint64("subcommittee_index", subcommitteeIndex),
+		zap.Uint64("participants", contribution.AggregationBits.Count()),
+	)
+}
@@ -45,6 +45,7 @@ type Storage interface {
 	RemoveHighestAttestation(pubKey []byte) error
 	RemoveHighestProposal(pubKey []byte) error
 	SetEncryptionKey(newKey string) error
+	ReEncryptAccountsTxn(rw basedb.ReadWriter, newKey string) (int, error)
 	ListAccountsTxn(r basedb.Reader) ([]core.ValidatorAccount, error)
 	SaveAccountTxn(rw basedb.ReadWriter, account core.ValidatorAccount) error
 
@@ -84,6 +85,47 @@ func (s *storage) SetEncryptionKey(newKey string) error {
 	return nil
 }
 
+// ReEncryptAccountsTxn re-encrypts all stored accounts with newKey within rw and returns their count.
+// The storage keeps using its current encryption key, so the caller should switch to newKey with SetEncryptionKey
+// once rw is committed.
+func (s *storage) ReEncryptAccountsTxn(rw basedb.ReadWriter, newKey string) (int, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	next := &storage{
+		db:      s.db,
+		network: s.network,
+		logger:  s.logger,
+	}
+	if err := next.SetEncryptionKey(newKey); err != nil {
+		return 0, err
+	}
+
+	var accounts []basedb.Obj
+	err := s.db.Using(rw).GetAll(s.objPrefix(accountsPrefix), func(i int, obj basedb.Obj) error {
+		value, err := s.decryptData(obj.Value)
+		if err != nil {
+			return errors.Wrap(err, "failed to decrypt account")
+		}
+		value, err = next.encryptData(value)
+		if err != nil {
+			return err
+		}
+		accounts = append(accounts, basedb.Obj{Key: obj.Key, Value: value})
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, account := range accounts {
+		if err := s.db.Using(rw).Set(s.objPrefix(accountsPrefix), account.Key, account.Value); err != nil {
+			return 0, errors.Wrap(err, "failed to save account")
+		}
+	}
+	return len(accounts), nil
+}
+
 func (s *storage) DropRegistryData() error {
 	return s.db.DropPrefix(s.objPrefix(accountsPrefix))
 }
@@ -32,6 +32,7 @@ type Provider interface {
 	BlockByNumber(ctx context.Context, number *big.Int) (*ethtypes.Block, error)
 	HeaderByNumber(ctx context.Context, blockNumber *big.Int) (*ethtypes.Header, error)
 	ChainID(ctx context.Context) (*big.Int, error)
+	ClientVersion(ctx context.Context) (string, error)
 	Healthy(ctx context.Context) error
 	SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- ethtypes.Log) (ethereum.Subscription, error)
 	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]ethtypes.Log, error)
@@ -504,3 +505,12 @@ func (ec *ExecutionClient) Filterer() (*contract.ContractFilterer, error) {
 func (ec *ExecutionClient) ChainID(ctx context.Context) (*big.Int, error) {
 	return ec.client.ChainID(ctx)
 }
+
+// ClientVersion returns the "name/version/..." string reported by web3_clientVersion.
+func (ec *ExecutionClient) ClientVersion(ctx context.Context) (string, error) {
+	var version string
+	if err := ec.client.Client().CallContext(ctx, &version, "web3_clientVersion"); err != nil {
+		return "", err
+	}
+	return version, nil
+}
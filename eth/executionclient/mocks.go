@@ -73,6 +73,21 @@ func (mr *MockProviderMockRecorder) ChainID(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainID", reflect.TypeOf((*MockProvider)(nil).ChainID), ctx)
 }
 
+// ClientVersion mocks base method.
+func (m *MockProvider) ClientVersion(ctx context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClientVersion", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClientVersion indicates an expected call of ClientVersion.
+func (mr *MockProviderMockRecorder) ClientVersion(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientVersion", reflect.TypeOf((*MockProvider)(nil).ClientVersion), ctx)
+}
+
 // Close mocks base method.
 func (m *MockProvider) Close() error {
 	m.ctrl.T.Helper()
@@ -244,6 +259,21 @@ func (mr *MockSingleClientProviderMockRecorder) ChainID(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainID", reflect.TypeOf((*MockSingleClientProvider)(nil).ChainID), ctx)
 }
 
+// ClientVersion mocks base method.
+func (m *MockSingleClientProvider) ClientVersion(ctx context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClientVersion", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClientVersion indicates an expected call of ClientVersion.
+func (mr *MockSingleClientProviderMockRecorder) ClientVersion(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientVersion", reflect.TypeOf((*MockSingleClientProvider)(nil).ClientVersion), ctx)
+}
+
 // Close mocks base method.
 func (m *MockSingleClientProvider) Close() error {
 	m.ctrl.T.Helper()
@@ -331,6 +331,19 @@ func (mc *MultiClient) BlockByNumber(ctx context.Context, blockNumber *big.Int)
 	return res.(*ethtypes.Block), nil
 }
 
+// ClientVersion returns the version of the first client which responds.
+func (mc *MultiClient) ClientVersion(ctx context.Context) (string, error) {
+	f := func(client SingleClientProvider) (any, error) {
+		return client.ClientVersion(ctx)
+	}
+	res, err := mc.call(contextWithMethod(ctx, "ClientVersion"), f, len(mc.clients))
+	if err != nil {
+		return "", err
+	}
+
+	return res.(string), nil
+}
+
 // HeaderByNumber retrieves a block header by its number.
 func (mc *MultiClient) HeaderByNumber(ctx context.Context, blockNumber *big.Int) (*ethtypes.Header, error) {
 	f := func(client SingleClientProvider) (any, error) {
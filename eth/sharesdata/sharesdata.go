@@ -0,0 +1,118 @@
+// Package sharesdata builds the shares data of the ValidatorAdded event,
+// which is passed as the shares argument of the registerValidator contract call.
+package sharesdata
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/operator/keys"
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
+	"github.com/ssvlabs/ssv/utils/threshold"
+)
+
+// EncryptedKeyLength is the length of a share private key encrypted with a 2048-bit operator key,
+// which is the only length the event handler accepts.
+const EncryptedKeyLength = 256
+
+// Operator is a committee member to create a share for.
+type Operator struct {
+	ID        spectypes.OperatorID
+	PublicKey keys.OperatorPublicKey
+}
+
+// Share is the share of a single operator.
+type Share struct {
+	OperatorID   spectypes.OperatorID
+	PublicKey    []byte
+	EncryptedKey []byte
+}
+
+// SharesData is the validator key split between the committee operators.
+type SharesData struct {
+	ValidatorPublicKey []byte
+	// Signature is the validator key signature of the owner address and nonce.
+	Signature []byte
+	// Shares are ordered by operator ID, as the contract expects.
+	Shares []Share
+}
+
+// Build splits the validator key between the operators, encrypts each share with its operator public key
+// and signs the owner nonce with the validator key.
+func Build(validatorKey *bls.SecretKey, operators []Operator, owner ethcommon.Address, nonce uint64) (*SharesData, error) {
+	if !ssvtypes.ValidCommitteeSize(uint64(len(operators))) {
+		return nil, fmt.Errorf("invalid committee size %d", len(operators))
+	}
+	operators = slices.Clone(operators)
+	slices.SortFunc(operators, func(a, b Operator) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	ids := make([]uint64, len(operators))
+	for i, op := range operators {
+		ids[i] = op.ID
+	}
+	quorum, _ := ssvtypes.ComputeQuorumAndPartialQuorum(uint64(len(operators)))
+	shareKeys, err := threshold.CreateForIDs(validatorKey.Serialize(), quorum, ids)
+	if err != nil {
+		return nil, fmt.Errorf("could not split validator key: %w", err)
+	}
+
+	shares := make([]Share, len(operators))
+	for i, op := range operators {
+		shareKey := shareKeys[op.ID]
+		encryptedKey, err := op.PublicKey.Encrypt([]byte(shareKey.SerializeToHexStr()))
+		if err != nil {
+			return nil, fmt.Errorf("could not encrypt share of operator %d: %w", op.ID, err)
+		}
+		if len(encryptedKey) != EncryptedKeyLength {
+			return nil, fmt.Errorf("encrypted share of operator %d is %d bytes long, expected %d, is its key 2048 bits long?",
+				op.ID, len(encryptedKey), EncryptedKeyLength)
+		}
+		shares[i] = Share{
+			OperatorID:   op.ID,
+			PublicKey:    shareKey.GetPublicKey().Serialize(),
+			EncryptedKey: encryptedKey,
+		}
+	}
+
+	return &SharesData{
+		ValidatorPublicKey: validatorKey.GetPublicKey().Serialize(),
+		Signature:          validatorKey.SignByte(OwnerNonceHash(owner, nonce)).Serialize(),
+		Shares:             shares,
+	}, nil
+}
+
+// OwnerNonceHash returns the hash the validator key signs to register the validator with the given owner nonce.
+func OwnerNonceHash(owner ethcommon.Address, nonce uint64) []byte {
+	return crypto.Keccak256([]byte(fmt.Sprintf("%s:%d", owner.String(), nonce)))
+}
+
+// OperatorIDs returns the operator IDs of the shares.
+func (s *SharesData) OperatorIDs() []uint64 {
+	ids := make([]uint64, len(s.Shares))
+	for i, share := range s.Shares {
+		ids[i] = share.OperatorID
+	}
+	return ids
+}
+
+// Bytes returns the shares data as the signature, followed by the share public keys, followed by the encrypted keys.
+func (s *SharesData) Bytes() []byte {
+	data := make([]byte, 0, phase0.SignatureLength+len(s.Shares)*(phase0.PublicKeyLength+EncryptedKeyLength))
+	data = append(data, s.Signature...)
+	for _, share := range s.Shares {
+		data = append(data, share.PublicKey...)
+	}
+	for _, share := range s.Shares {
+		data = append(data, share.EncryptedKey...)
+	}
+	return data
+}
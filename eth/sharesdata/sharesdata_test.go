@@ -0,0 +1,76 @@
+package sharesdata
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/operator/keys"
+	"github.com/ssvlabs/ssv/utils/threshold"
+)
+
+func TestBuild(t *testing.T) {
+	threshold.Init()
+
+	validatorKey := &bls.SecretKey{}
+	validatorKey.SetByCSPRNG()
+	owner := ethcommon.HexToAddress("0x1234567890123456789012345678901234567890")
+	nonce := uint64(7)
+
+	// unsorted, non-consecutive operator IDs
+	operatorIDs := []spectypes.OperatorID{42, 7, 1000, 13}
+	privKeys := make(map[spectypes.OperatorID]keys.OperatorPrivateKey)
+	operators := make([]Operator, len(operatorIDs))
+	for i, id := range operatorIDs {
+		privKey, err := keys.GeneratePrivateKey()
+		require.NoError(t, err)
+		privKeys[id] = privKey
+		operators[i] = Operator{ID: id, PublicKey: privKey.Public()}
+	}
+
+	sharesData, err := Build(validatorKey, operators, owner, nonce)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{7, 13, 42, 1000}, sharesData.OperatorIDs())
+	require.Equal(t, validatorKey.GetPublicKey().Serialize(), sharesData.ValidatorPublicKey)
+
+	// parse the same way as the event handler
+	data := sharesData.Bytes()
+	operatorCount := len(operators)
+	signatureOffset := phase0.SignatureLength
+	pubKeysOffset := phase0.PublicKeyLength*operatorCount + signatureOffset
+	require.Len(t, data, EncryptedKeyLength*operatorCount+pubKeysOffset)
+
+	sig := &bls.Sign{}
+	require.NoError(t, sig.Deserialize(data[:signatureOffset]))
+	require.True(t, sig.VerifyByte(validatorKey.GetPublicKey(), OwnerNonceHash(owner, nonce)))
+	require.False(t, sig.VerifyByte(validatorKey.GetPublicKey(), OwnerNonceHash(owner, nonce+1)))
+
+	msg := []byte("message")
+	partialSigs := make(map[spectypes.OperatorID][]byte)
+	for i, id := range sharesData.OperatorIDs() {
+		sharePubKey := data[signatureOffset+i*phase0.PublicKeyLength : signatureOffset+(i+1)*phase0.PublicKeyLength]
+		encryptedKey := data[pubKeysOffset+i*EncryptedKeyLength : pubKeysOffset+(i+1)*EncryptedKeyLength]
+
+		decrypted, err := privKeys[id].Decrypt(encryptedKey)
+		require.NoError(t, err)
+		shareKey := &bls.SecretKey{}
+		require.NoError(t, shareKey.SetHexString(string(decrypted)))
+		require.Equal(t, shareKey.GetPublicKey().Serialize(), sharePubKey)
+
+		if len(partialSigs) < 3 {
+			partialSigs[id] = shareKey.SignByte(msg).Serialize()
+		}
+	}
+
+	// a quorum of partial signatures by operator ID reconstructs the validator signature
+	reconstructed, err := threshold.ReconstructSignatures(partialSigs)
+	require.NoError(t, err)
+	require.True(t, reconstructed.VerifyByte(validatorKey.GetPublicKey(), msg))
+
+	_, err = Build(validatorKey, operators[:3], owner, nonce)
+	require.Error(t, err)
+}
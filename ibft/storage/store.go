@@ -55,6 +55,22 @@ func (i *participantStorage) Prune(ctx context.Context, logger *zap.Logger, thre
 	logger.Info("removed stale slot entries", zap.String("store", i.ID()), fields.Slot(threshold), zap.Int("count", count), zap.Duration("took", time.Since(start)))
 }
 
+// PruneRange removes the slots in the range [from, to), unlike Prune it doesn't look below from.
+func (i *participantStorage) PruneRange(ctx context.Context, logger *zap.Logger, from, to phase0.Slot) {
+	start := time.Now()
+	count := 0
+	for slot := from; slot < to && ctx.Err() == nil; slot++ {
+		removed, err := i.removeSlotAt(slot)
+		if err != nil {
+			logger.Error("remove slot at", zap.String("store", i.ID()), fields.Slot(slot), zap.Error(err))
+			continue
+		}
+		count += removed
+	}
+
+	logger.Debug("removed stale slot range", zap.String("store", i.ID()), zap.Uint64("from", uint64(from)), zap.Uint64("to", uint64(to)), zap.Int("count", count), zap.Duration("took", time.Since(start)))
+}
+
 // PruneContinously on every tick looks up and removes the slots that fall below the retain threshold
 func (i *participantStorage) PruneContinously(ctx context.Context, logger *zap.Logger, slotTickerProvider slotticker.Provider, retain phase0.Slot) {
 	ticker := slotTickerProvider()
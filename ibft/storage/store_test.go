@@ -233,6 +233,20 @@ func TestSlotCleanupJob(t *testing.T) {
 	assert.Equal(t, phase0.Slot(4), pp[1].Slot)
 	assert.Equal(t, phase0.Slot(9), pp[10].Slot)
 	assert.Equal(t, phase0.Slot(9), pp[11].Slot)
+
+	// range cleanup removes only the slots in the range
+	storage.PruneRange(context.Background(), zap.NewNop(), 6, 8)
+
+	pp, err = storage.GetAllParticipantsInRange(phase0.Slot(0), phase0.Slot(10))
+	require.Nil(t, err)
+	require.Equal(t, 8, len(pp))
+
+	// 	0	1	2	3	4	5	6	7	8	9
+	//	x   x   x	x	~	~	x	x	~
+	assert.Equal(t, phase0.Slot(4), pp[0].Slot)
+	assert.Equal(t, phase0.Slot(5), pp[3].Slot)
+	assert.Equal(t, phase0.Slot(8), pp[4].Slot)
+	assert.Equal(t, phase0.Slot(9), pp[7].Slot)
 }
 
 func TestEncodeDecodeOperators(t *testing.T) {
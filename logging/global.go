@@ -13,6 +13,9 @@ import (
 	"go.uber.org/zap/zapcore"
 )
 
+// globalLevel is the level of the global console logger, it can be changed with SetGlobalLogLevel.
+var globalLevel = zap.NewAtomicLevel()
+
 func parseConfigLevel(levelName string) (zapcore.Level, error) {
 	return zapcore.ParseLevel(levelName)
 }
@@ -48,13 +51,12 @@ func SetGlobalLogger(levelName string, levelEncoderName string, logFormat string
 
 	levelEncoder := parseConfigLevelEncoder(levelEncoderName)
 
-	lv := zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
-		return lvl >= level
-	})
+	globalLevel.SetLevel(level)
+	lv := globalLevel
 
 	cfg := zap.Config{
 		Encoding:    logFormat,
-		Level:       zap.NewAtomicLevelAt(level),
+		Level:       globalLevel,
 		OutputPaths: []string{"stdout"},
 		EncoderConfig: zapcore.EncoderConfig{
 			MessageKey:  "msg",
@@ -97,6 +99,16 @@ func SetGlobalLogger(levelName string, levelEncoderName string, logFormat string
 	return nil
 }
 
+// SetGlobalLogLevel changes the level of the global console logger set up by SetGlobalLogger.
+func SetGlobalLogLevel(levelName string) error {
+	level, err := parseConfigLevel(levelName)
+	if err != nil {
+		return err
+	}
+	globalLevel.SetLevel(level)
+	return nil
+}
+
 type LogFileOptions struct {
 	FileName   string
 	MaxSize    int
@@ -19,10 +19,19 @@ const (
 	NameCreateThreshold   = "CreateThreshold"
 	NameDiscoveryV5Logger = "DiscoveryV5Logger"
 	NameExportKeys        = "ExportKeys"
+	NameBuildShares       = "BuildShares"
+	NameExitValidators    = "ExitValidators"
+	NameMaintenanceWindow = "MaintenanceWindow"
 	NameP2PStorage        = "P2PStorage"
 	NamePubsubTrace       = "PubsubTrace"
 	NameScoreInspector    = "ScoreInspector"
 	NameEventHandler      = "EventHandler"
 	NameDutyFetcher       = "DutyFetcher"
 	NameDoppelganger      = "Doppelganger"
+	NameInclusionTracker  = "InclusionTracker"
+	NameBalanceTracker    = "BalanceTracker"
+	NameReplay            = "Replay"
+	NameTrace             = "Trace"
+	NameCrawler           = "Crawler"
+	NameKeyRotation       = "KeyRotation"
 )
@@ -118,6 +118,7 @@ func (mv *messageValidator) validateBeaconDuty(
 	slot phase0.Slot,
 	indices []phase0.ValidatorIndex,
 	randaoMsg bool,
+	receivedAt time.Time,
 ) error {
 	epoch := mv.netCfg.Beacon.EstimatedEpochAtSlot(slot)
 
@@ -126,8 +127,8 @@ func (mv *messageValidator) validateBeaconDuty(
 		// Tolerate missing duties for RANDAO signatures during the first slot of an epoch,
 		// while duties are still being fetched from the Beacon node.
 		//
-		// Note: we allow current slot to be lower because of the ErrEarlyMessage rule.
-		if randaoMsg && mv.netCfg.Beacon.IsFirstSlotOfEpoch(slot) && mv.netCfg.Beacon.EstimatedCurrentSlot() <= slot {
+		// Note: we allow the slot at the receive time to be lower because of the ErrEarlyMessage rule.
+		if randaoMsg && mv.netCfg.Beacon.IsFirstSlotOfEpoch(slot) && mv.netCfg.Beacon.EstimatedSlotAtTime(receivedAt.Unix()) <= slot {
 			if !mv.dutyStore.Proposer.IsEpochSet(epoch) {
 				return nil
 			}
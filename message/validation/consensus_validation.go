@@ -267,7 +267,7 @@ func (mv *messageValidator) validateQBFTMessageByDutyLogic(
 
 	msgSlot := phase0.Slot(consensusMessage.Height)
 	randaoMsg := false
-	if err := mv.validateBeaconDuty(signedSSVMessage.SSVMessage.GetID().GetRoleType(), msgSlot, validatorIndices, randaoMsg); err != nil {
+	if err := mv.validateBeaconDuty(signedSSVMessage.SSVMessage.GetID().GetRoleType(), msgSlot, validatorIndices, randaoMsg, receivedAt); err != nil {
 		return err
 	}
 
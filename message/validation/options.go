@@ -1,6 +1,8 @@
 package validation
 
 import (
+	"time"
+
 	"github.com/libp2p/go-libp2p/core/peer"
 	"go.uber.org/zap"
 )
@@ -22,3 +24,11 @@ func WithSelfAccept(selfPID peer.ID, selfAccept bool) Option {
 		mv.selfAccept = selfAccept
 	}
 }
+
+// WithClock sets the function returning the receive time of validated messages.
+// Useful for replaying recorded messages.
+func WithClock(now func() time.Time) Option {
+	return func(mv *messageValidator) {
+		mv.now = now
+	}
+}
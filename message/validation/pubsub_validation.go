@@ -1,10 +1,12 @@
 package validation
 
 import (
+	"time"
+
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 )
 
-func (mv *messageValidator) validatePubSubMessage(pMsg *pubsub.Message) error {
+func (mv *messageValidator) validatePubSubMessage(pMsg *pubsub.Message, receivedAt time.Time) error {
 	// Rule: Pubsub.Message.Message.Data must not be empty
 	if len(pMsg.GetData()) == 0 {
 		return ErrPubSubMessageHasNoData
@@ -12,7 +14,9 @@ func (mv *messageValidator) validatePubSubMessage(pMsg *pubsub.Message) error {
 
 	maxMsgSize := MaxEncodedMsgSizeBeforePectra
 
-	if mv.netCfg.Beacon.EstimatedCurrentEpoch() >= mv.pectraForkEpoch {
+	// The epoch is of the receive time rather than the wall clock, so that recorded messages are replayed as received.
+	receivedEpoch := mv.netCfg.Beacon.EstimatedEpochAtSlot(mv.netCfg.Beacon.EstimatedSlotAtTime(receivedAt.Unix()))
+	if receivedEpoch >= mv.pectraForkEpoch {
 		maxMsgSize = MaxEncodedMsgSize
 	}
 
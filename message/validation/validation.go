@@ -47,6 +47,9 @@ type messageValidator struct {
 
 	selfPID    peer.ID
 	selfAccept bool
+
+	// now returns the time at which a message is considered received.
+	now func() time.Time
 }
 
 // New returns a new MessageValidator with the given network configuration and options.
@@ -67,6 +70,7 @@ func New(
 		dutyStore:           dutyStore,
 		signatureVerifier:   signatureVerifier,
 		pectraForkEpoch:     pectraForkEpoch,
+		now:                 time.Now,
 	}
 
 	for _, opt := range opts {
@@ -96,7 +100,7 @@ func (mv *messageValidator) Validate(ctx context.Context, peerID peer.ID, pmsg *
 
 	recordMessage(ctx)
 
-	decodedMessage, err := mv.handlePubsubMessage(pmsg, time.Now())
+	decodedMessage, err := mv.handlePubsubMessage(pmsg, mv.now())
 	if err != nil {
 		return mv.handleValidationError(ctx, peerID, decodedMessage, err)
 	}
@@ -107,7 +111,7 @@ func (mv *messageValidator) Validate(ctx context.Context, peerID peer.ID, pmsg *
 }
 
 func (mv *messageValidator) handlePubsubMessage(pMsg *pubsub.Message, receivedAt time.Time) (*queue.SSVMessage, error) {
-	if err := mv.validatePubSubMessage(pMsg); err != nil {
+	if err := mv.validatePubSubMessage(pMsg, receivedAt); err != nil {
 		return nil, err
 	}
 
@@ -135,7 +139,7 @@ func (mv *messageValidator) handleSignedSSVMessage(signedSSVMessage *spectypes.S
 	}
 
 	// TODO: leverage the ValidatorStore to keep track of committees' indices and return them in Committee methods (which already return a Committee struct that we should add an Indices filter to): https://github.com/ssvlabs/ssv/pull/1393#discussion_r1667681686
-	committeeInfo, err := mv.getCommitteeAndValidatorIndices(signedSSVMessage.SSVMessage.GetID())
+	committeeInfo, err := mv.getCommitteeAndValidatorIndices(signedSSVMessage.SSVMessage.GetID(), receivedAt)
 	if err != nil {
 		return decodedMessage, err
 	}
@@ -210,7 +214,7 @@ type CommitteeInfo struct {
 	committeeID spectypes.CommitteeID
 }
 
-func (mv *messageValidator) getCommitteeAndValidatorIndices(msgID spectypes.MessageID) (CommitteeInfo, error) {
+func (mv *messageValidator) getCommitteeAndValidatorIndices(msgID spectypes.MessageID, receivedAt time.Time) (CommitteeInfo, error) {
 	if mv.committeeRole(msgID.GetRoleType()) {
 		// TODO: add metrics and logs for committee role
 		committeeID := spectypes.CommitteeID(msgID.GetDutyExecutorID()[16:])
@@ -251,7 +255,8 @@ func (mv *messageValidator) getCommitteeAndValidatorIndices(msgID spectypes.Mess
 	}
 
 	// Rule: If validator is not active
-	if !share.IsAttesting(mv.netCfg.Beacon.EstimatedCurrentEpoch()) {
+	receivedEpoch := mv.netCfg.Beacon.EstimatedEpochAtSlot(mv.netCfg.Beacon.EstimatedSlotAtTime(receivedAt.Unix()))
+	if !share.IsAttesting(receivedEpoch) {
 		e := ErrValidatorNotAttesting
 		e.got = share.Status.String()
 		return CommitteeInfo{}, e
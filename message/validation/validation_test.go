@@ -251,6 +251,35 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		require.ErrorIs(t, err, e)
 	})
 
+	// The size limit is raised at the pectra fork epoch of the message's receive time
+	t.Run("pubsub data size limit by receive epoch", func(t *testing.T) {
+		pectraForkEpoch := phase0.Epoch(10)
+		validator := New(netCfg, validatorStore, dutyStore, signatureVerifier, pectraForkEpoch).(*messageValidator)
+
+		topic := commons.GetTopicFullName(commons.CommitteeTopicID(committeeID)[0])
+		msgSize := MaxEncodedMsgSizeBeforePectra + 1
+
+		pmsg := &pubsub.Message{
+			Message: &pspb.Message{
+				Data:  bytes.Repeat([]byte{1}, msgSize),
+				Topic: &topic,
+				From:  []byte("16Uiu2HAkyWQyCb6reWXGQeBUt9EXArk6h3aq3PsFMwLNq3pPGH1r"),
+			},
+		}
+
+		e := ErrPubSubDataTooBig
+		e.got = msgSize
+
+		receivedAt := netCfg.Beacon.EpochStartTime(pectraForkEpoch - 1)
+		_, err = validator.handlePubsubMessage(pmsg, receivedAt)
+		require.ErrorIs(t, err, e)
+
+		receivedAt = netCfg.Beacon.EpochStartTime(pectraForkEpoch)
+		_, err = validator.handlePubsubMessage(pmsg, receivedAt)
+		require.Error(t, err)
+		require.NotErrorIs(t, err, e)
+	})
+
 	// Send a malformed pubsub message (empty message) should return an error
 	t.Run("empty pubsub message", func(t *testing.T) {
 		validator := New(netCfg, validatorStore, dutyStore, signatureVerifier, phase0.Epoch(0)).(*messageValidator)
@@ -0,0 +1,104 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	opstorage "github.com/ssvlabs/ssv/operator/storage"
+	"github.com/ssvlabs/ssv/protocol/v2/types"
+	"github.com/ssvlabs/ssv/registry/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"go.uber.org/zap"
+)
+
+// This migration re-encodes shares with the exit epoch and consolidation status of their validator,
+// which are added to the SSZ encoding of shares. Both are left zero (unknown) until the next metadata
+// update, so validators keep their current status-based duties until then.
+var migration_6_add_exit_epoch_to_shares = Migration{
+	Name: "migration_6_add_exit_epoch_to_shares",
+	Run: func(ctx context.Context, logger *zap.Logger, opt Options, key []byte, completed CompletedFunc) (err error) {
+		var sharesTotal int
+
+		defer func() {
+			if err != nil {
+				return // cannot complete migration successfully
+			}
+			// complete migration, this makes sure migration applies only once
+			if err = completed(opt.Db); err != nil {
+				err = fmt.Errorf("complete transaction: %w", err)
+				return
+			}
+			logger.Info("migration completed", zap.Int("shares_total", sharesTotal))
+		}()
+
+		// As in migration_5, the updates are split up into batches by SetMany. This migration is
+		// idempotent too, since shares which already have the new encoding are skipped.
+		sharesEncoded := make([]basedb.Obj, 0)
+		err = opt.Db.GetAll(storage.SharesDBPrefix(opstorage.OperatorStoragePrefix), func(i int, obj basedb.Obj) error {
+			if err := (&storage.Share{}).Decode(obj.Value); err == nil {
+				return nil
+			}
+
+			oldShare := &storageShareSSZ{}
+			if err := oldShare.UnmarshalSSZ(obj.Value); err != nil {
+				return fmt.Errorf("decode old ssz share: %w", err)
+			}
+			share := storageShareFromOldSSZ(oldShare)
+			value, err := share.Encode()
+			if err != nil {
+				return fmt.Errorf("encode ssz share: %w", err)
+			}
+			sharesEncoded = append(sharesEncoded, basedb.Obj{
+				Key:   storage.SharesDBKey(share.ValidatorPubKey),
+				Value: value,
+			})
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("GetAll: %w", err)
+		}
+
+		sharesTotal = len(sharesEncoded)
+		if sharesTotal == 0 {
+			return nil
+		}
+
+		if err := opt.Db.SetMany(opstorage.OperatorStoragePrefix, len(sharesEncoded), func(i int) (basedb.Obj, error) {
+			return sharesEncoded[i], nil
+		}); err != nil {
+			return fmt.Errorf("SetMany: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func storageShareFromOldSSZ(oldShare *storageShareSSZ) *storage.Share {
+	committee := make([]*spectypes.ShareMember, len(oldShare.Committee))
+	for i, operator := range oldShare.Committee {
+		committee[i] = &spectypes.ShareMember{
+			Signer:      operator.OperatorID,
+			SharePubKey: operator.PubKey,
+		}
+	}
+
+	share := &types.SSVShare{
+		Share: spectypes.Share{
+			ValidatorIndex:      phase0.ValidatorIndex(oldShare.ValidatorIndex),
+			SharePubKey:         oldShare.SharePubKey,
+			Committee:           committee,
+			DomainType:          oldShare.DomainType,
+			FeeRecipientAddress: oldShare.FeeRecipientAddress,
+			Graffiti:            oldShare.Graffiti,
+		},
+		Status:          eth2apiv1.ValidatorState(oldShare.Status), // nolint: gosec
+		ActivationEpoch: phase0.Epoch(oldShare.ActivationEpoch),
+		OwnerAddress:    oldShare.OwnerAddress,
+		Liquidated:      oldShare.Liquidated,
+	}
+	copy(share.ValidatorPubKey[:], oldShare.ValidatorPubKey)
+	return storage.FromSSVShare(share)
+}
@@ -0,0 +1,107 @@
+package migrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+	opstorage "github.com/ssvlabs/ssv/operator/storage"
+	"github.com/ssvlabs/ssv/registry/storage"
+)
+
+// shareSSZHexString is a share encoded before migration_6_add_exit_epoch_to_shares,
+// the same as in registry/storage Test_storageShare_encoding_decoding.
+const shareSSZHexString = "c4a519000000000083cfeef36e9f114f2432b39ff3f47f8b15d39074a96960377b3b0e5f0e422099ee54a4eddaf8815c8fe2dab89528c86e81000000b100000000003113d1dc869556f1f3027def029d23cd0968cf08cb0eb10100000300000000000000abb80000000000005cc0dde14e7256340cc820415a6022a7d1c93a35018f5f803c3531ca8eebb62cf858c228a8729d568bafc9e07d797d6c83368474f5c2170f653891aad55fda74a825e5d1e4100000004c00000088000000c400000013010000000000000c000000a754fefa9bf4b967d9581ac7a9476dc65a806e42573c6322ad9eb90e3527fc1634afebeb3f7532d7c80b84794b2992bd14010000000000000c0000008f5f803c3531ca8eebb62cf858c228a8729d568bafc9e07d797d6c83368474f5c2170f653891aad55fda74a825e5d1e415010000000000000c000000b1d28fc4c98309e8249bb1bfb737ccd7e4a8996ce692958e848aa7d0ee41ffacc562c5b9ce869d6f3b69d72a1b44408e16010000000000000c00000097e9bd027e9769a34fb730fff5cba87823508267b33aa843f44b450ace06f0e9a5aaedf4ddab255737e1e84b7bb8fd4a83cfeef36e9f114f2432b39ff3f47f8b15d39074a96960377b3b"
+
+// migratedShareSSZHexString is shareSSZHexString encoded after migration_6_add_exit_epoch_to_shares,
+// with a zero (unknown) exit epoch.
+const migratedShareSSZHexString = "c4a519000000000083cfeef36e9f114f2432b39ff3f47f8b15d39074a96960377b3b0e5f0e422099ee54a4eddaf8815c8fe2dab89528c86e8a000000ba00000000003113d1dc869556f1f3027def029d23cd0968cf08cb0eba0100000300000000000000abb80000000000000000000000000000005cc0dde14e7256340cc820415a6022a7d1c93a35018f5f803c3531ca8eebb62cf858c228a8729d568bafc9e07d797d6c83368474f5c2170f653891aad55fda74a825e5d1e4100000004c00000088000000c400000013010000000000000c000000a754fefa9bf4b967d9581ac7a9476dc65a806e42573c6322ad9eb90e3527fc1634afebeb3f7532d7c80b84794b2992bd14010000000000000c0000008f5f803c3531ca8eebb62cf858c228a8729d568bafc9e07d797d6c83368474f5c2170f653891aad55fda74a825e5d1e415010000000000000c000000b1d28fc4c98309e8249bb1bfb737ccd7e4a8996ce692958e848aa7d0ee41ffacc562c5b9ce869d6f3b69d72a1b44408e16010000000000000c00000097e9bd027e9769a34fb730fff5cba87823508267b33aa843f44b450ace06f0e9a5aaedf4ddab255737e1e84b7bb8fd4a83cfeef36e9f114f2432b39ff3f47f8b15d39074a96960377b3b"
+
+func TestMigration6_shareSSZ_Decode(t *testing.T) {
+	shareSSZHex, err := hex.DecodeString(shareSSZHexString)
+	require.NoError(t, err)
+
+	// Shares encoded before the migration can't be decoded with the new layout.
+	require.Error(t, (&storage.Share{}).Decode(shareSSZHex))
+
+	oldShare := &storageShareSSZ{}
+	require.NoError(t, oldShare.UnmarshalSSZ(shareSSZHex))
+	share := storageShareFromOldSSZ(oldShare)
+
+	require.EqualValues(t, 1680836, share.ValidatorIndex)
+	require.Equal(t, oldShare.ValidatorPubKey, share.ValidatorPubKey)
+	require.Equal(t, oldShare.SharePubKey, share.SharePubKey)
+	require.Len(t, share.Committee, 4)
+	require.EqualValues(t, 275, share.Committee[0].OperatorID)
+	require.Equal(t, oldShare.Committee[0].PubKey, share.Committee[0].PubKey)
+	require.EqualValues(t, 3, share.Quorum)
+	require.EqualValues(t, 2, share.PartialQuorum)
+	require.Equal(t, types.DomainType{0, 0, 49, 19}, types.DomainType(share.DomainType))
+	require.Equal(t, [20]uint8{209, 220, 134, 149, 86, 241, 243, 2, 125, 239, 2, 157, 35, 205, 9, 104, 207, 8, 203, 14}, share.FeeRecipientAddress)
+	require.Equal(t, oldShare.Graffiti, share.Graffiti)
+	require.EqualValues(t, 3, share.Status)
+	require.EqualValues(t, 47275, share.ActivationEpoch)
+	require.EqualValues(t, 0, share.ExitEpoch)
+	require.False(t, share.Consolidating)
+	require.Equal(t, common.Address{92, 192, 221, 225, 78, 114, 86, 52, 12, 200, 32, 65, 90, 96, 34, 167, 209, 201, 58, 53}, common.Address(share.OwnerAddress))
+	require.True(t, share.Liquidated)
+
+	// The migrated share must round-trip through the new encoding.
+	encoded, err := share.Encode()
+	require.NoError(t, err)
+	decoded := &storage.Share{}
+	require.NoError(t, decoded.Decode(encoded))
+	require.Equal(t, share, decoded)
+}
+
+func TestMigration6_addExitEpochToShares(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.TestLogger(t)
+	opt, err := setupOptions(ctx, t)
+	require.NoError(t, err)
+
+	shareSSZHex, err := hex.DecodeString(shareSSZHexString)
+	require.NoError(t, err)
+	migratedShareSSZHex, err := hex.DecodeString(migratedShareSSZHexString)
+	require.NoError(t, err)
+
+	oldShare := &storageShareSSZ{}
+	require.NoError(t, oldShare.UnmarshalSSZ(shareSSZHex))
+
+	// A share which already has the new encoding must be left as is.
+	newShare := storageShareFromOldSSZ(oldShare)
+	newShare.ValidatorPubKey = bytes.Repeat([]byte{1}, len(newShare.ValidatorPubKey))
+	newShare.ExitEpoch = 300
+	newShare.Consolidating = true
+	newShareSSZHex, err := newShare.Encode()
+	require.NoError(t, err)
+
+	oldKey := storage.SharesDBKey(oldShare.ValidatorPubKey)
+	newKey := storage.SharesDBKey(newShare.ValidatorPubKey)
+	require.NoError(t, opt.Db.Set(opstorage.OperatorStoragePrefix, oldKey, shareSSZHex))
+	require.NoError(t, opt.Db.Set(opstorage.OperatorStoragePrefix, newKey, newShareSSZHex))
+
+	applied, err := Migrations{migration_6_add_exit_epoch_to_shares}.Run(ctx, logger, opt)
+	require.NoError(t, err)
+	require.Equal(t, 1, applied)
+
+	obj, found, err := opt.Db.Get(opstorage.OperatorStoragePrefix, oldKey)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, migratedShareSSZHex, obj.Value)
+
+	migrated := &storage.Share{}
+	require.NoError(t, migrated.Decode(obj.Value))
+	require.Equal(t, storageShareFromOldSSZ(oldShare), migrated)
+
+	obj, found, err = opt.Db.Get(opstorage.OperatorStoragePrefix, newKey)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, newShareSSZHex, obj.Value)
+}
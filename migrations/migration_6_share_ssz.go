@@ -0,0 +1,25 @@
+package migrations
+
+//go:generate sszgen -path ./migration_6_share_ssz.go --objs storageShareSSZ
+
+// storageShareSSZ is the SSZ-encoded share as stored before migration_6_add_exit_epoch_to_shares.
+type storageShareSSZ struct {
+	ValidatorIndex        uint64
+	ValidatorPubKey       []byte                `ssz-size:"48"`
+	SharePubKey           []byte                `ssz-max:"48"`
+	Committee             []*storageOperatorSSZ `ssz-max:"13"`
+	Quorum, PartialQuorum uint64
+	DomainType            [4]byte  `ssz-size:"4"`
+	FeeRecipientAddress   [20]byte `ssz-size:"20"`
+	Graffiti              []byte   `ssz-max:"32"`
+
+	Status          uint64
+	ActivationEpoch uint64
+	OwnerAddress    [20]byte `ssz-size:"20"`
+	Liquidated      bool
+}
+
+type storageOperatorSSZ struct {
+	OperatorID uint64
+	PubKey     []byte `ssz-max:"48"`
+}
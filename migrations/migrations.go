@@ -25,6 +25,7 @@ var (
 		migration_3_drop_registry_data,
 		migration_4_configlock_add_alan_fork_to_network_name,
 		migration_5_change_share_format_from_gob_to_ssz,
+		migration_6_add_exit_epoch_to_shares,
 	}
 )
 
@@ -0,0 +1,211 @@
+package crawler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	p2pcommons "github.com/ssvlabs/ssv/network/commons"
+)
+
+// unknownClient is reported for nodes which didn't share the given client
+const unknownClient = "unknown"
+
+// CrawledPeer holds what the crawler learned about a single node,
+// from its ENR (DomainType, ENRSubnets) and from the handshake (NetworkID, versions, Subnets).
+type CrawledPeer struct {
+	PeerID     peer.ID   `json:"peer_id"`
+	ENR        string    `json:"enr"`
+	Addrs      []string  `json:"addrs"`
+	DomainType string    `json:"domain_type"`
+	ENRSubnets string    `json:"enr_subnets"`
+	FirstSeen  time.Time `json:"first_seen"`
+	LastSeen   time.Time `json:"last_seen"`
+
+	LastHandshake  time.Time `json:"last_handshake"`
+	HandshakeError string    `json:"handshake_error,omitempty"`
+	NetworkID      string    `json:"network_id,omitempty"`
+	NodeVersion    string    `json:"node_version,omitempty"`
+	ExecutionNode  string    `json:"execution_node,omitempty"`
+	ConsensusNode  string    `json:"consensus_node,omitempty"`
+	Subnets        string    `json:"subnets,omitempty"`
+
+	// pending is true while the peer is queued for handshake
+	pending bool
+}
+
+// Handshaked returns whether the last handshake with the peer succeeded.
+func (p *CrawledPeer) Handshaked() bool {
+	return !p.LastHandshake.IsZero() && p.HandshakeError == ""
+}
+
+// subnets returns the subnets the peer is subscribed to, preferring the handshake over the ENR.
+func (p *CrawledPeer) subnets() p2pcommons.Subnets {
+	s := p.Subnets
+	if s == "" {
+		s = p.ENRSubnets
+	}
+	if s == "" {
+		return nil
+	}
+	subnets, err := p2pcommons.FromString(s)
+	if err != nil {
+		return nil
+	}
+	return subnets
+}
+
+// Snapshot is the state of the crawled peers at a point in time.
+type Snapshot struct {
+	Time  time.Time     `json:"time"`
+	Peers []CrawledPeer `json:"peers"`
+}
+
+func (s *Snapshot) sort() {
+	sort.Slice(s.Peers, func(i, j int) bool {
+		return s.Peers[i].PeerID < s.Peers[j].PeerID
+	})
+}
+
+// WriteJSON writes the snapshot along with its census as JSON.
+func (s *Snapshot) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		*Snapshot
+		Census *Census `json:"census"`
+	}{s, s.Census()})
+}
+
+var csvHeader = []string{
+	"peer_id", "addrs", "domain_type", "enr_subnets", "first_seen", "last_seen",
+	"last_handshake", "handshake_error", "network_id", "node_version", "execution_node", "consensus_node", "subnets",
+}
+
+// WriteCSV writes the snapshot peers as CSV, one row per peer.
+func (s *Snapshot) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+	for _, p := range s.Peers {
+		err := cw.Write([]string{
+			p.PeerID.String(),
+			strings.Join(p.Addrs, " "),
+			p.DomainType,
+			p.ENRSubnets,
+			formatTime(p.FirstSeen),
+			formatTime(p.LastSeen),
+			formatTime(p.LastHandshake),
+			p.HandshakeError,
+			p.NetworkID,
+			p.NodeVersion,
+			p.ExecutionNode,
+			p.ConsensusNode,
+			p.Subnets,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Census summarizes a snapshot: subnet coverage and client diversity.
+type Census struct {
+	Time       time.Time `json:"time"`
+	Discovered int       `json:"discovered"`
+	Handshaked int       `json:"handshaked"`
+	// SubnetPeers is the number of peers subscribed to each subnet
+	SubnetPeers []int `json:"subnet_peers"`
+	// CoveredSubnets is the number of subnets with at least one peer
+	CoveredSubnets int `json:"covered_subnets"`
+	// DomainTypes, NodeVersions and the clients map names to the number of peers using them,
+	// clients are grouped by name without their version (e.g. "Geth/v1.14.0" is counted as "Geth")
+	DomainTypes      map[string]int `json:"domain_types"`
+	NodeVersions     map[string]int `json:"node_versions"`
+	ExecutionClients map[string]int `json:"execution_clients"`
+	ConsensusClients map[string]int `json:"consensus_clients"`
+}
+
+// Census computes the census of the snapshot.
+func (s *Snapshot) Census() *Census {
+	c := &Census{
+		Time:             s.Time,
+		Discovered:       len(s.Peers),
+		SubnetPeers:      make([]int, p2pcommons.SubnetsCount),
+		DomainTypes:      make(map[string]int),
+		NodeVersions:     make(map[string]int),
+		ExecutionClients: make(map[string]int),
+		ConsensusClients: make(map[string]int),
+	}
+	for i := range s.Peers {
+		p := &s.Peers[i]
+		if p.DomainType != "" {
+			c.DomainTypes[p.DomainType]++
+		}
+		for subnet, active := range p.subnets() {
+			if active > 0 && subnet < len(c.SubnetPeers) {
+				c.SubnetPeers[subnet]++
+			}
+		}
+		if !p.Handshaked() {
+			continue
+		}
+		c.Handshaked++
+		c.NodeVersions[orUnknown(p.NodeVersion)]++
+		c.ExecutionClients[clientName(p.ExecutionNode)]++
+		c.ConsensusClients[clientName(p.ConsensusNode)]++
+	}
+	for _, n := range c.SubnetPeers {
+		if n > 0 {
+			c.CoveredSubnets++
+		}
+	}
+	return c
+}
+
+// WeakestSubnets returns the n subnets with the fewest peers, formatted as "subnet:peers".
+func (c *Census) WeakestSubnets(n int) []string {
+	subnets := make([]int, len(c.SubnetPeers))
+	for i := range subnets {
+		subnets[i] = i
+	}
+	sort.SliceStable(subnets, func(i, j int) bool {
+		return c.SubnetPeers[subnets[i]] < c.SubnetPeers[subnets[j]]
+	})
+	if n > len(subnets) {
+		n = len(subnets)
+	}
+	weakest := make([]string, 0, n)
+	for _, subnet := range subnets[:n] {
+		weakest = append(weakest, strconv.Itoa(subnet)+":"+strconv.Itoa(c.SubnetPeers[subnet]))
+	}
+	return weakest
+}
+
+// clientName returns the client name of a "name/version" string.
+func clientName(client string) string {
+	name, _, _ := strings.Cut(client, "/")
+	return orUnknown(strings.TrimSpace(name))
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return unknownClient
+	}
+	return s
+}
@@ -0,0 +1,98 @@
+package crawler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	p2pcommons "github.com/ssvlabs/ssv/network/commons"
+)
+
+func testPeerID(t *testing.T) peer.ID {
+	sk, err := p2pcommons.GenNetworkKey()
+	require.NoError(t, err)
+	isk, err := p2pcommons.ECDSAPrivToInterface(sk)
+	require.NoError(t, err)
+	pid, err := peer.IDFromPrivateKey(isk)
+	require.NoError(t, err)
+	return pid
+}
+
+func TestSnapshotCensus(t *testing.T) {
+	now := time.Now()
+	snapshot := &Snapshot{
+		Time: now,
+		Peers: []CrawledPeer{
+			{
+				PeerID:        testPeerID(t),
+				DomainType:    "0x00000502",
+				ENRSubnets:    p2pcommons.ZeroSubnets,
+				LastHandshake: now,
+				NodeVersion:   "v2.2.0",
+				ExecutionNode: "Geth/v1.14.0",
+				ConsensusNode: "Lighthouse/v5.3.0",
+				// subnets 0-3 from the handshake take precedence over the ENR
+				Subnets: "0f000000000000000000000000000000",
+			},
+			{
+				PeerID:        testPeerID(t),
+				DomainType:    "0x00000502",
+				ENRSubnets:    "01000000000000000000000000000000",
+				LastHandshake: now,
+				NodeVersion:   "v2.2.0",
+				ExecutionNode: "Nethermind/v1.28.0",
+			},
+			{
+				PeerID:         testPeerID(t),
+				DomainType:     "0x00000401",
+				ENRSubnets:     p2pcommons.AllSubnets,
+				LastHandshake:  now,
+				HandshakeError: "could not connect",
+			},
+		},
+	}
+
+	snapshot.sort()
+	census := snapshot.Census()
+	require.Equal(t, 3, census.Discovered)
+	require.Equal(t, 2, census.Handshaked)
+	require.Equal(t, map[string]int{"0x00000502": 2, "0x00000401": 1}, census.DomainTypes)
+	require.Equal(t, map[string]int{"v2.2.0": 2}, census.NodeVersions)
+	require.Equal(t, map[string]int{"Geth": 1, "Nethermind": 1}, census.ExecutionClients)
+	require.Equal(t, map[string]int{"Lighthouse": 1, unknownClient: 1}, census.ConsensusClients)
+
+	require.Len(t, census.SubnetPeers, p2pcommons.SubnetsCount)
+	require.Equal(t, p2pcommons.SubnetsCount, census.CoveredSubnets)
+	total := 0
+	for _, n := range census.SubnetPeers {
+		total += n
+	}
+	require.Equal(t, 4+1+p2pcommons.SubnetsCount, total)
+	require.Len(t, census.WeakestSubnets(3), 3)
+
+	var jsonOut bytes.Buffer
+	require.NoError(t, snapshot.WriteJSON(&jsonOut))
+	var decoded struct {
+		Peers  []CrawledPeer `json:"peers"`
+		Census Census        `json:"census"`
+	}
+	require.NoError(t, json.Unmarshal(jsonOut.Bytes(), &decoded))
+	require.Len(t, decoded.Peers, 3)
+	require.Equal(t, census.Handshaked, decoded.Census.Handshaked)
+
+	var csvOut bytes.Buffer
+	require.NoError(t, snapshot.WriteCSV(&csvOut))
+	rows, err := csv.NewReader(&csvOut).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+	require.Equal(t, csvHeader, rows[0])
+	for i, p := range snapshot.Peers {
+		require.Equal(t, p.PeerID.String(), rows[i+1][0])
+		require.Equal(t, p.HandshakeError, rows[i+1][7])
+	}
+}
@@ -0,0 +1,351 @@
+package crawler
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	libp2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/host/basic"
+	"github.com/libp2p/go-libp2p/p2p/protocol/identify"
+	"github.com/libp2p/go-libp2p/p2p/security/noise"
+	libp2ptcp "github.com/libp2p/go-libp2p/p2p/transport/tcp"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging/fields"
+	p2pcommons "github.com/ssvlabs/ssv/network/commons"
+	"github.com/ssvlabs/ssv/network/discovery"
+	"github.com/ssvlabs/ssv/network/peers"
+	"github.com/ssvlabs/ssv/network/peers/connections"
+	"github.com/ssvlabs/ssv/network/records"
+	"github.com/ssvlabs/ssv/network/streams"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/utils/commons"
+)
+
+const (
+	// handshakeQueueSize is the amount of discovered peers waiting to be handshaked
+	handshakeQueueSize = 1024
+	// peersIndexPruneTTL is the prune TTL of the peers index, unused by the crawler
+	peersIndexPruneTTL = 10 * time.Minute
+)
+
+// Options configures the crawler
+type Options struct {
+	NetworkConfig networkconfig.NetworkConfig
+	NetworkKey    *ecdsa.PrivateKey
+	// UDPPort and TCPPort are the local ports used for discovery and handshakes
+	UDPPort uint16
+	TCPPort uint16
+	// Bootnodes overrides the network config bootnodes if not empty
+	Bootnodes []string
+	// HandshakeTimeout limits connecting to and handshaking with a single peer
+	HandshakeTimeout time.Duration
+	// Concurrency is the number of parallel handshakes
+	Concurrency int
+	// RecrawlInterval is the time to wait before handshaking with an already crawled peer again
+	RecrawlInterval time.Duration
+}
+
+// crawlSource walks the DHT and reports discovered SSV nodes
+type crawlSource interface {
+	Crawl(logger *zap.Logger, handler discovery.HandleNewPeer)
+	Close() error
+}
+
+// Crawler walks discv5 and handshakes with every SSV node it finds
+// to collect its metadata, so that the network topology and client census can be reported.
+type Crawler struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	logger *zap.Logger
+	opts   Options
+
+	host       host.Host
+	disc       crawlSource
+	handshaker connections.Handshaker
+	nodeInfos  peers.NodeInfoIndex
+
+	queue chan discovery.PeerEvent
+
+	mu    sync.RWMutex
+	peers map[peer.ID]*CrawledPeer
+}
+
+// New creates a new Crawler
+func New(ctx context.Context, logger *zap.Logger, opts Options) (*Crawler, error) {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	c := &Crawler{
+		ctx:    ctx,
+		cancel: cancel,
+		logger: logger,
+		opts:   opts,
+		queue:  make(chan discovery.PeerEvent, handshakeQueueSize),
+		peers:  make(map[peer.ID]*CrawledPeer),
+	}
+
+	if err := c.setupHost(); err != nil {
+		cancel()
+		return nil, err
+	}
+	if err := c.setupDiscovery(); err != nil {
+		cancel()
+		_ = c.host.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *Crawler) setupHost() error {
+	sk, err := p2pcommons.ECDSAPrivToInterface(c.opts.NetworkKey)
+	if err != nil {
+		return errors.Wrap(err, "could not convert to interface priv key")
+	}
+	listenAddr, err := p2pcommons.BuildMultiAddress(net.IPv4zero.String(), "tcp", uint(c.opts.TCPPort), "")
+	if err != nil {
+		return errors.Wrap(err, "could not build listen address")
+	}
+	h, err := libp2p.New(
+		libp2p.Identity(sk),
+		libp2p.Transport(libp2ptcp.NewTCPTransport),
+		libp2p.Security(noise.ID, noise.New),
+		libp2p.ListenAddrs(listenAddr),
+		libp2p.UserAgent(fmt.Sprintf("%s:crawler", commons.GetNodeVersion())),
+	)
+	if err != nil {
+		return errors.Wrap(err, "could not create p2p host")
+	}
+	c.host = h
+
+	domainType := c.opts.NetworkConfig.DomainType
+	self := records.NewNodeInfo("0x" + hex.EncodeToString(domainType[:]))
+	self.Metadata = &records.NodeMetadata{
+		NodeVersion: commons.GetNodeVersion(),
+		Subnets:     p2pcommons.ZeroSubnets,
+	}
+	idx := peers.NewPeersIndex(c.logger, h.Network(), self, func(string) int { return 0 },
+		func() libp2pcrypto.PrivKey { return sk }, p2pcommons.SubnetsCount, peersIndexPruneTTL, peers.NewGossipScoreIndex())
+	c.nodeInfos = idx
+
+	var ids identify.IDService
+	if bh, ok := h.(*basichost.BasicHost); ok {
+		ids = bh.IDService()
+	} else {
+		ids, err = identify.NewIDService(h)
+		if err != nil {
+			return errors.Wrap(err, "could not create ID service")
+		}
+		ids.Start()
+	}
+
+	c.handshaker = connections.NewHandshaker(c.ctx, &connections.HandshakerCfg{
+		Streams:    streams.NewStreamController(c.ctx, h, c.opts.HandshakeTimeout, c.opts.HandshakeTimeout),
+		NodeInfos:  idx,
+		PeerInfos:  idx,
+		ConnIdx:    idx,
+		SubnetsIdx: idx,
+		IDService:  ids,
+		Network:    h.Network(),
+		DomainType: domainType,
+		SubnetsProvider: func() p2pcommons.Subnets {
+			return make(p2pcommons.Subnets, p2pcommons.SubnetsCount)
+		},
+	}, func() []connections.HandshakeFilter {
+		// all nodes are accepted, the census reports their network ID
+		return nil
+	})
+	// respond to handshakes initiated by the crawled nodes
+	h.SetStreamHandler(peers.NodeInfoProtocol, c.handshaker.Handler(c.logger))
+
+	return nil
+}
+
+func (c *Crawler) setupDiscovery() error {
+	ipAddr, err := p2pcommons.IPAddr()
+	if err != nil {
+		return errors.Wrap(err, "could not get ip addr")
+	}
+	bootnodes := c.opts.Bootnodes
+	if len(bootnodes) == 0 {
+		bootnodes = c.opts.NetworkConfig.Bootnodes
+	}
+	disc, err := discovery.NewService(c.ctx, c.logger, discovery.Options{
+		Host: c.host,
+		DiscV5Opts: &discovery.DiscV5Options{
+			IP:         ipAddr.String(),
+			BindIP:     net.IPv4zero.String(),
+			Port:       c.opts.UDPPort,
+			TCPPort:    c.opts.TCPPort,
+			NetworkKey: c.opts.NetworkKey,
+			Bootnodes:  bootnodes,
+		},
+		NetworkConfig: c.opts.NetworkConfig,
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not create discovery service")
+	}
+	source, ok := disc.(crawlSource)
+	if !ok {
+		_ = disc.Close()
+		return errors.New("discovery service doesn't support crawling")
+	}
+	c.disc = source
+	return nil
+}
+
+// Start starts crawling, note that this function blocks until the crawler is closed.
+func (c *Crawler) Start() {
+	var wg sync.WaitGroup
+	for i := 0; i < c.opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-c.ctx.Done():
+					return
+				case e := <-c.queue:
+					c.handshake(e)
+				}
+			}
+		}()
+	}
+
+	c.disc.Crawl(c.logger, c.onDiscovered)
+	wg.Wait()
+}
+
+// Close stops crawling and releases the network resources
+func (c *Crawler) Close() error {
+	c.cancel()
+	var errs []error
+	if err := c.disc.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.host.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("could not close crawler: %v", errs)
+	}
+	return nil
+}
+
+// onDiscovered records the ENR data of a discovered node and queues it for handshake if it's due.
+func (c *Crawler) onDiscovered(e discovery.PeerEvent) {
+	now := time.Now()
+
+	c.mu.Lock()
+	p, ok := c.peers[e.AddrInfo.ID]
+	if !ok {
+		p = &CrawledPeer{PeerID: e.AddrInfo.ID, FirstSeen: now}
+		c.peers[e.AddrInfo.ID] = p
+	}
+	p.LastSeen = now
+	p.ENR = e.Node.String()
+	p.Addrs = p.Addrs[:0]
+	for _, addr := range e.AddrInfo.Addrs {
+		p.Addrs = append(p.Addrs, addr.String())
+	}
+	if domainType, err := records.GetDomainTypeEntry(e.Node.Record(), records.KeyDomainType); err == nil {
+		p.DomainType = "0x" + hex.EncodeToString(domainType[:])
+	}
+	if subnets, err := records.GetSubnetsEntry(e.Node.Record()); err == nil {
+		p.ENRSubnets = p2pcommons.Subnets(subnets).String()
+	}
+	due := !p.pending && (p.LastHandshake.IsZero() || now.Sub(p.LastHandshake) >= c.opts.RecrawlInterval)
+	if due {
+		p.pending = true
+	}
+	c.mu.Unlock()
+
+	if !due {
+		return
+	}
+	select {
+	case c.queue <- e:
+	default:
+		c.logger.Debug("handshake queue is full, skipping peer", fields.PeerID(e.AddrInfo.ID))
+		c.mu.Lock()
+		p.pending = false
+		c.mu.Unlock()
+	}
+}
+
+// handshake connects to the given peer, exchanges node info and disconnects.
+func (c *Crawler) handshake(e discovery.PeerEvent) {
+	pid := e.AddrInfo.ID
+	logger := c.logger.With(fields.PeerID(pid))
+
+	err := func() error {
+		ctx, cancel := context.WithTimeout(c.ctx, c.opts.HandshakeTimeout)
+		defer cancel()
+
+		if err := c.host.Connect(ctx, e.AddrInfo); err != nil {
+			return errors.Wrap(err, "could not connect")
+		}
+		defer func() {
+			_ = c.host.Network().ClosePeer(pid)
+		}()
+
+		conns := c.host.Network().ConnsToPeer(pid)
+		if len(conns) == 0 {
+			return errors.New("no connection")
+		}
+		return c.handshaker.Handshake(logger, conns[0])
+	}()
+
+	nodeInfo := c.nodeInfos.NodeInfo(pid)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	p := c.peers[pid]
+	p.pending = false
+	p.LastHandshake = time.Now()
+	p.HandshakeError = ""
+	if err != nil {
+		p.HandshakeError = err.Error()
+		logger.Debug("could not handshake with crawled peer", zap.Error(err))
+		return
+	}
+	if nodeInfo != nil {
+		p.NetworkID = nodeInfo.NetworkID
+		if nodeInfo.Metadata != nil {
+			p.NodeVersion = nodeInfo.Metadata.NodeVersion
+			p.ExecutionNode = nodeInfo.Metadata.ExecutionNode
+			p.ConsensusNode = nodeInfo.Metadata.ConsensusNode
+			p.Subnets = nodeInfo.Metadata.Subnets
+		}
+	}
+}
+
+// Snapshot returns the current state of the crawled peers
+func (c *Crawler) Snapshot() *Snapshot {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	s := &Snapshot{
+		Time:  time.Now(),
+		Peers: make([]CrawledPeer, 0, len(c.peers)),
+	}
+	for _, p := range c.peers {
+		cp := *p
+		cp.Addrs = append([]string(nil), p.Addrs...)
+		s.Peers = append(s.Peers, cp)
+	}
+	s.sort()
+	return s
+}
@@ -188,6 +188,12 @@ func (dvs *DiscV5Service) Bootstrap(logger *zap.Logger, handler HandleNewPeer) e
 	return nil
 }
 
+// Crawl walks the DHT and calls the handler for every discovered SSV node,
+// without the connection related filters applied by Bootstrap. Note that this function blocks.
+func (dvs *DiscV5Service) Crawl(logger *zap.Logger, handler HandleNewPeer) {
+	dvs.discover(dvs.ctx, handler, defaultDiscoveryInterval, dvs.ssvNodeFilter(logger))
+}
+
 var zeroSubnets, _ = commons.FromString(commons.ZeroSubnets)
 
 func (dvs *DiscV5Service) checkPeer(ctx context.Context, logger *zap.Logger, e PeerEvent) error {
@@ -21,6 +21,7 @@ import (
 	operatordatastore "github.com/ssvlabs/ssv/operator/datastore"
 	"github.com/ssvlabs/ssv/operator/keys"
 	"github.com/ssvlabs/ssv/operator/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
 	uc "github.com/ssvlabs/ssv/utils/commons"
 	"go.uber.org/zap"
 )
@@ -35,7 +36,10 @@ type Config struct {
 	Ctx          context.Context
 	Bootnodes    string   `yaml:"Bootnodes" env:"BOOTNODES" env-description:"Bootnodes to use to start discovery, seperated with ';'" env-default:""`
 	Discovery    string   `yaml:"Discovery" env:"P2P_DISCOVERY" env-description:"Discovery system to use" env-default:"discv5"`
-	TrustedPeers []string `yaml:"TrustedPeers" env:"TRUSTED_PEERS" env-default:"" env-description:"List of peers to connect to."`
+	TrustedPeers []string `yaml:"TrustedPeers" env:"TRUSTED_PEERS" env-default:"" env-description:"List of static peers to connect to, these are reconnected when disconnected and never trimmed." reload:"true"`
+	AllowedPeers []string `yaml:"AllowedPeers" env:"P2P_ALLOWED_PEERS" env-description:"List of peer IDs, IPs or CIDRs that bypass connection limits"`
+	DeniedPeers  []string `yaml:"DeniedPeers" env:"P2P_DENIED_PEERS" env-description:"List of peer IDs, IPs or CIDRs that are never connected"`
+	PersistPeers bool     `yaml:"PersistPeers" env:"P2P_PERSIST_PEERS" env-default:"true" env-description:"Flag to persist known good peers in the DB and reconnect to them on restart"`
 
 	TCPPort     uint16 `yaml:"TcpPort" env:"TCP_PORT" env-default:"13001" env-description:"TCP port for p2p transport"`
 	UDPPort     uint16 `yaml:"UdpPort" env:"UDP_PORT" env-default:"12001" env-description:"UDP port for discovery"`
@@ -45,7 +49,7 @@ type Config struct {
 	RequestTimeout   time.Duration `yaml:"RequestTimeout" env:"P2P_REQUEST_TIMEOUT"  env-default:"10s"`
 	MaxBatchResponse uint64        `yaml:"MaxBatchResponse" env:"P2P_MAX_BATCH_RESPONSE" env-default:"25" env-description:"Maximum number of returned objects in a batch"`
 
-	MaxPeers             int  `yaml:"MaxPeers" env:"P2P_MAX_PEERS" env-default:"60" env-description:"Connected peers limit. At the time being, this may be increased by DynamicMaxPeers until that is phased out."`
+	MaxPeers             int  `yaml:"MaxPeers" env:"P2P_MAX_PEERS" env-default:"60" env-description:"Connected peers limit. At the time being, this may be increased by DynamicMaxPeers until that is phased out." reload:"true"`
 	DynamicMaxPeers      bool `yaml:"DynamicMaxPeers" env:"P2P_DYNAMIC_MAX_PEERS" env-default:"true" env-description:"If true, MaxPeers will grow with the operator's number of committees."`
 	DynamicMaxPeersLimit int  `yaml:"DynamicMaxPeersLimit" env:"P2P_DYNAMIC_MAX_PEERS_LIMIT" env-default:"150" env-description:"Limit for MaxPeers when DynamicMaxPeers is enabled."`
 	TopicMaxPeers        int  `yaml:"TopicMaxPeers" env:"P2P_TOPIC_MAX_PEERS" env-default:"10" env-description:"Connected peers limit per pubsub topic"`
@@ -54,10 +58,23 @@ type Config struct {
 	Subnets string `yaml:"Subnets" env:"SUBNETS" env-description:"Hex string that represents the subnets that this node will join upon start"`
 	// PubSubScoring is a flag to turn on/off pubsub scoring
 	PubSubScoring bool `yaml:"PubSubScoring" env:"PUBSUB_SCORING" env-default:"true" env-description:"Flag to turn on/off pubsub scoring"`
+	// PubSubScoreOverrides is a YAML file of topic score params overrides, reloaded on every score params update
+	PubSubScoreOverrides string `yaml:"PubSubScoreOverrides" env:"PUBSUB_SCORE_OVERRIDES" env-description:"YAML file of topic score params overrides, reloaded once per epoch when score params are updated"`
 	// PubSubTrace is a flag to turn on/off pubsub tracing in logs
 	PubSubTrace bool `yaml:"PubSubTrace" env:"PUBSUB_TRACE" env-description:"Flag to turn on/off pubsub tracing in logs"`
+	// PubSubTraceFile is a file to write pubsub trace events into, disabled if empty
+	PubSubTraceFile        string   `yaml:"PubSubTraceFile" env:"PUBSUB_TRACE_FILE" env-description:"File path to write pubsub trace events into, disabled if empty"`
+	PubSubTraceFormat      string   `yaml:"PubSubTraceFormat" env:"PUBSUB_TRACE_FORMAT" env-default:"pb" env-description:"Format of the pubsub trace file: pb (length-delimited protobuf) or json"`
+	PubSubTraceSampleRate  float64  `yaml:"PubSubTraceSampleRate" env:"PUBSUB_TRACE_SAMPLE_RATE" env-default:"1" env-description:"Fraction of messages to write into the pubsub trace file, in (0, 1]"`
+	PubSubTraceTopics      []string `yaml:"PubSubTraceTopics" env:"PUBSUB_TRACE_TOPICS" env-description:"Topics to write into the pubsub trace file, all topics if empty"`
+	PubSubTraceFileSize    int      `yaml:"PubSubTraceFileSize" env:"PUBSUB_TRACE_FILE_SIZE" env-default:"100" env-description:"File size in megabytes to rotate the pubsub trace file"`
+	PubSubTraceFileBackups int      `yaml:"PubSubTraceFileBackups" env:"PUBSUB_TRACE_FILE_BACKUPS" env-default:"10" env-description:"Number of rotated pubsub trace files to keep"`
 	// DiscoveryTrace is a flag to turn on/off discovery tracing in logs
 	DiscoveryTrace bool `yaml:"DiscoveryTrace" env:"DISCOVERY_TRACE" env-description:"Flag to turn on/off discovery tracing in logs"`
+	// MessageRecordPath is a file to record incoming pubsub messages into, recording is disabled if empty
+	MessageRecordPath        string `yaml:"MessageRecordPath" env:"P2P_MESSAGE_RECORD_PATH" env-description:"File path to record incoming pubsub messages into for offline replay through message validation, disabled if empty"`
+	MessageRecordFileSize    int    `yaml:"MessageRecordFileSize" env:"P2P_MESSAGE_RECORD_FILE_SIZE" env-default:"100" env-description:"File size in megabytes to rotate the message record file"`
+	MessageRecordFileBackups int    `yaml:"MessageRecordFileBackups" env:"P2P_MESSAGE_RECORD_FILE_BACKUPS" env-default:"10" env-description:"Number of rotated message record files to keep"`
 	// NetworkPrivateKey is used for network identity, MUST be injected
 	NetworkPrivateKey *ecdsa.PrivateKey
 	// OperatorSigner is used for signing with operator private key, MUST be injected
@@ -72,6 +89,8 @@ type Config struct {
 	UserAgent string
 	// NodeStorage is used to get operator metadata.
 	NodeStorage storage.Storage
+	// DB is used to persist known peers, optional
+	DB basedb.Database
 	// Network defines a network configuration.
 	Network networkconfig.NetworkConfig
 	// MessageValidator validates incoming messages.
@@ -90,6 +109,10 @@ type Config struct {
 
 	GetValidatorStats network.GetValidatorStats
 
+	// ExecutionNodeVersion and ConsensusNodeVersion are the versions of the Ethereum nodes, shared in the node metadata.
+	ExecutionNodeVersion string
+	ConsensusNodeVersion string
+
 	// PeerScoreInspector is called periodically to inspect the peer scores.
 	PeerScoreInspector func(peerMap map[peer.ID]*pubsub.PeerScoreSnapshot)
 
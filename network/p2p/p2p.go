@@ -8,6 +8,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -58,6 +59,8 @@ const (
 	peersReportingInterval          = 60 * time.Second
 	peerIdentitiesReportingInterval = 5 * time.Minute
 	topicsReportingInterval         = 60 * time.Second
+	staticPeersReconnectInterval    = 60 * time.Second
+	knownPeersSavingInterval        = 5 * time.Minute
 )
 
 // PeersIndexProvider holds peers index instance
@@ -88,9 +91,18 @@ type p2pNetwork struct {
 	msgRouter    network.MessageRouter
 	msgResolver  topics.MsgPeersResolver
 	msgValidator validation.MessageValidator
+	msgRecorder  *MessageRecorder
+	traceFile    topics.TraceCloser
 	connHandler  connections.ConnHandler
 	connGater    connmgr.ConnectionGater
-	trustedPeers []*peer.AddrInfo
+	accessList   *connections.AccessList
+	knownPeers   *peers.KnownPeersStore
+
+	// trustedPeers can be replaced at runtime by SetTrustedPeers.
+	trustedPeers   []*peer.AddrInfo
+	trustedPeersMu sync.RWMutex
+	// maxPeersOverride overrides cfg.MaxPeers if set at runtime by SetMaxPeers.
+	maxPeersOverride atomic.Int64
 
 	state int32
 
@@ -144,31 +156,42 @@ func New(
 		discoveredPeersPool:     ttl.New[peer.ID, discovery.DiscoveredPeer](30*time.Minute, 3*time.Minute),
 		trimmedRecently:         ttl.New[peer.ID, struct{}](30*time.Minute, 3*time.Minute),
 	}
-	if err := n.parseTrustedPeers(); err != nil {
+	trustedPeers, err := parseTrustedPeers(cfg.TrustedPeers)
+	if err != nil {
 		return nil, err
 	}
+	n.trustedPeers = trustedPeers
+	accessList, err := connections.NewAccessList(cfg.AllowedPeers, cfg.DeniedPeers)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse peers access list: %w", err)
+	}
+	n.accessList = accessList
+	if cfg.PersistPeers && cfg.DB != nil {
+		n.knownPeers = peers.NewKnownPeersStore(cfg.DB)
+	}
 	return n, nil
 }
 
-func (n *p2pNetwork) parseTrustedPeers() error {
-	if len(n.cfg.TrustedPeers) == 0 {
-		return nil // No trusted peers to parse, return early
+func parseTrustedPeers(addrs []string) ([]*peer.AddrInfo, error) {
+	if len(addrs) == 0 {
+		return nil, nil // No trusted peers to parse, return early
 	}
 	// Group addresses by peer ID.
 	trustedPeers := map[peer.ID][]ma.Multiaddr{}
-	for _, mas := range n.cfg.TrustedPeers {
+	for _, mas := range addrs {
 		for _, ma := range strings.Split(mas, ",") {
 			addrInfo, err := peer.AddrInfoFromString(ma)
 			if err != nil {
-				return fmt.Errorf("could not parse trusted peer: %w", err)
+				return nil, fmt.Errorf("could not parse trusted peer: %w", err)
 			}
 			trustedPeers[addrInfo.ID] = append(trustedPeers[addrInfo.ID], addrInfo.Addrs...)
 		}
 	}
+	parsed := make([]*peer.AddrInfo, 0, len(trustedPeers))
 	for id, addrs := range trustedPeers {
-		n.trustedPeers = append(n.trustedPeers, &peer.AddrInfo{ID: id, Addrs: addrs})
+		parsed = append(parsed, &peer.AddrInfo{ID: id, Addrs: addrs})
 	}
-	return nil
+	return parsed, nil
 }
 
 // Host implements HostProvider
@@ -220,9 +243,20 @@ func (n *p2pNetwork) Close() error {
 	if err := n.idx.Close(); err != nil {
 		n.logger.Warn("could not close index", zap.Error(err))
 	}
+	n.saveKnownPeers(n.logger)()
 	if err := n.topicsCtrl.Close(); err != nil {
 		n.logger.Warn("could not close topics controller", zap.Error(err))
 	}
+	if n.msgRecorder != nil {
+		if err := n.msgRecorder.Close(); err != nil {
+			n.logger.Warn("could not close message recorder", zap.Error(err))
+		}
+	}
+	if n.traceFile != nil {
+		if err := n.traceFile.Close(); err != nil {
+			n.logger.Warn("could not close pubsub trace file", zap.Error(err))
+		}
+	}
 	return n.host.Close()
 }
 
@@ -237,11 +271,18 @@ func (n *p2pNetwork) getConnector() (chan peer.AddrInfo, error) {
 		n.backoffConnector.Connect(ctx, connector)
 	}()
 
-	// Connect to trusted peers first.
+	// Connect to trusted peers first, then to the peers we've known before restart.
+	knownPeers := n.loadKnownPeers(n.logger)
 	go func() {
-		for _, addrInfo := range n.trustedPeers {
+		for _, addrInfo := range n.getTrustedPeers() {
 			connector <- *addrInfo
 		}
+		for _, addrInfo := range knownPeers {
+			if n.accessList.IsDenied(addrInfo.ID, nil) {
+				continue
+			}
+			connector <- addrInfo
+		}
 	}()
 
 	return connector, nil
@@ -269,7 +310,7 @@ func (n *p2pNetwork) Start(logger *zap.Logger) error {
 	}
 	logger.Info("starting p2p",
 		zap.String("my_address", strings.Join(maStrs, ",")),
-		zap.Int("trusted_peers", len(n.trustedPeers)),
+		zap.Int("trusted_peers", len(n.getTrustedPeers())),
 	)
 
 	err = n.startDiscovery(logger)
@@ -285,6 +326,10 @@ func (n *p2pNetwork) Start(logger *zap.Logger) error {
 
 	async.Interval(n.ctx, topicsReportingInterval, recordPeerCountPerTopic(n.ctx, logger, n.topicsCtrl, 2))
 
+	async.Interval(n.ctx, staticPeersReconnectInterval, n.reconnectStaticPeers(logger))
+
+	async.Interval(n.ctx, knownPeersSavingInterval, n.saveKnownPeers(logger))
+
 	if err := n.subscribeToFixedSubnets(logger); err != nil {
 		return err
 	}
@@ -305,6 +350,11 @@ func (n *p2pNetwork) peersTrimming(logger *zap.Logger) func() {
 			_ = n.idx.GetSubnetsStats() // collect metrics
 		}()
 
+		if n.DisconnectDeniedPeers() > 0 {
+			// we can accept more peer connections now, no need to trim
+			return
+		}
+
 		connMgr := peers.NewConnManager(logger, n.libConnManager, n.idx, n.idx, n.trimmedRecently)
 
 		disconnectedCnt := connMgr.DisconnectFromBadPeers(logger, n.host.Network(), n.host.Network().Peers())
@@ -341,7 +391,7 @@ func (n *p2pNetwork) peersTrimming(logger *zap.Logger) func() {
 		// enough to it - this ensures we don't skip trim iteration because of "random fluctuations"
 		// in currently connected peer count at that limit boundary
 		connectedPeers = n.host.Network().Peers()
-		if len(connectedPeers) <= n.cfg.MaxPeers-maxPeersToDrop {
+		if len(connectedPeers) <= n.maxPeers()-maxPeersToDrop {
 			// we probably don't want to trim then
 
 			// additionally, make sure incoming connections aren't at the limit - since if they are we
@@ -618,7 +668,7 @@ func (n *p2pNetwork) UpdateScoreParams(logger *zap.Logger) {
 // getMaxPeers returns max peers of the given topic.
 func (n *p2pNetwork) getMaxPeers(topic string) int {
 	if len(topic) == 0 {
-		return n.cfg.MaxPeers
+		return n.maxPeers()
 	}
 	return n.cfg.TopicMaxPeers
 }
@@ -102,13 +102,13 @@ func (n *p2pNetwork) startDiscovery(logger *zap.Logger) error {
 
 		// Avoid connecting to more peers if we're already at the limit.
 		inbound, outbound := n.connectionStats()
-		vacantOutboundSlots := n.cfg.MaxPeers - (inbound + outbound)
+		vacantOutboundSlots := n.maxPeers() - (inbound + outbound)
 		if vacantOutboundSlots <= 0 {
 			n.logger.Debug(
 				"no vacant outbound slots, skipping peer selection",
 				zap.Int("inbound_peers", inbound),
 				zap.Int("outbound_peers", outbound),
-				zap.Int("max_peers", n.cfg.MaxPeers),
+				zap.Int("max_peers", n.maxPeers()),
 			)
 			return
 		}
@@ -0,0 +1,178 @@
+package p2pv1
+
+import (
+	"context"
+	"slices"
+
+	p2pnet "github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/network/peers"
+	"github.com/ssvlabs/ssv/network/peers/connections"
+	"github.com/ssvlabs/ssv/network/topics"
+)
+
+// AccessList returns the peers access list, it can be changed at runtime.
+func (n *p2pNetwork) AccessList() *connections.AccessList {
+	return n.accessList
+}
+
+// PeerScore returns the gossipsub score breakdown of the given peer, or false if it's unknown.
+func (n *p2pNetwork) PeerScore(pid peer.ID) (*topics.PeerScoreBreakdown, bool) {
+	if n.topicsCtrl == nil {
+		return nil, false
+	}
+	return n.topicsCtrl.PeerScore(pid)
+}
+
+// DisconnectDeniedPeers closes the connections to peers that are denied by the access list,
+// it returns the number of disconnected peers.
+func (n *p2pNetwork) DisconnectDeniedPeers() int {
+	disconnected := 0
+	for _, pid := range n.host.Network().Peers() {
+		denied := n.accessList.IsDenied(pid, nil)
+		for _, conn := range n.host.Network().ConnsToPeer(pid) {
+			if denied {
+				break
+			}
+			denied = n.accessList.IsDenied("", connections.RemoteIP(conn))
+		}
+		if !denied {
+			continue
+		}
+		if err := n.host.Network().ClosePeer(pid); err != nil {
+			n.logger.Debug("could not disconnect from denied peer", fields.PeerID(pid), zap.Error(err))
+			continue
+		}
+		n.logger.Debug("disconnected from denied peer", fields.PeerID(pid))
+		disconnected++
+	}
+	return disconnected
+}
+
+// SetTrustedPeers replaces the trusted peers at runtime. The new ones are protected and connected to,
+// and the removed ones lose their protection from trimming but aren't disconnected.
+func (n *p2pNetwork) SetTrustedPeers(addrs []string) error {
+	trustedPeers, err := parseTrustedPeers(addrs)
+	if err != nil {
+		return err
+	}
+
+	n.trustedPeersMu.Lock()
+	previous := n.trustedPeers
+	n.trustedPeers = trustedPeers
+	n.trustedPeersMu.Unlock()
+
+	if n.libConnManager == nil {
+		return nil
+	}
+	for _, addrInfo := range previous {
+		n.libConnManager.Unprotect(addrInfo.ID, peers.StaticTag)
+	}
+	n.protectStaticPeers()
+	go n.reconnectStaticPeers(n.logger)()
+	return nil
+}
+
+// SetMaxPeers changes the connected peers limit at runtime.
+func (n *p2pNetwork) SetMaxPeers(maxPeers int) {
+	n.maxPeersOverride.Store(int64(maxPeers))
+}
+
+func (n *p2pNetwork) maxPeers() int {
+	if maxPeers := n.maxPeersOverride.Load(); maxPeers > 0 {
+		return int(maxPeers)
+	}
+	return n.cfg.MaxPeers
+}
+
+func (n *p2pNetwork) getTrustedPeers() []*peer.AddrInfo {
+	n.trustedPeersMu.RLock()
+	defer n.trustedPeersMu.RUnlock()
+	return n.trustedPeers
+}
+
+// protectStaticPeers protects the trusted peers from being trimmed.
+func (n *p2pNetwork) protectStaticPeers() {
+	for _, addrInfo := range n.getTrustedPeers() {
+		n.libConnManager.Protect(addrInfo.ID, peers.StaticTag)
+	}
+}
+
+// reconnectStaticPeers returns a function that connects to trusted peers we are not connected to.
+func (n *p2pNetwork) reconnectStaticPeers(logger *zap.Logger) func() {
+	return func() {
+		for _, addrInfo := range n.getTrustedPeers() {
+			if n.host.Network().Connectedness(addrInfo.ID) == p2pnet.Connected {
+				continue
+			}
+			ctx, cancel := context.WithTimeout(n.ctx, connectTimeout)
+			err := n.host.Connect(ctx, *addrInfo)
+			cancel()
+			if err != nil {
+				logger.Debug("could not reconnect to static peer", fields.PeerID(addrInfo.ID), zap.Error(err))
+				continue
+			}
+			logger.Debug("reconnected to static peer", fields.PeerID(addrInfo.ID))
+		}
+	}
+}
+
+// loadKnownPeers returns the peers persisted by saveKnownPeers.
+func (n *p2pNetwork) loadKnownPeers(logger *zap.Logger) []peer.AddrInfo {
+	if n.knownPeers == nil {
+		return nil
+	}
+	knownPeers, err := n.knownPeers.Load()
+	if err != nil {
+		logger.Warn("could not load known peers", zap.Error(err))
+		return nil
+	}
+	logger.Debug("loaded known peers", zap.Int("count", len(knownPeers)))
+	return knownPeers
+}
+
+// saveKnownPeers persists the best connected peers up to MaxPeers, so that we can reconnect to them on restart.
+func (n *p2pNetwork) saveKnownPeers(logger *zap.Logger) func() {
+	return func() {
+		if n.knownPeers == nil {
+			return
+		}
+		var candidates []peer.ID
+		for _, pid := range n.host.Network().Peers() {
+			if n.IsBadPeer(logger, pid) || n.accessList.IsDenied(pid, nil) {
+				continue
+			}
+			candidates = append(candidates, pid)
+		}
+		scores := make(map[peer.ID]float64, len(candidates))
+		for _, pid := range candidates {
+			scores[pid] = n.peerScore(pid)
+		}
+		slices.SortFunc(candidates, func(a, b peer.ID) int {
+			// sort in desc order (peers with the highest scores come first)
+			if scores[a] < scores[b] {
+				return 1
+			}
+			if scores[a] > scores[b] {
+				return -1
+			}
+			return 0
+		})
+		if maxPeers := n.maxPeers(); len(candidates) > maxPeers {
+			candidates = candidates[:maxPeers]
+		}
+
+		knownPeers := make([]peer.AddrInfo, 0, len(candidates))
+		for _, pid := range candidates {
+			knownPeers = append(knownPeers, n.host.Peerstore().PeerInfo(pid))
+		}
+		if err := n.knownPeers.Save(knownPeers); err != nil {
+			logger.Warn("could not save known peers", zap.Error(err))
+			return
+		}
+		logger.Debug("saved known peers", zap.Int("count", len(knownPeers)))
+	}
+}
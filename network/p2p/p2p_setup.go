@@ -139,6 +139,7 @@ func (n *p2pNetwork) SetupHost(logger *zap.Logger) error {
 		n.IsBadPeer,
 		n.atInboundLimit,
 		n.trimmedRecently,
+		n.accessList,
 	)
 	opts = append(opts, libp2p.ResourceManager(rmgr), libp2p.ConnectionGater(n.connGater))
 	host, err := libp2p.New(opts...)
@@ -147,6 +148,7 @@ func (n *p2pNetwork) SetupHost(logger *zap.Logger) error {
 	}
 	n.host = host
 	n.libConnManager = host.ConnManager()
+	n.protectStaticPeers()
 
 	backoffFactory := libp2pdiscbackoff.NewExponentialDecorrelatedJitter(backoffLow, backoffHigh, backoffExponentBase, rand.NewSource(0))
 	backoffConnector, err := libp2pdiscbackoff.NewBackoffConnector(host, backoffConnectorCacheSize, connectTimeout, backoffFactory)
@@ -192,8 +194,10 @@ func (n *p2pNetwork) setupPeerServices(logger *zap.Logger) error {
 	domain := "0x" + hex.EncodeToString(d[:])
 	self := records.NewNodeInfo(domain)
 	self.Metadata = &records.NodeMetadata{
-		NodeVersion: commons.GetNodeVersion(),
-		Subnets:     p2pcommons.Subnets(n.fixedSubnets).String(),
+		NodeVersion:   commons.GetNodeVersion(),
+		ExecutionNode: n.cfg.ExecutionNodeVersion,
+		ConsensusNode: n.cfg.ConsensusNodeVersion,
+		Subnets:       p2pcommons.Subnets(n.fixedSubnets).String(),
 	}
 	getPrivKey := func() crypto.PrivKey {
 		return libPrivKey
@@ -318,6 +322,7 @@ func (n *p2pNetwork) setupPubsub(logger *zap.Logger) (topics.Controller, error)
 		MsgIDCacheTTL:       n.cfg.PubsubMsgCacheTTL,
 		DisableIPRateLimit:  n.cfg.DisableIPRateLimit,
 		GetValidatorStats:   n.cfg.GetValidatorStats,
+		ScoreOverridesPath:  n.cfg.PubSubScoreOverrides,
 	}
 
 	if n.cfg.PeerScoreInspector != nil && n.cfg.PeerScoreInspectorInterval > 0 {
@@ -329,6 +334,29 @@ func (n *p2pNetwork) setupPubsub(logger *zap.Logger) (topics.Controller, error)
 		cfg.ScoreIndex = nil
 	}
 
+	if n.cfg.MessageRecordPath != "" && n.msgValidator != nil {
+		n.msgRecorder = NewRotatingMessageRecorder(n.cfg.MessageRecordPath, n.cfg.MessageRecordFileSize, n.cfg.MessageRecordFileBackups)
+		cfg.MsgValidator = newRecordingValidator(logger, n.msgValidator, n.msgRecorder)
+		logger.Info("recording incoming pubsub messages", zap.String("path", n.cfg.MessageRecordPath))
+	}
+
+	if n.cfg.PubSubTraceFile != "" {
+		tracer, err := topics.NewFileTracer(logger, topics.TraceFileConfig{
+			FileName:   n.cfg.PubSubTraceFile,
+			MaxSize:    n.cfg.PubSubTraceFileSize,
+			MaxBackups: n.cfg.PubSubTraceFileBackups,
+			Format:     n.cfg.PubSubTraceFormat,
+			SampleRate: n.cfg.PubSubTraceSampleRate,
+			Topics:     n.cfg.PubSubTraceTopics,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create pubsub file tracer")
+		}
+		n.traceFile = tracer
+		cfg.Tracer = tracer
+		logger.Info("writing pubsub trace events", zap.String("path", n.cfg.PubSubTraceFile))
+	}
+
 	midHandler := topics.NewMsgIDHandler(n.ctx, n.cfg.Network, time.Minute*2)
 	n.msgResolver = midHandler
 	cfg.MsgIDHandler = midHandler
@@ -361,7 +389,7 @@ func (n *p2pNetwork) atInboundLimit() bool {
 			"Preventing inbound connections due to reaching inbound limit",
 			zap.Int("inbound", in),
 			zap.Int("inbound_limit", inboundLimit),
-			zap.Int("max_peers", n.cfg.MaxPeers),
+			zap.Int("max_peers", n.maxPeers()),
 		)
 		return true
 	}
@@ -370,7 +398,7 @@ func (n *p2pNetwork) atInboundLimit() bool {
 }
 
 func (n *p2pNetwork) inboundLimit() int {
-	return int(float64(n.cfg.MaxPeers) * inboundLimitRatio)
+	return int(float64(n.maxPeers()) * inboundLimitRatio)
 }
 
 func (n *p2pNetwork) connectionStats() (inbound, outbound int) {
@@ -0,0 +1,149 @@
+package p2pv1
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pspb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"go.uber.org/zap"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/ssvlabs/ssv/message/validation"
+)
+
+// MessageRecord is a single pubsub message as it was received from a peer,
+// before it was passed to message validation.
+type MessageRecord struct {
+	ReceivedAt time.Time `json:"received_at"`
+	Topic      string    `json:"topic"`
+	Peer       string    `json:"peer"`
+	// Data is the encoded SignedSSVMessage.
+	Data []byte `json:"data"`
+}
+
+// PeerID decodes the peer that has sent the recorded message.
+func (r *MessageRecord) PeerID() (peer.ID, error) {
+	return peer.Decode(r.Peer)
+}
+
+// PubsubMessage rebuilds the pubsub message the way it was handed to the topic validator.
+func (r *MessageRecord) PubsubMessage() (*pubsub.Message, error) {
+	from, err := r.PeerID()
+	if err != nil {
+		return nil, fmt.Errorf("could not decode peer id: %w", err)
+	}
+	topic := r.Topic
+	return &pubsub.Message{
+		Message: &pspb.Message{
+			Data:  r.Data,
+			Topic: &topic,
+		},
+		ReceivedFrom: from,
+	}, nil
+}
+
+// MessageRecorder writes message records as JSON lines into the underlying writer.
+type MessageRecorder struct {
+	mu  sync.Mutex
+	w   io.WriteCloser
+	enc *json.Encoder
+}
+
+// NewMessageRecorder creates a MessageRecorder writing into w.
+func NewMessageRecorder(w io.WriteCloser) *MessageRecorder {
+	return &MessageRecorder{
+		w:   w,
+		enc: json.NewEncoder(w),
+	}
+}
+
+// NewRotatingMessageRecorder creates a MessageRecorder writing into the given file,
+// which is rotated once it reaches maxSize megabytes.
+func NewRotatingMessageRecorder(fileName string, maxSize, maxBackups int) *MessageRecorder {
+	return NewMessageRecorder(&lumberjack.Logger{
+		Filename:   fileName,
+		MaxSize:    maxSize, // megabytes
+		MaxBackups: maxBackups,
+		Compress:   false,
+	})
+}
+
+// Record appends the given record.
+func (r *MessageRecorder) Record(record *MessageRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.enc.Encode(record)
+}
+
+// Close implements io.Closer
+func (r *MessageRecorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.w.Close()
+}
+
+// MessageRecordReader reads message records written by MessageRecorder.
+type MessageRecordReader struct {
+	dec *json.Decoder
+}
+
+// NewMessageRecordReader creates a MessageRecordReader reading from rd.
+func NewMessageRecordReader(rd io.Reader) *MessageRecordReader {
+	return &MessageRecordReader{
+		dec: json.NewDecoder(bufio.NewReader(rd)),
+	}
+}
+
+// Next returns the next record, or io.EOF when there are no more records.
+func (r *MessageRecordReader) Next() (*MessageRecord, error) {
+	record := &MessageRecord{}
+	if err := r.dec.Decode(record); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("could not decode message record: %w", err)
+	}
+	return record, nil
+}
+
+// recordingValidator records every message before passing it to the wrapped validator.
+type recordingValidator struct {
+	validation.MessageValidator
+	logger   *zap.Logger
+	recorder *MessageRecorder
+}
+
+func newRecordingValidator(logger *zap.Logger, mv validation.MessageValidator, recorder *MessageRecorder) *recordingValidator {
+	return &recordingValidator{
+		MessageValidator: mv,
+		logger:           logger,
+		recorder:         recorder,
+	}
+}
+
+// ValidatorForTopic returns a validation function for the given topic that records incoming messages.
+func (v *recordingValidator) ValidatorForTopic(topic string) func(ctx context.Context, p peer.ID, pmsg *pubsub.Message) pubsub.ValidationResult {
+	validate := v.MessageValidator.ValidatorForTopic(topic)
+	return func(ctx context.Context, p peer.ID, pmsg *pubsub.Message) pubsub.ValidationResult {
+		err := v.recorder.Record(&MessageRecord{
+			ReceivedAt: time.Now(),
+			Topic:      pmsg.GetTopic(),
+			Peer:       p.String(),
+			Data:       pmsg.GetData(),
+		})
+		if err != nil {
+			v.logger.Debug("could not record message", zap.Error(err))
+		}
+		return validate(ctx, p, pmsg)
+	}
+}
@@ -0,0 +1,79 @@
+package p2pv1
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pspb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/network/commons"
+)
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+type acceptingValidator struct {
+	validated int
+}
+
+func (v *acceptingValidator) ValidatorForTopic(_ string) func(ctx context.Context, p peer.ID, pmsg *pubsub.Message) pubsub.ValidationResult {
+	return v.Validate
+}
+
+func (v *acceptingValidator) Validate(context.Context, peer.ID, *pubsub.Message) pubsub.ValidationResult {
+	v.validated++
+	return pubsub.ValidationAccept
+}
+
+func TestMessageRecorder(t *testing.T) {
+	sk, err := commons.GenNetworkKey()
+	require.NoError(t, err)
+	isk, err := commons.ECDSAPrivToInterface(sk)
+	require.NoError(t, err)
+	pid, err := peer.IDFromPrivateKey(isk)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	recorder := NewMessageRecorder(nopWriteCloser{&buf})
+	mv := &acceptingValidator{}
+	validate := newRecordingValidator(zap.NewNop(), mv, recorder).ValidatorForTopic("ssv.v2.1")
+
+	topic := "ssv.v2.1"
+	payloads := [][]byte{{1, 2, 3}, {4, 5, 6}}
+	before := time.Now()
+	for _, data := range payloads {
+		res := validate(context.Background(), pid, &pubsub.Message{
+			Message: &pspb.Message{Data: data, Topic: &topic},
+		})
+		require.Equal(t, pubsub.ValidationAccept, res)
+	}
+	require.Equal(t, len(payloads), mv.validated)
+	require.NoError(t, recorder.Close())
+
+	reader := NewMessageRecordReader(&buf)
+	for _, data := range payloads {
+		record, err := reader.Next()
+		require.NoError(t, err)
+		require.Equal(t, topic, record.Topic)
+		require.Equal(t, data, record.Data)
+		require.False(t, record.ReceivedAt.Before(before))
+
+		pmsg, err := record.PubsubMessage()
+		require.NoError(t, err)
+		require.Equal(t, pid, pmsg.ReceivedFrom)
+		require.Equal(t, topic, pmsg.GetTopic())
+		require.Equal(t, data, pmsg.GetData())
+	}
+	_, err = reader.Next()
+	require.ErrorIs(t, err, io.EOF)
+}
@@ -13,6 +13,8 @@ import (
 
 const (
 	ProtectedTag = "ssv/subnets"
+	// StaticTag protects operator-configured static peers, these are never trimmed or disconnected.
+	StaticTag = "ssv/static"
 )
 
 // ConnManager is a wrapper on top of go-libp2p/core/connmgr.ConnManager.
@@ -59,13 +61,18 @@ func (c connManager) disconnect(peerID peer.ID, net libp2pnetwork.Network) error
 	return net.ClosePeer(peerID)
 }
 
+// isStatic returns whether the peer is a static peer.
+func (c connManager) isStatic(peerID peer.ID) bool {
+	return c.connManager.IsProtected(peerID, StaticTag)
+}
+
 // TrimPeers closes the connection to all peers that are not protected, dropping up to maxTrims peers.
 func (c connManager) TrimPeers(ctx context.Context, logger *zap.Logger, net libp2pnetwork.Network, maxTrims int) {
 	allPeers := net.Peers()
 	before := len(allPeers)
 	trimmed := make([]peer.ID, 0)
 	for _, pid := range allPeers {
-		if !c.connManager.IsProtected(pid, ProtectedTag) {
+		if !c.connManager.IsProtected(pid, ProtectedTag) && !c.isStatic(pid) {
 			if err := c.disconnect(pid, net); err != nil {
 				logger.Debug("error closing peer", fields.PeerID(pid), zap.Error(err))
 			}
@@ -84,6 +91,9 @@ func (c connManager) TrimPeers(ctx context.Context, logger *zap.Logger, net libp
 func (c connManager) DisconnectFromBadPeers(logger *zap.Logger, net libp2pnetwork.Network, allPeers []peer.ID) int {
 	disconnectedPeers := 0
 	for _, peerID := range allPeers {
+		if c.isStatic(peerID) {
+			continue
+		}
 		// Disconnect if peer has bad gossip score.
 		if isBad, gossipScore := c.gossipScoreIndex.HasBadGossipScore(peerID); isBad {
 			err := c.disconnect(peerID, net)
@@ -103,6 +113,9 @@ func (c connManager) DisconnectFromBadPeers(logger *zap.Logger, net libp2pnetwor
 func (c connManager) DisconnectFromIrrelevantPeers(logger *zap.Logger, disconnectQuota int, net libp2pnetwork.Network, allPeers []peer.ID, mySubnets commons.Subnets) int {
 	disconnectedPeers := 0
 	for _, peerID := range allPeers {
+		if c.isStatic(peerID) {
+			continue
+		}
 		peerSubnets := c.subnetsIdx.GetPeerSubnets(peerID)
 		sharedSubnets := commons.SharedSubnets(mySubnets, peerSubnets, 0)
 
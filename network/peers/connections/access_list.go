@@ -0,0 +1,213 @@
+package connections
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+
+	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+// accessRule matches peers either by peer ID or by IP range.
+type accessRule struct {
+	peerID peer.ID
+	ipNet  *net.IPNet
+}
+
+func parseAccessRule(rule string) (accessRule, error) {
+	rule = strings.TrimSpace(rule)
+	if rule == "" {
+		return accessRule{}, fmt.Errorf("empty rule")
+	}
+	if strings.Contains(rule, "/") {
+		_, ipNet, err := net.ParseCIDR(rule)
+		if err != nil {
+			return accessRule{}, fmt.Errorf("invalid CIDR %q: %w", rule, err)
+		}
+		return accessRule{ipNet: ipNet}, nil
+	}
+	if ip := net.ParseIP(rule); ip != nil {
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip = ip4
+			bits = 8 * net.IPv4len
+		}
+		return accessRule{ipNet: &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}}, nil
+	}
+	id, err := peer.Decode(rule)
+	if err != nil {
+		return accessRule{}, fmt.Errorf("rule %q is neither a peer ID, an IP nor a CIDR: %w", rule, err)
+	}
+	return accessRule{peerID: id}, nil
+}
+
+// String returns the canonical representation of the rule.
+func (r accessRule) String() string {
+	if r.ipNet != nil {
+		return r.ipNet.String()
+	}
+	return r.peerID.String()
+}
+
+func (r accessRule) match(id peer.ID, ip net.IP) bool {
+	if r.ipNet != nil {
+		return ip != nil && r.ipNet.Contains(ip)
+	}
+	return id != "" && r.peerID == id
+}
+
+// AccessList holds operator-configured allow and deny rules for peers.
+// A rule is either a peer ID, an IP or a CIDR.
+// Denied peers are never connected, while allowed peers bypass connection limits.
+// A peer matching both lists is denied.
+type AccessList struct {
+	mu    sync.RWMutex
+	allow map[string]accessRule
+	deny  map[string]accessRule
+}
+
+// NewAccessList creates an AccessList with the given allow and deny rules.
+func NewAccessList(allow, deny []string) (*AccessList, error) {
+	l := &AccessList{
+		allow: make(map[string]accessRule),
+		deny:  make(map[string]accessRule),
+	}
+	if err := l.Allow(allow...); err != nil {
+		return nil, err
+	}
+	if err := l.Deny(deny...); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Allow adds the given rules to the allow list.
+func (l *AccessList) Allow(rules ...string) error {
+	return l.Update(rules, nil, nil)
+}
+
+// Deny adds the given rules to the deny list.
+func (l *AccessList) Deny(rules ...string) error {
+	return l.Update(nil, rules, nil)
+}
+
+// Remove removes the given rules from both lists.
+func (l *AccessList) Remove(rules ...string) error {
+	return l.Update(nil, nil, rules)
+}
+
+// Update removes the remove rules from both lists, then adds the allow and deny rules, in a single change.
+// The lists are left unchanged if any rule is invalid.
+func (l *AccessList) Update(allow, deny, remove []string) error {
+	parsedAllow, err := parseAccessRules(allow)
+	if err != nil {
+		return err
+	}
+	parsedDeny, err := parseAccessRules(deny)
+	if err != nil {
+		return err
+	}
+	parsedRemove, err := parseAccessRules(remove)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, r := range parsedRemove {
+		delete(l.allow, r.String())
+		delete(l.deny, r.String())
+	}
+	for _, r := range parsedAllow {
+		l.allow[r.String()] = r
+	}
+	for _, r := range parsedDeny {
+		l.deny[r.String()] = r
+	}
+	return nil
+}
+
+func parseAccessRules(rules []string) ([]accessRule, error) {
+	parsed := make([]accessRule, 0, len(rules))
+	for _, rule := range rules {
+		r, err := parseAccessRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, r)
+	}
+	return parsed, nil
+}
+
+// Rules returns the sorted rules of both lists.
+func (l *AccessList) Rules() (allow, deny []string) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return sortedRules(l.allow), sortedRules(l.deny)
+}
+
+func sortedRules(list map[string]accessRule) []string {
+	rules := make([]string, 0, len(list))
+	for rule := range list {
+		rules = append(rules, rule)
+	}
+	sort.Strings(rules)
+	return rules
+}
+
+// IsDenied returns whether the given peer ID or IP is denied, either of them may be empty.
+func (l *AccessList) IsDenied(id peer.ID, ip net.IP) bool {
+	if l == nil {
+		return false
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return matchAny(l.deny, id, ip)
+}
+
+// IsAllowed returns whether the given peer ID or IP is allowed and not denied, either of them may be empty.
+func (l *AccessList) IsAllowed(id peer.ID, ip net.IP) bool {
+	if l == nil {
+		return false
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return !matchAny(l.deny, id, ip) && matchAny(l.allow, id, ip)
+}
+
+func matchAny(list map[string]accessRule, id peer.ID, ip net.IP) bool {
+	for _, r := range list {
+		if r.match(id, ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// multiaddrIP returns the IP of the given address, or nil if it has none (e.g. a DNS address).
+func multiaddrIP(addr ma.Multiaddr) net.IP {
+	if addr == nil {
+		return nil
+	}
+	ip, err := manet.ToIP(addr)
+	if err != nil {
+		return nil
+	}
+	return ip
+}
+
+// RemoteIP returns the remote IP of the given connection, or nil if it has none.
+func RemoteIP(conn libp2pnetwork.Conn) net.IP {
+	return multiaddrIP(conn.RemoteMultiaddr())
+}
@@ -0,0 +1,79 @@
+package connections
+
+import (
+	crand "crypto/rand"
+	"net"
+	"testing"
+
+	libp2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessList(t *testing.T) {
+	sk, _, err := libp2pcrypto.GenerateSecp256k1Key(crand.Reader)
+	require.NoError(t, err)
+	pid, err := peer.IDFromPrivateKey(sk)
+	require.NoError(t, err)
+
+	t.Run("invalid rule", func(t *testing.T) {
+		_, err := NewAccessList([]string{"not-a-peer"}, nil)
+		require.Error(t, err)
+		_, err = NewAccessList(nil, []string{"10.0.0.0/33"})
+		require.Error(t, err)
+	})
+
+	t.Run("peer ID, IP and CIDR rules", func(t *testing.T) {
+		l, err := NewAccessList([]string{"10.0.0.0/8", "192.168.1.1"}, []string{pid.String(), "10.1.0.0/16"})
+		require.NoError(t, err)
+
+		allow, deny := l.Rules()
+		require.Equal(t, []string{"10.0.0.0/8", "192.168.1.1/32"}, allow)
+		require.Equal(t, []string{"10.1.0.0/16", pid.String()}, deny)
+
+		require.True(t, l.IsAllowed("", net.ParseIP("10.2.3.4")))
+		require.True(t, l.IsAllowed("", net.ParseIP("192.168.1.1")))
+		require.False(t, l.IsAllowed("", net.ParseIP("192.168.1.2")))
+
+		// Deny takes precedence over allow.
+		require.True(t, l.IsDenied("", net.ParseIP("10.1.2.3")))
+		require.False(t, l.IsAllowed("", net.ParseIP("10.1.2.3")))
+		require.True(t, l.IsDenied(pid, net.ParseIP("10.2.3.4")))
+		require.False(t, l.IsAllowed(pid, net.ParseIP("10.2.3.4")))
+	})
+
+	t.Run("runtime changes", func(t *testing.T) {
+		l, err := NewAccessList(nil, nil)
+		require.NoError(t, err)
+		require.False(t, l.IsDenied(pid, nil))
+
+		require.NoError(t, l.Deny(pid.String()))
+		require.True(t, l.IsDenied(pid, nil))
+
+		require.NoError(t, l.Remove(pid.String()))
+		require.False(t, l.IsDenied(pid, nil))
+	})
+
+	t.Run("update", func(t *testing.T) {
+		l, err := NewAccessList([]string{"10.0.0.0/8"}, nil)
+		require.NoError(t, err)
+
+		// An invalid rule in any list leaves both lists unchanged.
+		require.Error(t, l.Update([]string{"192.168.1.1"}, []string{pid.String()}, []string{"not-a-peer"}))
+		allow, deny := l.Rules()
+		require.Equal(t, []string{"10.0.0.0/8"}, allow)
+		require.Empty(t, deny)
+
+		// Removed rules can be added again in the same update.
+		require.NoError(t, l.Update([]string{"192.168.1.1"}, []string{"10.0.0.0/8"}, []string{"10.0.0.0/8"}))
+		allow, deny = l.Rules()
+		require.Equal(t, []string{"192.168.1.1/32"}, allow)
+		require.Equal(t, []string{"10.0.0.0/8"}, deny)
+	})
+
+	t.Run("nil access list", func(t *testing.T) {
+		var l *AccessList
+		require.False(t, l.IsDenied(pid, nil))
+		require.False(t, l.IsAllowed(pid, nil))
+	})
+}
@@ -37,6 +37,7 @@ type connGater struct {
 	isBadPeer       IsBadPeerF
 	atInboundLimit  AtInboundLimitF
 	trimmedRecently *ttl.Map[peer.ID, struct{}]
+	accessList      *AccessList
 }
 
 // NewConnectionGater creates a new instance of ConnectionGater
@@ -47,6 +48,7 @@ func NewConnectionGater(
 	isBadPeer IsBadPeerF,
 	atInboundLimit AtInboundLimitF,
 	trimmedRecently *ttl.Map[peer.ID, struct{}],
+	accessList *AccessList,
 ) connmgr.ConnectionGater {
 	return &connGater{
 		logger:          logger,
@@ -56,6 +58,7 @@ func NewConnectionGater(
 		isBadPeer:       isBadPeer,
 		atInboundLimit:  atInboundLimit,
 		trimmedRecently: trimmedRecently,
+		accessList:      accessList,
 	}
 }
 
@@ -63,6 +66,10 @@ func NewConnectionGater(
 // to the addresses of that peer being available/resolved. Blocking connections
 // at this stage is typical for blacklisting scenarios
 func (n *connGater) InterceptPeerDial(id peer.ID) bool {
+	if n.accessList.IsDenied(id, nil) {
+		n.logger.Debug("preventing outbound connection due to denied peer", fields.PeerID(id))
+		return false
+	}
 	return true
 }
 
@@ -70,6 +77,14 @@ func (n *connGater) InterceptPeerDial(id peer.ID) bool {
 // particular address. Blocking connections at this stage is typical for
 // address filtering.
 func (n *connGater) InterceptAddrDial(id peer.ID, multiaddr ma.Multiaddr) bool {
+	ip := multiaddrIP(multiaddr)
+	if n.accessList.IsDenied(id, ip) {
+		n.logger.Debug("preventing outbound connection due to denied peer", fields.PeerID(id))
+		return false
+	}
+	if n.accessList.IsAllowed(id, ip) {
+		return true
+	}
 	if n.isBadPeer(n.logger, id) {
 		n.logger.Debug("preventing outbound connection due to bad peer", fields.PeerID(id))
 		return false
@@ -82,6 +97,14 @@ func (n *connGater) InterceptAddrDial(id peer.ID, multiaddr ma.Multiaddr) bool {
 // accept already secure and/or multiplexed connections (e.g. possibly QUIC)
 // MUST call this method regardless, for correctness/consistency.
 func (n *connGater) InterceptAccept(multiaddrs libp2pnetwork.ConnMultiaddrs) bool {
+	remoteIP := multiaddrIP(multiaddrs.RemoteMultiaddr())
+	if n.accessList.IsDenied("", remoteIP) {
+		n.logger.Debug("connection rejected due to denied IP", zap.String("remote_addr", multiaddrs.RemoteMultiaddr().String()))
+		return false
+	}
+	if n.accessList.IsAllowed("", remoteIP) {
+		return true
+	}
 	if n.disable {
 		return true
 	}
@@ -103,6 +126,15 @@ func (n *connGater) InterceptAccept(multiaddrs libp2pnetwork.ConnMultiaddrs) boo
 // InterceptSecured is called for both inbound and outbound connections,
 // after a security handshake has taken place and we've authenticated the peer.
 func (n *connGater) InterceptSecured(direction libp2pnetwork.Direction, id peer.ID, multiaddrs libp2pnetwork.ConnMultiaddrs) bool {
+	remoteIP := multiaddrIP(multiaddrs.RemoteMultiaddr())
+	if n.accessList.IsDenied(id, remoteIP) {
+		n.logger.Debug("rejecting connection due to denied peer", fields.PeerID(id))
+		return false
+	}
+	if n.accessList.IsAllowed(id, remoteIP) {
+		return true
+	}
+
 	if n.trimmedRecently.Has(id) {
 		n.logger.Debug(
 			"InterceptSecured: trying to connect a peer we've recently trimmed",
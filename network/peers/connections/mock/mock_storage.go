@@ -5,6 +5,7 @@ import (
 	"math/big"
 
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
@@ -159,6 +160,23 @@ func (m NodeStorage) SavePrivateKeyHash(privKeyHash string) error {
 	panic("implement me")
 }
 
+func (m NodeStorage) SavePrivateKeyHashTxn(rw basedb.ReadWriter, privKeyHash string) error {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (m NodeStorage) GetExitRequest(r basedb.Reader, pubKey phase0.BLSPubKey) (*storage.ExitRequest, bool, error) {
+	panic("implement me")
+}
+
+func (m NodeStorage) SaveExitRequest(rw basedb.ReadWriter, request *storage.ExitRequest) error {
+	panic("implement me")
+}
+
+func (m NodeStorage) ListExitRequests(r basedb.Reader) ([]*storage.ExitRequest, error) {
+	panic("implement me")
+}
+
 func (m NodeStorage) GetConfig(rw basedb.ReadWriter) (*storage.ConfigLock, bool, error) {
 	panic("implement me")
 }
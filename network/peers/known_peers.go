@@ -0,0 +1,82 @@
+package peers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+var knownPeersPrefix = []byte("p2p/known-peers/")
+
+// KnownPeersStore persists known good peers, so that the node can
+// reconnect to them on restart without depending only on discovery.
+type KnownPeersStore struct {
+	db basedb.Database
+}
+
+// NewKnownPeersStore creates a new KnownPeersStore.
+func NewKnownPeersStore(db basedb.Database) *KnownPeersStore {
+	return &KnownPeersStore{db: db}
+}
+
+// Save replaces the stored peers with the given peers in a single transaction,
+// so that a failure leaves the previously stored peers in place.
+func (s *KnownPeersStore) Save(peers []peer.AddrInfo) error {
+	objs := make([]basedb.Obj, 0, len(peers))
+	keep := make(map[string]struct{}, len(peers))
+	for _, p := range peers {
+		if len(p.Addrs) == 0 {
+			continue
+		}
+		value, err := json.Marshal(p)
+		if err != nil {
+			return fmt.Errorf("could not encode peer %s: %w", p.ID, err)
+		}
+		objs = append(objs, basedb.Obj{Key: []byte(p.ID), Value: value})
+		keep[string(p.ID)] = struct{}{}
+	}
+
+	return s.db.Update(func(txn basedb.Txn) error {
+		var stale [][]byte
+		err := txn.GetAll(knownPeersPrefix, func(i int, obj basedb.Obj) error {
+			if _, ok := keep[string(obj.Key)]; !ok {
+				stale = append(stale, obj.Key)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("could not get known peers: %w", err)
+		}
+		for _, key := range stale {
+			if err := txn.Delete(knownPeersPrefix, key); err != nil {
+				return fmt.Errorf("could not delete known peer: %w", err)
+			}
+		}
+		if len(objs) == 0 {
+			return nil
+		}
+		return txn.SetMany(knownPeersPrefix, len(objs), func(i int) (basedb.Obj, error) {
+			return objs[i], nil
+		})
+	})
+}
+
+// Load returns the stored peers.
+func (s *KnownPeersStore) Load() ([]peer.AddrInfo, error) {
+	var peers []peer.AddrInfo
+	err := s.db.GetAll(knownPeersPrefix, func(i int, obj basedb.Obj) error {
+		var p peer.AddrInfo
+		if err := json.Unmarshal(obj.Value, &p); err != nil {
+			return fmt.Errorf("could not decode peer: %w", err)
+		}
+		peers = append(peers, p)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return peers, nil
+}
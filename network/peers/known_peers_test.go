@@ -0,0 +1,70 @@
+package peers
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestKnownPeersStore(t *testing.T) {
+	db, err := kv.NewInMemory(zap.NewNop(), basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	pids, err := createPeerIDs(3)
+	require.NoError(t, err)
+
+	addr, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/13001")
+	require.NoError(t, err)
+
+	store := NewKnownPeersStore(db)
+
+	loaded, err := store.Load()
+	require.NoError(t, err)
+	require.Empty(t, loaded)
+
+	require.NoError(t, store.Save([]peer.AddrInfo{
+		{ID: pids[0], Addrs: []ma.Multiaddr{addr}},
+		{ID: pids[1], Addrs: []ma.Multiaddr{addr}},
+		{ID: pids[2]}, // no addresses, should be skipped
+	}))
+	loaded, err = store.Load()
+	require.NoError(t, err)
+	require.Len(t, loaded, 2)
+	for _, p := range loaded {
+		require.Contains(t, pids[:2], p.ID)
+		require.Equal(t, []ma.Multiaddr{addr}, p.Addrs)
+	}
+
+	// Saving replaces the previously stored peers.
+	require.NoError(t, store.Save([]peer.AddrInfo{{ID: pids[2], Addrs: []ma.Multiaddr{addr}}}))
+	loaded, err = store.Load()
+	require.NoError(t, err)
+	require.Len(t, loaded, 1)
+	require.Equal(t, pids[2], loaded[0].ID)
+
+	// Peers which are saved again are updated and the others are removed.
+	addr2, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/13002")
+	require.NoError(t, err)
+	require.NoError(t, store.Save([]peer.AddrInfo{
+		{ID: pids[0], Addrs: []ma.Multiaddr{addr}},
+		{ID: pids[2], Addrs: []ma.Multiaddr{addr2}},
+	}))
+	loaded, err = store.Load()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []peer.AddrInfo{
+		{ID: pids[0], Addrs: []ma.Multiaddr{addr}},
+		{ID: pids[2], Addrs: []ma.Multiaddr{addr2}},
+	}, loaded)
+
+	require.NoError(t, store.Save(nil))
+	loaded, err = store.Load()
+	require.NoError(t, err)
+	require.Empty(t, loaded)
+}
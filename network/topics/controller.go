@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sync/atomic"
 	"time"
 
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
@@ -33,8 +34,11 @@ type Controller interface {
 	Topics() []string
 	// Broadcast publishes the message on the given topic
 	Broadcast(topicName string, data []byte, timeout time.Duration) error
-	// UpdateScoreParams refreshes the score params for every subscribed topic
+	// UpdateScoreParams refreshes the score params for every subscribed topic,
+	// reloading the score overrides file if one is configured
 	UpdateScoreParams(logger *zap.Logger) error
+	// PeerScore returns the score breakdown of the given peer, or false if it's unknown
+	PeerScore(pid peer.ID) (*PeerScoreBreakdown, bool)
 
 	io.Closer
 }
@@ -53,6 +57,10 @@ type topicsCtrl struct {
 	ps     *pubsub.PubSub
 	// scoreParamsFactory is a function that helps to set scoring params on topics
 	scoreParamsFactory func(string) *pubsub.TopicScoreParams
+	// scoreOverridesPath is a file of score params overrides, reloaded on every UpdateScoreParams
+	scoreOverridesPath string
+	scoreOverrides     atomic.Pointer[ScoreOverrides]
+	peerScores         *PeerScores
 	msgValidator       messageValidator
 	msgHandler         PubsubMessageHandler
 	subFilter          SubFilter
@@ -69,18 +77,28 @@ func NewTopicsController(
 	subFilter SubFilter,
 	pubSub *pubsub.PubSub,
 	scoreParams func(string) *pubsub.TopicScoreParams,
+	scoreOverridesPath string,
+	peerScores *PeerScores,
 ) Controller {
 	ctrl := &topicsCtrl{
 		ctx:                ctx,
 		logger:             logger,
 		ps:                 pubSub,
 		scoreParamsFactory: scoreParams,
+		scoreOverridesPath: scoreOverridesPath,
+		peerScores:         peerScores,
 		msgValidator:       msgValidator,
 		msgHandler:         msgHandler,
 
 		subFilter: subFilter,
 	}
 
+	if scoreOverridesPath != "" {
+		if err := ctrl.loadScoreOverrides(); err != nil {
+			logger.Warn("could not load score overrides", zap.String("path", scoreOverridesPath), zap.Error(err))
+		}
+	}
+
 	ctrl.container = newTopicsContainer(pubSub, ctrl.onNewTopic(logger))
 
 	return ctrl
@@ -96,10 +114,9 @@ func (ctrl *topicsCtrl) onNewTopic(logger *zap.Logger) onTopicJoined {
 			logger.Warn("could not setup topic", zap.String("topic", name), zap.Error(err))
 		}
 		if ctrl.scoreParamsFactory != nil {
-			if p := ctrl.scoreParamsFactory(name); p != nil {
+			if p := ctrl.topicScoreParams(name); p != nil {
 				logger.Debug("using scoring params for topic", zap.String("topic", name), zap.Any("params", p))
-				if err := topic.SetScoreParams(p); err != nil {
-					// logger.Warn("could not set topic score params", zap.String("topic", name), zap.Error(err))
+				if err := ctrl.setScoreParams(topic, p); err != nil {
 					logger.Warn("could not set topic score params", zap.String("topic", name), zap.Error(err))
 				}
 			}
@@ -111,6 +128,12 @@ func (ctrl *topicsCtrl) UpdateScoreParams(logger *zap.Logger) error {
 	if ctrl.scoreParamsFactory == nil {
 		return fmt.Errorf("scoreParamsFactory is not set")
 	}
+	if ctrl.scoreOverridesPath != "" {
+		if err := ctrl.loadScoreOverrides(); err != nil {
+			// keep using the previously loaded overrides
+			logger.Warn("could not reload score overrides", zap.String("path", ctrl.scoreOverridesPath), zap.Error(err))
+		}
+	}
 	var errs error
 	topics := ctrl.ps.GetTopics()
 	for _, topicName := range topics {
@@ -119,12 +142,12 @@ func (ctrl *topicsCtrl) UpdateScoreParams(logger *zap.Logger) error {
 			errs = errors.Join(errs, fmt.Errorf("topic %s is not ready; ", topicName))
 			continue
 		}
-		p := ctrl.scoreParamsFactory(topicName)
+		p := ctrl.topicScoreParams(topicName)
 		if p == nil {
 			errs = errors.Join(errs, fmt.Errorf("score params for topic %s is nil; ", topicName))
 			continue
 		}
-		if err := topic.SetScoreParams(p); err != nil {
+		if err := ctrl.setScoreParams(topic, p); err != nil {
 			errs = errors.Join(errs, fmt.Errorf("could not set score params for topic %s: %w; ", topicName, err))
 			continue
 		}
@@ -132,6 +155,38 @@ func (ctrl *topicsCtrl) UpdateScoreParams(logger *zap.Logger) error {
 	return errs
 }
 
+// topicScoreParams returns the score params of the given topic with the score overrides applied.
+func (ctrl *topicsCtrl) topicScoreParams(topicName string) *pubsub.TopicScoreParams {
+	return ctrl.scoreOverrides.Load().Apply(topicName, ctrl.scoreParamsFactory(topicName))
+}
+
+func (ctrl *topicsCtrl) setScoreParams(topic *pubsub.Topic, p *pubsub.TopicScoreParams) error {
+	if err := topic.SetScoreParams(p); err != nil {
+		return err
+	}
+	if ctrl.peerScores != nil {
+		ctrl.peerScores.SetTopicParams(topic.String(), p)
+	}
+	return nil
+}
+
+func (ctrl *topicsCtrl) loadScoreOverrides() error {
+	overrides, err := LoadScoreOverrides(ctrl.scoreOverridesPath)
+	if err != nil {
+		return err
+	}
+	ctrl.scoreOverrides.Store(overrides)
+	return nil
+}
+
+// PeerScore returns the score breakdown of the given peer, or false if it's unknown
+func (ctrl *topicsCtrl) PeerScore(pid peer.ID) (*PeerScoreBreakdown, bool) {
+	if ctrl.peerScores == nil {
+		return nil, false
+	}
+	return ctrl.peerScores.Breakdown(pid)
+}
+
 // Close implements io.Closer
 func (ctrl *topicsCtrl) Close() error {
 	topics := ctrl.ps.GetTopics()
@@ -48,8 +48,10 @@ const (
 type PubSubConfig struct {
 	NetworkConfig networkconfig.NetworkConfig
 
-	Host        host.Host
-	TraceLog    bool
+	Host     host.Host
+	TraceLog bool
+	// Tracer is an additional pubsub event tracer, e.g. a file tracer
+	Tracer      pubsub.EventTracer
 	StaticPeers []peer.AddrInfo
 	MsgHandler  PubsubMessageHandler
 	// MsgValidator accepts the topic name and returns the corresponding msg validator
@@ -70,6 +72,8 @@ type PubSubConfig struct {
 	GetValidatorStats      network.GetValidatorStats
 	ScoreInspector         pubsub.ExtendedPeerScoreInspectFn
 	ScoreInspectorInterval time.Duration
+	// ScoreOverridesPath is a YAML file of topic score params overrides, see ScoreOverrides
+	ScoreOverridesPath string
 }
 
 // ScoringConfig is the configuration for peer scoring
@@ -148,6 +152,7 @@ func NewPubSub(ctx context.Context, logger *zap.Logger, cfg *PubSubConfig, commi
 	}
 
 	var topicScoreFactory func(string) *pubsub.TopicScoreParams
+	var peerScores *PeerScores
 
 	inspector := cfg.ScoreInspector
 	inspectInterval := cfg.ScoreInspectorInterval
@@ -180,9 +185,12 @@ func NewPubSub(ctx context.Context, logger *zap.Logger, cfg *PubSubConfig, commi
 			inspectInterval = defaultScoreInspectInterval
 		}
 
+		// Keep the last snapshots for score breakdowns
+		peerScores = NewPeerScores(peerScoreParams)
+
 		// Append score params to pubsub options
 		psOpts = append(psOpts, pubsub.WithPeerScore(peerScoreParams, params.PeerScoreThresholds()),
-			pubsub.WithPeerScoreInspect(inspector, inspectInterval))
+			pubsub.WithPeerScoreInspect(peerScores.Inspector(inspector), inspectInterval))
 	}
 
 	if cfg.MsgIDHandler != nil {
@@ -193,8 +201,19 @@ func NewPubSub(ctx context.Context, logger *zap.Logger, cfg *PubSubConfig, commi
 		psOpts = append(psOpts, pubsub.WithDirectPeers(cfg.StaticPeers))
 	}
 
+	var tracers []pubsub.EventTracer
 	if cfg.TraceLog {
-		psOpts = append(psOpts, pubsub.WithEventTracer(newTracer(logger)))
+		tracers = append(tracers, newTracer(logger))
+	}
+	if cfg.Tracer != nil {
+		tracers = append(tracers, cfg.Tracer)
+	}
+	switch len(tracers) {
+	case 0:
+	case 1:
+		psOpts = append(psOpts, pubsub.WithEventTracer(tracers[0]))
+	default:
+		psOpts = append(psOpts, pubsub.WithEventTracer(multiTracer(tracers)))
 	}
 
 	ps, err := pubsub.NewGossipSub(ctx, cfg.Host, psOpts...)
@@ -202,7 +221,7 @@ func NewPubSub(ctx context.Context, logger *zap.Logger, cfg *PubSubConfig, commi
 		return nil, nil, err
 	}
 
-	ctrl := NewTopicsController(ctx, logger, cfg.MsgHandler, cfg.MsgValidator, sf, ps, topicScoreFactory)
+	ctrl := NewTopicsController(ctx, logger, cfg.MsgHandler, cfg.MsgValidator, sf, ps, topicScoreFactory, cfg.ScoreOverridesPath, peerScores)
 
 	return ps, ctrl, nil
 }
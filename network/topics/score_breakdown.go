@@ -0,0 +1,140 @@
+package topics
+
+import (
+	"sort"
+	"sync"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// TopicScoreBreakdown is the contribution of a single topic to a peer's score.
+// The components are weighted by their own weights, while Score is their sum weighted by the topic weight.
+type TopicScoreBreakdown struct {
+	Topic                      string  `json:"topic"`
+	Score                      float64 `json:"score"`
+	P1TimeInMesh               float64 `json:"p1_time_in_mesh"`
+	P2FirstMessageDeliveries   float64 `json:"p2_first_message_deliveries"`
+	P3MeshMessageDeliveries    float64 `json:"p3_mesh_message_deliveries"`
+	P4InvalidMessageDeliveries float64 `json:"p4_invalid_message_deliveries"`
+}
+
+// PeerScoreBreakdown is a peer's gossipsub score broken down by its components, as of the last score inspection.
+// P3b (mesh failure penalty) isn't exposed by pubsub snapshots, hence it's only reflected in Score.
+type PeerScoreBreakdown struct {
+	Score              float64               `json:"score"`
+	TopicsScore        float64               `json:"topics_score"`
+	Topics             []TopicScoreBreakdown `json:"topics"`
+	P5AppSpecific      float64               `json:"p5_app_specific"`
+	P6IPColocation     float64               `json:"p6_ip_colocation"`
+	P7BehaviourPenalty float64               `json:"p7_behaviour_penalty"`
+}
+
+// PeerScores keeps the last score snapshots of peers along with the score params they were computed with.
+type PeerScores struct {
+	peerParams *pubsub.PeerScoreParams
+
+	mu          sync.RWMutex
+	topicParams map[string]*pubsub.TopicScoreParams
+	snapshots   map[peer.ID]*pubsub.PeerScoreSnapshot
+}
+
+// NewPeerScores creates a new PeerScores.
+func NewPeerScores(peerParams *pubsub.PeerScoreParams) *PeerScores {
+	return &PeerScores{
+		peerParams:  peerParams,
+		topicParams: make(map[string]*pubsub.TopicScoreParams),
+		snapshots:   make(map[peer.ID]*pubsub.PeerScoreSnapshot),
+	}
+}
+
+// SetTopicParams records the score params that are currently set on the given topic.
+func (ps *PeerScores) SetTopicParams(topic string, params *pubsub.TopicScoreParams) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	ps.topicParams[topic] = params
+}
+
+// Inspector returns a score inspector that keeps the snapshots and then calls the given inspector.
+func (ps *PeerScores) Inspector(next pubsub.ExtendedPeerScoreInspectFn) pubsub.ExtendedPeerScoreInspectFn {
+	return func(snapshots map[peer.ID]*pubsub.PeerScoreSnapshot) {
+		ps.mu.Lock()
+		ps.snapshots = snapshots
+		ps.mu.Unlock()
+
+		if next != nil {
+			next(snapshots)
+		}
+	}
+}
+
+// Breakdown returns the score breakdown of the given peer, or false if it wasn't inspected yet.
+func (ps *PeerScores) Breakdown(pid peer.ID) (*PeerScoreBreakdown, bool) {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	snapshot, ok := ps.snapshots[pid]
+	if !ok {
+		return nil, false
+	}
+
+	b := &PeerScoreBreakdown{
+		Score:  snapshot.Score,
+		Topics: make([]TopicScoreBreakdown, 0, len(snapshot.Topics)),
+	}
+	for topic, ts := range snapshot.Topics {
+		params, ok := ps.topicParams[topic]
+		if !ok {
+			continue
+		}
+		tb := topicScoreBreakdown(topic, ts, params)
+		b.TopicsScore += tb.Score
+		b.Topics = append(b.Topics, tb)
+	}
+	sort.Slice(b.Topics, func(i, j int) bool {
+		return b.Topics[i].Topic < b.Topics[j].Topic
+	})
+
+	if ps.peerParams != nil {
+		if ps.peerParams.TopicScoreCap > 0 && b.TopicsScore > ps.peerParams.TopicScoreCap {
+			b.TopicsScore = ps.peerParams.TopicScoreCap
+		}
+		b.P5AppSpecific = snapshot.AppSpecificScore * ps.peerParams.AppSpecificWeight
+		b.P6IPColocation = snapshot.IPColocationFactor * ps.peerParams.IPColocationFactorWeight
+		if excess := snapshot.BehaviourPenalty - ps.peerParams.BehaviourPenaltyThreshold; excess > 0 {
+			b.P7BehaviourPenalty = excess * excess * ps.peerParams.BehaviourPenaltyWeight
+		}
+	}
+
+	return b, true
+}
+
+// topicScoreBreakdown computes the topic score components the same way gossipsub does.
+func topicScoreBreakdown(topic string, ts *pubsub.TopicScoreSnapshot, params *pubsub.TopicScoreParams) TopicScoreBreakdown {
+	tb := TopicScoreBreakdown{Topic: topic}
+
+	// P1 - time in mesh
+	if params.TimeInMeshQuantum > 0 {
+		p1 := float64(ts.TimeInMesh / params.TimeInMeshQuantum)
+		if p1 > params.TimeInMeshCap {
+			p1 = params.TimeInMeshCap
+		}
+		tb.P1TimeInMesh = p1 * params.TimeInMeshWeight
+	}
+
+	// P2 - first message deliveries
+	tb.P2FirstMessageDeliveries = ts.FirstMessageDeliveries * params.FirstMessageDeliveriesWeight
+
+	// P3 - mesh message deliveries deficit, active once the peer has been in the mesh long enough
+	if ts.TimeInMesh > params.MeshMessageDeliveriesActivation && ts.MeshMessageDeliveries < params.MeshMessageDeliveriesThreshold {
+		deficit := params.MeshMessageDeliveriesThreshold - ts.MeshMessageDeliveries
+		tb.P3MeshMessageDeliveries = deficit * deficit * params.MeshMessageDeliveriesWeight
+	}
+
+	// P4 - invalid message deliveries
+	tb.P4InvalidMessageDeliveries = ts.InvalidMessageDeliveries * ts.InvalidMessageDeliveries * params.InvalidMessageDeliveriesWeight
+
+	tb.Score = params.TopicWeight * (tb.P1TimeInMesh + tb.P2FirstMessageDeliveries + tb.P3MeshMessageDeliveries + tb.P4InvalidMessageDeliveries)
+	return tb
+}
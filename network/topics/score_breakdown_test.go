@@ -0,0 +1,123 @@
+package topics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerScoresBreakdown(t *testing.T) {
+	topicParams := &pubsub.TopicScoreParams{
+		TopicWeight:                     0.5,
+		TimeInMeshWeight:                0.1,
+		TimeInMeshQuantum:               time.Second,
+		TimeInMeshCap:                   10,
+		FirstMessageDeliveriesWeight:    2,
+		MeshMessageDeliveriesWeight:     -1,
+		MeshMessageDeliveriesThreshold:  5,
+		MeshMessageDeliveriesActivation: 5 * time.Second,
+		InvalidMessageDeliveriesWeight:  -10,
+	}
+	peerParams := &pubsub.PeerScoreParams{
+		IPColocationFactorWeight:  -3,
+		BehaviourPenaltyWeight:    -4,
+		BehaviourPenaltyThreshold: 1,
+		AppSpecificWeight:         1,
+	}
+
+	ps := NewPeerScores(peerParams)
+	ps.SetTopicParams("ssv.v2.1", topicParams)
+
+	pid := peer.ID("peer")
+	_, ok := ps.Breakdown(pid)
+	require.False(t, ok)
+
+	inspected := false
+	ps.Inspector(func(map[peer.ID]*pubsub.PeerScoreSnapshot) {
+		inspected = true
+	})(map[peer.ID]*pubsub.PeerScoreSnapshot{
+		pid: {
+			Score: -20,
+			Topics: map[string]*pubsub.TopicScoreSnapshot{
+				"ssv.v2.1": {
+					TimeInMesh:               20 * time.Second,
+					FirstMessageDeliveries:   3,
+					MeshMessageDeliveries:    2,
+					InvalidMessageDeliveries: 1,
+				},
+				"ssv.v2.2": {TimeInMesh: time.Second},
+			},
+			AppSpecificScore:   2,
+			IPColocationFactor: 1,
+			BehaviourPenalty:   3,
+		},
+	})
+	require.True(t, inspected)
+
+	b, ok := ps.Breakdown(pid)
+	require.True(t, ok)
+	require.Equal(t, -20.0, b.Score)
+	// topics without known params are skipped
+	require.Len(t, b.Topics, 1)
+
+	tb := b.Topics[0]
+	require.Equal(t, "ssv.v2.1", tb.Topic)
+	require.InDelta(t, 1.0, tb.P1TimeInMesh, 1e-9) // capped at 10 quanta
+	require.InDelta(t, 6.0, tb.P2FirstMessageDeliveries, 1e-9)
+	require.InDelta(t, -9.0, tb.P3MeshMessageDeliveries, 1e-9)
+	require.InDelta(t, -10.0, tb.P4InvalidMessageDeliveries, 1e-9)
+	require.InDelta(t, -6.0, tb.Score, 1e-9)
+	require.InDelta(t, -6.0, b.TopicsScore, 1e-9)
+
+	require.InDelta(t, 2.0, b.P5AppSpecific, 1e-9)
+	require.InDelta(t, -3.0, b.P6IPColocation, 1e-9)
+	require.InDelta(t, -16.0, b.P7BehaviourPenalty, 1e-9)
+}
+
+func TestScoreOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "overrides.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+Default:
+  TopicWeight: 0.25
+  TimeInMeshQuantum: 12s
+Topics:
+  "7":
+    InvalidMessageDeliveriesWeight: -100
+  ssv.v2.8:
+    TopicWeight: 1
+`), 0600))
+
+	overrides, err := LoadScoreOverrides(path)
+	require.NoError(t, err)
+
+	base := &pubsub.TopicScoreParams{
+		TopicWeight:                    0.5,
+		TimeInMeshQuantum:              time.Second,
+		InvalidMessageDeliveriesWeight: -10,
+		FirstMessageDeliveriesWeight:   2,
+	}
+
+	p := overrides.Apply("ssv.v2.7", base)
+	require.Equal(t, 0.25, p.TopicWeight)
+	require.Equal(t, 12*time.Second, p.TimeInMeshQuantum)
+	require.Equal(t, -100.0, p.InvalidMessageDeliveriesWeight)
+	require.Equal(t, 2.0, p.FirstMessageDeliveriesWeight)
+
+	p = overrides.Apply("ssv.v2.8", base)
+	require.Equal(t, 1.0, p.TopicWeight)
+	require.Equal(t, -10.0, p.InvalidMessageDeliveriesWeight)
+
+	// the base params are not modified
+	require.Equal(t, 0.5, base.TopicWeight)
+
+	var nilOverrides *ScoreOverrides
+	require.Same(t, base, nilOverrides.Apply("ssv.v2.7", base))
+
+	_, err = LoadScoreOverrides(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+}
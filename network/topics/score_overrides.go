@@ -0,0 +1,107 @@
+package topics
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ssvlabs/ssv/network/commons"
+)
+
+// TopicScoreOverrides overrides topic score params, only the set fields are overridden.
+type TopicScoreOverrides struct {
+	TopicWeight *float64 `yaml:"TopicWeight"`
+
+	TimeInMeshWeight  *float64       `yaml:"TimeInMeshWeight"`
+	TimeInMeshQuantum *time.Duration `yaml:"TimeInMeshQuantum"`
+	TimeInMeshCap     *float64       `yaml:"TimeInMeshCap"`
+
+	FirstMessageDeliveriesWeight *float64 `yaml:"FirstMessageDeliveriesWeight"`
+	FirstMessageDeliveriesDecay  *float64 `yaml:"FirstMessageDeliveriesDecay"`
+	FirstMessageDeliveriesCap    *float64 `yaml:"FirstMessageDeliveriesCap"`
+
+	MeshMessageDeliveriesWeight     *float64       `yaml:"MeshMessageDeliveriesWeight"`
+	MeshMessageDeliveriesDecay      *float64       `yaml:"MeshMessageDeliveriesDecay"`
+	MeshMessageDeliveriesCap        *float64       `yaml:"MeshMessageDeliveriesCap"`
+	MeshMessageDeliveriesThreshold  *float64       `yaml:"MeshMessageDeliveriesThreshold"`
+	MeshMessageDeliveriesWindow     *time.Duration `yaml:"MeshMessageDeliveriesWindow"`
+	MeshMessageDeliveriesActivation *time.Duration `yaml:"MeshMessageDeliveriesActivation"`
+
+	MeshFailurePenaltyWeight *float64 `yaml:"MeshFailurePenaltyWeight"`
+	MeshFailurePenaltyDecay  *float64 `yaml:"MeshFailurePenaltyDecay"`
+
+	InvalidMessageDeliveriesWeight *float64 `yaml:"InvalidMessageDeliveriesWeight"`
+	InvalidMessageDeliveriesDecay  *float64 `yaml:"InvalidMessageDeliveriesDecay"`
+}
+
+// ScoreOverrides overrides the computed topic score params, e.g. to try new weights without restarting the node.
+// Topic specific overrides are applied on top of the default overrides.
+type ScoreOverrides struct {
+	Default TopicScoreOverrides `yaml:"Default"`
+	// Topics maps topic names (either base names such as "12" or full names such as "ssv.v2.12") to their overrides.
+	Topics map[string]TopicScoreOverrides `yaml:"Topics"`
+}
+
+// LoadScoreOverrides reads score overrides from the given YAML file.
+func LoadScoreOverrides(path string) (*ScoreOverrides, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := &ScoreOverrides{}
+	if err := yaml.Unmarshal(data, overrides); err != nil {
+		return nil, err
+	}
+	return overrides, nil
+}
+
+// Apply returns a copy of the given params with the overrides of the given topic applied.
+func (o *ScoreOverrides) Apply(topic string, params *pubsub.TopicScoreParams) *pubsub.TopicScoreParams {
+	if o == nil || params == nil {
+		return params
+	}
+
+	p := *params
+	o.Default.apply(&p)
+	if to, ok := o.Topics[topic]; ok {
+		to.apply(&p)
+	} else if to, ok := o.Topics[commons.GetTopicBaseName(topic)]; ok {
+		to.apply(&p)
+	}
+	return &p
+}
+
+func (o TopicScoreOverrides) apply(p *pubsub.TopicScoreParams) {
+	set(&p.TopicWeight, o.TopicWeight)
+
+	set(&p.TimeInMeshWeight, o.TimeInMeshWeight)
+	set(&p.TimeInMeshQuantum, o.TimeInMeshQuantum)
+	set(&p.TimeInMeshCap, o.TimeInMeshCap)
+
+	set(&p.FirstMessageDeliveriesWeight, o.FirstMessageDeliveriesWeight)
+	set(&p.FirstMessageDeliveriesDecay, o.FirstMessageDeliveriesDecay)
+	set(&p.FirstMessageDeliveriesCap, o.FirstMessageDeliveriesCap)
+
+	set(&p.MeshMessageDeliveriesWeight, o.MeshMessageDeliveriesWeight)
+	set(&p.MeshMessageDeliveriesDecay, o.MeshMessageDeliveriesDecay)
+	set(&p.MeshMessageDeliveriesCap, o.MeshMessageDeliveriesCap)
+	set(&p.MeshMessageDeliveriesThreshold, o.MeshMessageDeliveriesThreshold)
+	set(&p.MeshMessageDeliveriesWindow, o.MeshMessageDeliveriesWindow)
+	set(&p.MeshMessageDeliveriesActivation, o.MeshMessageDeliveriesActivation)
+
+	set(&p.MeshFailurePenaltyWeight, o.MeshFailurePenaltyWeight)
+	set(&p.MeshFailurePenaltyDecay, o.MeshFailurePenaltyDecay)
+
+	set(&p.InvalidMessageDeliveriesWeight, o.InvalidMessageDeliveriesWeight)
+	set(&p.InvalidMessageDeliveriesDecay, o.InvalidMessageDeliveriesDecay)
+}
+
+func set[T any](dst *T, src *T) {
+	if src != nil {
+		*dst = *src
+	}
+}
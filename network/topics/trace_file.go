@@ -0,0 +1,287 @@
+package topics
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"sync"
+	"sync/atomic"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	ps_pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"go.uber.org/zap"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/ssvlabs/ssv/logging"
+)
+
+// Trace file formats.
+const (
+	// TraceFormatPB writes length-delimited protobuf records, the same format as pubsub.PBTracer.
+	TraceFormatPB = "pb"
+	// TraceFormatJSON writes one JSON record per line, the same format as pubsub.JSONTracer.
+	TraceFormatJSON = "json"
+)
+
+const (
+	// traceBufferSize is the amount of events that can be buffered before they are dropped.
+	traceBufferSize = 1 << 16
+	// maxTraceRecordSize is the maximum size of a single protobuf record when reading traces.
+	maxTraceRecordSize = 1 << 22
+)
+
+// TraceFileConfig configures the file tracer.
+type TraceFileConfig struct {
+	// FileName is the file to write trace events into, it is rotated once it reaches MaxSize megabytes.
+	FileName   string
+	MaxSize    int
+	MaxBackups int
+	// Format is either TraceFormatPB or TraceFormatJSON.
+	Format string
+	// SampleRate is the fraction of messages to trace, in (0, 1].
+	// Sampling is done by message ID, so all events of a sampled message are traced.
+	// Events which aren't related to a single message are always traced.
+	SampleRate float64
+	// Topics limits tracing to the given topics, all topics are traced if empty.
+	// Events which aren't related to a topic are always traced.
+	Topics []string
+}
+
+// fileTracer writes pubsub trace events into rotating files, implements pubsub.EventTracer.
+type fileTracer struct {
+	logger      *zap.Logger
+	w           io.WriteCloser
+	encode      func(w io.Writer, evt *ps_pb.TraceEvent) error
+	sampleBound uint64
+	topics      map[string]struct{}
+
+	// mu guards sending events against closing the events channel,
+	// as pubsub may still trace events while the tracer is closed.
+	mu      sync.RWMutex
+	closed  bool
+	events  chan *ps_pb.TraceEvent
+	dropped atomic.Uint64
+	done    chan struct{}
+}
+
+// TraceCloser is a pubsub.EventTracer that has to be closed to flush buffered events.
+type TraceCloser interface {
+	pubsub.EventTracer
+	io.Closer
+}
+
+// NewFileTracer creates a tracer writing events into rotating files.
+func NewFileTracer(logger *zap.Logger, cfg TraceFileConfig) (TraceCloser, error) {
+	return newFileTracer(logger, &lumberjack.Logger{
+		Filename:   cfg.FileName,
+		MaxSize:    cfg.MaxSize, // megabytes
+		MaxBackups: cfg.MaxBackups,
+		Compress:   false,
+	}, cfg)
+}
+
+func newFileTracer(logger *zap.Logger, w io.WriteCloser, cfg TraceFileConfig) (*fileTracer, error) {
+	t := &fileTracer{
+		logger: logger.Named(logging.NamePubsubTrace),
+		w:      w,
+		events: make(chan *ps_pb.TraceEvent, traceBufferSize),
+		done:   make(chan struct{}),
+	}
+
+	switch cfg.Format {
+	case TraceFormatPB, "":
+		t.encode = writeTraceEventPB
+	case TraceFormatJSON:
+		t.encode = writeTraceEventJSON
+	default:
+		return nil, fmt.Errorf("unknown trace format: %s", cfg.Format)
+	}
+
+	switch {
+	case cfg.SampleRate == 0 || cfg.SampleRate >= 1:
+		t.sampleBound = math.MaxUint64
+	case cfg.SampleRate > 0:
+		t.sampleBound = uint64(cfg.SampleRate * math.MaxUint64)
+	default:
+		return nil, fmt.Errorf("invalid trace sample rate: %f", cfg.SampleRate)
+	}
+
+	if len(cfg.Topics) > 0 {
+		t.topics = make(map[string]struct{}, len(cfg.Topics))
+		for _, topic := range cfg.Topics {
+			t.topics[topic] = struct{}{}
+		}
+	}
+
+	go t.write()
+
+	return t, nil
+}
+
+// Trace handles events, implementation of pubsub.EventTracer
+func (t *fileTracer) Trace(evt *ps_pb.TraceEvent) {
+	if evt == nil || !t.filter(evt) {
+		return
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.closed {
+		return
+	}
+	select {
+	case t.events <- evt:
+	default:
+		t.dropped.Add(1)
+	}
+}
+
+// Close flushes buffered events and closes the underlying file, events traced afterwards are ignored.
+func (t *fileTracer) Close() error {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		<-t.done
+		return nil
+	}
+	t.closed = true
+	close(t.events)
+	t.mu.Unlock()
+
+	<-t.done
+	return t.w.Close()
+}
+
+func (t *fileTracer) write() {
+	defer close(t.done)
+
+	for evt := range t.events {
+		if err := t.encode(t.w, evt); err != nil {
+			t.logger.Debug("could not write trace event", zap.Error(err))
+		}
+	}
+	if dropped := t.dropped.Load(); dropped > 0 {
+		t.logger.Warn("dropped trace events due to full buffer", zap.Uint64("dropped", dropped))
+	}
+}
+
+// filter returns whether the event should be traced according to the topics and sampling configuration.
+func (t *fileTracer) filter(evt *ps_pb.TraceEvent) bool {
+	topic, msgID, ok := traceEventMessage(evt)
+	if !ok {
+		switch evt.GetType() {
+		case ps_pb.TraceEvent_JOIN:
+			topic = evt.GetJoin().GetTopic()
+		case ps_pb.TraceEvent_LEAVE:
+			topic = evt.GetLeave().GetTopic()
+		case ps_pb.TraceEvent_GRAFT:
+			topic = evt.GetGraft().GetTopic()
+		case ps_pb.TraceEvent_PRUNE:
+			topic = evt.GetPrune().GetTopic()
+		}
+	}
+	if t.topics != nil && topic != "" {
+		if _, ok := t.topics[topic]; !ok {
+			return false
+		}
+	}
+	if msgID != nil && t.sampleBound != math.MaxUint64 {
+		h := fnv.New64a()
+		_, _ = h.Write(msgID)
+		return h.Sum64() <= t.sampleBound
+	}
+	return true
+}
+
+// traceEventMessage returns the topic and message ID of events related to a single message.
+func traceEventMessage(evt *ps_pb.TraceEvent) (topic string, msgID []byte, ok bool) {
+	switch evt.GetType() {
+	case ps_pb.TraceEvent_PUBLISH_MESSAGE:
+		return evt.GetPublishMessage().GetTopic(), evt.GetPublishMessage().GetMessageID(), true
+	case ps_pb.TraceEvent_REJECT_MESSAGE:
+		return evt.GetRejectMessage().GetTopic(), evt.GetRejectMessage().GetMessageID(), true
+	case ps_pb.TraceEvent_DUPLICATE_MESSAGE:
+		return evt.GetDuplicateMessage().GetTopic(), evt.GetDuplicateMessage().GetMessageID(), true
+	case ps_pb.TraceEvent_DELIVER_MESSAGE:
+		return evt.GetDeliverMessage().GetTopic(), evt.GetDeliverMessage().GetMessageID(), true
+	default:
+		return "", nil, false
+	}
+}
+
+func writeTraceEventPB(w io.Writer, evt *ps_pb.TraceEvent) error {
+	data, err := evt.Marshal()
+	if err != nil {
+		return err
+	}
+	buf := binary.AppendUvarint(make([]byte, 0, binary.MaxVarintLen64+len(data)), uint64(len(data)))
+	_, err = w.Write(append(buf, data...))
+	return err
+}
+
+func writeTraceEventJSON(w io.Writer, evt *ps_pb.TraceEvent) error {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// TraceReader reads trace events written by the file tracer or by pubsub's own PB/JSON tracers.
+type TraceReader struct {
+	r      *bufio.Reader
+	format string
+	dec    *json.Decoder
+}
+
+// NewTraceReader creates a TraceReader reading events of the given format from r.
+func NewTraceReader(r io.Reader, format string) (*TraceReader, error) {
+	tr := &TraceReader{r: bufio.NewReader(r), format: format}
+	switch format {
+	case TraceFormatPB:
+	case TraceFormatJSON:
+		tr.dec = json.NewDecoder(tr.r)
+	default:
+		return nil, fmt.Errorf("unknown trace format: %s", format)
+	}
+	return tr, nil
+}
+
+// Next returns the next event, or io.EOF when there are no more events.
+func (tr *TraceReader) Next() (*ps_pb.TraceEvent, error) {
+	evt := &ps_pb.TraceEvent{}
+	if tr.format == TraceFormatJSON {
+		if err := tr.dec.Decode(evt); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, io.EOF
+			}
+			return nil, fmt.Errorf("could not decode trace event: %w", err)
+		}
+		return evt, nil
+	}
+
+	size, err := binary.ReadUvarint(tr.r)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("could not read trace event size: %w", err)
+	}
+	if size > maxTraceRecordSize {
+		return nil, fmt.Errorf("trace event is too big: %d", size)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(tr.r, data); err != nil {
+		return nil, fmt.Errorf("could not read trace event: %w", err)
+	}
+	if err := evt.Unmarshal(data); err != nil {
+		return nil, fmt.Errorf("could not decode trace event: %w", err)
+	}
+	return evt, nil
+}
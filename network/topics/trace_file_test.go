@@ -0,0 +1,150 @@
+package topics
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	ps_pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+)
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
+
+func testTraceEvents() []*ps_pb.TraceEvent {
+	ts := func(ms int64) *int64 {
+		v := ms * int64(time.Millisecond)
+		return &v
+	}
+	typ := func(t ps_pb.TraceEvent_Type) *ps_pb.TraceEvent_Type { return &t }
+	str := func(s string) *string { return &s }
+
+	return []*ps_pb.TraceEvent{
+		{Type: typ(ps_pb.TraceEvent_JOIN), Timestamp: ts(0), Join: &ps_pb.TraceEvent_Join{Topic: str("a")}},
+		{Type: typ(ps_pb.TraceEvent_GRAFT), Timestamp: ts(1), Graft: &ps_pb.TraceEvent_Graft{Topic: str("a")}},
+		{Type: typ(ps_pb.TraceEvent_PRUNE), Timestamp: ts(2), Prune: &ps_pb.TraceEvent_Prune{Topic: str("a")}},
+		{Type: typ(ps_pb.TraceEvent_RECV_RPC), Timestamp: ts(10), RecvRPC: &ps_pb.TraceEvent_RecvRPC{Meta: &ps_pb.TraceEvent_RPCMeta{
+			Messages: []*ps_pb.TraceEvent_MessageMeta{{MessageID: []byte("m1"), Topic: str("a")}},
+			Control: &ps_pb.TraceEvent_ControlMeta{
+				Ihave: []*ps_pb.TraceEvent_ControlIHaveMeta{{Topic: str("a"), MessageIDs: [][]byte{[]byte("m1"), []byte("m2")}}},
+			},
+		}}},
+		{Type: typ(ps_pb.TraceEvent_DELIVER_MESSAGE), Timestamp: ts(30), DeliverMessage: &ps_pb.TraceEvent_DeliverMessage{MessageID: []byte("m1"), Topic: str("a")}},
+		{Type: typ(ps_pb.TraceEvent_DUPLICATE_MESSAGE), Timestamp: ts(40), DuplicateMessage: &ps_pb.TraceEvent_DuplicateMessage{MessageID: []byte("m1"), Topic: str("a")}},
+		{Type: typ(ps_pb.TraceEvent_SEND_RPC), Timestamp: ts(50), SendRPC: &ps_pb.TraceEvent_SendRPC{Meta: &ps_pb.TraceEvent_RPCMeta{
+			Control: &ps_pb.TraceEvent_ControlMeta{
+				Iwant: []*ps_pb.TraceEvent_ControlIWantMeta{{MessageIDs: [][]byte{[]byte("m1"), []byte("x")}}},
+			},
+		}}},
+		{Type: typ(ps_pb.TraceEvent_REJECT_MESSAGE), Timestamp: ts(60), RejectMessage: &ps_pb.TraceEvent_RejectMessage{MessageID: []byte("m3"), Topic: str("b")}},
+	}
+}
+
+func TestFileTracer(t *testing.T) {
+	logger := logging.TestLogger(t)
+
+	for _, format := range []string{TraceFormatPB, TraceFormatJSON} {
+		t.Run(format, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			tracer, err := newFileTracer(logger, nopCloser{buf}, TraceFileConfig{Format: format})
+			require.NoError(t, err)
+			for _, evt := range testTraceEvents() {
+				tracer.Trace(evt)
+			}
+			require.NoError(t, tracer.Close())
+
+			reader, err := NewTraceReader(buf, format)
+			require.NoError(t, err)
+			summarizer := NewTraceSummarizer()
+			events := 0
+			for {
+				evt, err := reader.Next()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				require.NoError(t, err)
+				summarizer.Add(evt)
+				events++
+			}
+			require.Equal(t, len(testTraceEvents()), events)
+
+			summary := summarizer.Summary()
+			require.Len(t, summary, 3)
+
+			a := summary[0]
+			require.Equal(t, "a", a.Topic)
+			require.EqualValues(t, 1, a.Delivered)
+			require.EqualValues(t, 1, a.Duplicates)
+			require.Equal(t, 0.5, a.DuplicateRate())
+			require.EqualValues(t, 2, a.MeshChurn())
+			require.EqualValues(t, 2, a.IHaveReceived)
+			require.EqualValues(t, 1, a.IWantSent)
+			require.Equal(t, 20*time.Millisecond, a.DeliveryLatency(50))
+
+			require.Equal(t, "b", summary[1].Topic)
+			require.EqualValues(t, 1, summary[1].Rejected)
+
+			require.Equal(t, unknownTopic, summary[2].Topic)
+			require.EqualValues(t, 1, summary[2].IWantSent)
+		})
+	}
+}
+
+func TestFileTracerFilter(t *testing.T) {
+	logger := logging.TestLogger(t)
+
+	tracer, err := newFileTracer(logger, nopCloser{io.Discard}, TraceFileConfig{Topics: []string{"a"}})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, tracer.Close())
+	}()
+
+	for _, evt := range testTraceEvents() {
+		topic, _, ok := traceEventMessage(evt)
+		require.Equal(t, !ok || topic == "a", tracer.filter(evt))
+	}
+
+	_, err = newFileTracer(logger, nopCloser{io.Discard}, TraceFileConfig{SampleRate: -1})
+	require.Error(t, err)
+	_, err = newFileTracer(logger, nopCloser{io.Discard}, TraceFileConfig{Format: "xml"})
+	require.Error(t, err)
+}
+
+func TestFileTracerTraceWhileClosing(t *testing.T) {
+	logger := logging.TestLogger(t)
+
+	tracer, err := newFileTracer(logger, nopCloser{io.Discard}, TraceFileConfig{})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			for j := 0; j < 1000; j++ {
+				for _, evt := range testTraceEvents() {
+					tracer.Trace(evt)
+				}
+			}
+		}()
+	}
+
+	close(start)
+	require.NoError(t, tracer.Close())
+	wg.Wait()
+
+	// Events traced after closing are ignored, and closing again is a no-op.
+	tracer.Trace(testTraceEvents()[0])
+	require.NoError(t, tracer.Close())
+}
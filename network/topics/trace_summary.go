@@ -0,0 +1,226 @@
+package topics
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	ps_pb "github.com/libp2p/go-libp2p-pubsub/pb"
+)
+
+// unknownTopic groups IWANT message IDs whose topic wasn't seen in the trace.
+const unknownTopic = "unknown"
+
+// TopicTraceSummary holds the gossip statistics of a single topic.
+type TopicTraceSummary struct {
+	Topic string
+
+	Published  uint64
+	Delivered  uint64
+	Duplicates uint64
+	Rejected   uint64
+
+	Joins  uint64
+	Leaves uint64
+	Grafts uint64
+	Prunes uint64
+
+	// IHave and IWant volumes are counted in message IDs.
+	IHaveSent     uint64
+	IHaveReceived uint64
+	IWantSent     uint64
+	IWantReceived uint64
+
+	// latencies are sorted durations between the first time a message was seen
+	// (published or received in any of the traces) and its delivery.
+	latencies []time.Duration
+}
+
+// MeshChurn returns the number of mesh changes (grafts and prunes) in the topic.
+func (s *TopicTraceSummary) MeshChurn() uint64 {
+	return s.Grafts + s.Prunes
+}
+
+// DuplicateRate returns the fraction of received messages which were duplicates.
+func (s *TopicTraceSummary) DuplicateRate() float64 {
+	total := s.Delivered + s.Duplicates
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Duplicates) / float64(total)
+}
+
+// DeliveryLatency returns the given percentile (in [0, 100]) of the delivery latency.
+func (s *TopicTraceSummary) DeliveryLatency(percentile float64) time.Duration {
+	if len(s.latencies) == 0 {
+		return 0
+	}
+	idx := int(math.Ceil(percentile/100*float64(len(s.latencies)))) - 1
+	idx = max(0, min(idx, len(s.latencies)-1))
+	return s.latencies[idx]
+}
+
+type messageDelivery struct {
+	topic     string
+	timestamp int64
+}
+
+// TraceSummarizer aggregates pubsub trace events, possibly from traces of several nodes, into per-topic statistics.
+type TraceSummarizer struct {
+	topics     map[string]*TopicTraceSummary
+	msgTopics  map[string]string
+	firstSeen  map[string]int64
+	deliveries map[string][]messageDelivery
+	iwants     map[string]*[2]uint64 // message ID -> sent, received
+}
+
+// NewTraceSummarizer creates a new TraceSummarizer.
+func NewTraceSummarizer() *TraceSummarizer {
+	return &TraceSummarizer{
+		topics:     make(map[string]*TopicTraceSummary),
+		msgTopics:  make(map[string]string),
+		firstSeen:  make(map[string]int64),
+		deliveries: make(map[string][]messageDelivery),
+		iwants:     make(map[string]*[2]uint64),
+	}
+}
+
+func (ts *TraceSummarizer) topic(name string) *TopicTraceSummary {
+	s, ok := ts.topics[name]
+	if !ok {
+		s = &TopicTraceSummary{Topic: name}
+		ts.topics[name] = s
+	}
+	return s
+}
+
+// seen records the first time a message was seen.
+func (ts *TraceSummarizer) seen(msgID []byte, topic string, timestamp int64) {
+	id := string(msgID)
+	if topic != "" {
+		ts.msgTopics[id] = topic
+	}
+	if first, ok := ts.firstSeen[id]; !ok || timestamp < first {
+		ts.firstSeen[id] = timestamp
+	}
+}
+
+// Add adds the given event to the summary.
+func (ts *TraceSummarizer) Add(evt *ps_pb.TraceEvent) {
+	timestamp := evt.GetTimestamp()
+	switch evt.GetType() {
+	case ps_pb.TraceEvent_PUBLISH_MESSAGE:
+		msg := evt.GetPublishMessage()
+		ts.topic(msg.GetTopic()).Published++
+		ts.seen(msg.GetMessageID(), msg.GetTopic(), timestamp)
+	case ps_pb.TraceEvent_DELIVER_MESSAGE:
+		msg := evt.GetDeliverMessage()
+		ts.topic(msg.GetTopic()).Delivered++
+		ts.seen(msg.GetMessageID(), msg.GetTopic(), timestamp)
+		id := string(msg.GetMessageID())
+		ts.deliveries[id] = append(ts.deliveries[id], messageDelivery{topic: msg.GetTopic(), timestamp: timestamp})
+	case ps_pb.TraceEvent_DUPLICATE_MESSAGE:
+		msg := evt.GetDuplicateMessage()
+		ts.topic(msg.GetTopic()).Duplicates++
+		ts.seen(msg.GetMessageID(), msg.GetTopic(), timestamp)
+	case ps_pb.TraceEvent_REJECT_MESSAGE:
+		msg := evt.GetRejectMessage()
+		ts.topic(msg.GetTopic()).Rejected++
+		ts.seen(msg.GetMessageID(), msg.GetTopic(), timestamp)
+	case ps_pb.TraceEvent_JOIN:
+		ts.topic(evt.GetJoin().GetTopic()).Joins++
+	case ps_pb.TraceEvent_LEAVE:
+		ts.topic(evt.GetLeave().GetTopic()).Leaves++
+	case ps_pb.TraceEvent_GRAFT:
+		ts.topic(evt.GetGraft().GetTopic()).Grafts++
+	case ps_pb.TraceEvent_PRUNE:
+		ts.topic(evt.GetPrune().GetTopic()).Prunes++
+	case ps_pb.TraceEvent_RECV_RPC:
+		meta := evt.GetRecvRPC().GetMeta()
+		for _, msg := range meta.GetMessages() {
+			ts.seen(msg.GetMessageID(), msg.GetTopic(), timestamp)
+		}
+		ts.addControl(meta.GetControl(), false)
+	case ps_pb.TraceEvent_SEND_RPC:
+		ts.addControl(evt.GetSendRPC().GetMeta().GetControl(), true)
+	}
+}
+
+func (ts *TraceSummarizer) addControl(ctrl *ps_pb.TraceEvent_ControlMeta, sent bool) {
+	if ctrl == nil {
+		return
+	}
+	for _, ihave := range ctrl.GetIhave() {
+		s := ts.topic(ihave.GetTopic())
+		if sent {
+			s.IHaveSent += uint64(len(ihave.GetMessageIDs()))
+		} else {
+			s.IHaveReceived += uint64(len(ihave.GetMessageIDs()))
+		}
+	}
+	// IWANT doesn't specify the topic, so it's resolved by message ID once all events are added.
+	for _, iwant := range ctrl.GetIwant() {
+		for _, msgID := range iwant.GetMessageIDs() {
+			counts, ok := ts.iwants[string(msgID)]
+			if !ok {
+				counts = &[2]uint64{}
+				ts.iwants[string(msgID)] = counts
+			}
+			if sent {
+				counts[0]++
+			} else {
+				counts[1]++
+			}
+		}
+	}
+}
+
+// Summary returns the per-topic statistics sorted by topic name.
+func (ts *TraceSummarizer) Summary() []*TopicTraceSummary {
+	summaries := make(map[string]*TopicTraceSummary, len(ts.topics))
+	summary := func(name string) *TopicTraceSummary {
+		s, ok := summaries[name]
+		if !ok {
+			s = &TopicTraceSummary{Topic: name}
+			if orig, ok := ts.topics[name]; ok {
+				*s = *orig
+			}
+			s.latencies = nil
+			summaries[name] = s
+		}
+		return s
+	}
+	for name := range ts.topics {
+		summary(name)
+	}
+
+	for id, counts := range ts.iwants {
+		topic, ok := ts.msgTopics[id]
+		if !ok {
+			topic = unknownTopic
+		}
+		s := summary(topic)
+		s.IWantSent += counts[0]
+		s.IWantReceived += counts[1]
+	}
+
+	for id, deliveries := range ts.deliveries {
+		first := ts.firstSeen[id]
+		for _, d := range deliveries {
+			s := summary(d.topic)
+			s.latencies = append(s.latencies, time.Duration(d.timestamp-first))
+		}
+	}
+
+	result := make([]*TopicTraceSummary, 0, len(summaries))
+	for _, s := range summaries {
+		sort.Slice(s.latencies, func(i, j int) bool {
+			return s.latencies[i] < s.latencies[j]
+		})
+		result = append(result, s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Topic < result[j].Topic
+	})
+	return result
+}
@@ -182,3 +182,13 @@ func appendIWant(fields []zap.Field, iwant []*ps_pb.TraceEvent_ControlIWantMeta)
 	}
 	return fields
 }
+
+// multiTracer passes events to multiple tracers, as pubsub accepts a single event tracer
+type multiTracer []pubsub.EventTracer
+
+// Trace handles events, implementation of pubsub.EventTracer
+func (mt multiTracer) Trace(evt *ps_pb.TraceEvent) {
+	for _, t := range mt {
+		t.Trace(evt)
+	}
+}
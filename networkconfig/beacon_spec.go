@@ -0,0 +1,99 @@
+package networkconfig
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+)
+
+const beaconSpecTimeout = 30 * time.Second
+
+// FetchBeaconSpec reads the chain parameters of the beacon node's network
+// from its /eth/v1/config/spec and /eth/v1/beacon/genesis endpoints.
+func FetchBeaconSpec(ctx context.Context, beaconNodeAddr string) (beacon.Spec, error) {
+	ctx, cancel := context.WithTimeout(ctx, beaconSpecTimeout)
+	defer cancel()
+
+	var specResponse struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := getBeaconJSON(ctx, beaconNodeAddr, "/eth/v1/config/spec", &specResponse); err != nil {
+		return beacon.Spec{}, err
+	}
+	var genesisResponse struct {
+		Data struct {
+			GenesisTime        string `json:"genesis_time"`
+			GenesisForkVersion string `json:"genesis_fork_version"`
+		} `json:"data"`
+	}
+	if err := getBeaconJSON(ctx, beaconNodeAddr, "/eth/v1/beacon/genesis", &genesisResponse); err != nil {
+		return beacon.Spec{}, err
+	}
+
+	specUint := func(name string) (uint64, error) {
+		v, ok := specResponse.Data[name].(string)
+		if !ok {
+			return 0, fmt.Errorf("spec is missing %s", name)
+		}
+		n, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s: %w", name, err)
+		}
+		return n, nil
+	}
+
+	var spec beacon.Spec
+	secondsPerSlot, err := specUint("SECONDS_PER_SLOT")
+	if err != nil {
+		return beacon.Spec{}, err
+	}
+	spec.SlotDuration = time.Duration(secondsPerSlot) * time.Second
+	if spec.SlotsPerEpoch, err = specUint("SLOTS_PER_EPOCH"); err != nil {
+		return beacon.Spec{}, err
+	}
+	if spec.EpochsPerSyncCommitteePeriod, err = specUint("EPOCHS_PER_SYNC_COMMITTEE_PERIOD"); err != nil {
+		return beacon.Spec{}, err
+	}
+
+	genesisTime, err := strconv.ParseInt(genesisResponse.Data.GenesisTime, 10, 64)
+	if err != nil {
+		return beacon.Spec{}, fmt.Errorf("invalid genesis time: %w", err)
+	}
+	spec.GenesisTime = time.Unix(genesisTime, 0)
+	version, err := decodeHex(genesisResponse.Data.GenesisForkVersion, len(spec.GenesisForkVersion))
+	if err != nil {
+		return beacon.Spec{}, fmt.Errorf("invalid genesis fork version: %w", err)
+	}
+	copy(spec.GenesisForkVersion[:], version)
+
+	return spec, nil
+}
+
+func getBeaconJSON(ctx context.Context, beaconNodeAddr, path string, dest any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(beaconNodeAddr, "/")+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not get %s: %w", path, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not get %s: status %d", path, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(dest); err != nil {
+		return fmt.Errorf("could not decode %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package networkconfig
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+)
+
+// CustomNetwork is a network definition file, in YAML or JSON, see NEW_NETWORK.md.
+type CustomNetwork struct {
+	Name                 string   `yaml:"Name" json:"Name"`
+	DomainType           string   `yaml:"DomainType" json:"DomainType"`
+	GenesisEpoch         uint64   `yaml:"GenesisEpoch" json:"GenesisEpoch"`
+	RegistrySyncOffset   uint64   `yaml:"RegistrySyncOffset" json:"RegistrySyncOffset"`
+	RegistryContractAddr string   `yaml:"RegistryContractAddr" json:"RegistryContractAddr"`
+	Bootnodes            []string `yaml:"Bootnodes" json:"Bootnodes"`
+	// DiscoveryProtocolID is the 6 characters discv5 protocol ID, defaults to "ssvdv5".
+	DiscoveryProtocolID string       `yaml:"DiscoveryProtocolID" json:"DiscoveryProtocolID"`
+	Beacon              CustomBeacon `yaml:"Beacon" json:"Beacon"`
+}
+
+// CustomBeacon defines the beacon chain of a custom network.
+// Chain parameters which aren't set are read from the beacon node.
+//
+// The genesis time and slot timing are always those of Network: the runners and the key manager's
+// slashing protection time duties by the known beacon network, so devnets with their own timing aren't supported.
+type CustomBeacon struct {
+	// Network is the known beacon network the custom network shares its genesis time and slot timing with.
+	Network                      string `yaml:"Network" json:"Network"`
+	GenesisForkVersion           string `yaml:"GenesisForkVersion" json:"GenesisForkVersion"`
+	EpochsPerSyncCommitteePeriod uint64 `yaml:"EpochsPerSyncCommitteePeriod" json:"EpochsPerSyncCommitteePeriod"`
+}
+
+// complete returns whether all the required chain parameters are set.
+func (b CustomBeacon) complete() bool {
+	return b.GenesisForkVersion != ""
+}
+
+// LoadCustomNetwork reads a network definition file, YAML being a superset of JSON both are supported.
+// Beacon chain parameters missing from the file are read from the given beacon node,
+// which may be a semicolon separated list of addresses as in the node config.
+func LoadCustomNetwork(ctx context.Context, path string, beaconNodeAddr string) (NetworkConfig, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return NetworkConfig{}, fmt.Errorf("could not read network config file: %w", err)
+	}
+
+	var custom CustomNetwork
+	if err := yaml.Unmarshal(data, &custom); err != nil {
+		return NetworkConfig{}, fmt.Errorf("could not parse network config file: %w", err)
+	}
+
+	var spec *beacon.Spec
+	if !custom.Beacon.complete() {
+		addr, _, _ := strings.Cut(beaconNodeAddr, ";")
+		if addr == "" {
+			return NetworkConfig{}, fmt.Errorf("beacon chain parameters are missing from the network config file and no beacon node is configured")
+		}
+		fetched, err := FetchBeaconSpec(ctx, addr)
+		if err != nil {
+			return NetworkConfig{}, fmt.Errorf("could not fetch beacon chain parameters: %w", err)
+		}
+		spec = &fetched
+	}
+
+	return custom.Build(spec)
+}
+
+// Build validates the network definition and returns its config.
+// Beacon chain parameters set in the definition take precedence over the given spec, which may be nil.
+func (c CustomNetwork) Build(spec *beacon.Spec) (NetworkConfig, error) {
+	if c.Name == "" {
+		return NetworkConfig{}, fmt.Errorf("network name is required")
+	}
+	if _, ok := SupportedConfigs[c.Name]; ok {
+		return NetworkConfig{}, fmt.Errorf("network name %s is taken by a built-in network", c.Name)
+	}
+
+	domainType, err := decodeHex(c.DomainType, len(spectypes.DomainType{}))
+	if err != nil {
+		return NetworkConfig{}, fmt.Errorf("invalid domain type: %w", err)
+	}
+	if !ethcommon.IsHexAddress(c.RegistryContractAddr) {
+		return NetworkConfig{}, fmt.Errorf("invalid registry contract address %q", c.RegistryContractAddr)
+	}
+
+	config := NetworkConfig{
+		Name:                 c.Name,
+		DomainType:           spectypes.DomainType(domainType),
+		GenesisEpoch:         phase0.Epoch(c.GenesisEpoch),
+		RegistrySyncOffset:   new(big.Int).SetUint64(c.RegistrySyncOffset),
+		RegistryContractAddr: c.RegistryContractAddr,
+		Bootnodes:            c.Bootnodes,
+	}
+	if c.DiscoveryProtocolID != "" {
+		if len(c.DiscoveryProtocolID) != len(config.DiscoveryProtocolID) {
+			return NetworkConfig{}, fmt.Errorf("discovery protocol ID must be %d characters", len(config.DiscoveryProtocolID))
+		}
+		copy(config.DiscoveryProtocolID[:], c.DiscoveryProtocolID)
+	}
+
+	config.Beacon, err = c.Beacon.build(spec)
+	if err != nil {
+		return NetworkConfig{}, err
+	}
+	return config, nil
+}
+
+func (b CustomBeacon) build(spec *beacon.Spec) (beacon.Network, error) {
+	specNetwork := spectypes.NetworkFromString(b.Network)
+	if specNetwork == "" {
+		return beacon.Network{}, fmt.Errorf("unknown beacon network %q", b.Network)
+	}
+
+	s := beacon.Spec{
+		GenesisTime:   time.Unix(int64(specNetwork.MinGenesisTime()), 0), // #nosec G115
+		SlotDuration:  specNetwork.SlotDurationSec(),
+		SlotsPerEpoch: specNetwork.SlotsPerEpoch(),
+	}
+	if spec != nil {
+		// A beacon node with other timing is on a chain, such as a devnet, which can't be followed.
+		switch {
+		case !spec.GenesisTime.Equal(s.GenesisTime):
+			return beacon.Network{}, fmt.Errorf("beacon node genesis time %d differs from the genesis time %d of beacon network %s, devnets with their own timing aren't supported",
+				spec.GenesisTime.Unix(), s.GenesisTime.Unix(), specNetwork)
+		case spec.SlotDuration != s.SlotDuration:
+			return beacon.Network{}, fmt.Errorf("beacon node slot duration %s differs from the slot duration %s of beacon network %s, devnets with their own timing aren't supported",
+				spec.SlotDuration, s.SlotDuration, specNetwork)
+		case spec.SlotsPerEpoch != s.SlotsPerEpoch:
+			return beacon.Network{}, fmt.Errorf("beacon node %d slots per epoch differs from the %d slots per epoch of beacon network %s, devnets with their own timing aren't supported",
+				spec.SlotsPerEpoch, s.SlotsPerEpoch, specNetwork)
+		}
+		s.GenesisForkVersion = spec.GenesisForkVersion
+		s.EpochsPerSyncCommitteePeriod = spec.EpochsPerSyncCommitteePeriod
+	}
+	if b.GenesisForkVersion != "" {
+		version, err := decodeHex(b.GenesisForkVersion, len(phase0.Version{}))
+		if err != nil {
+			return beacon.Network{}, fmt.Errorf("invalid genesis fork version: %w", err)
+		}
+		s.GenesisForkVersion = phase0.Version(version)
+	}
+	if b.EpochsPerSyncCommitteePeriod != 0 {
+		s.EpochsPerSyncCommitteePeriod = b.EpochsPerSyncCommitteePeriod
+	}
+
+	return beacon.NewCustomNetwork(specNetwork, s), nil
+}
+
+func decodeHex(s string, length int) ([]byte, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != length {
+		return nil, fmt.Errorf("expected %d bytes, got %d", length, len(b))
+	}
+	return b, nil
+}
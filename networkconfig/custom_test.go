@@ -0,0 +1,146 @@
+package networkconfig
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+)
+
+const customNetworkYAML = `
+Name: devnet
+DomainType: "0x00000509"
+GenesisEpoch: 10
+RegistrySyncOffset: 100
+RegistryContractAddr: "0x38A4794cCEd47d3baf7370CcC43B560D3a1beEFA"
+Bootnodes:
+  - enr:-Ja4QKFD3u5tZob7xukp
+DiscoveryProtocolID: devdv5
+Beacon:
+  Network: holesky
+`
+
+func fakeBeaconNode(t *testing.T, genesisTime int64, secondsPerSlot int) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/config/spec", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"data":{"SECONDS_PER_SLOT":"%d","SLOTS_PER_EPOCH":"32","EPOCHS_PER_SYNC_COMMITTEE_PERIOD":"8","CONFIG_NAME":"devnet"}}`, secondsPerSlot)
+	})
+	mux.HandleFunc("/eth/v1/beacon/genesis", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"data":{"genesis_time":"%d","genesis_validators_root":"0x00","genesis_fork_version":"0x10000038"}}`, genesisTime)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func writeCustomNetwork(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "network.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestLoadCustomNetwork(t *testing.T) {
+	genesisTime := Holesky.Beacon.MinGenesisTime()
+
+	t.Run("beacon parameters from beacon node", func(t *testing.T) {
+		server := fakeBeaconNode(t, genesisTime, 12)
+		config, err := LoadCustomNetwork(context.Background(), writeCustomNetwork(t, customNetworkYAML), server.URL+";http://localhost:1")
+		require.NoError(t, err)
+
+		require.Equal(t, "devnet", config.Name)
+		require.Equal(t, spectypes.DomainType{0, 0, 5, 9}, config.DomainType)
+		require.Equal(t, phase0.Epoch(10), config.GenesisEpoch)
+		require.Equal(t, uint64(100), config.RegistrySyncOffset.Uint64())
+		require.Equal(t, [6]byte{'d', 'e', 'v', 'd', 'v', '5'}, config.DiscoveryProtocolID)
+		require.Len(t, config.Bootnodes, 1)
+
+		require.Equal(t, spectypes.HoleskyNetwork, config.Beacon.GetBeaconNetwork())
+		require.Equal(t, [4]byte{0x10, 0, 0, 0x38}, config.ForkVersion())
+		require.Equal(t, genesisTime, config.Beacon.MinGenesisTime())
+		require.Equal(t, 12*time.Second, config.SlotDurationSec())
+		require.Equal(t, uint64(8), config.Beacon.EpochsPerSyncCommitteePeriod())
+		require.Equal(t, phase0.Slot(300), config.Beacon.EstimatedSlotAtTime(genesisTime+3600))
+		require.Equal(t, genesisTime+384, config.Beacon.EpochStartTime(1).Unix())
+	})
+
+	t.Run("beacon node slot duration differs from beacon network", func(t *testing.T) {
+		server := fakeBeaconNode(t, genesisTime, 6)
+		_, err := LoadCustomNetwork(context.Background(), writeCustomNetwork(t, customNetworkYAML), server.URL)
+		require.ErrorContains(t, err, "devnets with their own timing aren't supported")
+	})
+
+	t.Run("beacon node genesis differs from beacon network", func(t *testing.T) {
+		server := fakeBeaconNode(t, genesisTime+60, 12)
+		_, err := LoadCustomNetwork(context.Background(), writeCustomNetwork(t, customNetworkYAML), server.URL)
+		require.ErrorContains(t, err, "genesis time")
+	})
+
+	t.Run("beacon parameters from file", func(t *testing.T) {
+		content := customNetworkYAML + `  GenesisForkVersion: "0x10000039"
+`
+		config, err := LoadCustomNetwork(context.Background(), writeCustomNetwork(t, content), "")
+		require.NoError(t, err)
+		require.Equal(t, [4]byte{0x10, 0, 0, 0x39}, config.ForkVersion())
+		require.Equal(t, genesisTime, config.Beacon.MinGenesisTime())
+		require.Equal(t, 12*time.Second, config.SlotDurationSec())
+		require.Equal(t, uint64(32), config.SlotsPerEpoch())
+		require.Equal(t, uint64(256), config.Beacon.EpochsPerSyncCommitteePeriod())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		server := fakeBeaconNode(t, genesisTime, 12)
+		content := `{"Name":"devnet","DomainType":"0x00000509","RegistryContractAddr":"0x38A4794cCEd47d3baf7370CcC43B560D3a1beEFA","Beacon":{"Network":"holesky"}}`
+		config, err := LoadCustomNetwork(context.Background(), writeCustomNetwork(t, content), server.URL)
+		require.NoError(t, err)
+		require.Equal(t, "devnet", config.Name)
+		require.Equal(t, [6]byte{}, config.DiscoveryProtocolID)
+	})
+
+	t.Run("missing beacon node", func(t *testing.T) {
+		_, err := LoadCustomNetwork(context.Background(), writeCustomNetwork(t, customNetworkYAML), "")
+		require.ErrorContains(t, err, "no beacon node")
+	})
+}
+
+func TestCustomNetworkBuild(t *testing.T) {
+	valid := CustomNetwork{
+		Name:                 "devnet",
+		DomainType:           "0x00000509",
+		RegistryContractAddr: "0x38A4794cCEd47d3baf7370CcC43B560D3a1beEFA",
+		Beacon: CustomBeacon{
+			Network:            "holesky",
+			GenesisForkVersion: "0x10000038",
+		},
+	}
+	_, err := valid.Build(nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		modify func(c *CustomNetwork)
+		err    string
+	}{
+		{"built-in name", func(c *CustomNetwork) { c.Name = Mainnet.Name }, "built-in"},
+		{"invalid domain type", func(c *CustomNetwork) { c.DomainType = "0x0005" }, "domain type"},
+		{"invalid registry contract", func(c *CustomNetwork) { c.RegistryContractAddr = "0x1234" }, "registry contract"},
+		{"invalid discovery protocol ID", func(c *CustomNetwork) { c.DiscoveryProtocolID = "ssv" }, "discovery protocol ID"},
+		{"unknown beacon network", func(c *CustomNetwork) { c.Beacon.Network = "devnet" }, "unknown beacon network"},
+		{"invalid genesis fork version", func(c *CustomNetwork) { c.Beacon.GenesisForkVersion = "0x1000" }, "genesis fork version"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid
+			tt.modify(&c)
+			_, err := c.Build(nil)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
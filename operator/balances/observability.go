@@ -0,0 +1,70 @@
+package balances
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/ssvlabs/ssv/observability"
+)
+
+const (
+	observabilityName      = "github.com/ssvlabs/ssv/operator/balances"
+	observabilityNamespace = "ssv.operator.balances"
+)
+
+var (
+	meter = otel.Meter(observabilityName)
+
+	validatorsGauge = observability.NewMetric(
+		meter.Int64Gauge(
+			metricName("validators"),
+			metric.WithUnit("{validator}"),
+			metric.WithDescription("number of own validators whose balance is tracked")))
+
+	balanceGauge = observability.NewMetric(
+		meter.Int64Gauge(
+			metricName("balance"),
+			metric.WithUnit("Gwei"),
+			metric.WithDescription("total balance of own validators at the start of the epoch")))
+
+	rewardGauge = observability.NewMetric(
+		meter.Int64Gauge(
+			metricName("reward"),
+			metric.WithUnit("Gwei"),
+			metric.WithDescription("total balance change of own validators since the start of the previous epoch")))
+
+	negativeValidatorsGauge = observability.NewMetric(
+		meter.Int64Gauge(
+			metricName("validators.negative"),
+			metric.WithUnit("{validator}"),
+			metric.WithDescription("number of own validators whose balance decreased in consecutive epochs")))
+)
+
+func metricName(name string) string {
+	return fmt.Sprintf("%s.%s", observabilityNamespace, name)
+}
+
+func recordSnapshot(ctx context.Context, snapshot *Snapshot, deltas *EpochDeltas) {
+	var balance int64
+	for _, validator := range snapshot.Validators {
+		balance += int64(validator.Balance)
+	}
+	validatorsGauge.Record(ctx, int64(len(snapshot.Validators)))
+	balanceGauge.Record(ctx, balance)
+
+	if deltas == nil {
+		return
+	}
+	var reward int64
+	for _, committee := range deltas.Committees {
+		reward += committee.Delta
+	}
+	rewardGauge.Record(ctx, reward)
+}
+
+func recordNegativeValidators(ctx context.Context, count int) {
+	negativeValidatorsGauge.Record(ctx, int64(count))
+}
@@ -0,0 +1,153 @@
+package balances
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+var (
+	snapshotsPrefix   = []byte("balances/snapshot/")
+	oldestSnapshotKey = []byte("balances/oldest")
+)
+
+// ValidatorBalance is the balance of a validator at the start of an epoch.
+type ValidatorBalance struct {
+	ValidatorIndex phase0.ValidatorIndex  `json:"validator_index"`
+	Committee      []spectypes.OperatorID `json:"committee"`
+	Balance        phase0.Gwei            `json:"balance"`
+}
+
+// Snapshot is the balances of own validators at the start of an epoch.
+type Snapshot struct {
+	Epoch      phase0.Epoch        `json:"epoch"`
+	Validators []*ValidatorBalance `json:"validators"`
+}
+
+// Store persists a snapshot per epoch and deletes the snapshots which are older than the retention.
+type Store struct {
+	db basedb.Database
+}
+
+// NewStore creates a new Store.
+func NewStore(db basedb.Database) *Store {
+	return &Store{db: db}
+}
+
+// Save saves the snapshot, replacing the snapshot of the same epoch if there is one.
+func (s *Store) Save(snapshot *Snapshot) error {
+	value, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	return s.db.Update(func(txn basedb.Txn) error {
+		if err := txn.Set(snapshotsPrefix, snapshotKey(snapshot.Epoch), value); err != nil {
+			return fmt.Errorf("db: %w", err)
+		}
+
+		oldest, found, err := s.oldest(txn)
+		if err != nil {
+			return err
+		}
+		if !found || snapshot.Epoch < oldest {
+			return s.setOldest(txn, snapshot.Epoch)
+		}
+		return nil
+	})
+}
+
+// Snapshots returns the saved snapshots of the epochs in the range [from, to], ordered by epoch.
+func (s *Store) Snapshots(from, to phase0.Epoch) ([]*Snapshot, error) {
+	if from > to {
+		return nil, nil
+	}
+
+	keys := make([][]byte, 0, to-from+1)
+	for epoch := from; epoch <= to; epoch++ {
+		keys = append(keys, snapshotKey(epoch))
+	}
+
+	var snapshots []*Snapshot
+	err := s.db.GetMany(snapshotsPrefix, keys, func(obj basedb.Obj) error {
+		snapshot := &Snapshot{}
+		if err := json.Unmarshal(obj.Value, snapshot); err != nil {
+			return fmt.Errorf("unmarshal: %w", err)
+		}
+		snapshots = append(snapshots, snapshot)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+// NewestEpoch returns the epoch of the most recent saved snapshot.
+func (s *Store) NewestEpoch() (phase0.Epoch, bool, error) {
+	var (
+		newest phase0.Epoch
+		found  bool
+	)
+	err := s.db.GetAll(snapshotsPrefix, func(_ int, obj basedb.Obj) error {
+		if len(obj.Key) != 8 {
+			return fmt.Errorf("invalid snapshot key %x", obj.Key)
+		}
+		epoch := phase0.Epoch(binary.BigEndian.Uint64(obj.Key))
+		if !found || epoch > newest {
+			newest, found = epoch, true
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, false, err
+	}
+	return newest, found, nil
+}
+
+// DeleteBefore deletes the snapshots of the epochs before the given epoch.
+func (s *Store) DeleteBefore(epoch phase0.Epoch) error {
+	return s.db.Update(func(txn basedb.Txn) error {
+		oldest, found, err := s.oldest(txn)
+		if err != nil {
+			return err
+		}
+		if !found || oldest >= epoch {
+			return nil
+		}
+
+		for e := oldest; e < epoch; e++ {
+			if err := txn.Delete(snapshotsPrefix, snapshotKey(e)); err != nil {
+				return fmt.Errorf("db: %w", err)
+			}
+		}
+		return s.setOldest(txn, epoch)
+	})
+}
+
+func (s *Store) oldest(r basedb.Reader) (phase0.Epoch, bool, error) {
+	obj, found, err := r.Get(oldestSnapshotKey, nil)
+	if err != nil {
+		return 0, false, fmt.Errorf("db: %w", err)
+	}
+	if !found {
+		return 0, false, nil
+	}
+	return phase0.Epoch(binary.BigEndian.Uint64(obj.Value)), true, nil
+}
+
+func (s *Store) setOldest(rw basedb.ReadWriter, epoch phase0.Epoch) error {
+	if err := rw.Set(oldestSnapshotKey, nil, snapshotKey(epoch)); err != nil {
+		return fmt.Errorf("db: %w", err)
+	}
+	return nil
+}
+
+func snapshotKey(epoch phase0.Epoch) []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(epoch))
+}
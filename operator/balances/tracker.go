@@ -0,0 +1,354 @@
+package balances
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/operator/slotticker"
+	"github.com/ssvlabs/ssv/protocol/v2/types"
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+// trackingSlotOffset is the slot within the epoch at which the balances at its start are tracked,
+// so that the state of the epoch's first slot is available even if the slot's block arrived late.
+const trackingSlotOffset = 1
+
+// BeaconNode represents a provider of validator balances.
+type BeaconNode interface {
+	// ValidatorBalances returns the balances of the given validators at the first slot of the given epoch.
+	ValidatorBalances(ctx context.Context, epoch phase0.Epoch, indices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]phase0.Gwei, error)
+}
+
+// ValidatorProvider represents a provider of validator information.
+type ValidatorProvider interface {
+	SelfParticipatingValidators(epoch phase0.Epoch) []*types.SSVShare
+}
+
+// Options contains the configuration options for the balance tracker.
+type Options struct {
+	Network            networkconfig.NetworkConfig
+	BeaconNode         BeaconNode
+	ValidatorProvider  ValidatorProvider
+	DB                 basedb.Database
+	SlotTickerProvider slotticker.Provider
+	Logger             *zap.Logger
+	// RetainedEpochs is the number of most recent epochs whose balances are kept.
+	RetainedEpochs uint64
+	// NegativeEpochs is the number of consecutive epochs with a negative balance delta
+	// after which a validator is flagged.
+	NegativeEpochs uint64
+}
+
+// ValidatorDelta is the change of a validator's balance from the start of the previous epoch.
+type ValidatorDelta struct {
+	ValidatorIndex phase0.ValidatorIndex
+	Committee      []spectypes.OperatorID
+	Balance        phase0.Gwei
+	Delta          int64
+}
+
+// CommitteeDelta is the sum of the balance changes of a committee's validators.
+type CommitteeDelta struct {
+	Committee  []spectypes.OperatorID
+	Validators int
+	Delta      int64
+}
+
+// EpochDeltas are the balance changes of own validators from the start of the previous epoch to the start of Epoch.
+// Validators whose balance wasn't tracked at the start of both epochs are left out.
+type EpochDeltas struct {
+	Epoch      phase0.Epoch
+	Validators []*ValidatorDelta
+	Committees []*CommitteeDelta
+}
+
+// NegativeValidator is a validator whose balance decreased in every one of the most recent Epochs epochs.
+type NegativeValidator struct {
+	ValidatorIndex phase0.ValidatorIndex
+	Committee      []spectypes.OperatorID
+	Epochs         uint64
+	// Delta is the sum of the balance changes over the epochs.
+	Delta int64
+}
+
+// Tracker takes a snapshot of the balances of own validators at the start of every epoch and keeps
+// the snapshots of the most recent epochs, from which it computes the rewards (or penalties) of
+// validators and committees. A single negative delta is common (e.g. withdrawals of the balance
+// above the maximum effective balance), so a validator is flagged only after several in a row.
+type Tracker struct {
+	network            networkconfig.NetworkConfig
+	beaconNode         BeaconNode
+	validatorProvider  ValidatorProvider
+	store              *Store
+	slotTickerProvider slotticker.Provider
+	logger             *zap.Logger
+	retainedEpochs     uint64
+	negativeEpochs     uint64
+
+	// mu synchronizes access to lastEpoch
+	mu        sync.RWMutex
+	lastEpoch *phase0.Epoch
+}
+
+// New initializes a new balance tracker, which resumes from the most recent snapshot saved before a restart.
+func New(opts *Options) *Tracker {
+	t := &Tracker{
+		network:            opts.Network,
+		beaconNode:         opts.BeaconNode,
+		validatorProvider:  opts.ValidatorProvider,
+		store:              NewStore(opts.DB),
+		slotTickerProvider: opts.SlotTickerProvider,
+		logger:             opts.Logger.Named(logging.NameBalanceTracker),
+		retainedEpochs:     max(opts.RetainedEpochs, opts.NegativeEpochs+1),
+		negativeEpochs:     max(opts.NegativeEpochs, 1),
+	}
+
+	lastEpoch, found, err := t.store.NewestEpoch()
+	if err != nil {
+		t.logger.Warn("could not load the epoch of the most recent balances", zap.Error(err))
+	} else if found {
+		t.lastEpoch = &lastEpoch
+	}
+	return t
+}
+
+// Start tracks the balances at the start of every epoch until the context is done.
+func (t *Tracker) Start(ctx context.Context) {
+	t.logger.Info("balance tracking started",
+		zap.Uint64("retained_epochs", t.retainedEpochs),
+		zap.Uint64("negative_epochs", t.negativeEpochs))
+
+	ticker := t.slotTickerProvider()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.Next():
+			slot := ticker.Slot()
+			if uint64(slot)%t.network.SlotsPerEpoch() != trackingSlotOffset {
+				continue
+			}
+			epoch := t.network.Beacon.EstimatedEpochAtSlot(slot)
+
+			start := time.Now()
+			snapshot, err := t.TrackEpoch(ctx, epoch)
+			if err != nil {
+				t.logger.Warn("could not track balances", fields.Epoch(epoch), zap.Error(err))
+				continue
+			}
+
+			negative, err := t.NegativeValidators(epoch)
+			if err != nil {
+				t.logger.Warn("could not find validators with negative balance deltas", fields.Epoch(epoch), zap.Error(err))
+				continue
+			}
+			recordNegativeValidators(ctx, len(negative))
+			for _, validator := range negative {
+				t.logger.Warn("validator balance decreased in consecutive epochs",
+					fields.ValidatorIndex(validator.ValidatorIndex),
+					fields.Epoch(epoch),
+					zap.Uint64("epochs", validator.Epochs),
+					zap.Int64("delta_gwei", validator.Delta))
+			}
+
+			t.logger.Debug("tracked balances",
+				fields.Epoch(epoch),
+				zap.Int("validators", len(snapshot.Validators)),
+				zap.Int("negative", len(negative)),
+				fields.Took(time.Since(start)))
+		}
+	}
+}
+
+// TrackEpoch saves the balances of own validators at the start of the given epoch, records them
+// in metrics along with the rewards since the previous epoch and deletes the snapshots past the retention.
+func (t *Tracker) TrackEpoch(ctx context.Context, epoch phase0.Epoch) (*Snapshot, error) {
+	shares := t.validatorProvider.SelfParticipatingValidators(epoch)
+	indices := make([]phase0.ValidatorIndex, 0, len(shares))
+	for _, share := range shares {
+		indices = append(indices, share.ValidatorIndex)
+	}
+
+	snapshot := &Snapshot{Epoch: epoch}
+	if len(indices) > 0 {
+		balances, err := t.beaconNode.ValidatorBalances(ctx, epoch, indices)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch validator balances: %w", err)
+		}
+		for _, share := range shares {
+			balance, ok := balances[share.ValidatorIndex]
+			if !ok {
+				continue
+			}
+			snapshot.Validators = append(snapshot.Validators, &ValidatorBalance{
+				ValidatorIndex: share.ValidatorIndex,
+				Committee:      share.OperatorIDs(),
+				Balance:        balance,
+			})
+		}
+		slices.SortFunc(snapshot.Validators, func(a, b *ValidatorBalance) int {
+			return cmp.Compare(a.ValidatorIndex, b.ValidatorIndex)
+		})
+	}
+
+	if err := t.store.Save(snapshot); err != nil {
+		return nil, fmt.Errorf("could not save balances: %w", err)
+	}
+	if uint64(epoch) >= t.retainedEpochs {
+		if err := t.store.DeleteBefore(epoch - phase0.Epoch(t.retainedEpochs) + 1); err != nil {
+			return nil, fmt.Errorf("could not delete old balances: %w", err)
+		}
+	}
+
+	var delta *EpochDeltas
+	if epoch > 0 {
+		deltas, err := t.Deltas(epoch, epoch)
+		if err != nil {
+			return nil, err
+		}
+		if len(deltas) > 0 {
+			delta = deltas[0]
+		}
+	}
+	recordSnapshot(ctx, snapshot, delta)
+
+	t.mu.Lock()
+	if t.lastEpoch == nil || epoch > *t.lastEpoch {
+		t.lastEpoch = &epoch
+	}
+	t.mu.Unlock()
+
+	return snapshot, nil
+}
+
+// LastEpoch returns the most recent epoch whose balances were tracked.
+func (t *Tracker) LastEpoch() (phase0.Epoch, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.lastEpoch == nil {
+		return 0, false
+	}
+	return *t.lastEpoch, true
+}
+
+// NegativeEpochs returns the number of consecutive epochs with a negative balance delta after which a validator is flagged.
+func (t *Tracker) NegativeEpochs() uint64 {
+	return t.negativeEpochs
+}
+
+// Deltas returns the balance changes of the epochs in the range [from, to] whose balances
+// were tracked at the start of both the epoch and the previous one, ordered by epoch.
+func (t *Tracker) Deltas(from, to phase0.Epoch) ([]*EpochDeltas, error) {
+	if from > to {
+		return nil, nil
+	}
+	snapshotsFrom := from
+	if snapshotsFrom > 0 {
+		snapshotsFrom--
+	}
+	snapshots, err := t.store.Snapshots(snapshotsFrom, to)
+	if err != nil {
+		return nil, fmt.Errorf("could not load balances: %w", err)
+	}
+
+	var deltas []*EpochDeltas
+	for i := 1; i < len(snapshots); i++ {
+		previous, current := snapshots[i-1], snapshots[i]
+		if previous.Epoch+1 != current.Epoch {
+			continue
+		}
+		deltas = append(deltas, epochDeltas(previous, current))
+	}
+	return deltas, nil
+}
+
+// NegativeValidators returns the validators whose balance decreased in each
+// of the configured number of consecutive epochs up to the given epoch.
+func (t *Tracker) NegativeValidators(epoch phase0.Epoch) ([]*NegativeValidator, error) {
+	if uint64(epoch) < t.negativeEpochs {
+		return nil, nil
+	}
+	deltas, err := t.Deltas(epoch-phase0.Epoch(t.negativeEpochs)+1, epoch)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(deltas)) < t.negativeEpochs {
+		// Some of the epochs weren't tracked.
+		return nil, nil
+	}
+
+	negative := make(map[phase0.ValidatorIndex]*NegativeValidator)
+	for i, epochDeltas := range deltas {
+		for _, delta := range epochDeltas.Validators {
+			validator, ok := negative[delta.ValidatorIndex]
+			if i == 0 && delta.Delta < 0 {
+				negative[delta.ValidatorIndex] = &NegativeValidator{
+					ValidatorIndex: delta.ValidatorIndex,
+					Committee:      delta.Committee,
+					Epochs:         1,
+					Delta:          delta.Delta,
+				}
+			} else if ok && uint64(i) == validator.Epochs && delta.Delta < 0 {
+				validator.Epochs++
+				validator.Delta += delta.Delta
+			}
+		}
+	}
+
+	var validators []*NegativeValidator
+	for _, validator := range negative {
+		if validator.Epochs == t.negativeEpochs {
+			validators = append(validators, validator)
+		}
+	}
+	slices.SortFunc(validators, func(a, b *NegativeValidator) int {
+		return cmp.Compare(a.ValidatorIndex, b.ValidatorIndex)
+	})
+	return validators, nil
+}
+
+func epochDeltas(previous, current *Snapshot) *EpochDeltas {
+	previousBalances := make(map[phase0.ValidatorIndex]phase0.Gwei, len(previous.Validators))
+	for _, validator := range previous.Validators {
+		previousBalances[validator.ValidatorIndex] = validator.Balance
+	}
+
+	deltas := &EpochDeltas{Epoch: current.Epoch}
+	committees := make(map[spectypes.CommitteeID]*CommitteeDelta)
+	for _, validator := range current.Validators {
+		previousBalance, ok := previousBalances[validator.ValidatorIndex]
+		if !ok {
+			continue
+		}
+		delta := int64(validator.Balance) - int64(previousBalance)
+		deltas.Validators = append(deltas.Validators, &ValidatorDelta{
+			ValidatorIndex: validator.ValidatorIndex,
+			Committee:      validator.Committee,
+			Balance:        validator.Balance,
+			Delta:          delta,
+		})
+
+		committeeID := types.ComputeCommitteeID(validator.Committee)
+		committee, ok := committees[committeeID]
+		if !ok {
+			committee = &CommitteeDelta{Committee: validator.Committee}
+			committees[committeeID] = committee
+			deltas.Committees = append(deltas.Committees, committee)
+		}
+		committee.Validators++
+		committee.Delta += delta
+	}
+	return deltas
+}
@@ -0,0 +1,127 @@
+package balances
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/protocol/v2/types"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+type fakeBeaconNode struct {
+	balances map[phase0.Epoch]map[phase0.ValidatorIndex]phase0.Gwei
+}
+
+func (b *fakeBeaconNode) ValidatorBalances(_ context.Context, epoch phase0.Epoch, indices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]phase0.Gwei, error) {
+	balances := make(map[phase0.ValidatorIndex]phase0.Gwei)
+	for _, index := range indices {
+		if balance, ok := b.balances[epoch][index]; ok {
+			balances[index] = balance
+		}
+	}
+	return balances, nil
+}
+
+type fakeValidatorProvider []*types.SSVShare
+
+func (p fakeValidatorProvider) SelfParticipatingValidators(phase0.Epoch) []*types.SSVShare {
+	return p
+}
+
+func testShare(index phase0.ValidatorIndex, committee ...spectypes.OperatorID) *types.SSVShare {
+	share := &types.SSVShare{}
+	share.ValidatorIndex = index
+	for _, operatorID := range committee {
+		share.Committee = append(share.Committee, &spectypes.ShareMember{Signer: operatorID})
+	}
+	return share
+}
+
+func TestTracker(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := kv.NewInMemory(logging.TestLogger(t), basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	const gwei = 32_000_000_000
+	beaconNode := &fakeBeaconNode{balances: map[phase0.Epoch]map[phase0.ValidatorIndex]phase0.Gwei{
+		10: {1: gwei, 2: gwei, 3: gwei},
+		11: {1: gwei + 10, 2: gwei - 5, 3: gwei + 20},
+		12: {1: gwei + 20, 2: gwei - 10, 3: gwei + 10},
+		13: {1: gwei + 30, 2: gwei - 15, 3: gwei + 20},
+	}}
+	opts := &Options{
+		Network:    networkconfig.TestNetwork,
+		BeaconNode: beaconNode,
+		ValidatorProvider: fakeValidatorProvider{
+			testShare(1, 1, 2, 3, 4),
+			testShare(2, 1, 2, 3, 4),
+			testShare(3, 5, 6, 7, 8),
+		},
+		DB:             db,
+		Logger:         zap.NewNop(),
+		RetainedEpochs: 3,
+		NegativeEpochs: 2,
+	}
+	tracker := New(opts)
+	_, ok := tracker.LastEpoch()
+	require.False(t, ok)
+
+	for epoch := phase0.Epoch(10); epoch <= 13; epoch++ {
+		snapshot, err := tracker.TrackEpoch(ctx, epoch)
+		require.NoError(t, err)
+		require.Len(t, snapshot.Validators, 3)
+	}
+
+	// The balances of epoch 10 are past the retention.
+	deltas, err := tracker.Deltas(10, 13)
+	require.NoError(t, err)
+	require.Len(t, deltas, 2)
+	require.Equal(t, phase0.Epoch(12), deltas[0].Epoch)
+	require.Equal(t, phase0.Epoch(13), deltas[1].Epoch)
+
+	require.Len(t, deltas[1].Validators, 3)
+	require.Equal(t, int64(10), deltas[1].Validators[0].Delta)
+	require.Equal(t, int64(-5), deltas[1].Validators[1].Delta)
+	require.Equal(t, phase0.Gwei(gwei+20), deltas[1].Validators[2].Balance)
+
+	require.Len(t, deltas[1].Committees, 2)
+	require.Equal(t, []spectypes.OperatorID{1, 2, 3, 4}, deltas[1].Committees[0].Committee)
+	require.Equal(t, 2, deltas[1].Committees[0].Validators)
+	require.Equal(t, int64(5), deltas[1].Committees[0].Delta)
+	require.Equal(t, int64(10), deltas[1].Committees[1].Delta)
+
+	negative, err := tracker.NegativeValidators(13)
+	require.NoError(t, err)
+	require.Len(t, negative, 1)
+	require.Equal(t, phase0.ValidatorIndex(2), negative[0].ValidatorIndex)
+	require.Equal(t, uint64(2), negative[0].Epochs)
+	require.Equal(t, int64(-10), negative[0].Delta)
+
+	// Without the balances of epoch 10 the delta of epoch 11 is unknown, so no validator is flagged.
+	negative, err = tracker.NegativeValidators(12)
+	require.NoError(t, err)
+	require.Empty(t, negative)
+
+	// Epochs whose previous epoch wasn't tracked have no deltas.
+	beaconNode.balances[15] = map[phase0.ValidatorIndex]phase0.Gwei{1: gwei}
+	_, err = tracker.TrackEpoch(ctx, 15)
+	require.NoError(t, err)
+	deltas, err = tracker.Deltas(14, 15)
+	require.NoError(t, err)
+	require.Empty(t, deltas)
+
+	// After a restart, the last epoch is that of the most recent saved balances.
+	lastEpoch, ok := New(opts).LastEpoch()
+	require.True(t, ok)
+	require.Equal(t, phase0.Epoch(15), lastEpoch)
+}
@@ -97,6 +97,21 @@ func (d *Duties[D]) ResetEpoch(epoch phase0.Epoch) {
 	delete(d.m, epoch)
 }
 
+// PruneBefore removes the duties of all epochs before the given one and returns the number of removed epochs.
+func (d *Duties[D]) PruneBefore(epoch phase0.Epoch) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	pruned := 0
+	for e := range d.m {
+		if e < epoch {
+			delete(d.m, e)
+			pruned++
+		}
+	}
+	return pruned
+}
+
 func (d *Duties[D]) IsEpochSet(epoch phase0.Epoch) bool {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
@@ -72,9 +72,32 @@ func (d *SyncCommitteeDuties) Set(period uint64, duties []StoreSyncCommitteeDuty
 	d.m[period] = mapped
 }
 
+func (d *SyncCommitteeDuties) IsPeriodSet(period uint64) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	_, exists := d.m[period]
+	return exists
+}
+
 func (d *SyncCommitteeDuties) Reset(period uint64) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
 	delete(d.m, period)
 }
+
+// PruneBefore removes the duties of all periods before the given one and returns the number of removed periods.
+func (d *SyncCommitteeDuties) PruneBefore(period uint64) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	pruned := 0
+	for p := range d.m {
+		if p < period {
+			delete(d.m, p)
+			pruned++
+		}
+	}
+	return pruned
+}
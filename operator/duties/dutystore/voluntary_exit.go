@@ -29,6 +29,18 @@ func (d *VoluntaryExitDuties) GetDutyCount(slot phase0.Slot, pk phase0.BLSPubKey
 	return v[pk]
 }
 
+// PubKeys returns the public keys of the validators with exit duties at the given slot.
+func (d *VoluntaryExitDuties) PubKeys(slot phase0.Slot) []phase0.BLSPubKey {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	pubKeys := make([]phase0.BLSPubKey, 0, len(d.m[slot]))
+	for pk := range d.m[slot] {
+		pubKeys = append(pubKeys, pk)
+	}
+	return pubKeys
+}
+
 func (d *VoluntaryExitDuties) AddDuty(slot phase0.Slot, pk phase0.BLSPubKey) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
@@ -54,6 +55,8 @@ type BeaconNode interface {
 	SubmitBeaconCommitteeSubscriptions(ctx context.Context, subscription []*eth2apiv1.BeaconCommitteeSubscription) error
 	SubmitSyncCommitteeSubscriptions(ctx context.Context, subscription []*eth2apiv1.SyncCommitteeSubscription) error
 	SubscribeToHeadEvents(ctx context.Context, subscriberIdentifier string, ch chan<- *eth2apiv1.HeadEvent) error
+	SubscribeToChainReorgEvents(ctx context.Context, subscriberIdentifier string, ch chan<- *eth2apiv1.ChainReorgEvent) error
+	SubscribeToFinalizedCheckpointEvents(ctx context.Context, subscriberIdentifier string, ch chan<- *eth2apiv1.FinalizedCheckpointEvent) error
 }
 
 type ExecutionClient interface {
@@ -97,6 +100,7 @@ type Scheduler struct {
 	dutyExecutor        DutyExecutor
 
 	handlers            []dutyHandler
+	dutyStore           *dutystore.Store
 	blockPropagateDelay time.Duration
 
 	reorg      chan ReorgEvent
@@ -104,6 +108,7 @@ type Scheduler struct {
 	ticker     slotticker.SlotTicker
 	waitCond   *sync.Cond
 	pool       *pool.ContextPool
+	stopped    atomic.Bool
 
 	headSlot                  phase0.Slot
 	lastBlockEpoch            phase0.Epoch
@@ -127,6 +132,7 @@ func NewScheduler(opts *SchedulerOptions) *Scheduler {
 		validatorController: opts.ValidatorController,
 		indicesChg:          opts.IndicesChg,
 		blockPropagateDelay: blockPropagationDelay,
+		dutyStore:           dutyStore,
 
 		handlers: []dutyHandler{
 			NewAttesterHandler(dutyStore.Attester),
@@ -158,9 +164,9 @@ func (s *Scheduler) Start(ctx context.Context, logger *zap.Logger) error {
 	logger = logger.Named(logging.NameDutyScheduler)
 	logger.Info("duty scheduler started")
 
-	logger.Info("subscribing to head events")
-	if err := s.listenToHeadEvents(ctx, logger); err != nil {
-		return fmt.Errorf("failed to listen to head events: %w", err)
+	logger.Info("subscribing to beacon events")
+	if err := s.listenToBeaconEvents(ctx, logger); err != nil {
+		return fmt.Errorf("failed to listen to beacon events: %w", err)
 	}
 
 	s.pool = pool.New().WithContext(ctx).WithCancelOnError()
@@ -207,8 +213,10 @@ func (s *Scheduler) Start(ctx context.Context, logger *zap.Logger) error {
 	return nil
 }
 
-func (s *Scheduler) listenToHeadEvents(ctx context.Context, logger *zap.Logger) error {
+func (s *Scheduler) listenToBeaconEvents(ctx context.Context, logger *zap.Logger) error {
 	headEventHandler := s.HandleHeadEvent(logger)
+	chainReorgEventHandler := s.HandleChainReorgEvent(logger)
+	finalizedCheckpointEventHandler := s.HandleFinalizedCheckpointEvent(logger)
 
 	// Subscribe to head events. This allows us to go early for attestations & sync committees if a block arrives,
 	// as well as re-request duties if there is a change in beacon block.
@@ -218,6 +226,20 @@ func (s *Scheduler) listenToHeadEvents(ctx context.Context, logger *zap.Logger)
 		return fmt.Errorf("failed to subscribe to head events: %w", err)
 	}
 
+	// Subscribe to chain reorg events, to re-request duties as soon as a reorg is reported,
+	// rather than when it's noticed in the dependent roots of the next head event.
+	reorgCh := make(chan *eth2apiv1.ChainReorgEvent, 32)
+	if err := s.beaconNode.SubscribeToChainReorgEvents(ctx, "duty_scheduler", reorgCh); err != nil {
+		return fmt.Errorf("failed to subscribe to chain reorg events: %w", err)
+	}
+
+	// Subscribe to finalized checkpoint events, to prune the duties of finalized epochs.
+	finalizedCh := make(chan *eth2apiv1.FinalizedCheckpointEvent, 32)
+	if err := s.beaconNode.SubscribeToFinalizedCheckpointEvents(ctx, "duty_scheduler", finalizedCh); err != nil {
+		return fmt.Errorf("failed to subscribe to finalized checkpoint events: %w", err)
+	}
+
+	// The events are handled in a single goroutine, since the head and chain reorg handlers share state.
 	go func() {
 		for {
 			select {
@@ -234,6 +256,18 @@ func (s *Scheduler) listenToHeadEvents(ctx context.Context, logger *zap.Logger)
 					Info("received head event. Processing...")
 
 				headEventHandler(headEvent)
+			case reorgEvent := <-reorgCh:
+				if reorgEvent == nil {
+					logger.Warn("chain reorg event was nil, skipping")
+					continue
+				}
+				chainReorgEventHandler(reorgEvent)
+			case finalizedEvent := <-finalizedCh:
+				if finalizedEvent == nil {
+					logger.Warn("finalized checkpoint event was nil, skipping")
+					continue
+				}
+				finalizedCheckpointEventHandler(finalizedEvent)
 			}
 		}
 	}()
@@ -245,6 +279,12 @@ func (s *Scheduler) Wait() error {
 	return s.pool.Wait()
 }
 
+// Stop stops executing new duties, e.g. when shutting down, while the duties which already started keep running.
+// Duties keep being fetched, so the scheduler still stops once its context is done.
+func (s *Scheduler) Stop() {
+	s.stopped.Store(true)
+}
+
 type EventFeed[T any] struct {
 	feed *event.Feed
 }
@@ -320,7 +360,7 @@ func (s *Scheduler) HandleHeadEvent(logger *zap.Logger) func(event *eth2apiv1.He
 			if epoch > s.lastBlockEpoch {
 				// Change of epoch.
 				// Ensure that the new previous dependent root is the same as the old current root.
-				if !bytes.Equal(s.previousDutyDependentRoot[:], zeroRoot[:]) &&
+				if !bytes.Equal(s.currentDutyDependentRoot[:], zeroRoot[:]) &&
 					!bytes.Equal(s.currentDutyDependentRoot[:], event.PreviousDutyDependentRoot[:]) {
 					logger.Debug("🔀 Previous duty dependent root has changed on epoch transition",
 						zap.String("old_current_dependent_root", fmt.Sprintf("%#x", s.currentDutyDependentRoot[:])),
@@ -383,8 +423,73 @@ func (s *Scheduler) HandleHeadEvent(logger *zap.Logger) func(event *eth2apiv1.He
 	}
 }
 
+// HandleChainReorgEvent handles the "chain_reorg" events from the beacon node.
+// Duties are refetched right away if the reorg replaced the blocks their dependent roots point to,
+// instead of waiting for the next head event to report the changed dependent roots.
+func (s *Scheduler) HandleChainReorgEvent(logger *zap.Logger) func(event *eth2apiv1.ChainReorgEvent) {
+	return func(event *eth2apiv1.ChainReorgEvent) {
+		epoch := s.network.Beacon.EstimatedEpochAtSlot(event.Slot)
+		forkSlot := event.Slot - min(phase0.Slot(event.Depth), event.Slot)
+
+		logger := logger.With(
+			fields.Slot(event.Slot),
+			fields.Epoch(epoch),
+			zap.Uint64("depth", event.Depth),
+		)
+
+		// The current duty dependent root is the block at the last slot of the previous epoch,
+		// and the previous duty dependent root is the block at the last slot of the epoch before that.
+		// A reorg changes them if it forked off before these slots.
+		reorgEvent := ReorgEvent{
+			Slot:     event.Slot,
+			Current:  forkSlot+1 < s.network.Beacon.FirstSlotAtEpoch(epoch),
+			Previous: epoch > 0 && forkSlot+1 < s.network.Beacon.FirstSlotAtEpoch(epoch-1),
+		}
+		if !reorgEvent.Current && !reorgEvent.Previous {
+			logger.Debug("🔀 Chain reorg did not change the duty dependent roots")
+			return
+		}
+
+		logger.Debug("🔀 Chain reorg changed the duty dependent roots",
+			zap.Bool("previous", reorgEvent.Previous),
+			zap.Bool("current", reorgEvent.Current))
+
+		// Reset the changed dependent roots, so the next head event doesn't report the same reorg again.
+		if reorgEvent.Previous {
+			s.previousDutyDependentRoot = phase0.Root{}
+		}
+		if reorgEvent.Current {
+			s.currentDutyDependentRoot = phase0.Root{}
+		}
+
+		s.reorg <- reorgEvent
+	}
+}
+
+// HandleFinalizedCheckpointEvent handles the "finalized_checkpoint" events from the beacon node
+// by pruning the duties of the finalized epochs, which can no longer change.
+func (s *Scheduler) HandleFinalizedCheckpointEvent(logger *zap.Logger) func(event *eth2apiv1.FinalizedCheckpointEvent) {
+	return func(event *eth2apiv1.FinalizedCheckpointEvent) {
+		period := s.network.Beacon.EstimatedSyncCommitteePeriodAtEpoch(event.Epoch)
+
+		attesterPruned := s.dutyStore.Attester.PruneBefore(event.Epoch)
+		proposerPruned := s.dutyStore.Proposer.PruneBefore(event.Epoch)
+		syncCommitteePruned := s.dutyStore.SyncCommittee.PruneBefore(period)
+
+		logger.Debug("🗑 pruned duties of finalized epochs",
+			fields.Epoch(event.Epoch),
+			zap.Int("attester_epochs", attesterPruned),
+			zap.Int("proposer_epochs", proposerPruned),
+			zap.Int("sync_committee_periods", syncCommitteePruned))
+	}
+}
+
 // ExecuteDuties tries to execute the given duties
 func (s *Scheduler) ExecuteDuties(ctx context.Context, logger *zap.Logger, duties []*spectypes.ValidatorDuty) {
+	if s.stopped.Load() {
+		logger.Debug("duty scheduler is stopped, skipping duties", zap.Int("duties", len(duties)))
+		return
+	}
 	for _, duty := range duties {
 		duty := duty
 		logger := s.loggerWithDutyContext(logger, duty)
@@ -397,6 +502,10 @@ func (s *Scheduler) ExecuteDuties(ctx context.Context, logger *zap.Logger, dutie
 			if duty.Type == spectypes.BNRoleAttester || duty.Type == spectypes.BNRoleSyncCommittee {
 				s.waitOneThirdOrValidBlock(duty.Slot)
 			}
+			if s.stopped.Load() {
+				logger.Debug("duty scheduler stopped before the duty started")
+				return
+			}
 			recordDutyExecuted(ctx, duty.RunnerRole())
 			s.dutyExecutor.ExecuteDuty(ctx, logger, duty)
 		}()
@@ -405,6 +514,10 @@ func (s *Scheduler) ExecuteDuties(ctx context.Context, logger *zap.Logger, dutie
 
 // ExecuteCommitteeDuties tries to execute the given committee duties
 func (s *Scheduler) ExecuteCommitteeDuties(ctx context.Context, logger *zap.Logger, duties committeeDutiesMap) {
+	if s.stopped.Load() {
+		logger.Debug("duty scheduler is stopped, skipping committee duties", zap.Int("committees", len(duties)))
+		return
+	}
 	for _, committee := range duties {
 		duty := committee.duty
 		logger := s.loggerWithCommitteeDutyContext(logger, committee)
@@ -418,6 +531,10 @@ func (s *Scheduler) ExecuteCommitteeDuties(ctx context.Context, logger *zap.Logg
 		slotDelayHistogram.Record(ctx, slotDelay.Seconds())
 		go func() {
 			s.waitOneThirdOrValidBlock(duty.Slot)
+			if s.stopped.Load() {
+				logger.Debug("duty scheduler stopped before the committee duty started")
+				return
+			}
 			recordDutyExecuted(ctx, duty.RunnerRole())
 			s.dutyExecutor.ExecuteCommitteeDuty(ctx, logger, committee.id, duty)
 		}()
@@ -201,6 +201,34 @@ func (mr *MockBeaconNodeMockRecorder) SubmitSyncCommitteeSubscriptions(ctx, subs
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitSyncCommitteeSubscriptions", reflect.TypeOf((*MockBeaconNode)(nil).SubmitSyncCommitteeSubscriptions), ctx, subscription)
 }
 
+// SubscribeToChainReorgEvents mocks base method.
+func (m *MockBeaconNode) SubscribeToChainReorgEvents(ctx context.Context, subscriberIdentifier string, ch chan<- *v1.ChainReorgEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeToChainReorgEvents", ctx, subscriberIdentifier, ch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubscribeToChainReorgEvents indicates an expected call of SubscribeToChainReorgEvents.
+func (mr *MockBeaconNodeMockRecorder) SubscribeToChainReorgEvents(ctx, subscriberIdentifier, ch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeToChainReorgEvents", reflect.TypeOf((*MockBeaconNode)(nil).SubscribeToChainReorgEvents), ctx, subscriberIdentifier, ch)
+}
+
+// SubscribeToFinalizedCheckpointEvents mocks base method.
+func (m *MockBeaconNode) SubscribeToFinalizedCheckpointEvents(ctx context.Context, subscriberIdentifier string, ch chan<- *v1.FinalizedCheckpointEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeToFinalizedCheckpointEvents", ctx, subscriberIdentifier, ch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubscribeToFinalizedCheckpointEvents indicates an expected call of SubscribeToFinalizedCheckpointEvents.
+func (mr *MockBeaconNodeMockRecorder) SubscribeToFinalizedCheckpointEvents(ctx, subscriberIdentifier, ch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeToFinalizedCheckpointEvents", reflect.TypeOf((*MockBeaconNode)(nil).SubscribeToFinalizedCheckpointEvents), ctx, subscriberIdentifier, ch)
+}
+
 // SubscribeToHeadEvents mocks base method.
 func (m *MockBeaconNode) SubscribeToHeadEvents(ctx context.Context, subscriberIdentifier string, ch chan<- *v1.HeadEvent) error {
 	m.ctrl.T.Helper()
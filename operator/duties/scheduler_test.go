@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/prysmaticlabs/prysm/v4/async/event"
 	"github.com/sourcegraph/conc/pool"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/ssvlabs/ssv/logging"
 	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/operator/duties/dutystore"
 	"github.com/ssvlabs/ssv/operator/slotticker"
 	mockslotticker "github.com/ssvlabs/ssv/operator/slotticker/mocks"
 	mocknetwork "github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon/mocks"
@@ -111,6 +113,8 @@ func setupSchedulerAndMocks(t *testing.T, handlers []dutyHandler, currentSlot *S
 	s.handlers = handlers
 
 	mockBeaconNode.EXPECT().SubscribeToHeadEvents(ctx, "duty_scheduler", gomock.Any()).Return(nil)
+	mockBeaconNode.EXPECT().SubscribeToChainReorgEvents(ctx, "duty_scheduler", gomock.Any()).Return(nil)
+	mockBeaconNode.EXPECT().SubscribeToFinalizedCheckpointEvents(ctx, "duty_scheduler", gomock.Any()).Return(nil)
 
 	mockNetworkConfig.Beacon.(*mocknetwork.MockBeaconNetwork).EXPECT().MinGenesisTime().Return(int64(0)).AnyTimes()
 	mockNetworkConfig.Beacon.(*mocknetwork.MockBeaconNetwork).EXPECT().SlotDurationSec().Return(150 * time.Millisecond).AnyTimes()
@@ -364,6 +368,8 @@ func TestScheduler_Run(t *testing.T) {
 	s.handlers = []dutyHandler{mockDutyHandler1, mockDutyHandler2}
 
 	mockBeaconNode.EXPECT().SubscribeToHeadEvents(ctx, "duty_scheduler", gomock.Any()).Return(nil)
+	mockBeaconNode.EXPECT().SubscribeToChainReorgEvents(ctx, "duty_scheduler", gomock.Any()).Return(nil)
+	mockBeaconNode.EXPECT().SubscribeToFinalizedCheckpointEvents(ctx, "duty_scheduler", gomock.Any()).Return(nil)
 	mockTicker.EXPECT().Next().Return(nil).AnyTimes()
 
 	// setup mock duty handler expectations
@@ -413,6 +419,8 @@ func TestScheduler_Regression_IndicesChangeStuck(t *testing.T) {
 	// add multiple mock duty handlers
 	s.handlers = []dutyHandler{NewValidatorRegistrationHandler()}
 	mockBeaconNode.EXPECT().SubscribeToHeadEvents(ctx, "duty_scheduler", gomock.Any()).Return(nil)
+	mockBeaconNode.EXPECT().SubscribeToChainReorgEvents(ctx, "duty_scheduler", gomock.Any()).Return(nil)
+	mockBeaconNode.EXPECT().SubscribeToFinalizedCheckpointEvents(ctx, "duty_scheduler", gomock.Any()).Return(nil)
 	mockTicker.EXPECT().Next().Return(nil).AnyTimes()
 	err := s.Start(ctx, logger)
 	require.NoError(t, err)
@@ -426,3 +434,159 @@ func TestScheduler_Regression_IndicesChangeStuck(t *testing.T) {
 	}
 
 }
+
+func TestScheduler_HandleChainReorgEvent(t *testing.T) {
+	logger := logging.TestLogger(t)
+
+	tests := []struct {
+		name     string
+		depth    uint64
+		expected *ReorgEvent
+	}{
+		{name: "reorg within the epoch", depth: 5},
+		{name: "reorg of the current dependent root", depth: 6, expected: &ReorgEvent{Slot: 100, Current: true}},
+		{name: "reorg within the previous epoch", depth: 37, expected: &ReorgEvent{Slot: 100, Current: true}},
+		{name: "reorg of the previous dependent root", depth: 38, expected: &ReorgEvent{Slot: 100, Current: true, Previous: true}},
+		{name: "reorg deeper than the chain", depth: 1000, expected: &ReorgEvent{Slot: 100, Current: true, Previous: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Scheduler{
+				network:                   networkconfig.TestNetwork,
+				reorg:                     make(chan ReorgEvent, 1),
+				previousDutyDependentRoot: phase0.Root{1},
+				currentDutyDependentRoot:  phase0.Root{2},
+			}
+
+			// Slot 100 is in epoch 3, so the dependent roots are the blocks at slots 95 and 63.
+			s.HandleChainReorgEvent(logger)(&eth2apiv1.ChainReorgEvent{Slot: 100, Depth: tt.depth})
+
+			if tt.expected == nil {
+				require.Empty(t, s.reorg)
+				require.Equal(t, phase0.Root{1}, s.previousDutyDependentRoot)
+				require.Equal(t, phase0.Root{2}, s.currentDutyDependentRoot)
+				return
+			}
+
+			require.Len(t, s.reorg, 1)
+			require.Equal(t, *tt.expected, <-s.reorg)
+			require.Equal(t, phase0.Root{}, s.currentDutyDependentRoot)
+			require.Equal(t, tt.expected.Previous, s.previousDutyDependentRoot == phase0.Root{})
+		})
+	}
+}
+
+func TestScheduler_HandleHeadEvent_EpochTransition(t *testing.T) {
+	logger := logging.TestLogger(t)
+
+	tests := []struct {
+		name                    string
+		previousRoot            phase0.Root
+		currentRoot             phase0.Root
+		eventPreviousRoot       phase0.Root
+		expectedPreviousChanged bool
+	}{
+		{name: "unchanged", previousRoot: phase0.Root{1}, currentRoot: phase0.Root{2}, eventPreviousRoot: phase0.Root{2}},
+		{name: "changed", previousRoot: phase0.Root{1}, currentRoot: phase0.Root{2}, eventPreviousRoot: phase0.Root{3}, expectedPreviousChanged: true},
+		// The chain reorg handler resets the current dependent root once it has triggered the refetch,
+		// so the next epoch's head event must not report the same reorg again.
+		{name: "current root reset by chain reorg", previousRoot: phase0.Root{1}, eventPreviousRoot: phase0.Root{3}},
+		{name: "both roots reset by chain reorg", eventPreviousRoot: phase0.Root{3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slot := networkconfig.TestNetwork.Beacon.EstimatedCurrentSlot()
+			epoch := networkconfig.TestNetwork.Beacon.EstimatedEpochAtSlot(slot)
+			s := &Scheduler{
+				network:                   networkconfig.TestNetwork,
+				reorg:                     make(chan ReorgEvent, 1),
+				waitCond:                  sync.NewCond(&sync.Mutex{}),
+				lastBlockEpoch:            epoch - 1,
+				previousDutyDependentRoot: tt.previousRoot,
+				currentDutyDependentRoot:  tt.currentRoot,
+			}
+
+			s.HandleHeadEvent(logger)(&eth2apiv1.HeadEvent{
+				Slot:                      slot,
+				PreviousDutyDependentRoot: tt.eventPreviousRoot,
+				CurrentDutyDependentRoot:  phase0.Root{4},
+			})
+			if networkconfig.TestNetwork.Beacon.EstimatedCurrentSlot() != slot {
+				t.Skip("slot changed while handling the head event")
+			}
+
+			if tt.expectedPreviousChanged {
+				require.Len(t, s.reorg, 1)
+				require.Equal(t, ReorgEvent{Slot: slot, Previous: true}, <-s.reorg)
+			} else {
+				require.Empty(t, s.reorg)
+			}
+			require.Equal(t, epoch, s.lastBlockEpoch)
+			require.Equal(t, tt.eventPreviousRoot, s.previousDutyDependentRoot)
+			require.Equal(t, phase0.Root{4}, s.currentDutyDependentRoot)
+		})
+	}
+}
+
+func TestScheduler_HandleFinalizedCheckpointEvent(t *testing.T) {
+	logger := logging.TestLogger(t)
+	store := dutystore.New()
+	s := &Scheduler{
+		network:   networkconfig.TestNetwork,
+		dutyStore: store,
+	}
+
+	for epoch := phase0.Epoch(0); epoch < 4; epoch++ {
+		store.Attester.Set(epoch, []dutystore.StoreDuty[eth2apiv1.AttesterDuty]{{ValidatorIndex: 1}})
+		store.Proposer.Set(epoch, []dutystore.StoreDuty[eth2apiv1.ProposerDuty]{{ValidatorIndex: 1}})
+	}
+	store.SyncCommittee.Set(0, []dutystore.StoreSyncCommitteeDuty{{ValidatorIndex: 1, Duty: &eth2apiv1.SyncCommitteeDuty{}, InCommittee: true}})
+	store.SyncCommittee.Set(1, []dutystore.StoreSyncCommitteeDuty{{ValidatorIndex: 1, Duty: &eth2apiv1.SyncCommitteeDuty{}, InCommittee: true}})
+
+	s.HandleFinalizedCheckpointEvent(logger)(&eth2apiv1.FinalizedCheckpointEvent{Epoch: 2})
+	require.False(t, store.Attester.IsEpochSet(1))
+	require.True(t, store.Attester.IsEpochSet(2))
+	require.False(t, store.Proposer.IsEpochSet(1))
+	require.True(t, store.Proposer.IsEpochSet(3))
+	require.NotEmpty(t, store.SyncCommittee.CommitteePeriodDuties(0))
+
+	// Epoch 257 is in the second sync committee period.
+	s.HandleFinalizedCheckpointEvent(logger)(&eth2apiv1.FinalizedCheckpointEvent{Epoch: 257})
+	require.False(t, store.Attester.IsEpochSet(3))
+	require.Empty(t, store.SyncCommittee.CommitteePeriodDuties(0))
+	require.NotEmpty(t, store.SyncCommittee.CommitteePeriodDuties(1))
+}
+
+func TestScheduler_Stop(t *testing.T) {
+	logger := logging.TestLogger(t)
+	ctrl := gomock.NewController(t)
+	dutyExecutor := NewMockDutyExecutor(ctrl)
+	s := &Scheduler{
+		network:      networkconfig.TestNetwork,
+		dutyExecutor: dutyExecutor,
+	}
+
+	slot := networkconfig.TestNetwork.Beacon.EstimatedCurrentSlot()
+	duty := &spectypes.ValidatorDuty{Type: spectypes.BNRoleProposer, Slot: slot, ValidatorIndex: 1}
+	executed := make(chan struct{})
+	dutyExecutor.EXPECT().ExecuteDuty(gomock.Any(), gomock.Any(), duty).Do(
+		func(context.Context, *zap.Logger, *spectypes.ValidatorDuty) { close(executed) },
+	).Times(1)
+
+	s.ExecuteDuties(context.Background(), logger, []*spectypes.ValidatorDuty{duty})
+	select {
+	case <-executed:
+	case <-time.After(time.Second):
+		require.Fail(t, "duty wasn't executed")
+	}
+
+	// No duty is executed once stopped.
+	s.Stop()
+	s.ExecuteDuties(context.Background(), logger, []*spectypes.ValidatorDuty{duty})
+	s.ExecuteCommitteeDuties(context.Background(), logger, committeeDutiesMap{
+		spectypes.CommitteeID{1}: {duty: &spectypes.CommitteeDuty{Slot: slot}, id: spectypes.CommitteeID{1}},
+	})
+	time.Sleep(100 * time.Millisecond)
+}
@@ -12,13 +12,17 @@ import (
 	"github.com/ssvlabs/ssv/operator/duties/dutystore"
 )
 
-const voluntaryExitSlotsToPostpone = phase0.Slot(4)
+// VoluntaryExitSlotsToPostpone is the number of slots an exit duty is scheduled after the slot it's requested at.
+const VoluntaryExitSlotsToPostpone = phase0.Slot(4)
 
 type ExitDescriptor struct {
 	OwnValidator   bool
 	PubKey         phase0.BLSPubKey
 	ValidatorIndex phase0.ValidatorIndex
 	BlockNumber    uint64
+	// Slot is the duty slot of an exit requested through the API rather than a contract event,
+	// in which case BlockNumber is ignored.
+	Slot phase0.Slot
 }
 
 type VoluntaryExitHandler struct {
@@ -64,15 +68,17 @@ func (h *VoluntaryExitHandler) HandleDuties(ctx context.Context) {
 				return
 			}
 
-			blockSlot, err := h.blockSlot(ctx, exitDescriptor.BlockNumber)
-			if err != nil {
-				h.logger.Warn("failed to get block time from execution client, skipping voluntary exit duty",
-					zap.Error(err))
-				continue
+			dutySlot := exitDescriptor.Slot
+			if dutySlot == 0 {
+				blockSlot, err := h.blockSlot(ctx, exitDescriptor.BlockNumber)
+				if err != nil {
+					h.logger.Warn("failed to get block time from execution client, skipping voluntary exit duty",
+						zap.Error(err))
+					continue
+				}
+				dutySlot = blockSlot + VoluntaryExitSlotsToPostpone
 			}
 
-			dutySlot := blockSlot + voluntaryExitSlotsToPostpone
-
 			duty := &spectypes.ValidatorDuty{
 				Type:           spectypes.BNRoleVoluntaryExit,
 				PubKey:         exitDescriptor.PubKey,
@@ -88,7 +94,6 @@ func (h *VoluntaryExitHandler) HandleDuties(ctx context.Context) {
 			h.dutyQueue = append(h.dutyQueue, duty)
 
 			h.logger.Debug("🛠 scheduled duty for execution",
-				zap.Uint64("block_slot", uint64(blockSlot)),
 				zap.Uint64("duty_slot", uint64(dutySlot)),
 				fields.BlockNumber(exitDescriptor.BlockNumber),
 			)
@@ -141,7 +146,7 @@ func (h *VoluntaryExitHandler) blockSlot(ctx context.Context, blockNumber uint64
 
 	h.blockSlots[blockNumber] = blockSlot
 	for k, v := range h.blockSlots {
-		if v < blockSlot && blockSlot-v >= voluntaryExitSlotsToPostpone {
+		if v < blockSlot && blockSlot-v >= VoluntaryExitSlotsToPostpone {
 			delete(h.blockSlots, k)
 		}
 	}
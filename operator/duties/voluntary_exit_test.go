@@ -89,14 +89,14 @@ func TestVoluntaryExitHandler_HandleDuties(t *testing.T) {
 	})
 
 	t.Run("slot = 4, block = 1 - no execution", func(t *testing.T) {
-		currentSlot.Set(phase0.Slot(normalExit.BlockNumber) + voluntaryExitSlotsToPostpone - 1)
+		currentSlot.Set(phase0.Slot(normalExit.BlockNumber) + VoluntaryExitSlotsToPostpone - 1)
 		ticker.Send(currentSlot.Get())
 		waitForNoAction(t, logger, nil, executeDutiesCall, timeout)
 		require.EqualValues(t, 2, blockByNumberCalls.Load())
 	})
 
 	t.Run("slot = 5, block = 1 - executing duty, fetching block number", func(t *testing.T) {
-		currentSlot.Set(phase0.Slot(normalExit.BlockNumber) + voluntaryExitSlotsToPostpone)
+		currentSlot.Set(phase0.Slot(normalExit.BlockNumber) + VoluntaryExitSlotsToPostpone)
 		ticker.Send(currentSlot.Get())
 		waitForDutiesExecution(t, logger, nil, executeDutiesCall, timeout, expectedDuties[:1])
 		require.EqualValues(t, 2, blockByNumberCalls.Load())
@@ -105,7 +105,7 @@ func TestVoluntaryExitHandler_HandleDuties(t *testing.T) {
 	exitCh <- sameBlockExit
 
 	t.Run("slot = 5, block = 1 - executing another duty, no block number fetch", func(t *testing.T) {
-		currentSlot.Set(phase0.Slot(sameBlockExit.BlockNumber) + voluntaryExitSlotsToPostpone)
+		currentSlot.Set(phase0.Slot(sameBlockExit.BlockNumber) + VoluntaryExitSlotsToPostpone)
 		ticker.Send(currentSlot.Get())
 		waitForDutiesExecution(t, logger, nil, executeDutiesCall, timeout, expectedDuties[1:2])
 		require.EqualValues(t, 2, blockByNumberCalls.Load())
@@ -114,14 +114,14 @@ func TestVoluntaryExitHandler_HandleDuties(t *testing.T) {
 	exitCh <- newBlockExit
 
 	t.Run("slot = 5, block = 2 - no execution", func(t *testing.T) {
-		currentSlot.Set(phase0.Slot(normalExit.BlockNumber) + voluntaryExitSlotsToPostpone)
+		currentSlot.Set(phase0.Slot(normalExit.BlockNumber) + VoluntaryExitSlotsToPostpone)
 		ticker.Send(currentSlot.Get())
 		waitForNoAction(t, logger, nil, executeDutiesCall, timeout)
 		require.EqualValues(t, 3, blockByNumberCalls.Load())
 	})
 
 	t.Run("slot = 6, block = 1 - executing new duty, fetching block number", func(t *testing.T) {
-		currentSlot.Set(phase0.Slot(newBlockExit.BlockNumber) + voluntaryExitSlotsToPostpone)
+		currentSlot.Set(phase0.Slot(newBlockExit.BlockNumber) + VoluntaryExitSlotsToPostpone)
 		ticker.Send(currentSlot.Get())
 		waitForDutiesExecution(t, logger, nil, executeDutiesCall, timeout, expectedDuties[2:3])
 		require.EqualValues(t, 3, blockByNumberCalls.Load())
@@ -130,7 +130,7 @@ func TestVoluntaryExitHandler_HandleDuties(t *testing.T) {
 	exitCh <- pastBlockExit
 
 	t.Run("slot = 10, block = 5 - executing past duty, fetching block number", func(t *testing.T) {
-		currentSlot.Set(phase0.Slot(pastBlockExit.BlockNumber) + voluntaryExitSlotsToPostpone + 1)
+		currentSlot.Set(phase0.Slot(pastBlockExit.BlockNumber) + VoluntaryExitSlotsToPostpone + 1)
 		ticker.Send(currentSlot.Get())
 		waitForDutiesExecution(t, logger, nil, executeDutiesCall, timeout, expectedDuties[3:4])
 		require.EqualValues(t, 4, blockByNumberCalls.Load())
@@ -177,7 +177,7 @@ func expectedExecutedVoluntaryExitDuties(descriptors []ExitDescriptor) []*specty
 		expectedDuties = append(expectedDuties, &spectypes.ValidatorDuty{
 			Type:           spectypes.BNRoleVoluntaryExit,
 			PubKey:         d.PubKey,
-			Slot:           phase0.Slot(d.BlockNumber) + voluntaryExitSlotsToPostpone,
+			Slot:           phase0.Slot(d.BlockNumber) + VoluntaryExitSlotsToPostpone,
 			ValidatorIndex: d.ValidatorIndex,
 		})
 	}
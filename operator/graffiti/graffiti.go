@@ -0,0 +1,154 @@
+package graffiti
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"gopkg.in/yaml.v3"
+)
+
+// MaxLength is the size of the graffiti field of a beacon block.
+const MaxLength = 32
+
+// Template variables. The operators of a committee must propose the same graffiti whichever of them leads the round,
+// so the variables are those of the validator's committee rather than of the proposing operator.
+const (
+	VarCommittee = "committee" // sorted operator IDs of the validator's committee, joined with "-"
+)
+
+// perNodeVars are variables which aren't supported since they differ between the operators of a committee.
+var perNodeVars = []string{"operator_id", "version", "el", "cl"}
+
+var placeholderRegex = regexp.MustCompile(`\{([a-z_]*)\}`)
+
+// Vars are the values of the template variables.
+type Vars struct {
+	Committee []spectypes.OperatorID
+}
+
+// value returns the value of the variable, or false if there is no such variable.
+func (v Vars) value(name string) (string, bool) {
+	switch name {
+	case VarCommittee:
+		ids := slices.Clone(v.Committee)
+		slices.Sort(ids)
+		parts := make([]string, len(ids))
+		for i, id := range ids {
+			parts[i] = strconv.FormatUint(id, 10)
+		}
+		return strings.Join(parts, "-"), true
+	default:
+		return "", false
+	}
+}
+
+// Validate checks that the template only uses known variables
+// and that its text without the variables fits into a block.
+func Validate(template string) error {
+	for _, match := range placeholderRegex.FindAllStringSubmatch(template, -1) {
+		switch {
+		case match[1] == VarCommittee:
+		case slices.Contains(perNodeVars, match[1]):
+			return fmt.Errorf("graffiti variable %s differs between the committee's operators, only {%s} is supported", match[0], VarCommittee)
+		default:
+			return fmt.Errorf("unknown graffiti variable %s", match[0])
+		}
+	}
+	if literal := placeholderRegex.ReplaceAllString(template, ""); len(literal) > MaxLength {
+		return fmt.Errorf("graffiti is %d bytes, at most %d are allowed", len(literal), MaxLength)
+	}
+	return nil
+}
+
+// Expand replaces the variables of the template with their values.
+// The result depends only on the template and vars, and is cut to MaxLength bytes at a character boundary.
+func Expand(template string, vars Vars) []byte {
+	expanded := placeholderRegex.ReplaceAllStringFunc(template, func(placeholder string) string {
+		value, ok := vars.value(placeholder[1 : len(placeholder)-1])
+		if !ok {
+			// Unknown variables are kept as is.
+			return placeholder
+		}
+		return value
+	})
+	for len(expanded) > MaxLength {
+		_, size := utf8.DecodeLastRuneInString(expanded[:MaxLength+1])
+		expanded = expanded[:MaxLength+1-size]
+	}
+	return []byte(expanded)
+}
+
+// LoadOverrides reads a YAML file mapping validator public keys (hex) to graffiti templates.
+func LoadOverrides(path string) (map[spectypes.ValidatorPK]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read graffiti overrides: %w", err)
+	}
+	var raw map[string]string
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("could not parse graffiti overrides: %w", err)
+	}
+
+	overrides := make(map[spectypes.ValidatorPK]string, len(raw))
+	for pubKeyHex, template := range raw {
+		pubKey, err := hexutil.Decode(pubKeyHex)
+		if err != nil || len(pubKey) != len(spectypes.ValidatorPK{}) {
+			return nil, fmt.Errorf("invalid validator public key %q in graffiti overrides", pubKeyHex)
+		}
+		if err := Validate(template); err != nil {
+			return nil, fmt.Errorf("invalid graffiti for validator %s: %w", pubKeyHex, err)
+		}
+		overrides[spectypes.ValidatorPK(pubKey)] = template
+	}
+	return overrides, nil
+}
+
+// Provider expands the graffiti of proposed blocks. Its template and overrides can be replaced at runtime.
+type Provider struct {
+	template  atomic.Pointer[string]
+	overrides atomic.Pointer[map[spectypes.ValidatorPK]string]
+}
+
+// New returns a Provider of the given template.
+func New(template string) *Provider {
+	p := &Provider{}
+	p.Set(template)
+	p.SetOverrides(nil)
+	return p
+}
+
+// Graffiti returns the graffiti of a block proposed for the given validator,
+// using the validator's override template if there is one.
+func (p *Provider) Graffiti(share *spectypes.Share) []byte {
+	template := *p.template.Load()
+	if override, ok := (*p.overrides.Load())[share.ValidatorPubKey]; ok {
+		template = override
+	}
+
+	committee := make([]spectypes.OperatorID, len(share.Committee))
+	for i, member := range share.Committee {
+		committee[i] = member.Signer
+	}
+	return Expand(template, Vars{Committee: committee})
+}
+
+// Set replaces the template of the next proposed blocks.
+func (p *Provider) Set(template string) {
+	p.template.Store(&template)
+}
+
+// SetOverrides replaces the per-validator templates.
+func (p *Provider) SetOverrides(overrides map[spectypes.ValidatorPK]string) {
+	if overrides == nil {
+		overrides = map[spectypes.ValidatorPK]string{}
+	}
+	p.overrides.Store(&overrides)
+}
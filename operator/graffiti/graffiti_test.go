@@ -0,0 +1,100 @@
+package graffiti
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, Validate(""))
+	require.NoError(t, Validate("ssv.network"))
+	require.NoError(t, Validate("ssv {committee}"))
+	require.NoError(t, Validate(strings.Repeat("a", MaxLength)+"{committee}"))
+
+	for _, template := range []string{"{operator_id}", "{committee} {version}", "{el}", "{cl}"} {
+		require.ErrorContains(t, Validate(template), "differs between the committee's operators", template)
+	}
+	require.ErrorContains(t, Validate("{operator}"), "unknown graffiti variable {operator}")
+	require.ErrorContains(t, Validate("{}"), "unknown graffiti variable {}")
+	require.ErrorContains(t, Validate(strings.Repeat("a", MaxLength+1)), "at most 32 are allowed")
+}
+
+func TestExpand(t *testing.T) {
+	vars := Vars{
+		Committee: []spectypes.OperatorID{4, 1, 3, 2},
+	}
+
+	require.Equal(t, []byte("ssv.network"), Expand("ssv.network", vars))
+	require.Equal(t, []byte("ssv 1-2-3-4"), Expand("ssv {committee}", vars))
+	require.Equal(t, []byte("{unknown}"), Expand("{unknown}", vars))
+	require.Equal(t, []byte("{}"), Expand("{}", vars))
+	require.Equal(t, []byte("{el}-1-2-3-4-{Committee}"), Expand("{el}-{committee}-{Committee}", vars))
+	require.Equal(t, []byte("committee "), Expand("committee {committee}", Vars{}))
+
+	// The committee order doesn't change the result.
+	reordered := vars
+	reordered.Committee = []spectypes.OperatorID{2, 4, 3, 1}
+	require.Equal(t, Expand("{committee}", vars), Expand("{committee}", reordered))
+	require.Equal(t, []spectypes.OperatorID{4, 1, 3, 2}, vars.Committee)
+
+	// Truncated at a character boundary.
+	expanded := Expand(strings.Repeat("a", MaxLength-1)+"é", vars)
+	require.Equal(t, []byte(strings.Repeat("a", MaxLength-1)), expanded)
+	expanded = Expand(strings.Repeat("a", 20)+"{committee}-{committee}", vars)
+	require.Len(t, expanded, MaxLength)
+}
+
+func TestLoadOverrides(t *testing.T) {
+	var pubKey spectypes.ValidatorPK
+	pubKey[0] = 0xaa
+	path := filepath.Join(t.TempDir(), "graffiti.yaml")
+
+	require.NoError(t, os.WriteFile(path, []byte(`"`+hexutil.Encode(pubKey[:])+`": "mine {committee}"`), 0600))
+	overrides, err := LoadOverrides(path)
+	require.NoError(t, err)
+	require.Equal(t, map[spectypes.ValidatorPK]string{pubKey: "mine {committee}"}, overrides)
+
+	require.NoError(t, os.WriteFile(path, []byte(`"0xaa": "mine"`), 0600))
+	_, err = LoadOverrides(path)
+	require.ErrorContains(t, err, "invalid validator public key")
+
+	require.NoError(t, os.WriteFile(path, []byte(`"`+hexutil.Encode(pubKey[:])+`": "{unknown}"`), 0600))
+	_, err = LoadOverrides(path)
+	require.ErrorContains(t, err, "unknown graffiti variable")
+
+	require.NoError(t, os.WriteFile(path, []byte(`"`+hexutil.Encode(pubKey[:])+`": "{operator_id}"`), 0600))
+	_, err = LoadOverrides(path)
+	require.ErrorContains(t, err, "differs between the committee's operators")
+
+	_, err = LoadOverrides(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+}
+
+func TestProvider(t *testing.T) {
+	var pubKey, otherPubKey spectypes.ValidatorPK
+	pubKey[0] = 0xaa
+	otherPubKey[0] = 0xbb
+	share := func(pk spectypes.ValidatorPK) *spectypes.Share {
+		return &spectypes.Share{
+			ValidatorPubKey: pk,
+			Committee:       []*spectypes.ShareMember{{Signer: 2}, {Signer: 1}},
+		}
+	}
+
+	p := New("ssv@{committee}")
+	require.Equal(t, []byte("ssv@1-2"), p.Graffiti(share(pubKey)))
+
+	p.SetOverrides(map[spectypes.ValidatorPK]string{pubKey: "mine {committee}"})
+	require.Equal(t, []byte("mine 1-2"), p.Graffiti(share(pubKey)))
+	require.Equal(t, []byte("ssv@1-2"), p.Graffiti(share(otherPubKey)))
+
+	p.Set("new")
+	p.SetOverrides(nil)
+	require.Equal(t, []byte("new"), p.Graffiti(share(pubKey)))
+}
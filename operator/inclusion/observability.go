@@ -0,0 +1,94 @@
+package inclusion
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/ssvlabs/ssv/observability"
+)
+
+const (
+	observabilityName      = "github.com/ssvlabs/ssv/operator/inclusion"
+	observabilityNamespace = "ssv.operator.inclusion"
+)
+
+var (
+	meter = otel.Meter(observabilityName)
+
+	attestationsCounter = observability.NewMetric(
+		meter.Int64Counter(
+			metricName("attestations"),
+			metric.WithUnit("{attestation}"),
+			metric.WithDescription("number of attestation duties of own validators by on-chain outcome")))
+
+	correctVotesCounter = observability.NewMetric(
+		meter.Int64Counter(
+			metricName("attestation.correct_votes"),
+			metric.WithUnit("{vote}"),
+			metric.WithDescription("number of correct source, target and head votes of included attestations")))
+
+	inclusionDelayHistogram = observability.NewMetric(
+		meter.Int64Histogram(
+			metricName("attestation.inclusion_delay"),
+			metric.WithUnit("{slot}"),
+			metric.WithDescription("number of slots between an attestation's slot and its inclusion"),
+			metric.WithExplicitBucketBoundaries(1, 2, 3, 4, 5, 8, 16, 32)))
+
+	proposalsCounter = observability.NewMetric(
+		meter.Int64Counter(
+			metricName("proposals"),
+			metric.WithUnit("{proposal}"),
+			metric.WithDescription("number of proposer duties of own validators by on-chain outcome")))
+
+	syncCommitteeMessagesCounter = observability.NewMetric(
+		meter.Int64Counter(
+			metricName("sync_committee.messages"),
+			metric.WithUnit("{message}"),
+			metric.WithDescription("number of sync committee messages of own validators by on-chain outcome")))
+)
+
+func metricName(name string) string {
+	return fmt.Sprintf("%s.%s", observabilityNamespace, name)
+}
+
+func outcomeAttribute(included bool) attribute.KeyValue {
+	outcome := "missed"
+	if included {
+		outcome = "included"
+	}
+	return attribute.String(metricName("outcome"), outcome)
+}
+
+func voteAttribute(vote string) attribute.KeyValue {
+	return attribute.String(metricName("vote"), vote)
+}
+
+func recordEpochOutcome(ctx context.Context, outcome *EpochOutcome) {
+	for _, attestation := range outcome.Attestations {
+		attestationsCounter.Add(ctx, 1, metric.WithAttributes(outcomeAttribute(attestation.Included)))
+		if !attestation.Included {
+			continue
+		}
+		inclusionDelayHistogram.Record(ctx, int64(attestation.InclusionDelay()))
+		for vote, correct := range map[string]bool{
+			"source": attestation.CorrectSource,
+			"target": attestation.CorrectTarget,
+			"head":   attestation.CorrectHead,
+		} {
+			if correct {
+				correctVotesCounter.Add(ctx, 1, metric.WithAttributes(voteAttribute(vote)))
+			}
+		}
+	}
+	for _, proposal := range outcome.Proposals {
+		proposalsCounter.Add(ctx, 1, metric.WithAttributes(outcomeAttribute(proposal.Canonical)))
+	}
+	for _, syncCommittee := range outcome.SyncCommittee {
+		syncCommitteeMessagesCounter.Add(ctx, int64(syncCommittee.Participated), metric.WithAttributes(outcomeAttribute(true)))
+		syncCommitteeMessagesCounter.Add(ctx, int64(syncCommittee.Missed), metric.WithAttributes(outcomeAttribute(false)))
+	}
+}
@@ -0,0 +1,105 @@
+package inclusion
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// AttestationOutcome is the on-chain outcome of an attestation duty.
+type AttestationOutcome struct {
+	ValidatorIndex phase0.ValidatorIndex
+	Slot           phase0.Slot
+	Included       bool
+	// InclusionSlot is the slot of the earliest block including the attestation.
+	InclusionSlot phase0.Slot
+	// CorrectSource, CorrectTarget and CorrectHead report whether the included attestation
+	// voted for the canonical checkpoints and head block.
+	CorrectSource bool
+	CorrectTarget bool
+	CorrectHead   bool
+}
+
+// InclusionDelay returns the number of slots between the attestation's slot and its inclusion.
+func (o *AttestationOutcome) InclusionDelay() phase0.Slot {
+	if !o.Included {
+		return 0
+	}
+	return o.InclusionSlot - o.Slot
+}
+
+// ProposalOutcome is the on-chain outcome of a proposer duty.
+type ProposalOutcome struct {
+	ValidatorIndex phase0.ValidatorIndex
+	Slot           phase0.Slot
+	// Canonical reports whether the canonical chain has the validator's block at the slot.
+	Canonical bool
+}
+
+// SyncCommitteeOutcome is the on-chain participation of a sync committee member in an epoch.
+// A member with several positions in the sync committee participates once per position and slot.
+type SyncCommitteeOutcome struct {
+	ValidatorIndex phase0.ValidatorIndex
+	Participated   int
+	Missed         int
+}
+
+// EpochOutcome holds the on-chain outcomes of the duties of own validators in an epoch.
+type EpochOutcome struct {
+	Epoch         phase0.Epoch
+	Attestations  []*AttestationOutcome
+	Proposals     []*ProposalOutcome
+	SyncCommittee []*SyncCommitteeOutcome
+}
+
+// Summary aggregates the outcomes of an epoch.
+type Summary struct {
+	Attestations struct {
+		Expected      int
+		Included      int
+		CorrectSource int
+		CorrectTarget int
+		CorrectHead   int
+		// TotalInclusionDelay is the sum of the inclusion delays of the included attestations.
+		TotalInclusionDelay phase0.Slot
+	}
+	Proposals struct {
+		Expected  int
+		Canonical int
+	}
+	SyncCommittee struct {
+		Participated int
+		Missed       int
+	}
+}
+
+// Summary aggregates the outcomes.
+func (o *EpochOutcome) Summary() Summary {
+	var summary Summary
+	for _, attestation := range o.Attestations {
+		summary.Attestations.Expected++
+		if !attestation.Included {
+			continue
+		}
+		summary.Attestations.Included++
+		summary.Attestations.TotalInclusionDelay += attestation.InclusionDelay()
+		if attestation.CorrectSource {
+			summary.Attestations.CorrectSource++
+		}
+		if attestation.CorrectTarget {
+			summary.Attestations.CorrectTarget++
+		}
+		if attestation.CorrectHead {
+			summary.Attestations.CorrectHead++
+		}
+	}
+	for _, proposal := range o.Proposals {
+		summary.Proposals.Expected++
+		if proposal.Canonical {
+			summary.Proposals.Canonical++
+		}
+	}
+	for _, syncCommittee := range o.SyncCommittee {
+		summary.SyncCommittee.Participated += syncCommittee.Participated
+		summary.SyncCommittee.Missed += syncCommittee.Missed
+	}
+	return summary
+}
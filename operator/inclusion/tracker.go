@@ -0,0 +1,554 @@
+package inclusion
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/operator/duties/dutystore"
+	"github.com/ssvlabs/ssv/operator/slotticker"
+)
+
+const (
+	// retainedEpochs is the number of most recently tracked epochs kept in memory.
+	retainedEpochs = 64
+
+	// trackingSlotOffset is the slot within the epoch at which the epoch before the previous one is tracked.
+	// Attestations may be included until the end of the epoch after their own, and a few slots
+	// into the following epoch the blocks of both are unlikely to be reorged anymore.
+	// The duties of the previous epoch are taken from the duty store at the same slot, since the duty
+	// handlers remove them at the end of the current epoch, before they can be tracked.
+	trackingSlotOffset = 4
+
+	// blockFetchConcurrency is the number of blocks fetched in parallel.
+	blockFetchConcurrency = 8
+)
+
+// BeaconNode represents a provider of beacon chain blocks.
+type BeaconNode interface {
+	// SignedBeaconBlock returns the canonical block of the slot, or nil if the slot has no block.
+	SignedBeaconBlock(ctx context.Context, slot phase0.Slot) (*spec.VersionedSignedBeaconBlock, error)
+	BeaconCommittees(ctx context.Context, epoch phase0.Epoch) ([]*eth2apiv1.BeaconCommittee, error)
+}
+
+// Options contains the configuration options for the inclusion tracker.
+type Options struct {
+	Network            networkconfig.NetworkConfig
+	BeaconNode         BeaconNode
+	DutyStore          *dutystore.Store
+	SlotTickerProvider slotticker.Provider
+	Logger             *zap.Logger
+}
+
+// Tracker checks whether the duties of own validators made it on chain. Once the attestations
+// of an epoch can no longer be included, it compares the duties of the epoch against
+// the canonical blocks and keeps the outcomes of the most recent epochs.
+type Tracker struct {
+	network            networkconfig.NetworkConfig
+	beaconNode         BeaconNode
+	dutyStore          *dutystore.Store
+	slotTickerProvider slotticker.Provider
+	logger             *zap.Logger
+
+	// mu synchronizes access to outcomes, snapshots and nextBlocks
+	mu       sync.RWMutex
+	outcomes []*EpochOutcome
+	// snapshots are the duties of epochs which ended but aren't tracked yet.
+	snapshots map[phase0.Epoch]*epochDuties
+	// nextBlocks are the blocks of the epoch after the last tracked one, which are fetched
+	// for its attestations and reused when it's tracked itself.
+	nextBlocks *chainBlocks
+}
+
+// epochDuties are the duties of own validators in an epoch.
+type epochDuties struct {
+	attester      []*eth2apiv1.AttesterDuty
+	proposer      []*eth2apiv1.ProposerDuty
+	syncCommittee []*eth2apiv1.SyncCommitteeDuty
+}
+
+// New initializes a new inclusion tracker.
+func New(opts *Options) *Tracker {
+	return &Tracker{
+		network:            opts.Network,
+		beaconNode:         opts.BeaconNode,
+		dutyStore:          opts.DutyStore,
+		slotTickerProvider: opts.SlotTickerProvider,
+		logger:             opts.Logger.Named(logging.NameInclusionTracker),
+		snapshots:          make(map[phase0.Epoch]*epochDuties),
+	}
+}
+
+// Start tracks the epoch before the previous one every epoch until the context is done,
+// with the duties taken from the duty store in the epoch before. Epochs which ended before the tracker
+// started aren't tracked, since the duty store doesn't have their duties after a restart.
+func (t *Tracker) Start(ctx context.Context) {
+	t.logger.Info("inclusion tracking started")
+
+	ticker := t.slotTickerProvider()
+	var (
+		lastEpoch phase0.Epoch
+		started   bool
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.Next():
+			// Track once per epoch from the tracking slot on, in case the ticker skips it.
+			slot := ticker.Slot()
+			epoch := t.network.Beacon.EstimatedEpochAtSlot(slot)
+			if uint64(slot)%t.network.SlotsPerEpoch() < trackingSlotOffset || (started && epoch <= lastEpoch) {
+				continue
+			}
+			firstEpoch := !started
+			lastEpoch, started = epoch, true
+			if epoch < 1 {
+				continue
+			}
+			if !firstEpoch {
+				t.SnapshotEpoch(epoch - 1)
+			}
+			if epoch < 2 {
+				continue
+			}
+			if !t.hasSnapshot(epoch - 2) {
+				t.logger.Debug("skipping inclusion tracking of epoch without duties snapshot", fields.Epoch(epoch-2))
+				continue
+			}
+
+			start := time.Now()
+			outcome, err := t.TrackEpoch(ctx, epoch-2)
+			if err != nil {
+				t.logger.Warn("could not track inclusion of duties", fields.Epoch(epoch-2), zap.Error(err))
+				continue
+			}
+
+			summary := outcome.Summary()
+			t.logger.Debug("tracked inclusion of duties",
+				fields.Epoch(outcome.Epoch),
+				zap.Int("attestations", summary.Attestations.Expected),
+				zap.Int("attestations_included", summary.Attestations.Included),
+				zap.Int("proposals", summary.Proposals.Expected),
+				zap.Int("proposals_canonical", summary.Proposals.Canonical),
+				zap.Int("sync_committee_participated", summary.SyncCommittee.Participated),
+				zap.Int("sync_committee_missed", summary.SyncCommittee.Missed),
+				fields.Took(time.Since(start)))
+		}
+	}
+}
+
+// Outcomes returns the retained outcomes of the epochs in the range [from, to], ordered by epoch.
+func (t *Tracker) Outcomes(from, to phase0.Epoch) []*EpochOutcome {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var outcomes []*EpochOutcome
+	for _, outcome := range t.outcomes {
+		if outcome.Epoch >= from && outcome.Epoch <= to {
+			outcomes = append(outcomes, outcome)
+		}
+	}
+	return outcomes
+}
+
+// SnapshotEpoch keeps the duties of the given epoch from the duty store until the epoch is tracked.
+// It must be called after the epoch ended, as the duty handlers remove its duties at the end of the next epoch.
+func (t *Tracker) SnapshotEpoch(epoch phase0.Epoch) {
+	duties := t.storedDuties(epoch)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for snapshotEpoch := range t.snapshots {
+		if snapshotEpoch+retainedEpochs < epoch {
+			delete(t.snapshots, snapshotEpoch)
+		}
+	}
+	t.snapshots[epoch] = duties
+}
+
+func (t *Tracker) hasSnapshot(epoch phase0.Epoch) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	_, ok := t.snapshots[epoch]
+	return ok
+}
+
+// TrackEpoch determines the on-chain outcome of the duties of the given epoch, records it in metrics
+// and retains it. The epoch's attestations may be included until the end of the following epoch,
+// so tracking it any earlier reports attestations which are still to be included as missed.
+// The duties are those of the epoch's snapshot, or those in the duty store if there is none.
+func (t *Tracker) TrackEpoch(ctx context.Context, epoch phase0.Epoch) (*EpochOutcome, error) {
+	beaconNetwork := t.network.Beacon
+	firstSlot := beaconNetwork.FirstSlotAtEpoch(epoch)
+	nextEpochSlot := beaconNetwork.FirstSlotAtEpoch(epoch + 1)
+
+	t.mu.Lock()
+	duties, ok := t.snapshots[epoch]
+	delete(t.snapshots, epoch)
+	t.mu.Unlock()
+	if !ok {
+		duties = t.storedDuties(epoch)
+	}
+	attesterDuties, proposerDuties, syncCommitteeDuties := duties.attester, duties.proposer, duties.syncCommittee
+
+	outcome := &EpochOutcome{Epoch: epoch}
+	if len(attesterDuties) > 0 || len(proposerDuties) > 0 || len(syncCommitteeDuties) > 0 {
+		// Attestations of the epoch are included until the end of the next one.
+		blocks, err := t.epochBlocks(ctx, epoch)
+		if err != nil {
+			return nil, err
+		}
+
+		outcome.Attestations, err = t.attestationOutcomes(ctx, epoch, blocks, attesterDuties)
+		if err != nil {
+			return nil, err
+		}
+		outcome.Proposals, err = proposalOutcomes(blocks, proposerDuties)
+		if err != nil {
+			return nil, err
+		}
+		// Messages of the epoch's last slot are aggregated by the committee of the next epoch,
+		// which belongs to another period at the end of a period.
+		syncCommitteeEnd := nextEpochSlot
+		if beaconNetwork.EstimatedSyncCommitteePeriodAtEpoch(epoch+1) != beaconNetwork.EstimatedSyncCommitteePeriodAtEpoch(epoch) {
+			syncCommitteeEnd--
+		}
+		outcome.SyncCommittee, err = syncCommitteeOutcomes(firstSlot, syncCommitteeEnd, blocks, syncCommitteeDuties)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	recordEpochOutcome(ctx, outcome)
+
+	t.mu.Lock()
+	t.outcomes = slices.DeleteFunc(t.outcomes, func(o *EpochOutcome) bool {
+		return o.Epoch == epoch
+	})
+	t.outcomes = append(t.outcomes, outcome)
+	slices.SortFunc(t.outcomes, func(a, b *EpochOutcome) int {
+		return cmp.Compare(a.Epoch, b.Epoch)
+	})
+	if len(t.outcomes) > retainedEpochs {
+		t.outcomes = t.outcomes[len(t.outcomes)-retainedEpochs:]
+	}
+	t.mu.Unlock()
+
+	return outcome, nil
+}
+
+// storedDuties returns the duties of own validators in the given epoch from the duty store.
+func (t *Tracker) storedDuties(epoch phase0.Epoch) *epochDuties {
+	beaconNetwork := t.network.Beacon
+	duties := &epochDuties{}
+	for slot := beaconNetwork.FirstSlotAtEpoch(epoch); slot < beaconNetwork.FirstSlotAtEpoch(epoch+1); slot++ {
+		duties.attester = append(duties.attester, t.dutyStore.Attester.CommitteeSlotDuties(epoch, slot)...)
+		duties.proposer = append(duties.proposer, t.dutyStore.Proposer.CommitteeSlotDuties(epoch, slot)...)
+	}
+	duties.syncCommittee = t.dutyStore.SyncCommittee.CommitteePeriodDuties(beaconNetwork.EstimatedSyncCommitteePeriodAtEpoch(epoch))
+
+	// Duties come out of the duty store in no particular order.
+	slices.SortFunc(duties.attester, func(a, b *eth2apiv1.AttesterDuty) int {
+		return cmp.Or(cmp.Compare(a.Slot, b.Slot), cmp.Compare(a.ValidatorIndex, b.ValidatorIndex))
+	})
+	slices.SortFunc(duties.proposer, func(a, b *eth2apiv1.ProposerDuty) int {
+		return cmp.Compare(a.Slot, b.Slot)
+	})
+	slices.SortFunc(duties.syncCommittee, func(a, b *eth2apiv1.SyncCommitteeDuty) int {
+		return cmp.Compare(a.ValidatorIndex, b.ValidatorIndex)
+	})
+	return duties
+}
+
+// chainBlocks are the canonical blocks of a range of slots.
+type chainBlocks struct {
+	firstSlot phase0.Slot
+	// blocks is indexed by the slot's offset from firstSlot, with nil for slots without a block.
+	blocks []*spec.VersionedSignedBeaconBlock
+}
+
+func (c *chainBlocks) at(slot phase0.Slot) *spec.VersionedSignedBeaconBlock {
+	if slot < c.firstSlot || slot >= c.firstSlot+phase0.Slot(len(c.blocks)) {
+		return nil
+	}
+	return c.blocks[slot-c.firstSlot]
+}
+
+// headRoots returns the root of the head block at each slot of the range.
+// Slots before the first block of the range get its parent root.
+func (c *chainBlocks) headRoots() ([]phase0.Root, error) {
+	roots := make([]phase0.Root, len(c.blocks))
+	var (
+		head      phase0.Root
+		foundHead bool
+	)
+	for i, block := range c.blocks {
+		if block == nil {
+			roots[i] = head
+			continue
+		}
+		root, err := block.Root()
+		if err != nil {
+			return nil, fmt.Errorf("could not get block root: %w", err)
+		}
+		if !foundHead {
+			parentRoot, err := block.ParentRoot()
+			if err != nil {
+				return nil, fmt.Errorf("could not get block parent root: %w", err)
+			}
+			for j := 0; j < i; j++ {
+				roots[j] = parentRoot
+			}
+			foundHead = true
+		}
+		head = root
+		roots[i] = root
+	}
+	return roots, nil
+}
+
+// epochBlocks returns the blocks of the given epoch and the next one. The blocks of the epoch are reused
+// if they were fetched when tracking the previous epoch, and those of the next epoch are kept for tracking it.
+func (t *Tracker) epochBlocks(ctx context.Context, epoch phase0.Epoch) (*chainBlocks, error) {
+	beaconNetwork := t.network.Beacon
+	firstSlot := beaconNetwork.FirstSlotAtEpoch(epoch)
+	nextEpochSlot := beaconNetwork.FirstSlotAtEpoch(epoch + 1)
+
+	t.mu.Lock()
+	blocks := t.nextBlocks
+	t.nextBlocks = nil
+	t.mu.Unlock()
+
+	if blocks == nil || blocks.firstSlot != firstSlot || len(blocks.blocks) != int(nextEpochSlot-firstSlot) {
+		var err error
+		blocks, err = t.fetchBlocks(ctx, firstSlot, nextEpochSlot)
+		if err != nil {
+			return nil, err
+		}
+	}
+	nextBlocks, err := t.fetchBlocks(ctx, nextEpochSlot, beaconNetwork.FirstSlotAtEpoch(epoch+2))
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	t.nextBlocks = nextBlocks
+	t.mu.Unlock()
+
+	return &chainBlocks{
+		firstSlot: firstSlot,
+		blocks:    append(slices.Clip(blocks.blocks), nextBlocks.blocks...),
+	}, nil
+}
+
+func (t *Tracker) fetchBlocks(ctx context.Context, from, to phase0.Slot) (*chainBlocks, error) {
+	blocks := &chainBlocks{
+		firstSlot: from,
+		blocks:    make([]*spec.VersionedSignedBeaconBlock, to-from),
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(blockFetchConcurrency)
+	for slot := from; slot < to; slot++ {
+		g.Go(func() error {
+			block, err := t.beaconNode.SignedBeaconBlock(ctx, slot)
+			if err != nil {
+				return fmt.Errorf("could not fetch block of slot %d: %w", slot, err)
+			}
+			blocks.blocks[slot-from] = block
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+type committeeKey struct {
+	slot  phase0.Slot
+	index phase0.CommitteeIndex
+}
+
+func (t *Tracker) attestationOutcomes(
+	ctx context.Context,
+	epoch phase0.Epoch,
+	blocks *chainBlocks,
+	duties []*eth2apiv1.AttesterDuty,
+) ([]*AttestationOutcome, error) {
+	if len(duties) == 0 {
+		return nil, nil
+	}
+
+	headRoots, err := blocks.headRoots()
+	if err != nil {
+		return nil, err
+	}
+	headRoot := func(slot phase0.Slot) phase0.Root {
+		return headRoots[slot-blocks.firstSlot]
+	}
+	targetRoot := headRoot(t.network.Beacon.FirstSlotAtEpoch(epoch))
+
+	outcomes := make([]*AttestationOutcome, len(duties))
+	committeeOutcomes := make(map[committeeKey][]int)
+	for i, duty := range duties {
+		outcomes[i] = &AttestationOutcome{
+			ValidatorIndex: duty.ValidatorIndex,
+			Slot:           duty.Slot,
+		}
+		key := committeeKey{slot: duty.Slot, index: duty.CommitteeIndex}
+		committeeOutcomes[key] = append(committeeOutcomes[key], i)
+	}
+
+	// Since Electra, an attestation aggregates several committees of a slot, and the aggregation bits
+	// of each committee are offset by the sizes of the committees before it.
+	var committeeSizes map[committeeKey]uint64
+	committeeSize := func(key committeeKey) (uint64, error) {
+		if committeeSizes == nil {
+			committees, err := t.beaconNode.BeaconCommittees(ctx, epoch)
+			if err != nil {
+				return 0, err
+			}
+			committeeSizes = make(map[committeeKey]uint64, len(committees))
+			for _, committee := range committees {
+				committeeSizes[committeeKey{slot: committee.Slot, index: committee.Index}] = uint64(len(committee.Validators))
+			}
+		}
+		size, ok := committeeSizes[key]
+		if !ok {
+			return 0, fmt.Errorf("committee %d of slot %d not found", key.index, key.slot)
+		}
+		return size, nil
+	}
+
+	// Blocks are scanned in slot order, so the earliest inclusion of each attestation is kept.
+	firstSlot, nextEpochSlot := t.network.Beacon.FirstSlotAtEpoch(epoch), t.network.Beacon.FirstSlotAtEpoch(epoch+1)
+	for i, block := range blocks.blocks {
+		if block == nil {
+			continue
+		}
+		inclusionSlot := blocks.firstSlot + phase0.Slot(i)
+
+		attestations, err := block.Attestations()
+		if err != nil {
+			return nil, fmt.Errorf("could not get attestations of block at slot %d: %w", inclusionSlot, err)
+		}
+		for _, attestation := range attestations {
+			data, err := attestation.Data()
+			if err != nil {
+				return nil, fmt.Errorf("could not get attestation data: %w", err)
+			}
+			if data.Slot < firstSlot || data.Slot >= nextEpochSlot {
+				continue
+			}
+			aggregationBits, err := attestation.AggregationBits()
+			if err != nil {
+				return nil, fmt.Errorf("could not get aggregation bits: %w", err)
+			}
+
+			committeeIndices := []phase0.CommitteeIndex{data.Index}
+			if attestation.Version >= spec.DataVersionElectra {
+				committeeBits, err := attestation.CommitteeBits()
+				if err != nil {
+					return nil, fmt.Errorf("could not get committee bits: %w", err)
+				}
+				committeeIndices = committeeIndices[:0]
+				for _, index := range committeeBits.BitIndices() {
+					committeeIndices = append(committeeIndices, phase0.CommitteeIndex(index))
+				}
+			}
+
+			var offset uint64
+			for _, committeeIndex := range committeeIndices {
+				key := committeeKey{slot: data.Slot, index: committeeIndex}
+				for _, i := range committeeOutcomes[key] {
+					outcome := outcomes[i]
+					if outcome.Included || !aggregationBits.BitAt(offset+duties[i].ValidatorCommitteeIndex) {
+						continue
+					}
+					outcome.Included = true
+					outcome.InclusionSlot = inclusionSlot
+					// Blocks only include attestations with the justified checkpoint as their source.
+					outcome.CorrectSource = true
+					outcome.CorrectTarget = data.Target.Root == targetRoot
+					outcome.CorrectHead = data.BeaconBlockRoot == headRoot(data.Slot)
+				}
+				if len(committeeIndices) > 1 {
+					size, err := committeeSize(key)
+					if err != nil {
+						return nil, fmt.Errorf("could not get committee size: %w", err)
+					}
+					offset += size
+				}
+			}
+		}
+	}
+
+	return outcomes, nil
+}
+
+func proposalOutcomes(blocks *chainBlocks, duties []*eth2apiv1.ProposerDuty) ([]*ProposalOutcome, error) {
+	var outcomes []*ProposalOutcome
+	for _, duty := range duties {
+		outcome := &ProposalOutcome{
+			ValidatorIndex: duty.ValidatorIndex,
+			Slot:           duty.Slot,
+		}
+		if block := blocks.at(duty.Slot); block != nil {
+			proposerIndex, err := block.ProposerIndex()
+			if err != nil {
+				return nil, fmt.Errorf("could not get proposer index of block at slot %d: %w", duty.Slot, err)
+			}
+			outcome.Canonical = proposerIndex == duty.ValidatorIndex
+		}
+		outcomes = append(outcomes, outcome)
+	}
+	return outcomes, nil
+}
+
+// syncCommitteeOutcomes counts the sync committee messages of the slots in [from, to) included in the
+// block of the next slot. Messages of slots followed by a missed block can't be included by anyone,
+// so they aren't counted.
+func syncCommitteeOutcomes(from, to phase0.Slot, blocks *chainBlocks, duties []*eth2apiv1.SyncCommitteeDuty) ([]*SyncCommitteeOutcome, error) {
+	if len(duties) == 0 {
+		return nil, nil
+	}
+
+	outcomes := make([]*SyncCommitteeOutcome, len(duties))
+	for i, duty := range duties {
+		outcomes[i] = &SyncCommitteeOutcome{ValidatorIndex: duty.ValidatorIndex}
+	}
+	for slot := from; slot < to; slot++ {
+		block := blocks.at(slot + 1)
+		if block == nil {
+			continue
+		}
+		syncAggregate, err := block.SyncAggregate()
+		if err != nil {
+			return nil, fmt.Errorf("could not get sync aggregate of block at slot %d: %w", slot+1, err)
+		}
+		for i, duty := range duties {
+			for _, index := range duty.ValidatorSyncCommitteeIndices {
+				if syncAggregate.SyncCommitteeBits.BitAt(uint64(index)) {
+					outcomes[i].Participated++
+				} else {
+					outcomes[i].Missed++
+				}
+			}
+		}
+	}
+	return outcomes, nil
+}
@@ -0,0 +1,443 @@
+package inclusion
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/holiman/uint256"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/operator/duties/dutystore"
+	"github.com/ssvlabs/ssv/operator/slotticker"
+)
+
+type fakeBeaconNode struct {
+	blocks     map[phase0.Slot]*spec.VersionedSignedBeaconBlock
+	committees []*eth2apiv1.BeaconCommittee
+
+	mu            sync.Mutex
+	blockRequests map[phase0.Slot]int
+}
+
+func (b *fakeBeaconNode) SignedBeaconBlock(_ context.Context, slot phase0.Slot) (*spec.VersionedSignedBeaconBlock, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.blockRequests == nil {
+		b.blockRequests = make(map[phase0.Slot]int)
+	}
+	b.blockRequests[slot]++
+	return b.blocks[slot], nil
+}
+
+func (b *fakeBeaconNode) BeaconCommittees(_ context.Context, _ phase0.Epoch) ([]*eth2apiv1.BeaconCommittee, error) {
+	return b.committees, nil
+}
+
+func testExecutionPayload() *deneb.ExecutionPayload {
+	return &deneb.ExecutionPayload{BaseFeePerGas: uint256.NewInt(0)}
+}
+
+func testSyncAggregate(syncBits ...uint64) *altair.SyncAggregate {
+	bits := bitfield.NewBitvector512()
+	for _, bit := range syncBits {
+		bits.SetBitAt(bit, true)
+	}
+	return &altair.SyncAggregate{SyncCommitteeBits: bits}
+}
+
+func denebBlock(slot phase0.Slot, proposer phase0.ValidatorIndex, parentRoot phase0.Root, attestations []*phase0.Attestation, syncBits ...uint64) *spec.VersionedSignedBeaconBlock {
+	return &spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionDeneb,
+		Deneb: &deneb.SignedBeaconBlock{
+			Message: &deneb.BeaconBlock{
+				Slot:          slot,
+				ProposerIndex: proposer,
+				ParentRoot:    parentRoot,
+				Body: &deneb.BeaconBlockBody{
+					ETH1Data:         &phase0.ETH1Data{BlockHash: make([]byte, 32)},
+					Attestations:     attestations,
+					SyncAggregate:    testSyncAggregate(syncBits...),
+					ExecutionPayload: testExecutionPayload(),
+				},
+			},
+		},
+	}
+}
+
+func electraBlock(slot phase0.Slot, parentRoot phase0.Root, attestations []*electra.Attestation) *spec.VersionedSignedBeaconBlock {
+	return &spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionElectra,
+		Electra: &electra.SignedBeaconBlock{
+			Message: &electra.BeaconBlock{
+				Slot:       slot,
+				ParentRoot: parentRoot,
+				Body: &electra.BeaconBlockBody{
+					ETH1Data:          &phase0.ETH1Data{BlockHash: make([]byte, 32)},
+					Attestations:      attestations,
+					SyncAggregate:     testSyncAggregate(),
+					ExecutionPayload:  testExecutionPayload(),
+					ExecutionRequests: &electra.ExecutionRequests{},
+				},
+			},
+		},
+	}
+}
+
+func aggregationBits(length uint64, bits ...uint64) bitfield.Bitlist {
+	aggregationBits := bitfield.NewBitlist(length)
+	for _, bit := range bits {
+		aggregationBits.SetBitAt(bit, true)
+	}
+	return aggregationBits
+}
+
+func blockRoot(t *testing.T, block *spec.VersionedSignedBeaconBlock) phase0.Root {
+	root, err := block.Root()
+	require.NoError(t, err)
+	return root
+}
+
+func TestTrackEpoch(t *testing.T) {
+	network := networkconfig.TestNetwork
+	epoch := phase0.Epoch(10)
+	firstSlot := network.Beacon.FirstSlotAtEpoch(epoch)
+	period := network.Beacon.EstimatedSyncCommitteePeriodAtEpoch(epoch)
+
+	store := dutystore.New()
+	store.Attester.Set(epoch, []dutystore.StoreDuty[eth2apiv1.AttesterDuty]{
+		{Slot: firstSlot + 1, ValidatorIndex: 1, InCommittee: true, Duty: &eth2apiv1.AttesterDuty{
+			Slot: firstSlot + 1, ValidatorIndex: 1, CommitteeIndex: 2, ValidatorCommitteeIndex: 3,
+		}},
+		{Slot: firstSlot + 2, ValidatorIndex: 2, InCommittee: true, Duty: &eth2apiv1.AttesterDuty{
+			Slot: firstSlot + 2, ValidatorIndex: 2, CommitteeIndex: 0, ValidatorCommitteeIndex: 0,
+		}},
+		{Slot: firstSlot + 6, ValidatorIndex: 4, InCommittee: true, Duty: &eth2apiv1.AttesterDuty{
+			Slot: firstSlot + 6, ValidatorIndex: 4, CommitteeIndex: 0, ValidatorCommitteeIndex: 1,
+		}},
+		// Duties of other operators' validators aren't tracked.
+		{Slot: firstSlot + 6, ValidatorIndex: 9, InCommittee: false, Duty: &eth2apiv1.AttesterDuty{
+			Slot: firstSlot + 6, ValidatorIndex: 9, CommitteeIndex: 0, ValidatorCommitteeIndex: 0,
+		}},
+	})
+	store.Proposer.Set(epoch, []dutystore.StoreDuty[eth2apiv1.ProposerDuty]{
+		{Slot: firstSlot + 2, ValidatorIndex: 5, InCommittee: true, Duty: &eth2apiv1.ProposerDuty{Slot: firstSlot + 2, ValidatorIndex: 5}},
+		{Slot: firstSlot + 3, ValidatorIndex: 6, InCommittee: true, Duty: &eth2apiv1.ProposerDuty{Slot: firstSlot + 3, ValidatorIndex: 6}},
+	})
+	store.SyncCommittee.Set(period, []dutystore.StoreSyncCommitteeDuty{
+		{ValidatorIndex: 7, InCommittee: true, Duty: &eth2apiv1.SyncCommitteeDuty{
+			ValidatorIndex: 7, ValidatorSyncCommitteeIndices: []phase0.CommitteeIndex{5, 300},
+		}},
+	})
+
+	// Slots firstSlot+1 and firstSlot+3 have no block.
+	block0 := denebBlock(firstSlot, 100, phase0.Root{1}, nil)
+	root0 := blockRoot(t, block0)
+	attestation1 := &phase0.Attestation{
+		AggregationBits: aggregationBits(8, 3),
+		Data: &phase0.AttestationData{
+			Slot:            firstSlot + 1,
+			Index:           2,
+			BeaconBlockRoot: root0,
+			Source:          &phase0.Checkpoint{},
+			Target:          &phase0.Checkpoint{Epoch: epoch, Root: root0},
+		},
+	}
+	block2 := denebBlock(firstSlot+2, 5, root0, []*phase0.Attestation{attestation1}, 5)
+	root2 := blockRoot(t, block2)
+	block4 := denebBlock(firstSlot+4, 101, root2, []*phase0.Attestation{attestation1})
+	root4 := blockRoot(t, block4)
+	// The attestation of validator 4 is included in the next epoch and votes for a block which isn't the head.
+	attestation4 := &phase0.Attestation{
+		AggregationBits: aggregationBits(8, 1),
+		Data: &phase0.AttestationData{
+			Slot:            firstSlot + 6,
+			Index:           0,
+			BeaconBlockRoot: root2,
+			Source:          &phase0.Checkpoint{},
+			Target:          &phase0.Checkpoint{Epoch: epoch, Root: root0},
+		},
+	}
+	block40 := denebBlock(firstSlot+40, 102, root4, []*phase0.Attestation{attestation4})
+
+	beaconNode := &fakeBeaconNode{
+		blocks: map[phase0.Slot]*spec.VersionedSignedBeaconBlock{
+			firstSlot:      block0,
+			firstSlot + 2:  block2,
+			firstSlot + 4:  block4,
+			firstSlot + 40: block40,
+		},
+	}
+	tracker := New(&Options{
+		Network:    network,
+		BeaconNode: beaconNode,
+		DutyStore:  store,
+		Logger:     zap.NewNop(),
+	})
+
+	outcome, err := tracker.TrackEpoch(context.Background(), epoch)
+	require.NoError(t, err)
+
+	attestations := make(map[phase0.ValidatorIndex]AttestationOutcome)
+	for _, attestation := range outcome.Attestations {
+		attestations[attestation.ValidatorIndex] = *attestation
+	}
+	require.Len(t, attestations, 3)
+	require.Equal(t, AttestationOutcome{
+		ValidatorIndex: 1,
+		Slot:           firstSlot + 1,
+		Included:       true,
+		InclusionSlot:  firstSlot + 2,
+		CorrectSource:  true,
+		CorrectTarget:  true,
+		CorrectHead:    true,
+	}, attestations[1])
+	require.False(t, attestations[2].Included)
+	require.Equal(t, AttestationOutcome{
+		ValidatorIndex: 4,
+		Slot:           firstSlot + 6,
+		Included:       true,
+		InclusionSlot:  firstSlot + 40,
+		CorrectSource:  true,
+		CorrectTarget:  true,
+		CorrectHead:    false,
+	}, attestations[4])
+
+	proposals := make(map[phase0.ValidatorIndex]bool)
+	for _, proposal := range outcome.Proposals {
+		proposals[proposal.ValidatorIndex] = proposal.Canonical
+	}
+	require.Equal(t, map[phase0.ValidatorIndex]bool{5: true, 6: false}, proposals)
+
+	// Only the messages of slots followed by a block count, in two positions each.
+	require.Equal(t, []*SyncCommitteeOutcome{{ValidatorIndex: 7, Participated: 1, Missed: 3}}, outcome.SyncCommittee)
+
+	summary := outcome.Summary()
+	require.Equal(t, 3, summary.Attestations.Expected)
+	require.Equal(t, 2, summary.Attestations.Included)
+	require.Equal(t, 1, summary.Attestations.CorrectHead)
+	require.Equal(t, phase0.Slot(35), summary.Attestations.TotalInclusionDelay)
+	require.Equal(t, 1, summary.Proposals.Canonical)
+
+	require.Equal(t, []*EpochOutcome{outcome}, tracker.Outcomes(0, epoch))
+	require.Empty(t, tracker.Outcomes(epoch+1, epoch+2))
+
+	// Tracking the epoch again replaces its outcome.
+	outcome, err = tracker.TrackEpoch(context.Background(), epoch)
+	require.NoError(t, err)
+	require.Equal(t, []*EpochOutcome{outcome}, tracker.Outcomes(0, epoch))
+}
+
+func TestTrackEpoch_ReusesBlocks(t *testing.T) {
+	network := networkconfig.TestNetwork
+	epoch := phase0.Epoch(10)
+
+	store := dutystore.New()
+	for e := epoch; e <= epoch+1; e++ {
+		slot := network.Beacon.FirstSlotAtEpoch(e) + 1
+		store.Attester.Set(e, []dutystore.StoreDuty[eth2apiv1.AttesterDuty]{
+			{Slot: slot, ValidatorIndex: 1, InCommittee: true, Duty: &eth2apiv1.AttesterDuty{Slot: slot, ValidatorIndex: 1}},
+		})
+	}
+
+	beaconNode := &fakeBeaconNode{}
+	tracker := New(&Options{
+		Network:    network,
+		BeaconNode: beaconNode,
+		DutyStore:  store,
+		Logger:     zap.NewNop(),
+	})
+
+	_, err := tracker.TrackEpoch(context.Background(), epoch)
+	require.NoError(t, err)
+	_, err = tracker.TrackEpoch(context.Background(), epoch+1)
+	require.NoError(t, err)
+
+	// The blocks of the epoch in between are only fetched once.
+	firstSlot, lastSlot := network.Beacon.FirstSlotAtEpoch(epoch), network.Beacon.FirstSlotAtEpoch(epoch+3)
+	require.Len(t, beaconNode.blockRequests, int(lastSlot-firstSlot))
+	for slot := firstSlot; slot < lastSlot; slot++ {
+		require.Equal(t, 1, beaconNode.blockRequests[slot], slot)
+	}
+}
+
+func TestTrackEpoch_Electra(t *testing.T) {
+	network := networkconfig.TestNetwork
+	epoch := phase0.Epoch(20)
+	firstSlot := network.Beacon.FirstSlotAtEpoch(epoch)
+
+	store := dutystore.New()
+	store.Attester.Set(epoch, []dutystore.StoreDuty[eth2apiv1.AttesterDuty]{
+		{Slot: firstSlot, ValidatorIndex: 1, InCommittee: true, Duty: &eth2apiv1.AttesterDuty{
+			Slot: firstSlot, ValidatorIndex: 1, CommitteeIndex: 2, ValidatorCommitteeIndex: 1,
+		}},
+		{Slot: firstSlot, ValidatorIndex: 2, InCommittee: true, Duty: &eth2apiv1.AttesterDuty{
+			Slot: firstSlot, ValidatorIndex: 2, CommitteeIndex: 2, ValidatorCommitteeIndex: 2,
+		}},
+	})
+
+	block0 := electraBlock(firstSlot, phase0.Root{1}, nil)
+	root0 := blockRoot(t, block0)
+
+	// The attestation aggregates committees 0 and 2, so the bits of committee 2 start after the 4 of committee 0.
+	committeeBits := bitfield.NewBitvector64()
+	committeeBits.SetBitAt(0, true)
+	committeeBits.SetBitAt(2, true)
+	attestation := &electra.Attestation{
+		AggregationBits: aggregationBits(7, 0, 5),
+		CommitteeBits:   committeeBits,
+		Data: &phase0.AttestationData{
+			Slot:            firstSlot,
+			BeaconBlockRoot: root0,
+			Source:          &phase0.Checkpoint{},
+			Target:          &phase0.Checkpoint{Epoch: epoch, Root: root0},
+		},
+	}
+
+	beaconNode := &fakeBeaconNode{
+		blocks: map[phase0.Slot]*spec.VersionedSignedBeaconBlock{
+			firstSlot:     block0,
+			firstSlot + 1: electraBlock(firstSlot+1, root0, []*electra.Attestation{attestation}),
+		},
+		committees: []*eth2apiv1.BeaconCommittee{
+			{Slot: firstSlot, Index: 0, Validators: make([]phase0.ValidatorIndex, 4)},
+			{Slot: firstSlot, Index: 1, Validators: make([]phase0.ValidatorIndex, 10)},
+			{Slot: firstSlot, Index: 2, Validators: make([]phase0.ValidatorIndex, 3)},
+		},
+	}
+	tracker := New(&Options{
+		Network:    network,
+		BeaconNode: beaconNode,
+		DutyStore:  store,
+		Logger:     zap.NewNop(),
+	})
+
+	outcome, err := tracker.TrackEpoch(context.Background(), epoch)
+	require.NoError(t, err)
+
+	included := make(map[phase0.ValidatorIndex]bool)
+	for _, attestation := range outcome.Attestations {
+		included[attestation.ValidatorIndex] = attestation.Included
+		if attestation.Included {
+			require.Equal(t, phase0.Slot(1), attestation.InclusionDelay())
+			require.True(t, attestation.CorrectHead)
+		}
+	}
+	require.Equal(t, map[phase0.ValidatorIndex]bool{1: true, 2: false}, included)
+}
+
+// fakeSlotTicker ticks at the slots sent to it, one at a time, so the test knows
+// the tracker handled a slot once the next one is accepted.
+type fakeSlotTicker struct {
+	slots chan phase0.Slot
+	slot  phase0.Slot
+}
+
+func (f *fakeSlotTicker) Next() <-chan time.Time {
+	slot, ok := <-f.slots
+	if !ok {
+		return nil
+	}
+	f.slot = slot
+	ch := make(chan time.Time, 1)
+	ch <- time.Now()
+	return ch
+}
+
+func (f *fakeSlotTicker) Slot() phase0.Slot {
+	return f.slot
+}
+
+func TestStart_DutiesResetByHandlers(t *testing.T) {
+	network := networkconfig.TestNetwork
+	slotsPerEpoch := network.Beacon.SlotsPerEpoch()
+	epoch := phase0.Epoch(10)
+	firstSlot := network.Beacon.FirstSlotAtEpoch(epoch)
+
+	store := dutystore.New()
+	store.Attester.Set(epoch, []dutystore.StoreDuty[eth2apiv1.AttesterDuty]{
+		{Slot: firstSlot + 1, ValidatorIndex: 1, InCommittee: true, Duty: &eth2apiv1.AttesterDuty{
+			Slot: firstSlot + 1, ValidatorIndex: 1, CommitteeIndex: 0, ValidatorCommitteeIndex: 0,
+		}},
+	})
+	store.Proposer.Set(epoch, []dutystore.StoreDuty[eth2apiv1.ProposerDuty]{
+		{Slot: firstSlot + 2, ValidatorIndex: 5, InCommittee: true, Duty: &eth2apiv1.ProposerDuty{Slot: firstSlot + 2, ValidatorIndex: 5}},
+	})
+
+	block0 := denebBlock(firstSlot, 100, phase0.Root{1}, nil)
+	root0 := blockRoot(t, block0)
+	attestation := &phase0.Attestation{
+		AggregationBits: aggregationBits(8, 0),
+		Data: &phase0.AttestationData{
+			Slot:            firstSlot + 1,
+			BeaconBlockRoot: root0,
+			Source:          &phase0.Checkpoint{},
+			Target:          &phase0.Checkpoint{Epoch: epoch, Root: root0},
+		},
+	}
+	beaconNode := &fakeBeaconNode{
+		blocks: map[phase0.Slot]*spec.VersionedSignedBeaconBlock{
+			firstSlot:     block0,
+			firstSlot + 2: denebBlock(firstSlot+2, 5, root0, []*phase0.Attestation{attestation}),
+		},
+	}
+
+	ticker := &fakeSlotTicker{slots: make(chan phase0.Slot)}
+	tracker := New(&Options{
+		Network:            network,
+		BeaconNode:         beaconNode,
+		DutyStore:          store,
+		SlotTickerProvider: func() slotticker.SlotTicker { return ticker },
+		Logger:             zap.NewNop(),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		tracker.Start(ctx)
+		close(done)
+	}()
+
+	// Run through the slots until epoch 10 is tracked, removing duties at the end of each epoch
+	// like AttesterHandler and ProposerHandler do. The ticker skips the tracking slot of epoch 12.
+	trackingSlot := network.Beacon.FirstSlotAtEpoch(epoch+2) + trackingSlotOffset
+	for slot := firstSlot; slot <= trackingSlot+1; slot++ {
+		if slot != trackingSlot {
+			ticker.slots <- slot
+		}
+		if uint64(slot)%slotsPerEpoch == slotsPerEpoch-1 {
+			currentEpoch := network.Beacon.EstimatedEpochAtSlot(slot)
+			store.Attester.ResetEpoch(currentEpoch - 1)
+			store.Proposer.ResetEpoch(currentEpoch - 1)
+		}
+	}
+	close(ticker.slots)
+	cancel()
+	<-done
+
+	require.False(t, store.Attester.IsEpochSet(epoch))
+	// The epochs which ended before the tracker started have no duties to track.
+	require.Empty(t, tracker.Outcomes(0, epoch-1))
+	outcomes := tracker.Outcomes(epoch, epoch)
+	require.Len(t, outcomes, 1)
+	require.Equal(t, []*AttestationOutcome{{
+		ValidatorIndex: 1,
+		Slot:           firstSlot + 1,
+		Included:       true,
+		InclusionSlot:  firstSlot + 2,
+		CorrectSource:  true,
+		CorrectTarget:  true,
+		CorrectHead:    true,
+	}}, outcomes[0].Attestations)
+	require.Equal(t, []*ProposalOutcome{{ValidatorIndex: 5, Slot: firstSlot + 2, Canonical: true}}, outcomes[0].Proposals)
+}
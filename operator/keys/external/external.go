@@ -0,0 +1,149 @@
+// Package external provides operator keys whose private part is held outside of the node,
+// either by a PKCS#11 module (e.g. an HSM) or by a local signing daemon listening on a unix socket.
+package external
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ssvlabs/ssv/operator/keys"
+	"github.com/ssvlabs/ssv/utils/rsaencryption"
+)
+
+const (
+	// ekmSeed is signed to derive the EKM encryption key,
+	// PKCS#1 v1.5 signatures are deterministic so the derived key is stable across restarts.
+	ekmSeed = "ssv/operator-key/ekm"
+	// probeMessage is signed on startup to check that the external signer holds the private key of the public key it reports.
+	probeMessage = "ssv/operator-key/probe"
+	// defaultTimeout is the default timeout of a single operation with the external signer.
+	defaultTimeout = 10 * time.Second
+)
+
+// Config selects and configures the external operator key, at most one of SocketPath and PKCS11.ModulePath may be set.
+type Config struct {
+	SocketPath string        `yaml:"SocketPath" env:"OPERATOR_KEY_SOCKET" env-description:"Unix socket of a signing daemon holding the operator private key"`
+	Timeout    time.Duration `yaml:"Timeout" env:"OPERATOR_KEY_TIMEOUT" env-default:"10s" env-description:"Timeout of a single operation with the external operator key"`
+	PKCS11     PKCS11Config  `yaml:"PKCS11"`
+}
+
+// Enabled returns whether an external operator key is configured.
+func (c Config) Enabled() bool {
+	return c.SocketPath != "" || c.PKCS11.ModulePath != ""
+}
+
+// New connects to the configured external signer and returns its operator key.
+func New(cfg Config) (*Key, error) {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	var b backend
+	switch {
+	case cfg.SocketPath != "" && cfg.PKCS11.ModulePath != "":
+		return nil, fmt.Errorf("either a signing daemon socket or a PKCS#11 module may be configured, not both")
+	case cfg.SocketPath != "":
+		b = newSocketBackend(cfg.SocketPath, timeout)
+	case cfg.PKCS11.ModulePath != "":
+		pb, err := newPKCS11Backend(cfg.PKCS11)
+		if err != nil {
+			return nil, fmt.Errorf("could not open PKCS#11 key: %w", err)
+		}
+		b = pb
+	default:
+		return nil, fmt.Errorf("no external operator key is configured")
+	}
+
+	k, err := newKey(b)
+	if err != nil {
+		_ = b.Close()
+		return nil, err
+	}
+	return k, nil
+}
+
+// backend performs the private key operations of an external operator key.
+type backend interface {
+	// publicKey returns the public key of the held private key.
+	publicKey() (*rsa.PublicKey, error)
+	// sign returns the RSASSA-PKCS1-v1_5 SHA-256 signature of data.
+	sign(data []byte) ([]byte, error)
+	// decrypt decrypts RSAES-PKCS1-v1_5 ciphertext.
+	decrypt(ciphertext []byte) ([]byte, error)
+	io.Closer
+}
+
+// Key is an operator key held by an external signer, implements keys.OperatorKey.
+type Key struct {
+	backend backend
+	pubKey  keys.OperatorPublicKey
+	pubPEM  []byte
+}
+
+func newKey(b backend) (*Key, error) {
+	rsaPubKey, err := b.publicKey()
+	if err != nil {
+		return nil, fmt.Errorf("could not get public key: %w", err)
+	}
+	pubKey := keys.PublicKeyFromRSA(rsaPubKey)
+	pubPEM, err := pubKey.Base64()
+	if err != nil {
+		return nil, fmt.Errorf("could not encode public key: %w", err)
+	}
+
+	k := &Key{
+		backend: b,
+		pubKey:  pubKey,
+		pubPEM:  pubPEM,
+	}
+
+	signature, err := k.Sign([]byte(probeMessage))
+	if err != nil {
+		return nil, fmt.Errorf("could not sign probe message: %w", err)
+	}
+	if err := pubKey.Verify([]byte(probeMessage), signature); err != nil {
+		return nil, fmt.Errorf("external signer doesn't hold the private key of its public key: %w", err)
+	}
+	return k, nil
+}
+
+// Sign signs the given data.
+func (k *Key) Sign(data []byte) ([]byte, error) {
+	return k.backend.sign(data)
+}
+
+// Public returns the public key.
+func (k *Key) Public() keys.OperatorPublicKey {
+	return k.pubKey
+}
+
+// Decrypt decrypts the given ciphertext, e.g. an encrypted share.
+func (k *Key) Decrypt(data []byte) ([]byte, error) {
+	return k.backend.decrypt(data)
+}
+
+// StorageHash identifies the key by its public key, as the private key isn't available.
+// Note that it differs from the hash of the same key loaded in memory, so the node storage can't be shared between them.
+func (k *Key) StorageHash() (string, error) {
+	return rsaencryption.HashRsaKey(k.pubPEM)
+}
+
+// EKMHash derives the EKM encryption key from a signature, so that only the key holder can derive it.
+func (k *Key) EKMHash() (string, error) {
+	signature, err := k.Sign([]byte(ekmSeed))
+	if err != nil {
+		return "", fmt.Errorf("could not sign EKM seed: %w", err)
+	}
+	hash := sha256.Sum256(signature)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// Close releases the connection to the external signer.
+func (k *Key) Close() error {
+	return k.backend.Close()
+}
@@ -0,0 +1,145 @@
+package external
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/operator/keys"
+)
+
+// testDaemon is a minimal signing daemon backed by an in-memory key.
+type testDaemon struct {
+	key    keys.OperatorPrivateKey
+	pubKey keys.OperatorPrivateKey // reported public key, defaults to key
+	fail   bool
+}
+
+func (d *testDaemon) serve(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "signer.sock")
+	l, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go d.handle(conn)
+		}
+	}()
+	return path
+}
+
+func (d *testDaemon) handle(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	var req SocketRequest
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		return
+	}
+	var (
+		data []byte
+		err  error
+	)
+	switch {
+	case d.fail && req.Method != MethodPublicKey:
+		err = errors.New("key is locked")
+	case req.Method == MethodPublicKey:
+		pubKey := d.key
+		if d.pubKey != nil {
+			pubKey = d.pubKey
+		}
+		data, err = pubKey.Public().Base64()
+	case req.Method == MethodSign:
+		data, err = d.key.Sign(req.Data)
+	case req.Method == MethodDecrypt:
+		data, err = d.key.Decrypt(req.Data)
+	default:
+		err = errors.New("unknown method")
+	}
+	resp := SocketResponse{Data: data}
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	_ = json.NewEncoder(conn).Encode(resp)
+}
+
+func TestSocketKey(t *testing.T) {
+	privKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+	path := (&testDaemon{key: privKey}).serve(t)
+
+	key, err := New(Config{SocketPath: path, Timeout: time.Second})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, key.Close()) }()
+
+	var _ keys.OperatorKey = key
+
+	expectedPubKey, err := privKey.Public().Base64()
+	require.NoError(t, err)
+	pubKey, err := key.Public().Base64()
+	require.NoError(t, err)
+	require.Equal(t, expectedPubKey, pubKey)
+
+	msg := []byte("hello")
+	signature, err := key.Sign(msg)
+	require.NoError(t, err)
+	require.NoError(t, privKey.Public().Verify(msg, signature))
+
+	ciphertext, err := key.Public().Encrypt([]byte("share"))
+	require.NoError(t, err)
+	plaintext, err := key.Decrypt(ciphertext)
+	require.NoError(t, err)
+	require.Equal(t, []byte("share"), plaintext)
+
+	ekmHash, err := key.EKMHash()
+	require.NoError(t, err)
+	ekmHash2, err := key.EKMHash()
+	require.NoError(t, err)
+	require.Equal(t, ekmHash, ekmHash2)
+	require.Len(t, ekmHash, 64)
+
+	storageHash, err := key.StorageHash()
+	require.NoError(t, err)
+	require.NotEmpty(t, storageHash)
+	privStorageHash, err := privKey.StorageHash()
+	require.NoError(t, err)
+	require.NotEqual(t, privStorageHash, storageHash)
+}
+
+func TestSocketKeyErrors(t *testing.T) {
+	privKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+	otherKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	t.Run("mismatched public key", func(t *testing.T) {
+		path := (&testDaemon{key: privKey, pubKey: otherKey}).serve(t)
+		_, err := New(Config{SocketPath: path})
+		require.ErrorContains(t, err, "doesn't hold the private key")
+	})
+
+	t.Run("daemon error", func(t *testing.T) {
+		path := (&testDaemon{key: privKey, fail: true}).serve(t)
+		_, err := New(Config{SocketPath: path})
+		require.ErrorContains(t, err, "key is locked")
+	})
+
+	t.Run("no daemon", func(t *testing.T) {
+		_, err := New(Config{SocketPath: filepath.Join(t.TempDir(), "missing.sock")})
+		require.ErrorContains(t, err, "could not connect to signing daemon")
+	})
+
+	t.Run("both backends", func(t *testing.T) {
+		_, err := New(Config{SocketPath: "signer.sock", PKCS11: PKCS11Config{ModulePath: "softhsm2.so"}})
+		require.Error(t, err)
+	})
+}
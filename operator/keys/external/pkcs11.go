@@ -0,0 +1,177 @@
+package external
+
+import (
+	"crypto/rsa"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/miekg/pkcs11"
+)
+
+// PKCS11Config configures an operator key held by a PKCS#11 module, e.g. an HSM or SoftHSM.
+type PKCS11Config struct {
+	ModulePath string `yaml:"ModulePath" env:"OPERATOR_KEY_PKCS11_MODULE" env-description:"Path of the PKCS#11 module library holding the operator private key"`
+	TokenLabel string `yaml:"TokenLabel" env:"OPERATOR_KEY_PKCS11_TOKEN_LABEL" env-description:"Label of the PKCS#11 token holding the operator private key"`
+	KeyLabel   string `yaml:"KeyLabel" env:"OPERATOR_KEY_PKCS11_KEY_LABEL" env-description:"Label of the operator RSA key pair in the PKCS#11 token"`
+	PINFile    string `yaml:"PINFile" env:"OPERATOR_KEY_PKCS11_PIN_FILE" env-description:"File containing the user PIN of the PKCS#11 token"`
+}
+
+// pkcs11Backend performs operator key operations in a PKCS#11 token.
+// A single session is used, as sessions can't be used concurrently.
+type pkcs11Backend struct {
+	mu      sync.Mutex
+	ctx     *pkcs11.Ctx
+	session pkcs11.SessionHandle
+	privKey pkcs11.ObjectHandle
+	pubKey  pkcs11.ObjectHandle
+}
+
+func newPKCS11Backend(cfg PKCS11Config) (b *pkcs11Backend, err error) {
+	ctx := pkcs11.New(cfg.ModulePath)
+	if ctx == nil {
+		return nil, fmt.Errorf("could not load PKCS#11 module %s", cfg.ModulePath)
+	}
+	if err := ctx.Initialize(); err != nil {
+		ctx.Destroy()
+		return nil, fmt.Errorf("could not initialize PKCS#11 module: %w", err)
+	}
+	b = &pkcs11Backend{ctx: ctx}
+	defer func() {
+		if err != nil {
+			_ = b.Close()
+		}
+	}()
+
+	slot, err := b.findSlot(cfg.TokenLabel)
+	if err != nil {
+		return nil, err
+	}
+	b.session, err = ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION)
+	if err != nil {
+		return nil, fmt.Errorf("could not open PKCS#11 session: %w", err)
+	}
+	if cfg.PINFile != "" {
+		pin, err := os.ReadFile(cfg.PINFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read PIN file: %w", err)
+		}
+		if err := ctx.Login(b.session, pkcs11.CKU_USER, strings.TrimSpace(string(pin))); err != nil {
+			return nil, fmt.Errorf("could not login to PKCS#11 token: %w", err)
+		}
+	}
+
+	if b.privKey, err = b.findObject(pkcs11.CKO_PRIVATE_KEY, cfg.KeyLabel); err != nil {
+		return nil, err
+	}
+	if b.pubKey, err = b.findObject(pkcs11.CKO_PUBLIC_KEY, cfg.KeyLabel); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (b *pkcs11Backend) findSlot(tokenLabel string) (uint, error) {
+	slots, err := b.ctx.GetSlotList(true)
+	if err != nil {
+		return 0, fmt.Errorf("could not list PKCS#11 slots: %w", err)
+	}
+	for _, slot := range slots {
+		info, err := b.ctx.GetTokenInfo(slot)
+		if err != nil {
+			continue
+		}
+		if info.Label == tokenLabel {
+			return slot, nil
+		}
+	}
+	return 0, fmt.Errorf("PKCS#11 token %q not found", tokenLabel)
+}
+
+func (b *pkcs11Backend) findObject(class uint, label string) (pkcs11.ObjectHandle, error) {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_RSA),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	}
+	if err := b.ctx.FindObjectsInit(b.session, template); err != nil {
+		return 0, fmt.Errorf("could not search PKCS#11 objects: %w", err)
+	}
+	objects, _, err := b.ctx.FindObjects(b.session, 2)
+	if finalErr := b.ctx.FindObjectsFinal(b.session); err == nil {
+		err = finalErr
+	}
+	if err != nil {
+		return 0, fmt.Errorf("could not search PKCS#11 objects: %w", err)
+	}
+	switch len(objects) {
+	case 0:
+		return 0, fmt.Errorf("PKCS#11 key %q not found", label)
+	case 1:
+		return objects[0], nil
+	default:
+		return 0, fmt.Errorf("PKCS#11 key label %q is ambiguous", label)
+	}
+}
+
+func (b *pkcs11Backend) publicKey() (*rsa.PublicKey, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	attrs, err := b.ctx.GetAttributeValue(b.session, b.pubKey, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_MODULUS, nil),
+		pkcs11.NewAttribute(pkcs11.CKA_PUBLIC_EXPONENT, nil),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not read PKCS#11 public key: %w", err)
+	}
+	pubKey := &rsa.PublicKey{}
+	for _, attr := range attrs {
+		switch attr.Type {
+		case pkcs11.CKA_MODULUS:
+			pubKey.N = new(big.Int).SetBytes(attr.Value)
+		case pkcs11.CKA_PUBLIC_EXPONENT:
+			pubKey.E = int(new(big.Int).SetBytes(attr.Value).Int64())
+		}
+	}
+	if pubKey.N == nil || pubKey.E == 0 {
+		return nil, fmt.Errorf("incomplete PKCS#11 public key")
+	}
+	return pubKey, nil
+}
+
+func (b *pkcs11Backend) sign(data []byte) ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	mechanism := []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_SHA256_RSA_PKCS, nil)}
+	if err := b.ctx.SignInit(b.session, mechanism, b.privKey); err != nil {
+		return nil, fmt.Errorf("could not init PKCS#11 signing: %w", err)
+	}
+	return b.ctx.Sign(b.session, data)
+}
+
+func (b *pkcs11Backend) decrypt(ciphertext []byte) ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	mechanism := []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_RSA_PKCS, nil)}
+	if err := b.ctx.DecryptInit(b.session, mechanism, b.privKey); err != nil {
+		return nil, fmt.Errorf("could not init PKCS#11 decryption: %w", err)
+	}
+	return b.ctx.Decrypt(b.session, ciphertext)
+}
+
+func (b *pkcs11Backend) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.session != 0 {
+		_ = b.ctx.Logout(b.session)
+		_ = b.ctx.CloseSession(b.session)
+	}
+	err := b.ctx.Finalize()
+	b.ctx.Destroy()
+	return err
+}
@@ -0,0 +1,202 @@
+package external
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/miekg/pkcs11"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/operator/keys"
+	"github.com/ssvlabs/ssv/utils/rsaencryption"
+)
+
+const (
+	softHSMSOPIN   = "1234"
+	softHSMUserPIN = "5678"
+	softHSMKey     = "operator"
+)
+
+// softHSMToken initializes a new SoftHSM token holding an operator RSA key pair and returns its config.
+// It requires SOFTHSM2_LIB to be the path of the SoftHSM module and SOFTHSM2_CONF its config,
+// whose token directory must be writable, e.g.:
+//
+//	SOFTHSM2_LIB=/usr/lib/softhsm/libsofthsm2.so SOFTHSM2_CONF=/tmp/softhsm2.conf go test ./operator/keys/external/...
+func softHSMToken(t *testing.T) PKCS11Config {
+	modulePath := os.Getenv("SOFTHSM2_LIB")
+	if modulePath == "" || os.Getenv("SOFTHSM2_CONF") == "" {
+		t.Skip("SOFTHSM2_LIB and SOFTHSM2_CONF are required to test PKCS#11 keys")
+	}
+
+	ctx := pkcs11.New(modulePath)
+	require.NotNil(t, ctx, "could not load PKCS#11 module %s", modulePath)
+	require.NoError(t, ctx.Initialize())
+	defer func() {
+		require.NoError(t, ctx.Finalize())
+		ctx.Destroy()
+	}()
+
+	// SoftHSM always has a slot with an uninitialized token, which gets a new slot ID once initialized.
+	slots, err := ctx.GetSlotList(true)
+	require.NoError(t, err)
+	var slot uint
+	found := false
+	for _, s := range slots {
+		info, err := ctx.GetTokenInfo(s)
+		require.NoError(t, err)
+		if info.Flags&pkcs11.CKF_TOKEN_INITIALIZED == 0 {
+			slot, found = s, true
+			break
+		}
+	}
+	require.True(t, found, "no uninitialized SoftHSM token")
+
+	tokenLabel := fmt.Sprintf("ssv-test-%d", time.Now().UnixNano())
+	require.NoError(t, ctx.InitToken(slot, softHSMSOPIN, tokenLabel))
+
+	slots, err = ctx.GetSlotList(true)
+	require.NoError(t, err)
+	found = false
+	for _, s := range slots {
+		info, err := ctx.GetTokenInfo(s)
+		require.NoError(t, err)
+		if info.Label == tokenLabel {
+			slot, found = s, true
+			break
+		}
+	}
+	require.True(t, found, "initialized SoftHSM token not found")
+
+	session, err := ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ctx.CloseSession(session)) }()
+
+	require.NoError(t, ctx.Login(session, pkcs11.CKU_SO, softHSMSOPIN))
+	require.NoError(t, ctx.InitPIN(session, softHSMUserPIN))
+	require.NoError(t, ctx.Logout(session))
+	require.NoError(t, ctx.Login(session, pkcs11.CKU_USER, softHSMUserPIN))
+	defer func() { require.NoError(t, ctx.Logout(session)) }()
+
+	_, _, err = ctx.GenerateKeyPair(session,
+		[]*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_RSA_PKCS_KEY_PAIR_GEN, nil)},
+		[]*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_LABEL, softHSMKey),
+			pkcs11.NewAttribute(pkcs11.CKA_VERIFY, true),
+			pkcs11.NewAttribute(pkcs11.CKA_ENCRYPT, true),
+			pkcs11.NewAttribute(pkcs11.CKA_MODULUS_BITS, 2048),
+			pkcs11.NewAttribute(pkcs11.CKA_PUBLIC_EXPONENT, []byte{1, 0, 1}),
+		},
+		[]*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_LABEL, softHSMKey),
+			pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+			pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+			pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, false),
+			pkcs11.NewAttribute(pkcs11.CKA_SIGN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_DECRYPT, true),
+		},
+	)
+	require.NoError(t, err)
+
+	pinFile := filepath.Join(t.TempDir(), "pin")
+	require.NoError(t, os.WriteFile(pinFile, []byte(softHSMUserPIN+"\n"), 0600))
+
+	return PKCS11Config{
+		ModulePath: modulePath,
+		TokenLabel: tokenLabel,
+		KeyLabel:   softHSMKey,
+		PINFile:    pinFile,
+	}
+}
+
+func TestPKCS11Key(t *testing.T) {
+	cfg := softHSMToken(t)
+
+	key, err := New(Config{PKCS11: cfg})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, key.Close()) }()
+
+	var _ keys.OperatorKey = key
+
+	msg := []byte("hello")
+	signature, err := key.Sign(msg)
+	require.NoError(t, err)
+	require.NoError(t, key.Public().Verify(msg, signature))
+	require.Error(t, key.Public().Verify([]byte("other"), signature))
+
+	ciphertext, err := key.Public().Encrypt([]byte("share"))
+	require.NoError(t, err)
+	plaintext, err := key.Decrypt(ciphertext)
+	require.NoError(t, err)
+	require.Equal(t, []byte("share"), plaintext)
+
+	pubPEM, err := key.Public().Base64()
+	require.NoError(t, err)
+	expectedStorageHash, err := rsaencryption.HashRsaKey(pubPEM)
+	require.NoError(t, err)
+	storageHash, err := key.StorageHash()
+	require.NoError(t, err)
+	require.Equal(t, expectedStorageHash, storageHash)
+
+	ekmSignature, err := key.Sign([]byte(ekmSeed))
+	require.NoError(t, err)
+	require.NoError(t, key.Public().Verify([]byte(ekmSeed), ekmSignature))
+	expectedEKMHash := sha256.Sum256(ekmSignature)
+	ekmHash, err := key.EKMHash()
+	require.NoError(t, err)
+	require.Equal(t, hex.EncodeToString(expectedEKMHash[:]), ekmHash)
+
+	// The key and its hashes are the same once the token is opened again, e.g. after a restart.
+	require.NoError(t, key.Close())
+	key, err = New(Config{PKCS11: cfg})
+	require.NoError(t, err)
+
+	reopenedPubPEM, err := key.Public().Base64()
+	require.NoError(t, err)
+	require.Equal(t, pubPEM, reopenedPubPEM)
+	reopenedStorageHash, err := key.StorageHash()
+	require.NoError(t, err)
+	require.Equal(t, storageHash, reopenedStorageHash)
+	reopenedEKMHash, err := key.EKMHash()
+	require.NoError(t, err)
+	require.Equal(t, ekmHash, reopenedEKMHash)
+}
+
+func TestPKCS11KeyErrors(t *testing.T) {
+	cfg := softHSMToken(t)
+
+	t.Run("unknown token", func(t *testing.T) {
+		c := cfg
+		c.TokenLabel = "missing"
+		_, err := New(Config{PKCS11: c})
+		require.ErrorContains(t, err, "token \"missing\" not found")
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		c := cfg
+		c.KeyLabel = "missing"
+		_, err := New(Config{PKCS11: c})
+		require.ErrorContains(t, err, "key \"missing\" not found")
+	})
+
+	t.Run("wrong PIN", func(t *testing.T) {
+		c := cfg
+		c.PINFile = filepath.Join(t.TempDir(), "pin")
+		require.NoError(t, os.WriteFile(c.PINFile, []byte("0000"), 0600))
+		_, err := New(Config{PKCS11: c})
+		require.ErrorContains(t, err, "could not login")
+	})
+
+	t.Run("missing module", func(t *testing.T) {
+		c := cfg
+		c.ModulePath = filepath.Join(t.TempDir(), "missing.so")
+		_, err := New(Config{PKCS11: c})
+		require.ErrorContains(t, err, "could not load PKCS#11 module")
+	})
+}
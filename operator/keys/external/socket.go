@@ -0,0 +1,94 @@
+package external
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/ssvlabs/ssv/utils/rsaencryption"
+)
+
+// Signing daemon methods.
+const (
+	// MethodPublicKey returns the base64 encoded PEM public key, as printed by generate-operator-keys.
+	MethodPublicKey = "public_key"
+	// MethodSign returns the RSASSA-PKCS1-v1_5 SHA-256 signature of the data.
+	MethodSign = "sign"
+	// MethodDecrypt returns the RSAES-PKCS1-v1_5 decryption of the data.
+	MethodDecrypt = "decrypt"
+)
+
+// SocketRequest is a request to the signing daemon.
+// The protocol is a single JSON request followed by a single JSON response per connection.
+type SocketRequest struct {
+	Method string `json:"method"`
+	Data   []byte `json:"data,omitempty"`
+}
+
+// SocketResponse is a response of the signing daemon, Error is set if the request failed.
+type SocketResponse struct {
+	Data  []byte `json:"data,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// socketBackend talks to a local signing daemon over a unix socket.
+type socketBackend struct {
+	path    string
+	timeout time.Duration
+}
+
+func newSocketBackend(path string, timeout time.Duration) *socketBackend {
+	return &socketBackend{path: path, timeout: timeout}
+}
+
+func (s *socketBackend) call(method string, data []byte) ([]byte, error) {
+	conn, err := net.DialTimeout("unix", s.path, s.timeout)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to signing daemon: %w", err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+	if err := conn.SetDeadline(time.Now().Add(s.timeout)); err != nil {
+		return nil, err
+	}
+
+	if err := json.NewEncoder(conn).Encode(SocketRequest{Method: method, Data: data}); err != nil {
+		return nil, fmt.Errorf("could not send %s request: %w", method, err)
+	}
+	var resp SocketResponse
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("could not read %s response: %w", method, err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("signing daemon failed to %s: %s", method, resp.Error)
+	}
+	return resp.Data, nil
+}
+
+func (s *socketBackend) publicKey() (*rsa.PublicKey, error) {
+	data, err := s.call(MethodPublicKey, nil)
+	if err != nil {
+		return nil, err
+	}
+	pubPEM, err := base64.StdEncoding.DecodeString(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("could not decode public key: %w", err)
+	}
+	return rsaencryption.ConvertPemToPublicKey(pubPEM)
+}
+
+func (s *socketBackend) sign(data []byte) ([]byte, error) {
+	return s.call(MethodSign, data)
+}
+
+func (s *socketBackend) decrypt(ciphertext []byte) ([]byte, error) {
+	return s.call(MethodDecrypt, ciphertext)
+}
+
+func (s *socketBackend) Close() error {
+	return nil
+}
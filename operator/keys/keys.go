@@ -17,11 +17,17 @@ type OperatorPublicKey interface {
 	Base64() ([]byte, error)
 }
 
-type OperatorPrivateKey interface {
+// OperatorKey is an operator key that can sign and decrypt, either held in memory or by an external signer.
+// StorageHash identifies the key in the node storage and EKMHash is used to encrypt the share keys in it.
+type OperatorKey interface {
 	OperatorSigner
 	OperatorDecrypter
 	StorageHash() (string, error)
 	EKMHash() (string, error)
+}
+
+type OperatorPrivateKey interface {
+	OperatorKey
 	Bytes() []byte
 	Base64() []byte
 }
@@ -106,6 +112,11 @@ func (p *privateKey) EKMHash() (string, error) {
 	return rsaencryption.HashRsaKey(x509.MarshalPKCS1PrivateKey(p.privKey))
 }
 
+// PublicKeyFromRSA wraps the given RSA public key.
+func PublicKeyFromRSA(pubKey *rsa.PublicKey) OperatorPublicKey {
+	return &publicKey{pubKey: pubKey}
+}
+
 func PublicKeyFromString(pubKeyString string) (OperatorPublicKey, error) {
 	pubPem, err := base64.StdEncoding.DecodeString(pubKeyString)
 	if err != nil {
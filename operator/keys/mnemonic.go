@@ -0,0 +1,105 @@
+package keys
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/ssvlabs/eth2-key-manager/core"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// mnemonicKeySalt is the HKDF salt of the mnemonic key derivation, it versions the derivation.
+	mnemonicKeySalt = "ssv/operator-key/rsa/v1"
+	mnemonicKeySize = 2048
+	// primeTestRounds is the number of Miller-Rabin rounds of the prime test, in addition to its Baillie-PSW test.
+	primeTestRounds = 20
+)
+
+var rsaPublicExponent = big.NewInt(65537)
+
+// GenerateMnemonic returns a new 24 words BIP-39 mnemonic to derive operator keys from.
+func GenerateMnemonic() (string, error) {
+	entropy, err := core.GenerateNewEntropy()
+	if err != nil {
+		return "", err
+	}
+	return core.EntropyToMnemonic(entropy)
+}
+
+// PrivateKeyFromMnemonic derives a 2048 bits operator key from a BIP-39 mnemonic,
+// so that the key can be recreated from a backup of the mnemonic and, optionally, its passphrase.
+//
+// Each prime is found by an incremental search from an HKDF-SHA256 output of the BIP-39 seed,
+// with info "<index>/p" or "<index>/q", so different indexes derive independent keys.
+func PrivateKeyFromMnemonic(mnemonic, passphrase string, index uint64) (OperatorPrivateKey, error) {
+	seed, err := core.SeedFromMnemonic(mnemonic, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mnemonic: %w", err)
+	}
+
+	p, err := derivePrime(seed, fmt.Sprintf("%d/p", index))
+	if err != nil {
+		return nil, err
+	}
+	q, err := derivePrime(seed, fmt.Sprintf("%d/q", index))
+	if err != nil {
+		return nil, err
+	}
+	if p.Cmp(q) == 0 {
+		return nil, fmt.Errorf("derived equal primes")
+	}
+
+	one := big.NewInt(1)
+	phi := new(big.Int).Mul(new(big.Int).Sub(p, one), new(big.Int).Sub(q, one))
+	d := new(big.Int).ModInverse(rsaPublicExponent, phi)
+	if d == nil {
+		return nil, fmt.Errorf("public exponent isn't invertible")
+	}
+
+	privKey := &rsa.PrivateKey{
+		PublicKey: rsa.PublicKey{
+			N: new(big.Int).Mul(p, q),
+			E: int(rsaPublicExponent.Int64()),
+		},
+		D:      d,
+		Primes: []*big.Int{p, q},
+	}
+	if privKey.N.BitLen() != mnemonicKeySize {
+		return nil, fmt.Errorf("derived a %d bits key", privKey.N.BitLen())
+	}
+	privKey.Precompute()
+	if err := privKey.Validate(); err != nil {
+		return nil, fmt.Errorf("derived an invalid key: %w", err)
+	}
+
+	return &privateKey{privKey: privKey}, nil
+}
+
+// derivePrime returns the first prime p, with p-1 coprime to the public exponent,
+// from a candidate read from the seed's HKDF output with its two top bits set,
+// so that the product of two such primes has exactly mnemonicKeySize bits.
+func derivePrime(seed []byte, info string) (*big.Int, error) {
+	const primeBytes = mnemonicKeySize / 16
+
+	candidate := make([]byte, primeBytes)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, seed, []byte(mnemonicKeySalt), []byte(info)), candidate); err != nil {
+		return nil, fmt.Errorf("could not derive prime candidate: %w", err)
+	}
+	candidate[0] |= 0xc0
+	candidate[len(candidate)-1] |= 1
+
+	p := new(big.Int).SetBytes(candidate)
+	two := big.NewInt(2)
+	pMinusOne := new(big.Int)
+	for p.BitLen() == primeBytes*8 {
+		if p.ProbablyPrime(primeTestRounds) && new(big.Int).Mod(pMinusOne.Sub(p, big.NewInt(1)), rsaPublicExponent).Sign() != 0 {
+			return p, nil
+		}
+		p.Add(p, two)
+	}
+	return nil, fmt.Errorf("no prime found for %s", info)
+}
@@ -0,0 +1,62 @@
+package keys
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art"
+	// pinnedMnemonicKeyHash is the storage hash of the key derived from testMnemonic at index 0.
+	pinnedMnemonicKeyHash = "078e1f1bc96b90361eeb0450081b38bb2035a30f6b98886515b3d430762edf18"
+)
+
+func TestPrivateKeyFromMnemonic(t *testing.T) {
+	key, err := PrivateKeyFromMnemonic(testMnemonic, "", 0)
+	require.NoError(t, err)
+
+	again, err := PrivateKeyFromMnemonic(testMnemonic, "", 0)
+	require.NoError(t, err)
+	require.Equal(t, key.Bytes(), again.Bytes(), "derivation should be deterministic")
+
+	// Pinned so that changes to the derivation, which would break key recovery, are caught.
+	hash, err := key.StorageHash()
+	require.NoError(t, err)
+	require.Equal(t, pinnedMnemonicKeyHash, hash)
+
+	otherIndex, err := PrivateKeyFromMnemonic(testMnemonic, "", 1)
+	require.NoError(t, err)
+	require.NotEqual(t, key.Bytes(), otherIndex.Bytes())
+
+	withPassphrase, err := PrivateKeyFromMnemonic(testMnemonic, "passphrase", 0)
+	require.NoError(t, err)
+	require.NotEqual(t, key.Bytes(), withPassphrase.Bytes())
+
+	// The derived key is a regular operator key.
+	parsed, err := PrivateKeyFromString(string(key.Base64()))
+	require.NoError(t, err)
+	require.Equal(t, key.Bytes(), parsed.Bytes())
+
+	signature, err := key.Sign([]byte("data"))
+	require.NoError(t, err)
+	require.NoError(t, key.Public().Verify([]byte("data"), signature))
+
+	encrypted, err := key.Public().Encrypt([]byte("share"))
+	require.NoError(t, err)
+	decrypted, err := key.Decrypt(encrypted)
+	require.NoError(t, err)
+	require.Equal(t, []byte("share"), decrypted)
+}
+
+func TestPrivateKeyFromMnemonicInvalid(t *testing.T) {
+	_, err := PrivateKeyFromMnemonic("abandon abandon abandon", "", 0)
+	require.ErrorContains(t, err, "invalid mnemonic")
+}
+
+func TestGenerateMnemonic(t *testing.T) {
+	mnemonic, err := GenerateMnemonic()
+	require.NoError(t, err)
+	_, err = PrivateKeyFromMnemonic(mnemonic, "", 0)
+	require.NoError(t, err)
+}
@@ -0,0 +1,100 @@
+// Package maintenance finds windows in which an operator can take its node down with the least impact
+// on the duties of its validators.
+package maintenance
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// SlotDuties counts the duties of the operator's validators at a slot.
+type SlotDuties struct {
+	Slot          phase0.Slot
+	Proposals     int
+	Attestations  int
+	SyncCommittee int
+}
+
+// Window is a range of consecutive slots without proposals.
+type Window struct {
+	// StartSlot is the first slot of the window, and EndSlot the first slot after it.
+	StartSlot     phase0.Slot
+	EndSlot       phase0.Slot
+	Attestations  int
+	SyncCommittee int
+}
+
+// FindWindows returns up to limit non-overlapping windows of the given number of slots without proposals,
+// ranked by the fewest attestation duties, then the fewest sync committee duties, then the earliest start.
+// The slots must be consecutive and sorted, and windows can't extend past the last of them.
+func FindWindows(slots []SlotDuties, length int, limit int) []Window {
+	if length <= 0 || length > len(slots) {
+		return nil
+	}
+
+	var candidates []Window
+	for start := 0; start+length <= len(slots); start++ {
+		window := Window{
+			StartSlot: slots[start].Slot,
+			EndSlot:   slots[start+length-1].Slot + 1,
+		}
+		proposals := 0
+		for _, slot := range slots[start : start+length] {
+			proposals += slot.Proposals
+			window.Attestations += slot.Attestations
+			window.SyncCommittee += slot.SyncCommittee
+		}
+		if proposals == 0 {
+			candidates = append(candidates, window)
+		}
+	}
+
+	slices.SortStableFunc(candidates, func(a, b Window) int {
+		return cmp.Or(
+			cmp.Compare(a.Attestations, b.Attestations),
+			cmp.Compare(a.SyncCommittee, b.SyncCommittee),
+			cmp.Compare(a.StartSlot, b.StartSlot),
+		)
+	})
+
+	var windows []Window
+	for _, candidate := range candidates {
+		if len(windows) == limit {
+			break
+		}
+		overlaps := slices.ContainsFunc(windows, func(w Window) bool {
+			return candidate.StartSlot < w.EndSlot && w.StartSlot < candidate.EndSlot
+		})
+		if !overlaps {
+			windows = append(windows, candidate)
+		}
+	}
+	return windows
+}
+
+// NextWindow returns the earliest window of the given number of slots without proposals.
+func NextWindow(slots []SlotDuties, length int) (Window, bool) {
+	run := 0
+	for i, slot := range slots {
+		if slot.Proposals > 0 {
+			run = 0
+			continue
+		}
+		run++
+		if run < length {
+			continue
+		}
+		window := Window{
+			StartSlot: slots[i-length+1].Slot,
+			EndSlot:   slot.Slot + 1,
+		}
+		for _, s := range slots[i-length+1 : i+1] {
+			window.Attestations += s.Attestations
+			window.SyncCommittee += s.SyncCommittee
+		}
+		return window, true
+	}
+	return Window{}, false
+}
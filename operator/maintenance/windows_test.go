@@ -0,0 +1,46 @@
+package maintenance
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWindows(t *testing.T) {
+	// Slots 100-109, with proposals at 103 and 107.
+	slots := make([]SlotDuties, 10)
+	for i := range slots {
+		slots[i] = SlotDuties{Slot: phase0.Slot(100 + i), Attestations: 2, SyncCommittee: 1}
+	}
+	slots[3].Proposals = 1
+	slots[7].Proposals = 1
+	slots[4].Attestations = 0
+	slots[5].Attestations = 0
+
+	t.Run("next window", func(t *testing.T) {
+		window, ok := NextWindow(slots, 3)
+		require.True(t, ok)
+		require.Equal(t, Window{StartSlot: 100, EndSlot: 103, Attestations: 6, SyncCommittee: 3}, window)
+
+		window, ok = NextWindow(slots, 2)
+		require.True(t, ok)
+		require.Equal(t, phase0.Slot(100), window.StartSlot)
+
+		_, ok = NextWindow(slots, 4)
+		require.False(t, ok)
+	})
+
+	t.Run("ranked windows", func(t *testing.T) {
+		windows := FindWindows(slots, 2, 10)
+		require.Equal(t, []Window{
+			{StartSlot: 104, EndSlot: 106, Attestations: 0, SyncCommittee: 2},
+			{StartSlot: 100, EndSlot: 102, Attestations: 4, SyncCommittee: 2},
+			{StartSlot: 108, EndSlot: 110, Attestations: 4, SyncCommittee: 2},
+		}, windows)
+
+		require.Len(t, FindWindows(slots, 2, 1), 1)
+		require.Empty(t, FindWindows(slots, 4, 10))
+		require.Empty(t, FindWindows(slots, 11, 10))
+	})
+}
@@ -28,6 +28,7 @@ import (
 type Options struct {
 	// NetworkName is the network name of this node
 	NetworkName         string `yaml:"Network" env:"NETWORK" env-default:"mainnet" env-description:"Network is the network of this node"`
+	NetworkConfigFile   string `yaml:"NetworkConfigFile" env:"NETWORK_CONFIG_FILE" env-description:"Path of a custom network definition file, overrides Network"`
 	CustomDomainType    string `yaml:"CustomDomainType" env:"CUSTOM_DOMAIN_TYPE" env-default:"" env-description:"Override the SSV domain type. This is used to isolate the node from the rest of the network. Do not set unless you know what you are doing. This would be incremented by 1 for Alan, for example: 0x01020304 becomes 0x01020305 post-fork."`
 	Network             networkconfig.NetworkConfig
 	BeaconNode          beaconprotocol.BeaconNode // TODO: consider renaming to ConsensusClient
@@ -135,6 +136,7 @@ func (n *Node) Start(logger *zap.Logger) error {
 	go n.net.UpdateSubnets(logger)
 	go n.net.UpdateScoreParams(logger)
 	n.validatorsCtrl.StartValidators(n.context)
+	n.validatorsCtrl.ResumeExitRequests()
 	go n.reportOperators(logger)
 
 	go n.feeRecipientCtrl.Start(logger)
@@ -154,6 +156,11 @@ func (n *Node) Start(logger *zap.Logger) error {
 	return nil
 }
 
+// StopDuties stops executing new duties, the duties which already started keep running until the node's context is done.
+func (n *Node) StopDuties() {
+	n.dutyScheduler.Stop()
+}
+
 // HealthCheck returns a list of issues regards the state of the operator node
 func (n *Node) HealthCheck() error {
 	// TODO: previously this checked availability of consensus & execution clients.
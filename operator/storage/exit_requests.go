@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+var exitRequestsPrefix = []byte("exit_request/")
+
+// ExitRequestRetryEpochs is the number of epochs after the epoch of an exit request
+// during which the validator is expected to be seen exiting, allowing for the delay of the validator metadata sync.
+// Afterwards the exit is assumed to have failed and can be requested again.
+const ExitRequestRetryEpochs = 4
+
+// ExitRequest records a voluntary exit requested through the API, so that repeated requests don't repeat the exit.
+type ExitRequest struct {
+	PubKey         phase0.BLSPubKey      `json:"pubkey"`
+	ValidatorIndex phase0.ValidatorIndex `json:"validator_index"`
+	Epoch          phase0.Epoch          `json:"epoch"`
+	Slot           phase0.Slot           `json:"slot"`
+	RequestedAt    time.Time             `json:"requested_at"`
+}
+
+// Expired returns whether the exit request is past its ExitRequestRetryEpochs at the given epoch.
+func (r *ExitRequest) Expired(currentEpoch phase0.Epoch) bool {
+	return r.Epoch+ExitRequestRetryEpochs < currentEpoch
+}
+
+// GetExitRequest returns the exit request of the given validator.
+func (s *storage) GetExitRequest(r basedb.Reader, pubKey phase0.BLSPubKey) (*ExitRequest, bool, error) {
+	obj, found, err := s.db.UsingReader(r).Get(OperatorStoragePrefix, exitRequestKey(pubKey))
+	if err != nil {
+		return nil, false, fmt.Errorf("db: %w", err)
+	}
+	if !found {
+		return nil, false, nil
+	}
+
+	request := &ExitRequest{}
+	if err := json.Unmarshal(obj.Value, request); err != nil {
+		return nil, false, fmt.Errorf("unmarshal: %w", err)
+	}
+	return request, true, nil
+}
+
+// SaveExitRequest saves the exit request of its validator.
+func (s *storage) SaveExitRequest(rw basedb.ReadWriter, request *ExitRequest) error {
+	b, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	if err := s.db.Using(rw).Set(OperatorStoragePrefix, exitRequestKey(request.PubKey), b); err != nil {
+		return fmt.Errorf("db: %w", err)
+	}
+	return nil
+}
+
+// ListExitRequests returns the exit requests of all validators.
+func (s *storage) ListExitRequests(r basedb.Reader) ([]*ExitRequest, error) {
+	prefix := append(append([]byte{}, OperatorStoragePrefix...), exitRequestsPrefix...)
+
+	var requests []*ExitRequest
+	err := s.db.UsingReader(r).GetAll(prefix, func(i int, obj basedb.Obj) error {
+		request := &ExitRequest{}
+		if err := json.Unmarshal(obj.Value, request); err != nil {
+			return fmt.Errorf("unmarshal: %w", err)
+		}
+		requests = append(requests, request)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("db: %w", err)
+	}
+	return requests, nil
+}
+
+func exitRequestKey(pubKey phase0.BLSPubKey) []byte {
+	return append(exitRequestsPrefix, pubKey[:]...)
+}
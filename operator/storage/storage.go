@@ -6,6 +6,7 @@ import (
 	"math/big"
 
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
@@ -37,6 +38,10 @@ type Storage interface {
 	SaveConfig(rw basedb.ReadWriter, config *ConfigLock) error
 	DeleteConfig(rw basedb.ReadWriter) error
 
+	GetExitRequest(r basedb.Reader, pubKey phase0.BLSPubKey) (*ExitRequest, bool, error)
+	SaveExitRequest(rw basedb.ReadWriter, request *ExitRequest) error
+	ListExitRequests(r basedb.Reader) ([]*ExitRequest, error)
+
 	registry.RegistryStore
 
 	registrystorage.Operators
@@ -46,6 +51,7 @@ type Storage interface {
 
 	GetPrivateKeyHash() (string, bool, error)
 	SavePrivateKeyHash(privKeyHash string) error
+	SavePrivateKeyHashTxn(rw basedb.ReadWriter, privKeyHash string) error
 }
 
 type storage struct {
@@ -219,7 +225,12 @@ func (s *storage) GetPrivateKeyHash() (string, bool, error) {
 
 // SavePrivateKeyHash saves operator private key hash
 func (s *storage) SavePrivateKeyHash(hashedKey string) error {
-	return s.db.Set(OperatorStoragePrefix, []byte(HashedPrivateKey), []byte(hashedKey))
+	return s.SavePrivateKeyHashTxn(nil, hashedKey)
+}
+
+// SavePrivateKeyHashTxn saves operator private key hash within the given transaction
+func (s *storage) SavePrivateKeyHashTxn(rw basedb.ReadWriter, hashedKey string) error {
+	return s.db.Using(rw).Set(OperatorStoragePrefix, []byte(HashedPrivateKey), []byte(hashedKey))
 }
 
 func (s *storage) GetConfig(rw basedb.ReadWriter) (*ConfigLock, bool, error) {
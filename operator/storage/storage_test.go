@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ssvlabs/ssv/logging"
 	"github.com/ssvlabs/ssv/networkconfig"
@@ -365,3 +367,34 @@ func Test_NonceBumping(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, registrystorage.Nonce(0), nonce)
 }
+
+func Test_ExitRequests(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer func() {
+		_ = db.Close()
+	}()
+
+	operatorStorage := storage{
+		db: db,
+	}
+
+	requests, err := operatorStorage.ListExitRequests(nil)
+	require.NoError(t, err)
+	require.Empty(t, requests)
+
+	first := &ExitRequest{PubKey: phase0.BLSPubKey{1}, ValidatorIndex: 1, Epoch: 10, Slot: 320, RequestedAt: time.Unix(1700000000, 0).UTC()}
+	second := &ExitRequest{PubKey: phase0.BLSPubKey{2}, ValidatorIndex: 2, Epoch: 11, Slot: 352, RequestedAt: time.Unix(1700000100, 0).UTC()}
+	require.NoError(t, operatorStorage.SaveExitRequest(nil, first))
+	require.NoError(t, operatorStorage.SaveExitRequest(nil, second))
+
+	request, found, err := operatorStorage.GetExitRequest(nil, first.PubKey)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, first, request)
+
+	requests, err = operatorStorage.ListExitRequests(nil)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []*ExitRequest{first, second}, requests)
+}
@@ -86,7 +86,7 @@ type ControllerOptions struct {
 	DoppelgangerHandler        doppelganger.Provider
 	NetworkConfig              networkconfig.NetworkConfig
 	ValidatorSyncer            *metadata.Syncer
-	Graffiti                   []byte
+	Graffiti                   runner.GraffitiProvider
 
 	// worker flags
 	WorkersCount    int    `yaml:"MsgWorkersCount" env:"MSG_WORKERS_COUNT" env-default:"256" env-description:"Number of goroutines to use for message workers"`
@@ -115,7 +115,12 @@ type Controller interface {
 	ReactivateCluster(owner common.Address, operatorIDs []uint64, toReactivate []*ssvtypes.SSVShare) error
 	UpdateFeeRecipient(owner, recipient common.Address) error
 	ExitValidator(pubKey phase0.BLSPubKey, blockNumber uint64, validatorIndex phase0.ValidatorIndex, ownValidator bool) error
+	RequestValidatorExit(pubKey phase0.BLSPubKey, validatorIndex phase0.ValidatorIndex, slot phase0.Slot) error
+	// ResumeExitRequests schedules again the saved exit requests of own validators which haven't started exiting yet.
+	ResumeExitRequests()
 	ReportValidatorStatuses(ctx context.Context)
+	// InFlightDuties returns the number of committee and proposer duties from the given slot onwards which are still running.
+	InFlightDuties(fromSlot phase0.Slot) int
 	duties.DutyExecutor
 }
 
@@ -130,6 +135,10 @@ type Recipients interface {
 	GetRecipientData(r basedb.Reader, owner common.Address) (*registrystorage.RecipientData, bool, error)
 }
 
+type ExitRequests interface {
+	ListExitRequests(r basedb.Reader) ([]*nodestorage.ExitRequest, error)
+}
+
 type SharesStorage interface {
 	Get(txn basedb.Reader, pubKey []byte) (*ssvtypes.SSVShare, bool)
 	List(txn basedb.Reader, filters ...registrystorage.SharesFilter) []*ssvtypes.SSVShare
@@ -154,6 +163,7 @@ type controller struct {
 	sharesStorage     SharesStorage
 	operatorsStorage  registrystorage.Operators
 	recipientsStorage Recipients
+	exitRequests      ExitRequests
 	ibftStorageMap    *storage.ParticipantStores
 
 	beacon         beaconprotocol.BeaconNode
@@ -238,6 +248,7 @@ func NewController(logger *zap.Logger, options ControllerOptions) Controller {
 		sharesStorage:     options.RegistryStorage.Shares(),
 		operatorsStorage:  options.RegistryStorage,
 		recipientsStorage: options.RegistryStorage,
+		exitRequests:      options.RegistryStorage,
 		ibftStorageMap:    options.StorageMap,
 		validatorStore:    options.ValidatorStore,
 		ctx:               options.Context,
@@ -559,6 +570,8 @@ func (c *controller) startValidatorsForMetadata(_ context.Context, validators me
 			v.Share.ValidatorIndex = share.ValidatorIndex
 			v.Share.Status = share.Status
 			v.Share.ActivationEpoch = share.ActivationEpoch
+			v.Share.ExitEpoch = share.ExitEpoch
+			v.Share.Consolidating = share.Consolidating
 			started, err := c.startValidator(v)
 			if err != nil {
 				c.logger.Warn("could not start validator", zap.Error(err))
@@ -639,6 +652,25 @@ func (c *controller) ExecuteCommitteeDuty(ctx context.Context, logger *zap.Logge
 	}
 }
 
+func (c *controller) InFlightDuties(fromSlot phase0.Slot) int {
+	inFlight := 0
+	c.validatorsMap.ForEachCommittee(func(cm *validator.Committee) bool {
+		inFlight += cm.RunningDuties(fromSlot)
+		return true
+	})
+	c.validatorsMap.ForEachValidator(func(v *validator.Validator) bool {
+		r, ok := v.DutyRunners[spectypes.RoleProposer]
+		if !ok {
+			return true
+		}
+		if slot, running := r.GetBaseRunner().RunningDutySlot(); running && slot >= fromSlot {
+			inFlight++
+		}
+		return true
+	})
+	return inFlight
+}
+
 // CreateDutyExecuteMsg returns ssvMsg with event type of execute duty
 func CreateDutyExecuteMsg(duty *spectypes.ValidatorDuty, pubKey []byte, domain spectypes.DomainType) (*spectypes.SSVMessage, error) {
 	executeDutyData := ssvtypes.ExecuteDutyData{Duty: duty}
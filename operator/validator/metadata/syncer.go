@@ -6,8 +6,10 @@ import (
 	"math/big"
 	"time"
 
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/ssvlabs/ssv/beacon/goclient"
 	"github.com/ssvlabs/ssv/logging/fields"
 	networkcommons "github.com/ssvlabs/ssv/network/commons"
 	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
@@ -154,7 +156,7 @@ func (s *Syncer) Sync(ctx context.Context, pubKeys []spectypes.ValidatorPK) (Val
 	return metadata, nil
 }
 
-func (s *Syncer) Fetch(_ context.Context, pubKeys []spectypes.ValidatorPK) (ValidatorMap, error) {
+func (s *Syncer) Fetch(ctx context.Context, pubKeys []spectypes.ValidatorPK) (ValidatorMap, error) {
 	if len(pubKeys) == 0 {
 		return nil, nil
 	}
@@ -170,6 +172,13 @@ func (s *Syncer) Fetch(_ context.Context, pubKeys []spectypes.ValidatorPK) (Vali
 		return nil, fmt.Errorf("get validator data from beacon node: %w", err)
 	}
 
+	consolidating, err := s.consolidatingIndices(ctx, validatorsIndexMap)
+	if err != nil {
+		// Consolidation status is informational only, so it shouldn't prevent
+		// the rest of the metadata from being updated.
+		s.logger.Warn("failed to fetch pending consolidations", zap.Error(err))
+	}
+
 	results := make(map[spectypes.ValidatorPK]*beacon.ValidatorMetadata, len(validatorsIndexMap))
 	for _, v := range validatorsIndexMap {
 		meta := &beacon.ValidatorMetadata{
@@ -177,6 +186,8 @@ func (s *Syncer) Fetch(_ context.Context, pubKeys []spectypes.ValidatorPK) (Vali
 			Status:          v.Status,
 			Index:           v.Index,
 			ActivationEpoch: v.Validator.ActivationEpoch,
+			ExitEpoch:       v.Validator.ExitEpoch,
+			Consolidating:   consolidating[v.Index],
 		}
 		results[spectypes.ValidatorPK(v.Validator.PublicKey)] = meta
 	}
@@ -184,6 +195,35 @@ func (s *Syncer) Fetch(_ context.Context, pubKeys []spectypes.ValidatorPK) (Vali
 	return results, nil
 }
 
+// consolidatingIndices returns the indices of the given validators which are the source of a pending consolidation.
+// The beacon node is only queried if at least one of the validators is yet to exit, since consolidation
+// sources are exited the same way as validators exiting voluntarily or via an EIP-7002 withdrawal request.
+func (s *Syncer) consolidatingIndices(ctx context.Context, validators map[phase0.ValidatorIndex]*eth2apiv1.Validator) (map[phase0.ValidatorIndex]bool, error) {
+	currentEpoch := s.beaconNetwork.EstimatedCurrentEpoch()
+	exiting := make(map[phase0.ValidatorIndex]bool)
+	for _, v := range validators {
+		if v.Validator != nil && v.Validator.ExitEpoch > currentEpoch && v.Validator.ExitEpoch != goclient.FarFutureEpoch {
+			exiting[v.Index] = true
+		}
+	}
+	if len(exiting) == 0 {
+		return nil, nil
+	}
+
+	pendingConsolidations, err := s.beaconNode.PendingConsolidations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	consolidating := make(map[phase0.ValidatorIndex]bool)
+	for _, pc := range pendingConsolidations {
+		if exiting[pc.SourceIndex] {
+			consolidating[pc.SourceIndex] = true
+		}
+	}
+	return consolidating, nil
+}
+
 func (s *Syncer) Stream(ctx context.Context) <-chan SyncBatch {
 	metadataUpdates := make(chan SyncBatch)
 
@@ -8,8 +8,10 @@ import (
 	"time"
 
 	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/electra"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/ssvlabs/ssv/beacon/goclient"
 	"github.com/ssvlabs/ssv/logging"
 	"github.com/ssvlabs/ssv/network/commons"
 	"github.com/ssvlabs/ssv/networkconfig"
@@ -131,9 +133,10 @@ func TestSyncer_Sync(t *testing.T) {
 		mockShareStorage.EXPECT().UpdateValidatorsMetadata(gomock.Any()).Return(nil)
 
 		syncer := &Syncer{
-			logger:       logger,
-			shareStorage: mockShareStorage,
-			beaconNode:   defaultMockBeaconNode,
+			logger:        logger,
+			shareStorage:  mockShareStorage,
+			beaconNetwork: networkconfig.TestNetwork.Beacon,
+			beaconNode:    defaultMockBeaconNode,
 		}
 
 		pubKeys := []spectypes.ValidatorPK{{0x1}, {0x2}}
@@ -159,9 +162,10 @@ func TestSyncer_Sync(t *testing.T) {
 		})
 
 		syncer := &Syncer{
-			logger:       logger,
-			shareStorage: mockShareStorage,
-			beaconNode:   errMockBeaconNode,
+			logger:        logger,
+			shareStorage:  mockShareStorage,
+			beaconNetwork: networkconfig.TestNetwork.Beacon,
+			beaconNode:    errMockBeaconNode,
 		}
 
 		pubKeys := []spectypes.ValidatorPK{{0x1}, {0x2}}
@@ -176,9 +180,10 @@ func TestSyncer_Sync(t *testing.T) {
 		mockShareStorage.EXPECT().UpdateValidatorsMetadata(gomock.Any()).Return(fmt.Errorf("update error"))
 
 		syncer := &Syncer{
-			logger:       logger,
-			shareStorage: mockShareStorage,
-			beaconNode:   defaultMockBeaconNode,
+			logger:        logger,
+			shareStorage:  mockShareStorage,
+			beaconNetwork: networkconfig.TestNetwork.Beacon,
+			beaconNode:    defaultMockBeaconNode,
 		}
 
 		pubKeys := []spectypes.ValidatorPK{{0x1}, {0x2}}
@@ -202,9 +207,10 @@ func TestSyncer_Sync(t *testing.T) {
 		unusedMockBeaconNode.EXPECT().GetValidatorData(gomock.Any()).Times(0)
 
 		syncer := &Syncer{
-			logger:       logger,
-			shareStorage: mockShareStorage,
-			beaconNode:   unusedMockBeaconNode,
+			logger:        logger,
+			shareStorage:  mockShareStorage,
+			beaconNetwork: networkconfig.TestNetwork.Beacon,
+			beaconNode:    unusedMockBeaconNode,
 		}
 
 		pubKeys := []spectypes.ValidatorPK{}
@@ -214,6 +220,93 @@ func TestSyncer_Sync(t *testing.T) {
 	})
 }
 
+func TestSyncer_Fetch_ExitingValidators(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := zap.NewNop()
+
+	activePK := spectypes.ValidatorPK{0x1}
+	consolidatingPK := spectypes.ValidatorPK{0x2}
+	exitingPK := spectypes.ValidatorPK{0x3}
+	exitedPK := spectypes.ValidatorPK{0x4}
+	pubKeys := []spectypes.ValidatorPK{activePK, consolidatingPK, exitingPK, exitedPK}
+
+	beaconNetwork := networkconfig.TestNetwork.Beacon
+	currentEpoch := beaconNetwork.EstimatedCurrentEpoch()
+
+	validators := map[phase0.ValidatorIndex]*eth2apiv1.Validator{
+		1: {
+			Index:     1,
+			Status:    eth2apiv1.ValidatorStateActiveOngoing,
+			Validator: &phase0.Validator{PublicKey: phase0.BLSPubKey(activePK), ActivationEpoch: 10, ExitEpoch: goclient.FarFutureEpoch},
+		},
+		2: {
+			Index:     2,
+			Status:    eth2apiv1.ValidatorStateActiveExiting,
+			Validator: &phase0.Validator{PublicKey: phase0.BLSPubKey(consolidatingPK), ActivationEpoch: 10, ExitEpoch: currentEpoch + 10},
+		},
+		3: {
+			Index:     3,
+			Status:    eth2apiv1.ValidatorStateActiveExiting,
+			Validator: &phase0.Validator{PublicKey: phase0.BLSPubKey(exitingPK), ActivationEpoch: 10, ExitEpoch: currentEpoch + 20},
+		},
+		4: {
+			Index:     4,
+			Status:    eth2apiv1.ValidatorStateExitedUnslashed,
+			Validator: &phase0.Validator{PublicKey: phase0.BLSPubKey(exitedPK), ActivationEpoch: 10, ExitEpoch: currentEpoch - 5},
+		},
+	}
+	pendingConsolidations := []*electra.PendingConsolidation{
+		{SourceIndex: 2, TargetIndex: 1},
+		{SourceIndex: 100, TargetIndex: 3}, // consolidation into own validator doesn't exit it
+		{SourceIndex: 4, TargetIndex: 1},   // already exited, so it's no longer consolidating
+	}
+
+	t.Run("Consolidating and exiting", func(t *testing.T) {
+		beaconNode := beacon.NewMockBeaconNode(ctrl)
+		beaconNode.EXPECT().GetValidatorData(gomock.Any()).Return(validators, nil)
+		beaconNode.EXPECT().PendingConsolidations(gomock.Any()).Return(pendingConsolidations, nil)
+
+		syncer := &Syncer{logger: logger, beaconNetwork: beaconNetwork, beaconNode: beaconNode}
+		result, err := syncer.Fetch(context.Background(), pubKeys)
+		require.NoError(t, err)
+
+		require.Equal(t, ValidatorMap{
+			activePK:        {Index: 1, Status: eth2apiv1.ValidatorStateActiveOngoing, ActivationEpoch: 10, ExitEpoch: goclient.FarFutureEpoch},
+			consolidatingPK: {Index: 2, Status: eth2apiv1.ValidatorStateActiveExiting, ActivationEpoch: 10, ExitEpoch: currentEpoch + 10, Consolidating: true},
+			exitingPK:       {Index: 3, Status: eth2apiv1.ValidatorStateActiveExiting, ActivationEpoch: 10, ExitEpoch: currentEpoch + 20},
+			exitedPK:        {Index: 4, Status: eth2apiv1.ValidatorStateExitedUnslashed, ActivationEpoch: 10, ExitEpoch: currentEpoch - 5},
+		}, result)
+	})
+
+	t.Run("Pending consolidations error", func(t *testing.T) {
+		beaconNode := beacon.NewMockBeaconNode(ctrl)
+		beaconNode.EXPECT().GetValidatorData(gomock.Any()).Return(validators, nil)
+		beaconNode.EXPECT().PendingConsolidations(gomock.Any()).Return(nil, fmt.Errorf("pending consolidations error"))
+
+		syncer := &Syncer{logger: logger, beaconNetwork: beaconNetwork, beaconNode: beaconNode}
+		result, err := syncer.Fetch(context.Background(), pubKeys)
+		require.NoError(t, err)
+		require.Len(t, result, 4)
+		require.EqualValues(t, currentEpoch+10, result[consolidatingPK].ExitEpoch)
+		require.False(t, result[consolidatingPK].Consolidating)
+	})
+
+	t.Run("No exiting validators", func(t *testing.T) {
+		beaconNode := beacon.NewMockBeaconNode(ctrl)
+		beaconNode.EXPECT().GetValidatorData(gomock.Any()).Return(map[phase0.ValidatorIndex]*eth2apiv1.Validator{1: validators[1], 4: validators[4]}, nil)
+		// PendingConsolidations should not be called in this case
+		beaconNode.EXPECT().PendingConsolidations(gomock.Any()).Times(0)
+
+		syncer := &Syncer{logger: logger, beaconNetwork: beaconNetwork, beaconNode: beaconNode}
+		result, err := syncer.Fetch(context.Background(), []spectypes.ValidatorPK{activePK, exitedPK})
+		require.NoError(t, err)
+		require.False(t, result[activePK].Consolidating)
+		require.False(t, result[exitedPK].Consolidating)
+	})
+}
+
 func TestSyncer_UpdateOnStartup(t *testing.T) {
 	logger := zap.NewNop()
 
@@ -304,6 +397,7 @@ func TestSyncer_UpdateOnStartup(t *testing.T) {
 			logger:         logger,
 			shareStorage:   mockShareStorage,
 			validatorStore: mockValidatorStore,
+			beaconNetwork:  networkconfig.TestNetwork.Beacon,
 			beaconNode:     defaultMockBeaconNode,
 		}
 
@@ -359,6 +453,7 @@ func TestSyncer_UpdateOnStartup(t *testing.T) {
 			logger:         logger,
 			shareStorage:   mockShareStorage,
 			validatorStore: mockValidatorStore,
+			beaconNetwork:  networkconfig.TestNetwork.Beacon,
 			beaconNode:     errMockBeaconNode,
 		}
 
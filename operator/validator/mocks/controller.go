@@ -19,9 +19,10 @@ import (
 	network "github.com/ssvlabs/ssv/network"
 	commons "github.com/ssvlabs/ssv/network/commons"
 	duties "github.com/ssvlabs/ssv/operator/duties"
+	storage "github.com/ssvlabs/ssv/operator/storage"
 	validator "github.com/ssvlabs/ssv/protocol/v2/ssv/validator"
 	types0 "github.com/ssvlabs/ssv/protocol/v2/types"
-	storage "github.com/ssvlabs/ssv/registry/storage"
+	storage0 "github.com/ssvlabs/ssv/registry/storage"
 	basedb "github.com/ssvlabs/ssv/storage/basedb"
 	gomock "go.uber.org/mock/gomock"
 	zap "go.uber.org/zap"
@@ -31,6 +32,7 @@ import (
 type MockController struct {
 	ctrl     *gomock.Controller
 	recorder *MockControllerMockRecorder
+	isgomock struct{}
 }
 
 // MockControllerMockRecorder is the mock recorder for MockController.
@@ -146,6 +148,20 @@ func (mr *MockControllerMockRecorder) HandleMetadataUpdates(ctx any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleMetadataUpdates", reflect.TypeOf((*MockController)(nil).HandleMetadataUpdates), ctx)
 }
 
+// InFlightDuties mocks base method.
+func (m *MockController) InFlightDuties(fromSlot phase0.Slot) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InFlightDuties", fromSlot)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// InFlightDuties indicates an expected call of InFlightDuties.
+func (mr *MockControllerMockRecorder) InFlightDuties(fromSlot any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InFlightDuties", reflect.TypeOf((*MockController)(nil).InFlightDuties), fromSlot)
+}
+
 // IndicesChangeChan mocks base method.
 func (m *MockController) IndicesChangeChan() chan struct{} {
 	m.ctrl.T.Helper()
@@ -200,6 +216,32 @@ func (mr *MockControllerMockRecorder) ReportValidatorStatuses(ctx any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportValidatorStatuses", reflect.TypeOf((*MockController)(nil).ReportValidatorStatuses), ctx)
 }
 
+// RequestValidatorExit mocks base method.
+func (m *MockController) RequestValidatorExit(pubKey phase0.BLSPubKey, validatorIndex phase0.ValidatorIndex, slot phase0.Slot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestValidatorExit", pubKey, validatorIndex, slot)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestValidatorExit indicates an expected call of RequestValidatorExit.
+func (mr *MockControllerMockRecorder) RequestValidatorExit(pubKey, validatorIndex, slot any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestValidatorExit", reflect.TypeOf((*MockController)(nil).RequestValidatorExit), pubKey, validatorIndex, slot)
+}
+
+// ResumeExitRequests mocks base method.
+func (m *MockController) ResumeExitRequests() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ResumeExitRequests")
+}
+
+// ResumeExitRequests indicates an expected call of ResumeExitRequests.
+func (mr *MockControllerMockRecorder) ResumeExitRequests() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeExitRequests", reflect.TypeOf((*MockController)(nil).ResumeExitRequests))
+}
+
 // StartNetworkHandlers mocks base method.
 func (m *MockController) StartNetworkHandlers() {
 	m.ctrl.T.Helper()
@@ -270,6 +312,7 @@ func (mr *MockControllerMockRecorder) ValidatorExitChan() *gomock.Call {
 type MockRecipients struct {
 	ctrl     *gomock.Controller
 	recorder *MockRecipientsMockRecorder
+	isgomock struct{}
 }
 
 // MockRecipientsMockRecorder is the mock recorder for MockRecipients.
@@ -290,10 +333,10 @@ func (m *MockRecipients) EXPECT() *MockRecipientsMockRecorder {
 }
 
 // GetRecipientData mocks base method.
-func (m *MockRecipients) GetRecipientData(r basedb.Reader, owner common.Address) (*storage.RecipientData, bool, error) {
+func (m *MockRecipients) GetRecipientData(r basedb.Reader, owner common.Address) (*storage0.RecipientData, bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetRecipientData", r, owner)
-	ret0, _ := ret[0].(*storage.RecipientData)
+	ret0, _ := ret[0].(*storage0.RecipientData)
 	ret1, _ := ret[1].(bool)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
@@ -305,10 +348,50 @@ func (mr *MockRecipientsMockRecorder) GetRecipientData(r, owner any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipientData", reflect.TypeOf((*MockRecipients)(nil).GetRecipientData), r, owner)
 }
 
+// MockExitRequests is a mock of ExitRequests interface.
+type MockExitRequests struct {
+	ctrl     *gomock.Controller
+	recorder *MockExitRequestsMockRecorder
+	isgomock struct{}
+}
+
+// MockExitRequestsMockRecorder is the mock recorder for MockExitRequests.
+type MockExitRequestsMockRecorder struct {
+	mock *MockExitRequests
+}
+
+// NewMockExitRequests creates a new mock instance.
+func NewMockExitRequests(ctrl *gomock.Controller) *MockExitRequests {
+	mock := &MockExitRequests{ctrl: ctrl}
+	mock.recorder = &MockExitRequestsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExitRequests) EXPECT() *MockExitRequestsMockRecorder {
+	return m.recorder
+}
+
+// ListExitRequests mocks base method.
+func (m *MockExitRequests) ListExitRequests(r basedb.Reader) ([]*storage.ExitRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExitRequests", r)
+	ret0, _ := ret[0].([]*storage.ExitRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExitRequests indicates an expected call of ListExitRequests.
+func (mr *MockExitRequestsMockRecorder) ListExitRequests(r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExitRequests", reflect.TypeOf((*MockExitRequests)(nil).ListExitRequests), r)
+}
+
 // MockSharesStorage is a mock of SharesStorage interface.
 type MockSharesStorage struct {
 	ctrl     *gomock.Controller
 	recorder *MockSharesStorageMockRecorder
+	isgomock struct{}
 }
 
 // MockSharesStorageMockRecorder is the mock recorder for MockSharesStorage.
@@ -344,7 +427,7 @@ func (mr *MockSharesStorageMockRecorder) Get(txn, pubKey any) *gomock.Call {
 }
 
 // List mocks base method.
-func (m *MockSharesStorage) List(txn basedb.Reader, filters ...storage.SharesFilter) []*types0.SSVShare {
+func (m *MockSharesStorage) List(txn basedb.Reader, filters ...storage0.SharesFilter) []*types0.SSVShare {
 	m.ctrl.T.Helper()
 	varargs := []any{txn}
 	for _, a := range filters {
@@ -378,6 +461,7 @@ func (mr *MockSharesStorageMockRecorder) Range(txn, fn any) *gomock.Call {
 type MockP2PNetwork struct {
 	ctrl     *gomock.Controller
 	recorder *MockP2PNetworkMockRecorder
+	isgomock struct{}
 }
 
 // MockP2PNetworkMockRecorder is the mock recorder for MockP2PNetwork.
@@ -104,7 +104,75 @@ func (c *controller) ExitValidator(pubKey phase0.BLSPubKey, blockNumber uint64,
 		ValidatorIndex: validatorIndex,
 		BlockNumber:    blockNumber,
 	}
+	c.scheduleExit(logger, exitDesc)
 
+	return nil
+}
+
+// RequestValidatorExit schedules a voluntary exit duty of an own validator at the given slot,
+// for exits which aren't triggered by a contract event.
+func (c *controller) RequestValidatorExit(pubKey phase0.BLSPubKey, validatorIndex phase0.ValidatorIndex, slot phase0.Slot) error {
+	logger := c.taskLogger("RequestValidatorExit",
+		fields.PubKey(pubKey[:]),
+		fields.Slot(slot),
+		zap.Uint64("validator_index", uint64(validatorIndex)),
+	)
+
+	c.scheduleExit(logger, duties.ExitDescriptor{
+		OwnValidator:   true,
+		PubKey:         pubKey,
+		ValidatorIndex: validatorIndex,
+		Slot:           slot,
+	})
+
+	return nil
+}
+
+// ResumeExitRequests schedules again the saved exit requests of own validators which haven't started exiting yet,
+// since the exit duties of the requests only live in memory and are lost when the node restarts.
+// Requests past their retry window are left to be requested again, and requests whose slot has passed
+// are rescheduled like a new exit, as peers reject the messages of a past duty.
+func (c *controller) ResumeExitRequests() {
+	logger := c.taskLogger("ResumeExitRequests")
+
+	requests, err := c.exitRequests.ListExitRequests(nil)
+	if err != nil {
+		logger.Error("failed to list exit requests", zap.Error(err))
+		return
+	}
+
+	currentSlot := c.networkConfig.Beacon.EstimatedCurrentSlot()
+	currentEpoch := c.networkConfig.Beacon.EstimatedEpochAtSlot(currentSlot)
+
+	resumed := 0
+	for _, request := range requests {
+		if request.Expired(currentEpoch) {
+			continue
+		}
+		share, found := c.sharesStorage.Get(nil, request.PubKey[:])
+		if !found ||
+			!share.BelongsToOperator(c.operatorDataStore.GetOperatorID()) ||
+			share.Liquidated ||
+			share.Exiting() {
+			continue
+		}
+		slot := request.Slot
+		if slot <= currentSlot {
+			slot = currentSlot + duties.VoluntaryExitSlotsToPostpone
+		}
+		if err := c.RequestValidatorExit(request.PubKey, request.ValidatorIndex, slot); err != nil {
+			logger.Error("failed to resume exit request", fields.PubKey(request.PubKey[:]), zap.Error(err))
+			continue
+		}
+		resumed++
+	}
+
+	logger.Debug("resumed exit requests",
+		zap.Int("exit_requests", len(requests)),
+		zap.Int("resumed", resumed))
+}
+
+func (c *controller) scheduleExit(logger *zap.Logger, exitDesc duties.ExitDescriptor) {
 	go func() {
 		select {
 		case c.validatorExitCh <- exitDesc:
@@ -113,6 +181,4 @@ func (c *controller) ExitValidator(pubKey phase0.BLSPubKey, blockNumber uint64,
 			logger.Error("failed to schedule ExitValidator duty!")
 		}
 	}()
-
-	return nil
 }
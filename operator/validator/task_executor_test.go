@@ -7,6 +7,7 @@ import (
 	"time"
 
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/herumi/bls-eth-go-binary/bls"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
@@ -14,10 +15,15 @@ import (
 	ibftstorage "github.com/ssvlabs/ssv/ibft/storage"
 	"github.com/ssvlabs/ssv/networkconfig"
 	operatordatastore "github.com/ssvlabs/ssv/operator/datastore"
+	"github.com/ssvlabs/ssv/operator/duties"
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	"github.com/ssvlabs/ssv/operator/validator/mocks"
 	"github.com/ssvlabs/ssv/operator/validators"
 	"github.com/ssvlabs/ssv/protocol/v2/ssv/runner"
 	"github.com/ssvlabs/ssv/protocol/v2/ssv/validator"
 	"github.com/ssvlabs/ssv/protocol/v2/types"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/utils"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -238,3 +244,108 @@ func TestController_ReactivateCluster(t *testing.T) {
 	}
 
 }
+
+func TestController_ResumeExitRequests(t *testing.T) {
+	ctrl, logger, sharesStorage, _, _, _, bc := setupCommonTestComponents(t)
+	defer ctrl.Finish()
+
+	bc.EXPECT().GetBeaconNetwork().AnyTimes().Return(networkconfig.TestNetwork.Beacon.GetBeaconNetwork())
+
+	exitShare := func(pubKey spectypes.ValidatorPK, status v1.ValidatorState, liquidated bool, operatorIDs ...spectypes.OperatorID) *types.SSVShare {
+		share := &types.SSVShare{
+			Share: spectypes.Share{
+				ValidatorPubKey: pubKey,
+			},
+			Status:     status,
+			Liquidated: liquidated,
+		}
+		for _, id := range operatorIDs {
+			share.Committee = append(share.Committee, &spectypes.ShareMember{Signer: id})
+		}
+		return share
+	}
+
+	pending := createPubKey(1)
+	exited := createPubKey(2)
+	liquidated := createPubKey(3)
+	foreign := createPubKey(4)
+	removed := createPubKey(5)
+	future := createPubKey(6)
+	stale := createPubKey(7)
+
+	shares := map[spectypes.ValidatorPK]*types.SSVShare{
+		pending:    exitShare(pending, v1.ValidatorStateActiveOngoing, false, 1, 2, 3, 4),
+		future:     exitShare(future, v1.ValidatorStateActiveOngoing, false, 1, 2, 3, 4),
+		stale:      exitShare(stale, v1.ValidatorStateActiveOngoing, false, 1, 2, 3, 4),
+		exited:     exitShare(exited, v1.ValidatorStateExitedUnslashed, false, 1, 2, 3, 4),
+		liquidated: exitShare(liquidated, v1.ValidatorStateActiveOngoing, true, 1, 2, 3, 4),
+		foreign:    exitShare(foreign, v1.ValidatorStateActiveOngoing, false, 5, 6, 7, 8),
+	}
+	sharesStorage.EXPECT().Get(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(_ basedb.Reader, pubKey []byte) (*types.SSVShare, bool) {
+		share, found := shares[spectypes.ValidatorPK(pubKey)]
+		return share, found
+	})
+
+	var requests []*operatorstorage.ExitRequest
+	for i, pubKey := range []spectypes.ValidatorPK{pending, exited, liquidated, foreign, removed} {
+		requests = append(requests, &operatorstorage.ExitRequest{
+			PubKey:         phase0.BLSPubKey(pubKey),
+			ValidatorIndex: phase0.ValidatorIndex(i),
+			Epoch:          10,
+			Slot:           320,
+		})
+	}
+	requests = append(requests,
+		&operatorstorage.ExitRequest{PubKey: phase0.BLSPubKey(future), ValidatorIndex: 5, Epoch: 12, Slot: 384},
+		&operatorstorage.ExitRequest{PubKey: phase0.BLSPubKey(stale), ValidatorIndex: 6, Epoch: 5, Slot: 160},
+	)
+	exitRequests := mocks.NewMockExitRequests(ctrl)
+	exitRequests.EXPECT().ListExitRequests(gomock.Any()).Return(requests, nil)
+
+	currentSlot := &utils.SlotValue{}
+	currentSlot.SetSlot(330)
+	networkConfig := networkconfig.TestNetwork
+	networkConfig.Beacon = utils.SetupMockBeaconNetwork(t, currentSlot)
+
+	ctr := setupController(logger, MockControllerOptions{
+		beacon:            bc,
+		sharesStorage:     sharesStorage,
+		operatorDataStore: operatordatastore.New(buildOperatorData(1, "67Ce5c69260bd819B4e0AD13f4b873074D479811")),
+		networkConfig:     networkConfig,
+	})
+	ctr.exitRequests = exitRequests
+	ctr.validatorExitCh = make(chan duties.ExitDescriptor, len(requests))
+
+	ctr.ResumeExitRequests()
+
+	// The request whose slot has passed is rescheduled, the stale request is past its retry window.
+	var resumed []duties.ExitDescriptor
+	for i := 0; i < 2; i++ {
+		select {
+		case exitDesc := <-ctr.validatorExitCh:
+			resumed = append(resumed, exitDesc)
+		case <-time.After(1 * time.Second):
+			require.Fail(t, "exit request wasn't resumed")
+		}
+	}
+	require.ElementsMatch(t, []duties.ExitDescriptor{
+		{
+			OwnValidator:   true,
+			PubKey:         phase0.BLSPubKey(pending),
+			ValidatorIndex: 0,
+			Slot:           330 + duties.VoluntaryExitSlotsToPostpone,
+		},
+		{
+			OwnValidator:   true,
+			PubKey:         phase0.BLSPubKey(future),
+			ValidatorIndex: 5,
+			Slot:           384,
+		},
+	}, resumed)
+
+	select {
+	case exitDesc := <-ctr.validatorExitCh:
+		require.Failf(t, "unexpected exit", "%x", exitDesc.PubKey)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
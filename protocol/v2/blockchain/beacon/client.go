@@ -6,6 +6,7 @@ import (
 
 	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/electra"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 
 	specssv "github.com/ssvlabs/ssv-spec/ssv"
@@ -21,6 +22,8 @@ type beaconDuties interface {
 	ProposerDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*eth2apiv1.ProposerDuty, error)
 	SyncCommitteeDuties(ctx context.Context, epoch phase0.Epoch, indices []phase0.ValidatorIndex) ([]*eth2apiv1.SyncCommitteeDuty, error)
 	SubscribeToHeadEvents(ctx context.Context, subscriberIdentifier string, ch chan<- *eth2apiv1.HeadEvent) error
+	SubscribeToChainReorgEvents(ctx context.Context, subscriberIdentifier string, ch chan<- *eth2apiv1.ChainReorgEvent) error
+	SubscribeToFinalizedCheckpointEvents(ctx context.Context, subscriberIdentifier string, ch chan<- *eth2apiv1.FinalizedCheckpointEvent) error
 }
 
 // beaconSubscriber interface serves all committee subscribe to subnet (p2p topic)
@@ -34,6 +37,8 @@ type beaconSubscriber interface {
 type beaconValidator interface {
 	// GetValidatorData returns metadata (balance, index, status, more) for each pubkey from the node
 	GetValidatorData(validatorPubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*eth2apiv1.Validator, error)
+	// PendingConsolidations returns the consolidations (EIP-7251) of the head state, whose source validators are exiting
+	PendingConsolidations(ctx context.Context) ([]*electra.PendingConsolidation, error)
 }
 
 type proposer interface {
@@ -60,12 +65,14 @@ type BeaconNode interface {
 
 // Options for controller struct creation
 type Options struct {
-	Context                     context.Context
-	Network                     Network
-	BeaconNodeAddr              string `yaml:"BeaconNodeAddr" env:"BEACON_NODE_ADDR" env-required:"true" env-description:"Beacon node address. Supports multiple semicolon separated addresses. ex: http://localhost:5052;http://localhost:5053"`
-	SyncDistanceTolerance       uint64 `yaml:"SyncDistanceTolerance" env:"BEACON_SYNC_DISTANCE_TOLERANCE" env-default:"4" env-description:"The number of out-of-sync slots we can tolerate"`
-	WithWeightedAttestationData bool   `yaml:"WithWeightedAttestationData" env:"WITH_WEIGHTED_ATTESTATION_DATA" env-default:"false" env-description:"Enables Attestation Data fetching & scoring using multiple Beacon nodes simultaneously (as opposed to fetching Attestation Data from just one Beacon node)"`
-	WithParallelSubmissions     bool   `yaml:"WithParallelSubmissions" env:"WITH_PARALLEL_SUBMISSIONS" env-default:"false" env-description:"Enables parallel Attestation and Sync Committee submissions to all Beacon nodes (as opposed to submitting to a single Beacon node via multiclient instance)"`
+	Context                       context.Context
+	Network                       Network
+	BeaconNodeAddr                string `yaml:"BeaconNodeAddr" env:"BEACON_NODE_ADDR" env-required:"true" env-description:"Beacon node address. Supports multiple semicolon separated addresses. ex: http://localhost:5052;http://localhost:5053"`
+	SyncDistanceTolerance         uint64 `yaml:"SyncDistanceTolerance" env:"BEACON_SYNC_DISTANCE_TOLERANCE" env-default:"4" env-description:"The number of out-of-sync slots we can tolerate"`
+	WithWeightedAttestationData   bool   `yaml:"WithWeightedAttestationData" env:"WITH_WEIGHTED_ATTESTATION_DATA" env-default:"false" env-description:"Enables Attestation Data fetching & scoring using multiple Beacon nodes simultaneously (as opposed to fetching Attestation Data from just one Beacon node)"`
+	WithParallelSubmissions       bool   `yaml:"WithParallelSubmissions" env:"WITH_PARALLEL_SUBMISSIONS" env-default:"false" env-description:"Enables parallel Attestation and Sync Committee submissions to all Beacon nodes (as opposed to submitting to a single Beacon node via multiclient instance)"`
+	WithWeightedSyncCommitteeData bool   `yaml:"WithWeightedSyncCommitteeData" env:"WITH_WEIGHTED_SYNC_COMMITTEE_DATA" env-default:"false" env-description:"Enables Sync Committee contribution fetching & scoring using multiple Beacon nodes simultaneously (as opposed to fetching them from just one Beacon node)"`
+	WithHighestValueProposal      bool   `yaml:"WithHighestValueProposal" env:"WITH_HIGHEST_VALUE_PROPOSAL" env-default:"false" env-description:"Enables requesting block proposals from all Beacon nodes simultaneously and choosing the one with the highest value (as opposed to requesting the proposal from just one Beacon node)"`
 
 	CommonTimeout time.Duration // Optional.
 	LongTimeout   time.Duration // Optional.
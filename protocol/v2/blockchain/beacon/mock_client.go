@@ -18,6 +18,7 @@ import (
 	spec "github.com/attestantio/go-eth2-client/spec"
 	altair "github.com/attestantio/go-eth2-client/spec/altair"
 	bellatrix "github.com/attestantio/go-eth2-client/spec/bellatrix"
+	electra "github.com/attestantio/go-eth2-client/spec/electra"
 	phase0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	ssz "github.com/ferranbt/fastssz"
 	types "github.com/ssvlabs/ssv-spec/types"
@@ -78,6 +79,34 @@ func (mr *MockbeaconDutiesMockRecorder) ProposerDuties(ctx, epoch, validatorIndi
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposerDuties", reflect.TypeOf((*MockbeaconDuties)(nil).ProposerDuties), ctx, epoch, validatorIndices)
 }
 
+// SubscribeToChainReorgEvents mocks base method.
+func (m *MockbeaconDuties) SubscribeToChainReorgEvents(ctx context.Context, subscriberIdentifier string, ch chan<- *v1.ChainReorgEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeToChainReorgEvents", ctx, subscriberIdentifier, ch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubscribeToChainReorgEvents indicates an expected call of SubscribeToChainReorgEvents.
+func (mr *MockbeaconDutiesMockRecorder) SubscribeToChainReorgEvents(ctx, subscriberIdentifier, ch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeToChainReorgEvents", reflect.TypeOf((*MockbeaconDuties)(nil).SubscribeToChainReorgEvents), ctx, subscriberIdentifier, ch)
+}
+
+// SubscribeToFinalizedCheckpointEvents mocks base method.
+func (m *MockbeaconDuties) SubscribeToFinalizedCheckpointEvents(ctx context.Context, subscriberIdentifier string, ch chan<- *v1.FinalizedCheckpointEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeToFinalizedCheckpointEvents", ctx, subscriberIdentifier, ch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubscribeToFinalizedCheckpointEvents indicates an expected call of SubscribeToFinalizedCheckpointEvents.
+func (mr *MockbeaconDutiesMockRecorder) SubscribeToFinalizedCheckpointEvents(ctx, subscriberIdentifier, ch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeToFinalizedCheckpointEvents", reflect.TypeOf((*MockbeaconDuties)(nil).SubscribeToFinalizedCheckpointEvents), ctx, subscriberIdentifier, ch)
+}
+
 // SubscribeToHeadEvents mocks base method.
 func (m *MockbeaconDuties) SubscribeToHeadEvents(ctx context.Context, subscriberIdentifier string, ch chan<- *v1.HeadEvent) error {
 	m.ctrl.T.Helper()
@@ -198,6 +227,21 @@ func (mr *MockbeaconValidatorMockRecorder) GetValidatorData(validatorPubKeys any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorData", reflect.TypeOf((*MockbeaconValidator)(nil).GetValidatorData), validatorPubKeys)
 }
 
+// PendingConsolidations mocks base method.
+func (m *MockbeaconValidator) PendingConsolidations(ctx context.Context) ([]*electra.PendingConsolidation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingConsolidations", ctx)
+	ret0, _ := ret[0].([]*electra.PendingConsolidation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingConsolidations indicates an expected call of PendingConsolidations.
+func (mr *MockbeaconValidatorMockRecorder) PendingConsolidations(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingConsolidations", reflect.TypeOf((*MockbeaconValidator)(nil).PendingConsolidations), ctx)
+}
+
 // Mockproposer is a mock of proposer interface.
 type Mockproposer struct {
 	ctrl     *gomock.Controller
@@ -466,6 +510,21 @@ func (mr *MockBeaconNodeMockRecorder) IsSyncCommitteeAggregator(proof any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSyncCommitteeAggregator", reflect.TypeOf((*MockBeaconNode)(nil).IsSyncCommitteeAggregator), proof)
 }
 
+// PendingConsolidations mocks base method.
+func (m *MockBeaconNode) PendingConsolidations(ctx context.Context) ([]*electra.PendingConsolidation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingConsolidations", ctx)
+	ret0, _ := ret[0].([]*electra.PendingConsolidation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingConsolidations indicates an expected call of PendingConsolidations.
+func (mr *MockBeaconNodeMockRecorder) PendingConsolidations(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingConsolidations", reflect.TypeOf((*MockBeaconNode)(nil).PendingConsolidations), ctx)
+}
+
 // ProposerDuties mocks base method.
 func (m *MockBeaconNode) ProposerDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*v1.ProposerDuty, error) {
 	m.ctrl.T.Helper()
@@ -651,6 +710,34 @@ func (mr *MockBeaconNodeMockRecorder) SubmitVoluntaryExit(voluntaryExit any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitVoluntaryExit", reflect.TypeOf((*MockBeaconNode)(nil).SubmitVoluntaryExit), voluntaryExit)
 }
 
+// SubscribeToChainReorgEvents mocks base method.
+func (m *MockBeaconNode) SubscribeToChainReorgEvents(ctx context.Context, subscriberIdentifier string, ch chan<- *v1.ChainReorgEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeToChainReorgEvents", ctx, subscriberIdentifier, ch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubscribeToChainReorgEvents indicates an expected call of SubscribeToChainReorgEvents.
+func (mr *MockBeaconNodeMockRecorder) SubscribeToChainReorgEvents(ctx, subscriberIdentifier, ch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeToChainReorgEvents", reflect.TypeOf((*MockBeaconNode)(nil).SubscribeToChainReorgEvents), ctx, subscriberIdentifier, ch)
+}
+
+// SubscribeToFinalizedCheckpointEvents mocks base method.
+func (m *MockBeaconNode) SubscribeToFinalizedCheckpointEvents(ctx context.Context, subscriberIdentifier string, ch chan<- *v1.FinalizedCheckpointEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeToFinalizedCheckpointEvents", ctx, subscriberIdentifier, ch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubscribeToFinalizedCheckpointEvents indicates an expected call of SubscribeToFinalizedCheckpointEvents.
+func (mr *MockBeaconNodeMockRecorder) SubscribeToFinalizedCheckpointEvents(ctx, subscriberIdentifier, ch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeToFinalizedCheckpointEvents", reflect.TypeOf((*MockBeaconNode)(nil).SubscribeToFinalizedCheckpointEvents), ctx, subscriberIdentifier, ch)
+}
+
 // SubscribeToHeadEvents mocks base method.
 func (m *MockBeaconNode) SubscribeToHeadEvents(ctx context.Context, subscriberIdentifier string, ch chan<- *v1.HeadEvent) error {
 	m.ctrl.T.Helper()
@@ -13,6 +13,18 @@ import (
 type Network struct {
 	spectypes.BeaconNetwork
 	LocalTestNet bool
+	// Spec overrides the chain parameters of BeaconNetwork, it's set for custom networks.
+	Spec *Spec
+}
+
+// Spec holds the chain parameters of a custom beacon chain network,
+// as returned by the beacon node's /eth/v1/config/spec and /eth/v1/beacon/genesis endpoints.
+type Spec struct {
+	GenesisForkVersion           phase0.Version
+	GenesisTime                  time.Time
+	SlotDuration                 time.Duration
+	SlotsPerEpoch                uint64
+	EpochsPerSyncCommitteePeriod uint64
 }
 
 type BeaconNetwork interface {
@@ -58,14 +70,65 @@ func NewLocalTestNetwork(network spectypes.BeaconNetwork) Network {
 	}
 }
 
+// NewCustomNetwork creates a new beacon chain network with the given chain parameters.
+// The spec network is still used by components which only support known networks, such as the key manager.
+func NewCustomNetwork(network spectypes.BeaconNetwork, spec Spec) Network {
+	return Network{
+		BeaconNetwork: network,
+		Spec:          &spec,
+	}
+}
+
+// ForkVersion returns the genesis fork version of the network.
+func (n Network) ForkVersion() [4]byte {
+	if n.Spec != nil {
+		return n.Spec.GenesisForkVersion
+	}
+	return n.BeaconNetwork.ForkVersion()
+}
+
 // MinGenesisTime returns min genesis time value
 func (n Network) MinGenesisTime() int64 {
+	if n.Spec != nil {
+		return n.Spec.GenesisTime.Unix()
+	}
 	if n.LocalTestNet {
 		return 1689072978
 	}
 	return int64(n.BeaconNetwork.MinGenesisTime()) // #nosec G115
 }
 
+// SlotDurationSec returns slot duration
+func (n Network) SlotDurationSec() time.Duration {
+	if n.Spec != nil {
+		return n.Spec.SlotDuration
+	}
+	return n.BeaconNetwork.SlotDurationSec()
+}
+
+// SlotsPerEpoch returns number of slots per one epoch
+func (n Network) SlotsPerEpoch() uint64 {
+	if n.Spec != nil {
+		return n.Spec.SlotsPerEpoch
+	}
+	return n.BeaconNetwork.SlotsPerEpoch()
+}
+
+// EstimatedTimeAtSlot returns the estimated unix time of the start of the given slot
+func (n Network) EstimatedTimeAtSlot(slot phase0.Slot) int64 {
+	return n.GetSlotStartTime(slot).Unix()
+}
+
+// FirstSlotAtEpoch returns the first slot of the given epoch
+func (n Network) FirstSlotAtEpoch(epoch phase0.Epoch) phase0.Slot {
+	return n.GetEpochFirstSlot(epoch)
+}
+
+// EpochStartTime returns the start time of the given epoch
+func (n Network) EpochStartTime(epoch phase0.Epoch) time.Time {
+	return n.GetSlotStartTime(n.GetEpochFirstSlot(epoch))
+}
+
 // GetNetwork returns the network
 func (n Network) GetNetwork() Network {
 	return n
@@ -125,6 +188,9 @@ func (n Network) GetEpochFirstSlot(epoch phase0.Epoch) phase0.Slot {
 
 // EpochsPerSyncCommitteePeriod returns the number of epochs per sync committee period.
 func (n Network) EpochsPerSyncCommitteePeriod() uint64 {
+	if n.Spec != nil && n.Spec.EpochsPerSyncCommitteePeriod != 0 {
+		return n.Spec.EpochsPerSyncCommitteePeriod
+	}
 	return 256
 }
 
@@ -11,6 +11,11 @@ type ValidatorMetadata struct {
 	Status          eth2apiv1.ValidatorState `json:"status"`
 	Index           phase0.ValidatorIndex    `json:"index"`
 	ActivationEpoch phase0.Epoch             `json:"activation_epoch"`
+	// ExitEpoch is FAR_FUTURE_EPOCH unless the validator is exiting or exited.
+	ExitEpoch phase0.Epoch `json:"exit_epoch"`
+	// Consolidating is true if the validator is the source of a pending consolidation (EIP-7251),
+	// so it exits to move its balance to the target validator.
+	Consolidating bool `json:"consolidating"`
 }
 
 // Equals returns true if the given metadata is equal to current
@@ -19,7 +24,9 @@ func (m *ValidatorMetadata) Equals(other *ValidatorMetadata) bool {
 		m.Status == other.Status &&
 		m.Index == other.Index &&
 		m.Balance == other.Balance &&
-		m.ActivationEpoch == other.ActivationEpoch
+		m.ActivationEpoch == other.ActivationEpoch &&
+		m.ExitEpoch == other.ExitEpoch &&
+		m.Consolidating == other.Consolidating
 }
 
 // Pending returns true if the validator is pending
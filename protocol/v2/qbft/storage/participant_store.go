@@ -61,6 +61,9 @@ type ParticipantStore interface {
 	// InitialSlotGC performs an initial cleanup (blocking) of slots bellow the retained threshold
 	Prune(ctx context.Context, logger *zap.Logger, below phase0.Slot)
 
+	// PruneRange removes the slots in the range [from, to)
+	PruneRange(ctx context.Context, logger *zap.Logger, from, to phase0.Slot)
+
 	// SlotGC continuously removes old slots
 	PruneContinously(ctx context.Context, logger *zap.Logger, slotTickerProvider slotticker.Provider, retain phase0.Slot)
 }
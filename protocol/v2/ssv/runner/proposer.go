@@ -38,7 +38,7 @@ type ProposerRunner struct {
 	doppelgangerHandler DoppelgangerProvider
 	valCheck            specqbft.ProposedValueCheckF
 	measurements        measurementsStore
-	graffiti            []byte
+	graffiti            GraffitiProvider
 }
 
 func NewProposerRunner(
@@ -53,7 +53,7 @@ func NewProposerRunner(
 	doppelgangerHandler DoppelgangerProvider,
 	valCheck specqbft.ProposedValueCheckF,
 	highestDecidedSlot phase0.Slot,
-	graffiti []byte,
+	graffiti GraffitiProvider,
 ) (Runner, error) {
 	if len(share) != 1 {
 		return nil, errors.New("must have one share")
@@ -124,7 +124,8 @@ func (r *ProposerRunner) ProcessPreConsensus(ctx context.Context, logger *zap.Lo
 
 	start := time.Now()
 	duty = r.GetState().StartingDuty.(*spectypes.ValidatorDuty)
-	obj, ver, err := r.GetBeaconNode().GetBeaconBlock(duty.Slot, r.graffiti, fullSig)
+	graffiti := r.graffiti.Graffiti(r.GetShare())
+	obj, ver, err := r.GetBeaconNode().GetBeaconBlock(duty.Slot, graffiti, fullSig)
 	if err != nil {
 		logger.Error("❌ failed to get blinded beacon block",
 			fields.PreConsensusTime(r.measurements.PreConsensusTime()),
@@ -138,6 +139,7 @@ func (r *ProposerRunner) ProcessPreConsensus(ctx context.Context, logger *zap.Lo
 	logger.Info("🧊 got beacon block proposal",
 		zap.String("block_hash", blockSummary.Hash.String()),
 		zap.Bool("blinded", blockSummary.Blinded),
+		zap.String("graffiti", string(graffiti)),
 		zap.Duration("took", time.Since(start)),
 		zap.NamedError("summarize_err", summarizeErr))
 
@@ -56,6 +56,11 @@ type DoppelgangerProvider interface {
 	ReportQuorum(validatorIndex phase0.ValidatorIndex)
 }
 
+// GraffitiProvider provides the graffiti of blocks proposed for the validator of the share.
+type GraffitiProvider interface {
+	Graffiti(share *spectypes.Share) []byte
+}
+
 var _ Runner = new(CommitteeRunner)
 
 type BaseRunner struct {
@@ -338,6 +343,17 @@ func (b *BaseRunner) hasRunningDuty() bool {
 	return !b.State.Finished
 }
 
+// RunningDutySlot returns the slot of the running duty, if a duty is running.
+func (b *BaseRunner) RunningDutySlot() (phase0.Slot, bool) {
+	b.mtx.RLock() // reads b.State
+	defer b.mtx.RUnlock()
+
+	if b.State == nil || b.State.Finished {
+		return 0, false
+	}
+	return b.State.StartingDuty.DutySlot(), true
+}
+
 func (b *BaseRunner) ShouldProcessDuty(duty spectypes.Duty) error {
 	if b.QBFTController.Height >= specqbft.Height(duty.DutySlot()) && b.QBFTController.Height != 0 {
 		return errors.Errorf("duty for slot %d already passed. Current height is %d", duty.DutySlot(),
@@ -13,6 +13,7 @@ import (
 	"github.com/ssvlabs/ssv/doppelganger"
 	"github.com/ssvlabs/ssv/integration/qbft/tests"
 	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/operator/graffiti"
 	"github.com/ssvlabs/ssv/protocol/v2/qbft/controller"
 	"github.com/ssvlabs/ssv/protocol/v2/qbft/testing"
 	"github.com/ssvlabs/ssv/protocol/v2/ssv"
@@ -161,7 +162,7 @@ var ConstructBaseRunner = func(
 			dgHandler,
 			valCheck,
 			TestingHighestDecidedSlot,
-			[]byte("graffiti"),
+			graffiti.New("graffiti"),
 		)
 	case spectypes.RoleSyncCommitteeContribution:
 		r, err = runner.NewSyncCommitteeAggregatorRunner(
@@ -416,7 +417,7 @@ var ConstructBaseRunnerWithShareMap = func(
 			dgHandler,
 			valCheck,
 			TestingHighestDecidedSlot,
-			[]byte("graffiti"),
+			graffiti.New("graffiti"),
 		)
 	case spectypes.RoleSyncCommitteeContribution:
 		r, err = runner.NewSyncCommitteeAggregatorRunner(
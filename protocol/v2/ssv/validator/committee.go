@@ -45,6 +45,20 @@ type Committee struct {
 	CreateRunnerFn CommitteeRunnerFunc
 }
 
+// RunningDuties returns the number of duties from the given slot onwards which are still running.
+func (c *Committee) RunningDuties(fromSlot phase0.Slot) int {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	running := 0
+	for slot, r := range c.Runners {
+		if slot >= fromSlot && r.HasRunningDuty() {
+			running++
+		}
+	}
+	return running
+}
+
 // NewCommittee creates a new cluster
 func NewCommittee(
 	ctx context.Context,
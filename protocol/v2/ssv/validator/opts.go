@@ -35,7 +35,7 @@ type Options struct {
 	QueueSize           int
 	GasLimit            uint64
 	MessageValidator    validation.MessageValidator
-	Graffiti            []byte
+	Graffiti            runner.GraffitiProvider
 }
 
 func (o *Options) defaults() {
@@ -26,7 +26,7 @@ type SsvOperatorSigner struct {
 	GetOperatorIdF func() spectypes.OperatorID
 }
 
-func NewSsvOperatorSigner(pk keys.OperatorPrivateKey, getOperatorId func() spectypes.OperatorID) *SsvOperatorSigner {
+func NewSsvOperatorSigner(pk keys.OperatorSigner, getOperatorId func() spectypes.OperatorID) *SsvOperatorSigner {
 	return &SsvOperatorSigner{
 		OperatorSigner: pk,
 		GetOperatorIdF: getOperatorId,
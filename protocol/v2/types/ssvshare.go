@@ -3,6 +3,7 @@ package types
 import (
 	"crypto/sha256"
 	"encoding/binary"
+	"math"
 	"slices"
 	"sort"
 	"sync/atomic"
@@ -16,8 +17,11 @@ import (
 	spectypes "github.com/ssvlabs/ssv-spec/types"
 )
 
+// farFutureEpoch is the exit epoch of validators which aren't exiting.
+const farFutureEpoch = phase0.Epoch(math.MaxUint64)
+
 const (
-	MaxPossibleShareSize = 1326
+	MaxPossibleShareSize = 1335
 	MaxAllowedShareSize  = MaxPossibleShareSize * 8 // Leaving some room for protocol updates and calculation mistakes.
 )
 
@@ -29,6 +33,11 @@ type SSVShare struct {
 	Status eth2apiv1.ValidatorState
 	// ActivationEpoch is validator (this share belongs to) epoch it activates at.
 	ActivationEpoch phase0.Epoch
+	// ExitEpoch is validator (this share belongs to) epoch it exits at, FAR_FUTURE_EPOCH if it isn't exiting.
+	// It's zero if unknown, which is the case for metadata fetched before exit epochs were stored.
+	ExitEpoch phase0.Epoch
+	// Consolidating is true if validator (this share belongs to) exits to move its balance to another validator (EIP-7251).
+	Consolidating bool
 	// OwnerAddress is validator (this share belongs to) owner address.
 	OwnerAddress common.Address
 	// Liquidated is validator (this share belongs to) liquidation status (true or false).
@@ -66,7 +75,7 @@ func (s *SSVShare) HasBeaconMetadata() bool {
 }
 
 func (s *SSVShare) IsAttesting(epoch phase0.Epoch) bool {
-	return s.HasBeaconMetadata() &&
+	return s.HasBeaconMetadata() && !s.ExitedBy(epoch) &&
 		(s.Status.IsAttesting() || (s.Status == eth2apiv1.ValidatorStatePendingQueued && s.ActivationEpoch <= epoch))
 }
 
@@ -87,7 +96,19 @@ func (s *SSVShare) IsActive() bool {
 
 // Exiting returns true if the validator is existing or exited
 func (s *SSVShare) Exiting() bool {
-	return s.Status.IsExited() || s.Status.HasExited()
+	return s.Status.IsExited() || s.Status.HasExited() || s.ExitInitiated()
+}
+
+// ExitInitiated returns true if the validator's exit epoch is set, which happens on a voluntary exit, an execution
+// layer withdrawal request (EIP-7002) or a consolidation request (EIP-7251) and can't be told apart except for the latter.
+func (s *SSVShare) ExitInitiated() bool {
+	return s.ExitEpoch != 0 && s.ExitEpoch != farFutureEpoch
+}
+
+// ExitedBy returns true if the validator exits at or before the given epoch, so it has no duties from that epoch on.
+// The status may still be active_exiting until the metadata is updated.
+func (s *SSVShare) ExitedBy(epoch phase0.Epoch) bool {
+	return s.ExitInitiated() && s.ExitEpoch <= epoch
 }
 
 // Slashed returns true if the validator is exiting or exited due to slashing
@@ -149,6 +149,51 @@ func TestSSVShare_IsAttesting(t *testing.T) {
 			Epoch:    currentEpoch,
 			Expected: true,
 		},
+		{
+			Name: "Active Exiting before Exit Epoch",
+			Share: &SSVShare{
+				Status:    eth2apiv1.ValidatorStateActiveExiting,
+				ExitEpoch: currentEpoch + 1,
+			},
+			Epoch:    currentEpoch,
+			Expected: true,
+		},
+		{
+			Name: "Active Exiting at Exit Epoch",
+			Share: &SSVShare{
+				Status:    eth2apiv1.ValidatorStateActiveExiting,
+				ExitEpoch: currentEpoch,
+			},
+			Epoch:    currentEpoch,
+			Expected: false,
+		},
+		{
+			Name: "Consolidating after Exit Epoch",
+			Share: &SSVShare{
+				Status:        eth2apiv1.ValidatorStateActiveExiting,
+				ExitEpoch:     currentEpoch - 1,
+				Consolidating: true,
+			},
+			Epoch:    currentEpoch,
+			Expected: false,
+		},
+		{
+			Name: "Active Ongoing with Far Future Exit Epoch",
+			Share: &SSVShare{
+				Status:    eth2apiv1.ValidatorStateActiveOngoing,
+				ExitEpoch: farFutureEpoch,
+			},
+			Epoch:    currentEpoch,
+			Expected: true,
+		},
+		{
+			Name: "Active Exiting with Unknown Exit Epoch",
+			Share: &SSVShare{
+				Status: eth2apiv1.ValidatorStateActiveExiting,
+			},
+			Epoch:    currentEpoch,
+			Expected: true,
+		},
 	}
 
 	for _, tc := range tt {
@@ -160,6 +205,13 @@ func TestSSVShare_IsAttesting(t *testing.T) {
 	}
 }
 
+func TestSSVShare_Exiting(t *testing.T) {
+	require.False(t, (&SSVShare{Status: eth2apiv1.ValidatorStateActiveOngoing}).Exiting())
+	require.False(t, (&SSVShare{Status: eth2apiv1.ValidatorStateActiveOngoing, ExitEpoch: farFutureEpoch}).Exiting())
+	require.True(t, (&SSVShare{Status: eth2apiv1.ValidatorStateActiveExiting, ExitEpoch: 200}).Exiting())
+	require.True(t, (&SSVShare{Status: eth2apiv1.ValidatorStateExitedUnslashed}).Exiting())
+}
+
 func TestSSVShare_IsParticipating(t *testing.T) {
 	currentEpoch := phase0.Epoch(100)
 	tt := []struct {
@@ -87,6 +87,8 @@ type Share struct {
 
 	Status          uint64
 	ActivationEpoch uint64
+	ExitEpoch       uint64
+	Consolidating   bool
 	OwnerAddress    [addressLength]byte
 	Liquidated      bool
 }
@@ -285,6 +287,8 @@ func FromSSVShare(share *types.SSVShare) *Share {
 		Liquidated:          share.Liquidated,
 		Status:              uint64(share.Status), // nolint: gosec
 		ActivationEpoch:     uint64(share.ActivationEpoch),
+		ExitEpoch:           uint64(share.ExitEpoch),
+		Consolidating:       share.Consolidating,
 	}
 }
 
@@ -316,6 +320,8 @@ func ToSSVShare(stShare *Share) (*types.SSVShare, error) {
 		},
 		Status:          eth2apiv1.ValidatorState(stShare.Status), // nolint: gosec
 		ActivationEpoch: phase0.Epoch(stShare.ActivationEpoch),
+		ExitEpoch:       phase0.Epoch(stShare.ExitEpoch),
+		Consolidating:   stShare.Consolidating,
 		OwnerAddress:    stShare.OwnerAddress,
 		Liquidated:      stShare.Liquidated,
 	}
@@ -380,6 +386,10 @@ func (s *sharesStorage) UpdateValidatorsMetadata(data map[spectypes.ValidatorPK]
 			share.ValidatorIndex = metadata.Index
 			share.Status = metadata.Status
 			share.ActivationEpoch = metadata.ActivationEpoch
+			// Pending consolidations are removed from the beacon state once processed,
+			// so a known consolidation is kept as long as the exit epoch doesn't change.
+			share.Consolidating = metadata.Consolidating || (share.Consolidating && share.ExitEpoch == metadata.ExitEpoch)
+			share.ExitEpoch = metadata.ExitEpoch
 			shares = append(shares, share)
 		}
 
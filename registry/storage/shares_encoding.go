@@ -1,5 +1,5 @@
 // Code generated by fastssz. DO NOT EDIT.
-// Hash: 1821c157319296caa5dbef11ceb0fb6bdbd93e7213881a7d41150da65424141b
+// Hash: 412a591ef7943d20d62e030bb51f5feda0261ddf1ff038cc450192afb64ebffe
 // Version: 0.1.3
 package storage
 
@@ -15,7 +15,7 @@ func (s *Share) MarshalSSZ() ([]byte, error) {
 // MarshalSSZTo ssz marshals the Share object to a target array
 func (s *Share) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	dst = buf
-	offset := int(129)
+	offset := int(138)
 
 	// Field (0) 'ValidatorIndex'
 	dst = ssz.MarshalUint64(dst, s.ValidatorIndex)
@@ -53,10 +53,16 @@ func (s *Share) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	// Field (8) 'ActivationEpoch'
 	dst = ssz.MarshalUint64(dst, s.ActivationEpoch)
 
-	// Field (9) 'OwnerAddress'
+	// Field (9) 'ExitEpoch'
+	dst = ssz.MarshalUint64(dst, s.ExitEpoch)
+
+	// Field (10) 'Consolidating'
+	dst = ssz.MarshalBool(dst, s.Consolidating)
+
+	// Field (11) 'OwnerAddress'
 	dst = append(dst, s.OwnerAddress[:]...)
 
-	// Field (10) 'Liquidated'
+	// Field (12) 'Liquidated'
 	dst = ssz.MarshalBool(dst, s.Liquidated)
 
 	// Field (2) 'SharePubKey'
@@ -98,7 +104,7 @@ func (s *Share) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 func (s *Share) UnmarshalSSZ(buf []byte) error {
 	var err error
 	size := uint64(len(buf))
-	if size < 129 {
+	if size < 138 {
 		return ssz.ErrSize
 	}
 
@@ -119,7 +125,7 @@ func (s *Share) UnmarshalSSZ(buf []byte) error {
 		return ssz.ErrOffset
 	}
 
-	if o2 != 129 {
+	if o2 != 138 {
 		return ssz.ErrInvalidVariableOffset
 	}
 
@@ -145,11 +151,17 @@ func (s *Share) UnmarshalSSZ(buf []byte) error {
 	// Field (8) 'ActivationEpoch'
 	s.ActivationEpoch = ssz.UnmarshallUint64(buf[100:108])
 
-	// Field (9) 'OwnerAddress'
-	copy(s.OwnerAddress[:], buf[108:128])
+	// Field (9) 'ExitEpoch'
+	s.ExitEpoch = ssz.UnmarshallUint64(buf[108:116])
+
+	// Field (10) 'Consolidating'
+	s.Consolidating = ssz.UnmarshalBool(buf[116:117])
 
-	// Field (10) 'Liquidated'
-	s.Liquidated = ssz.UnmarshalBool(buf[128:129])
+	// Field (11) 'OwnerAddress'
+	copy(s.OwnerAddress[:], buf[117:137])
+
+	// Field (12) 'Liquidated'
+	s.Liquidated = ssz.UnmarshalBool(buf[137:138])
 
 	// Field (2) 'SharePubKey'
 	{
@@ -201,7 +213,7 @@ func (s *Share) UnmarshalSSZ(buf []byte) error {
 
 // SizeSSZ returns the ssz encoded size in bytes for the Share object
 func (s *Share) SizeSSZ() (size int) {
-	size = 129
+	size = 138
 
 	// Field (2) 'SharePubKey'
 	size += len(s.SharePubKey)
@@ -289,10 +301,16 @@ func (s *Share) HashTreeRootWith(hh ssz.HashWalker) (err error) {
 	// Field (8) 'ActivationEpoch'
 	hh.PutUint64(s.ActivationEpoch)
 
-	// Field (9) 'OwnerAddress'
+	// Field (9) 'ExitEpoch'
+	hh.PutUint64(s.ExitEpoch)
+
+	// Field (10) 'Consolidating'
+	hh.PutBool(s.Consolidating)
+
+	// Field (11) 'OwnerAddress'
 	hh.PutBytes(s.OwnerAddress[:])
 
-	// Field (10) 'Liquidated'
+	// Field (12) 'Liquidated'
 	hh.PutBool(s.Liquidated)
 
 	hh.Merkleize(indx)
@@ -26,6 +26,8 @@ func Test_storageShare_encoding_decoding(t *testing.T) {
 	//		Graffiti:            []uint8{131, 207, 238, 243, 110, 159, 17, 79, 36, 50, 179, 159, 243, 244, 127, 139, 21, 211, 144, 116, 169, 105, 96, 55, 123, 59},
 	//		Status:              3,
 	//		ActivationEpoch:     47275,
+	//		ExitEpoch:           364032,
+	//		Consolidating:       true,
 	//		OwnerAddress:        common.Address{92, 192, 221, 225, 78, 114, 86, 52, 12, 200, 32, 65, 90, 96, 34, 167, 209, 201, 58, 53},
 	//		Liquidated:          true,
 	//	}
@@ -34,13 +36,28 @@ func Test_storageShare_encoding_decoding(t *testing.T) {
 	//	fmt.Println(fmt.Sprintf("stShareEncodedOld: %s", hex.EncodeToString(stShareEncodedOld)))
 	//})
 
-	t.Run("verify Share is backward-compatible with old encoding", func(t *testing.T) {
+	t.Run("verify Share encoded before exit epochs requires migration", func(t *testing.T) {
+		// Shares stored before exit epochs were added to Share are re-encoded by migration_6_add_exit_epoch_to_shares,
+		// see migrations.TestMigration6_addExitEpochToShares for the migrated encoding of this one.
 		stShareEncodedOldStr := "c4a519000000000083cfeef36e9f114f2432b39ff3f47f8b15d39074a96960377b3b0e5f0e422099ee54a4eddaf8815c8fe2dab89528c86e81000000b100000000003113d1dc869556f1f3027def029d23cd0968cf08cb0eb10100000300000000000000abb80000000000005cc0dde14e7256340cc820415a6022a7d1c93a35018f5f803c3531ca8eebb62cf858c228a8729d568bafc9e07d797d6c83368474f5c2170f653891aad55fda74a825e5d1e4100000004c00000088000000c400000013010000000000000c000000a754fefa9bf4b967d9581ac7a9476dc65a806e42573c6322ad9eb90e3527fc1634afebeb3f7532d7c80b84794b2992bd14010000000000000c0000008f5f803c3531ca8eebb62cf858c228a8729d568bafc9e07d797d6c83368474f5c2170f653891aad55fda74a825e5d1e415010000000000000c000000b1d28fc4c98309e8249bb1bfb737ccd7e4a8996ce692958e848aa7d0ee41ffacc562c5b9ce869d6f3b69d72a1b44408e16010000000000000c00000097e9bd027e9769a34fb730fff5cba87823508267b33aa843f44b450ace06f0e9a5aaedf4ddab255737e1e84b7bb8fd4a83cfeef36e9f114f2432b39ff3f47f8b15d39074a96960377b3b"
 		stShareEncodedOld, err := hex.DecodeString(stShareEncodedOldStr)
 		require.NoError(t, err)
 
 		stShareOld := &Share{}
 		err = stShareOld.Decode(stShareEncodedOld)
+		require.Error(t, err)
+	})
+
+	t.Run("verify Share is backward-compatible with current encoding", func(t *testing.T) {
+		stShareEncodedStr := "c4a519000000000083cfeef36e9f114f2432b39ff3f47f8b15d39074a96960377b3b0e5f0e422099ee54a4eddaf8815c8fe2dab89528c86e8a000000ba00000000003113d1dc869556f1f3027def029d23cd0968cf08cb0eba0100000300000000000000abb8000000000000008e050000000000015cc0dde14e7256340cc820415a6022a7d1c93a35018f5f803c3531ca8eebb62cf858c228a8729d568bafc9e07d797d6c83368474f5c2170f653891aad55fda74a825e5d1e4100000004c00000088000000c400000013010000000000000c000000a754fefa9bf4b967d9581ac7a9476dc65a806e42573c6322ad9eb90e3527fc1634afebeb3f7532d7c80b84794b2992bd14010000000000000c0000008f5f803c3531ca8eebb62cf858c228a8729d568bafc9e07d797d6c83368474f5c2170f653891aad55fda74a825e5d1e415010000000000000c000000b1d28fc4c98309e8249bb1bfb737ccd7e4a8996ce692958e848aa7d0ee41ffacc562c5b9ce869d6f3b69d72a1b44408e16010000000000000c00000097e9bd027e9769a34fb730fff5cba87823508267b33aa843f44b450ace06f0e9a5aaedf4ddab255737e1e84b7bb8fd4a83cfeef36e9f114f2432b39ff3f47f8b15d39074a96960377b3b"
+		stShareEncoded, err := hex.DecodeString(stShareEncodedStr)
+		require.NoError(t, err)
+
+		stShare := &Share{}
+		err = stShare.Decode(stShareEncoded)
 		require.NoError(t, err)
+		require.EqualValues(t, 364032, stShare.ExitEpoch)
+		require.True(t, stShare.Consolidating)
+		require.True(t, stShare.Liquidated)
 	})
 }
@@ -165,6 +165,35 @@ func TestSharesStorage(t *testing.T) {
 		require.Equal(t, 2, len(existingValidators))
 	})
 
+	t.Run("UpdateValidatorMetadata_consolidation", func(t *testing.T) {
+		consolidating := &beaconprotocol.ValidatorMetadata{
+			Index:           3,
+			Status:          eth2apiv1.ValidatorStateActiveExiting,
+			ActivationEpoch: 4,
+			ExitEpoch:       300,
+			Consolidating:   true,
+		}
+		require.NoError(t, storage.Shares.UpdateValidatorsMetadata(map[spectypes.ValidatorPK]*beaconprotocol.ValidatorMetadata{
+			validatorShare.ValidatorPubKey: consolidating,
+		}))
+
+		// The consolidation is no longer pending, but the exit epoch is unchanged.
+		processed := *consolidating
+		processed.Consolidating = false
+		require.NoError(t, storage.Shares.UpdateValidatorsMetadata(map[spectypes.ValidatorPK]*beaconprotocol.ValidatorMetadata{
+			validatorShare.ValidatorPubKey: &processed,
+		}))
+
+		storageDuplicate, _, err := NewSharesStorage(storage.db, []byte("test"))
+		require.NoError(t, err)
+		share, exists := storageDuplicate.Get(nil, validatorShare.ValidatorPubKey[:])
+		require.True(t, exists)
+		require.EqualValues(t, 300, share.ExitEpoch)
+		require.True(t, share.Consolidating)
+		require.True(t, share.IsAttesting(299))
+		require.False(t, share.IsAttesting(300))
+	})
+
 	require.NoError(t, storage.Shares.Delete(nil, validatorShare.ValidatorPubKey[:]))
 	share, exists := storage.Shares.Get(nil, validatorShare.ValidatorPubKey[:])
 	require.False(t, exists)
@@ -11,6 +11,8 @@ type Options struct {
 	Path       string        `yaml:"Path" env:"DB_PATH" env-default:"./data/db" env-description:"Path for storage"`
 	Reporting  bool          `yaml:"Reporting" env:"DB_REPORTING" env-default:"false" env-description:"Flag to run on-off db size reporting"`
 	GCInterval time.Duration `yaml:"GCInterval" env:"DB_GC_INTERVAL" env-default:"6m" env-description:"Interval between garbage collection cycles. Set to 0 to disable."`
+	// ReadOnly opens an existing database without writing to it, which fails if the database is open elsewhere.
+	ReadOnly bool
 }
 
 // Reader is a read-only accessor to the database.
@@ -60,6 +60,7 @@ func createDB(logger *zap.Logger, options basedb.Options, inMemory bool) (*Badge
 	}
 
 	opt.ValueLogFileSize = 1024 * 1024 * 100 // TODO:need to set the vlog proper (max) size
+	opt.ReadOnly = options.ReadOnly
 	db, err := badger.Open(opt)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to open badger")
@@ -86,7 +87,7 @@ func createDB(logger *zap.Logger, options basedb.Options, inMemory bool) (*Badge
 	}
 
 	// Start periodic garbage collection.
-	if options.GCInterval > 0 {
+	if options.GCInterval > 0 && !options.ReadOnly {
 		badgerDB.wg.Add(1)
 		go badgerDB.periodicallyCollectGarbage(logger, options.GCInterval)
 	}
@@ -0,0 +1,205 @@
+package bootnode
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/network/commons"
+	"github.com/ssvlabs/ssv/network/records"
+)
+
+// unknownDomainType is reported for nodes which don't advertise a domain type.
+const unknownDomainType = "unknown"
+
+type handler struct {
+	logger    *zap.Logger
+	nodeID    enode.ID
+	listeners []*listener
+}
+
+// status is the response of the status API.
+type status struct {
+	NodeID    string            `json:"node_id"`
+	Listeners []*listenerStatus `json:"listeners"`
+}
+
+// listenerStatus describes the routing table and traffic of a listener.
+type listenerStatus struct {
+	ProtocolID      string   `json:"protocol_id"`
+	Networks        []string `json:"networks"`
+	ENR             string   `json:"enr"`
+	TableSize       int      `json:"table_size"`
+	RecentNodes     int      `json:"recent_nodes"`
+	ReceivedPackets int64    `json:"received_packets"`
+	SentPackets     int64    `json:"sent_packets"`
+	ReceivedRate    float64  `json:"received_rate"`
+	SentRate        float64  `json:"sent_rate"`
+	// DomainTypes counts the table nodes by advertised domain type, named by network when known.
+	DomainTypes map[string]int `json:"domain_types"`
+	// Subnets counts the table nodes advertising each subnet in their ENR.
+	Subnets []int `json:"subnets"`
+}
+
+// nodeStatus describes a node in the routing table of a listener.
+type nodeStatus struct {
+	ID         string     `json:"id"`
+	ENR        string     `json:"enr"`
+	IP         string     `json:"ip"`
+	UDP        int        `json:"udp"`
+	TCP        int        `json:"tcp"`
+	DomainType string     `json:"domain_type"`
+	Subnets    string     `json:"subnets,omitempty"`
+	LastSeen   *time.Time `json:"last_seen,omitempty"`
+}
+
+func (l *listener) status() *listenerStatus {
+	receivedRate, sentRate := l.stats.rates()
+	s := &listenerStatus{
+		ProtocolID:      hex.EncodeToString(l.protocolID[:]),
+		Networks:        networkNames(l.networks),
+		ENR:             l.udp.Self().String(),
+		ReceivedPackets: l.stats.received.Load(),
+		SentPackets:     l.stats.sent.Load(),
+		ReceivedRate:    receivedRate,
+		SentRate:        sentRate,
+		DomainTypes:     make(map[string]int),
+		Subnets:         make([]int, commons.SubnetsCount),
+	}
+	for _, node := range l.nodes() {
+		s.TableSize++
+		if node.LastSeen != nil {
+			s.RecentNodes++
+		}
+		s.DomainTypes[node.DomainType]++
+		subnets, err := commons.FromString(node.Subnets)
+		if err != nil {
+			continue
+		}
+		for i, v := range subnets {
+			if v > 0 && i < len(s.Subnets) {
+				s.Subnets[i]++
+			}
+		}
+	}
+	return s
+}
+
+func (l *listener) nodes() []*nodeStatus {
+	all := l.udp.AllNodes()
+	nodes := make([]*nodeStatus, 0, len(all))
+	for _, node := range all {
+		ns := &nodeStatus{
+			ID:         node.ID().String(),
+			ENR:        node.String(),
+			IP:         node.IP().String(),
+			UDP:        node.UDP(),
+			TCP:        node.TCP(),
+			DomainType: l.domainTypeName(node),
+		}
+		if subnets, err := records.GetSubnetsEntry(node.Record()); err == nil {
+			ns.Subnets = commons.Subnets(subnets).String()
+		}
+		if addr, ok := netip.AddrFromSlice(node.IP()); ok {
+			if lastSeen, ok := l.stats.lastSeenAt(netip.AddrPortFrom(addr.Unmap(), uint16(node.UDP()))); ok { // #nosec G115
+				ns.LastSeen = &lastSeen
+			}
+		}
+		nodes = append(nodes, ns)
+	}
+	return nodes
+}
+
+// domainTypeName returns the name of the network of the node's domain type, or the domain type if it isn't served.
+func (l *listener) domainTypeName(node *enode.Node) string {
+	domainType, err := records.GetDomainTypeEntry(node.Record(), records.KeyDomainType)
+	if err != nil {
+		return unknownDomainType
+	}
+	for _, nc := range l.networks {
+		if nc.DomainType == domainType {
+			return nc.Name
+		}
+	}
+	return formatDomainType(domainType)
+}
+
+func formatDomainType(domainType spectypes.DomainType) string {
+	return "0x" + hex.EncodeToString(domainType[:])
+}
+
+// handleStatus responds with the status of every listener.
+func (h *handler) handleStatus(w http.ResponseWriter, _ *http.Request) {
+	resp := status{NodeID: h.nodeID.String()}
+	for _, l := range h.listeners {
+		resp.Listeners = append(resp.Listeners, l.status())
+	}
+	h.writeJSON(w, resp)
+}
+
+// handleNodes responds with the routing table nodes of the listener of the given protocol_id,
+// or of the first listener if none is given. Only recently seen nodes are returned if recent is true.
+func (h *handler) handleNodes(w http.ResponseWriter, r *http.Request) {
+	l := h.listeners[0]
+	if protocolID := r.URL.Query().Get("protocol_id"); protocolID != "" {
+		l = nil
+		for _, candidate := range h.listeners {
+			if hex.EncodeToString(candidate.protocolID[:]) == protocolID || string(candidate.protocolID[:]) == protocolID {
+				l = candidate
+				break
+			}
+		}
+		if l == nil {
+			http.Error(w, fmt.Sprintf("no listener for protocol ID %s", protocolID), http.StatusNotFound)
+			return
+		}
+	}
+	recentOnly := r.URL.Query().Get("recent") == "true"
+
+	nodes := make([]*nodeStatus, 0)
+	for _, node := range l.nodes() {
+		if recentOnly && node.LastSeen == nil {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	h.writeJSON(w, nodes)
+}
+
+// handleTable responds with the routing table nodes of every listener as text.
+func (h *handler) handleTable(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	write := func(w io.Writer, b []byte) {
+		if _, err := w.Write(b); err != nil {
+			h.logger.Error("Failed to write to http response", zap.Error(err))
+		}
+	}
+	write(w, []byte("Nodes stored in the table:\n"))
+	i := 0
+	for _, l := range h.listeners {
+		for _, n := range l.udp.AllNodes() {
+			write(w, []byte(fmt.Sprintf("Node %d\n", i)))
+			write(w, []byte(n.String()+"\n"))
+			write(w, []byte("Node ID: "+n.ID().String()+"\n"))
+			write(w, []byte("IP: "+n.IP().String()+"\n"))
+			write(w, []byte(fmt.Sprintf("UDP Port: %d", n.UDP())+"\n"))
+			write(w, []byte(fmt.Sprintf("TCP Port: %d", n.TCP())+"\n\n"))
+			i++
+		}
+	}
+}
+
+func (h *handler) writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.logger.Error("Failed to write to http response", zap.Error(err))
+	}
+}
@@ -3,9 +3,9 @@ package bootnode
 import (
 	"context"
 	"crypto/ecdsa"
+	"encoding/hex"
+	"errors"
 	"fmt"
-	"io"
-	"log"
 	"net"
 	"net/http"
 	"path/filepath"
@@ -14,7 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/p2p/discover"
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/p2p/enr"
-	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/prysmaticlabs/prysm/v4/network"
 	"go.uber.org/zap"
 
@@ -22,100 +22,140 @@ import (
 	"github.com/ssvlabs/ssv/logging/fields"
 	"github.com/ssvlabs/ssv/network/discovery"
 	"github.com/ssvlabs/ssv/networkconfig"
-	"github.com/ssvlabs/ssv/utils"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+const (
+	// statsInterval is the interval between updates of the packet rates and metrics.
+	statsInterval = time.Minute
+	// saveInterval is the interval between saves of the routing tables.
+	saveInterval = 5 * time.Minute
 )
 
 // Options contains options to create the node
 type Options struct {
-	PrivateKey string `yaml:"PrivateKey" env:"BOOT_NODE_PRIVATE_KEY" env-description:"boot node private key (default will generate new)"`
-	ExternalIP string `yaml:"ExternalIP" env:"BOOT_NODE_EXTERNAL_IP" env-description:"Override boot node's external IP"`
-	TCPPort    uint16 `yaml:"TcpPort" env:"TCP_PORT" env-default:"5000" env-description:"TCP port for p2p transport"`
-	UDPPort    uint16 `yaml:"UdpPort" env:"UDP_PORT" env-default:"4000" env-description:"UDP port for discovery"`
-	DbPath     string `yaml:"DbPath" env:"BOOT_NODE_DB_PATH" env-default:"/data/bootnode" env-description:"Path to the boot node's database"`
-	Network    string `yaml:"Network" env:"NETWORK" env-default:"mainnet"`
+	PrivateKey string   `yaml:"PrivateKey" env:"BOOT_NODE_PRIVATE_KEY" env-description:"boot node private key (default will load the stored key or generate and store a new one)"`
+	ExternalIP string   `yaml:"ExternalIP" env:"BOOT_NODE_EXTERNAL_IP" env-description:"Override boot node's external IP"`
+	TCPPort    uint16   `yaml:"TcpPort" env:"TCP_PORT" env-default:"5000" env-description:"TCP port for p2p transport"`
+	UDPPort    uint16   `yaml:"UdpPort" env:"UDP_PORT" env-default:"4000" env-description:"UDP port for discovery"`
+	DbPath     string   `yaml:"DbPath" env:"BOOT_NODE_DB_PATH" env-default:"/data/bootnode" env-description:"Path to the boot node's database"`
+	Network    string   `yaml:"Network" env:"NETWORK" env-default:"mainnet"`
+	Networks   []string `yaml:"Networks" env:"NETWORKS" env-separator:"," env-description:"Networks to serve from one process, overrides Network. Networks with different discovery protocol IDs are served by separate listeners on the same UDP port"`
+}
+
+// NetworkNames returns the names of the networks to serve.
+func (o Options) NetworkNames() []string {
+	if len(o.Networks) > 0 {
+		return o.Networks
+	}
+	return []string{o.Network}
 }
 
 // Node represents the behavior of boot node
 type Node interface {
-	// Start starts the SSV node
+	// Start starts the boot node and blocks until the given context is done
 	Start(ctx context.Context, logger *zap.Logger) error
 }
 
 // bootNode implements Node interface
 type bootNode struct {
-	privateKey  string
-	discv5port  uint16
-	forkVersion []byte
-	externalIP  string
-	tcpPort     uint16
-	dbPath      string
-	network     networkconfig.NetworkConfig
+	privateKey string
+	discv5port uint16
+	externalIP string
+	tcpPort    uint16
+	dbPath     string
+	networks   []networkconfig.NetworkConfig
 }
 
 // New is the constructor of ssvNode
-func New(networkConfig networkconfig.NetworkConfig, opts Options) (Node, error) {
+func New(networks []networkconfig.NetworkConfig, opts Options) (Node, error) {
+	if len(networks) == 0 {
+		return nil, errors.New("no network to serve")
+	}
 	return &bootNode{
-		privateKey:  opts.PrivateKey,
-		discv5port:  opts.UDPPort,
-		forkVersion: []byte{0x00, 0x00, 0x20, 0x09},
-		externalIP:  opts.ExternalIP,
-		tcpPort:     opts.TCPPort,
-		dbPath:      opts.DbPath,
-		network:     networkConfig,
+		privateKey: opts.PrivateKey,
+		discv5port: opts.UDPPort,
+		externalIP: opts.ExternalIP,
+		tcpPort:    opts.TCPPort,
+		dbPath:     opts.DbPath,
+		networks:   networks,
 	}, nil
 }
 
-type handler struct {
-	listener discovery.Listener
-}
-
-func (h *handler) httpHandler(logger *zap.Logger) func(w http.ResponseWriter, _ *http.Request) {
-	return func(w http.ResponseWriter, _ *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		write := func(w io.Writer, b []byte) {
-			if _, err := w.Write(b); err != nil {
-				logger.Error("Failed to write to http response", zap.Error(err))
-			}
-		}
-		allNodes := h.listener.AllNodes()
-		write(w, []byte("Nodes stored in the table:\n"))
-		for i, n := range allNodes {
-			write(w, []byte(fmt.Sprintf("Node %d\n", i)))
-			write(w, []byte(n.String()+"\n"))
-			write(w, []byte("Node ID: "+n.ID().String()+"\n"))
-			write(w, []byte("IP: "+n.IP().String()+"\n"))
-			write(w, []byte(fmt.Sprintf("UDP Port: %d", n.UDP())+"\n"))
-			write(w, []byte(fmt.Sprintf("TCP Port: %d", n.TCP())+"\n\n"))
-		}
-	}
+// listener is the discv5 listener of the networks sharing a discovery protocol ID.
+type listener struct {
+	protocolID [6]byte
+	networks   []networkconfig.NetworkConfig
+	udp        *discover.UDPv5
+	stats      *packetStats
+	// unhandled passes the packets of other protocols to the next listener, it's nil for the last listener.
+	unhandled chan discover.ReadPacket
 }
 
 // Start implements Node interface
 func (n *bootNode) Start(ctx context.Context, logger *zap.Logger) error {
 	logger = logger.Named(logging.NameBootNode)
-	privKey, err := utils.ECDSAPrivateKey(logger, n.privateKey)
+
+	db, err := kv.New(logger, basedb.Options{
+		Ctx:  ctx,
+		Path: filepath.Join(n.dbPath, "store"),
+	})
+	if err != nil {
+		return fmt.Errorf("could not open database: %w", err)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			logger.Error("could not close database", zap.Error(err))
+		}
+	}()
+	s := &store{db: db}
+
+	privKey, err := s.privateKey(logger, n.privateKey)
 	if err != nil {
-		log.Fatal("Failed to get p2p privateKey", zap.Error(err))
+		return fmt.Errorf("could not get p2p private key: %w", err)
 	}
 	ipAddr, err := network.ExternalIP()
-	// ipAddr = "127.0.0.1"
-	log.Print("TEST Ip addr----", ipAddr)
 	if err != nil {
-		logger.Fatal("Failed to get ExternalIP", zap.Error(err))
-	}
-	listener := n.createListener(logger, ipAddr, n.discv5port, privKey)
-	node := listener.LocalNode().Node()
-	logger.Info("Running",
-		zap.String("node", node.String()),
-		zap.String("network", n.network.Name),
-		fields.ProtocolID(n.network.DiscoveryProtocolID),
-	)
+		return fmt.Errorf("could not get external IP: %w", err)
+	}
 
-	handler := &handler{
-		listener: listener,
+	conn, listeners, err := n.createListeners(logger, s, ipAddr, privKey)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			logger.Error("could not close UDP connection", zap.Error(err))
+		}
+		for _, l := range listeners {
+			if l.unhandled != nil {
+				close(l.unhandled)
+			}
+		}
+		for _, l := range listeners {
+			l.udp.Close()
+		}
+	}()
+
+	for _, l := range listeners {
+		logger.Info("Running",
+			zap.String("node", l.udp.Self().String()),
+			zap.Strings("networks", networkNames(l.networks)),
+			fields.ProtocolID(l.protocolID),
+		)
+	}
+
+	h := &handler{
+		logger:    logger,
+		nodeID:    listeners[0].udp.Self().ID(),
+		listeners: listeners,
 	}
 	mux := http.NewServeMux()
-	mux.HandleFunc("/p2p", handler.httpHandler(logger))
+	mux.HandleFunc("/p2p", h.handleTable)
+	mux.HandleFunc("/status", h.handleStatus)
+	mux.HandleFunc("/nodes", h.handleNodes)
+	mux.Handle("/metrics", promhttp.Handler())
 
 	const timeout = 3 * time.Second
 
@@ -125,60 +165,133 @@ func (n *bootNode) Start(ctx context.Context, logger *zap.Logger) error {
 		ReadTimeout:  timeout,
 		WriteTimeout: timeout,
 	}
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- httpServer.ListenAndServe()
+	}()
+
+	statsTicker := time.NewTicker(statsInterval)
+	defer statsTicker.Stop()
+	saveTicker := time.NewTicker(saveInterval)
+	defer saveTicker.Stop()
 
-	if err := httpServer.ListenAndServe(); err != nil {
-		log.Fatalf("Failed to start server %v", err)
+	for {
+		select {
+		case <-ctx.Done():
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			if err := httpServer.Shutdown(shutdownCtx); err != nil {
+				logger.Error("could not shut down HTTP server", zap.Error(err))
+			}
+			n.saveTables(logger, s, listeners)
+			return nil
+		case err := <-serverErr:
+			n.saveTables(logger, s, listeners)
+			return fmt.Errorf("HTTP server failed: %w", err)
+		case <-statsTicker.C:
+			for _, l := range listeners {
+				l.stats.update(statsInterval)
+				recordListenerStatus(ctx, l.status())
+			}
+		case <-saveTicker.C:
+			n.saveTables(logger, s, listeners)
+		}
 	}
+}
 
-	return nil
+func (n *bootNode) saveTables(logger *zap.Logger, s *store, listeners []*listener) {
+	for _, l := range listeners {
+		if err := s.saveNodes(l.protocolID, l.udp.AllNodes()); err != nil {
+			logger.Error("could not save routing table", fields.ProtocolID(l.protocolID), zap.Error(err))
+		}
+	}
 }
 
-func (n *bootNode) createListener(logger *zap.Logger, ipAddr string, port uint16, privateKey *ecdsa.PrivateKey) discovery.Listener {
-	// Create the UDP listener and the LocalNode record.
+// createListeners creates a listener for every discovery protocol ID of the served networks, all sharing one UDP connection.
+// The first listener reads from the connection and every listener passes the packets of other protocols to the next one.
+func (n *bootNode) createListeners(logger *zap.Logger, s *store, ipAddr string, privateKey *ecdsa.PrivateKey) (*net.UDPConn, []*listener, error) {
 	ip := net.ParseIP(ipAddr)
 	if ip.To4() == nil {
-		logger.Fatal("IPV4 address not provided", fields.Address(ipAddr))
-	}
-	var bindIP net.IP
-	var networkVersion string
-	switch {
-	case ip.To16() != nil && ip.To4() == nil:
-		bindIP = net.IPv6zero
-		networkVersion = "udp6"
-	case ip.To4() != nil:
-		bindIP = net.IPv4zero
-		networkVersion = "udp4"
-	default:
-		logger.Fatal("Valid ip address not provided", fields.Address(ipAddr))
-	}
-	udpAddr := &net.UDPAddr{
-		IP:   bindIP,
-		Port: int(port),
-	}
-	conn, err := net.ListenUDP(networkVersion, udpAddr)
-	if err != nil {
-		log.Fatal(err)
+		return nil, nil, fmt.Errorf("IPv4 address not provided: %s", ipAddr)
 	}
-	localNode, err := n.createLocalNode(logger, privateKey, ip, port)
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{
+		IP:   net.IPv4zero,
+		Port: int(n.discv5port),
+	})
 	if err != nil {
-		log.Fatal(err)
+		return nil, nil, fmt.Errorf("could not listen on UDP port %d: %w", n.discv5port, err)
 	}
 
-	listener, err := discover.ListenV5(conn, localNode, discover.Config{
-		PrivateKey:   privateKey,
-		V5ProtocolID: &n.network.DiscoveryProtocolID,
-	})
-	if err != nil {
-		log.Fatal(err)
+	groups := groupByProtocolID(n.networks)
+	listeners := make([]*listener, 0, len(groups))
+	closeAll := func() {
+		_ = conn.Close()
+		for _, l := range listeners {
+			if l.unhandled != nil {
+				close(l.unhandled)
+			}
+			l.udp.Close()
+		}
 	}
 
-	return listener
+	var (
+		udpConn       discover.UDPConn = conn
+		previousStats *packetStats
+	)
+	for i, group := range groups {
+		l := &listener{
+			protocolID: group.protocolID,
+			networks:   group.networks,
+			stats:      newPacketStats(),
+		}
+		if i < len(groups)-1 {
+			l.unhandled = make(chan discover.ReadPacket, 100) // size taken from https://github.com/ethereum/go-ethereum/blob/v1.13.5/p2p/server.go#L551
+		}
+
+		enodeDBPath := filepath.Join(n.dbPath, "enode")
+		if i > 0 {
+			enodeDBPath += "-" + hex.EncodeToString(l.protocolID[:])
+		}
+		localNode, err := n.createLocalNode(logger, enodeDBPath, privateKey, ip)
+		if err != nil {
+			closeAll()
+			return nil, nil, err
+		}
+
+		storedNodes, err := s.nodes(logger, l.protocolID)
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("could not load routing table: %w", err)
+		}
+		logger.Info("loaded routing table", fields.ProtocolID(l.protocolID), zap.Int("nodes", len(storedNodes)))
+
+		l.udp, err = discover.ListenV5(&countingConn{
+			UDPConn:  udpConn,
+			stats:    l.stats,
+			previous: previousStats,
+		}, localNode, discover.Config{
+			PrivateKey:   privateKey,
+			V5ProtocolID: &l.protocolID,
+			Bootnodes:    storedNodes,
+			Unhandled:    l.unhandled,
+		})
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("could not start discovery listener: %w", err)
+		}
+		listeners = append(listeners, l)
+
+		udpConn = &discovery.SharedUDPConn{UDPConn: conn, Unhandled: l.unhandled}
+		previousStats = l.stats
+	}
+
+	return conn, listeners, nil
 }
 
-func (n *bootNode) createLocalNode(logger *zap.Logger, privKey *ecdsa.PrivateKey, ipAddr net.IP, port uint16) (*enode.LocalNode, error) {
-	db, err := enode.OpenDB(filepath.Join(n.dbPath, "enode"))
+func (n *bootNode) createLocalNode(logger *zap.Logger, dbPath string, privKey *ecdsa.PrivateKey, ipAddr net.IP) (*enode.LocalNode, error) {
+	db, err := enode.OpenDB(dbPath)
 	if err != nil {
-		return nil, errors.Wrap(err, "Could not open node's peer database")
+		return nil, fmt.Errorf("could not open node's peer database: %w", err)
 	}
 	external := net.ParseIP(n.externalIP)
 	if n.externalIP == "" {
@@ -191,10 +304,10 @@ func (n *bootNode) createLocalNode(logger *zap.Logger, privKey *ecdsa.PrivateKey
 	localNode := enode.NewLocalNode(db, privKey)
 	localNode.Set(enr.WithEntry("ssv", true))
 	localNode.SetFallbackIP(external)
-	localNode.SetFallbackUDP(int(port))
+	localNode.SetFallbackUDP(int(n.discv5port))
 
 	ipEntry := enr.IP(external)
-	udpEntry := enr.UDP(port)
+	udpEntry := enr.UDP(n.discv5port)
 	tcpEntry := enr.TCP(n.tcpPort)
 
 	localNode.Set(ipEntry)
@@ -203,3 +316,40 @@ func (n *bootNode) createLocalNode(logger *zap.Logger, privKey *ecdsa.PrivateKey
 
 	return localNode, nil
 }
+
+type protocolGroup struct {
+	protocolID [6]byte
+	networks   []networkconfig.NetworkConfig
+}
+
+// groupByProtocolID groups the networks by discovery protocol ID, in order of first appearance.
+// Networks sharing a protocol ID can't be told apart by discv5 and so share a listener.
+func groupByProtocolID(networks []networkconfig.NetworkConfig) []protocolGroup {
+	var groups []protocolGroup
+	for _, nc := range networks {
+		protocolID := nc.DiscoveryProtocolID
+		if protocolID == ([6]byte{}) {
+			protocolID = discovery.DefaultSSVProtocolID
+		}
+		found := false
+		for i := range groups {
+			if groups[i].protocolID == protocolID {
+				groups[i].networks = append(groups[i].networks, nc)
+				found = true
+				break
+			}
+		}
+		if !found {
+			groups = append(groups, protocolGroup{protocolID: protocolID, networks: []networkconfig.NetworkConfig{nc}})
+		}
+	}
+	return groups
+}
+
+func networkNames(networks []networkconfig.NetworkConfig) []string {
+	names := make([]string, len(networks))
+	for i, nc := range networks {
+		names[i] = nc.Name
+	}
+	return names
+}
@@ -0,0 +1,160 @@
+package bootnode
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"net"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/network/discovery"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func newTestStore(t *testing.T) *store {
+	db, err := kv.NewInMemory(zap.NewNop(), basedb.Options{Ctx: context.Background()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return &store{db: db}
+}
+
+func TestStore(t *testing.T) {
+	logger := zap.NewNop()
+	s := newTestStore(t)
+
+	key, err := s.privateKey(logger, "")
+	require.NoError(t, err)
+	storedKey, err := s.privateKey(logger, "")
+	require.NoError(t, err)
+	require.True(t, key.Equal(storedKey), "stored key should be reused")
+
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	configured, err := s.privateKey(logger, hexKey(other))
+	require.NoError(t, err)
+	require.True(t, other.Equal(configured), "configured key should take precedence")
+
+	protocolA := [6]byte{'a', 'a', 'a', 'a', 'a', 'a'}
+	protocolB := [6]byte{'b', 'b', 'b', 'b', 'b', 'b'}
+	nodes := []*enode.Node{testNode(t, 1000), testNode(t, 1001)}
+	require.NoError(t, s.saveNodes(protocolA, nodes))
+	require.NoError(t, s.saveNodes(protocolB, nodes[:1]))
+
+	loaded, err := s.nodes(logger, protocolA)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []enode.ID{nodes[0].ID(), nodes[1].ID()}, []enode.ID{loaded[0].ID(), loaded[1].ID()})
+
+	// Saving replaces the previous nodes.
+	require.NoError(t, s.saveNodes(protocolA, nodes[1:]))
+	loaded, err = s.nodes(logger, protocolA)
+	require.NoError(t, err)
+	require.Len(t, loaded, 1)
+	require.Equal(t, nodes[1].ID(), loaded[0].ID())
+
+	loaded, err = s.nodes(logger, protocolB)
+	require.NoError(t, err)
+	require.Len(t, loaded, 1)
+}
+
+func TestGroupByProtocolID(t *testing.T) {
+	devnet := networkconfig.LocalTestnet
+	devnet.Name = "devnet"
+	devnet.DiscoveryProtocolID = [6]byte{'d', 'e', 'v', 'd', 'v', '5'}
+
+	groups := groupByProtocolID([]networkconfig.NetworkConfig{networkconfig.Mainnet, devnet, networkconfig.Holesky, networkconfig.LocalTestnet})
+	require.Len(t, groups, 2)
+	require.Equal(t, discovery.DefaultSSVProtocolID, groups[0].protocolID)
+	require.Equal(t, []string{"mainnet", "holesky", "local-testnet"}, networkNames(groups[0].networks))
+	require.Equal(t, devnet.DiscoveryProtocolID, groups[1].protocolID)
+	require.Equal(t, []string{"devnet"}, networkNames(groups[1].networks))
+}
+
+func TestSharedPortListeners(t *testing.T) {
+	logger := zap.NewNop()
+	devnet := networkconfig.LocalTestnet
+	devnet.Name = "devnet"
+	devnet.DiscoveryProtocolID = [6]byte{'d', 'e', 'v', 'd', 'v', '5'}
+
+	n := &bootNode{
+		discv5port: uint16(freeUDPPort(t)),
+		tcpPort:    5000,
+		dbPath:     t.TempDir(),
+		networks:   []networkconfig.NetworkConfig{networkconfig.Mainnet, devnet},
+	}
+	s := newTestStore(t)
+	privKey, err := s.privateKey(logger, "")
+	require.NoError(t, err)
+
+	conn, listeners, err := n.createListeners(logger, s, "127.0.0.1", privKey)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+		for _, l := range listeners {
+			if l.unhandled != nil {
+				close(l.unhandled)
+			}
+			l.udp.Close()
+		}
+	})
+	require.Len(t, listeners, 2)
+
+	// A devnet client reaches the second listener through the first one.
+	client := newTestClient(t, devnet.DiscoveryProtocolID)
+	require.NoError(t, client.Ping(listeners[1].udp.Self()))
+
+	require.Positive(t, listeners[1].stats.received.Load())
+	require.Positive(t, listeners[1].stats.sent.Load())
+	require.Zero(t, listeners[0].stats.received.Load())
+	require.Zero(t, listeners[0].stats.sent.Load())
+
+	status := listeners[1].status()
+	require.Equal(t, []string{"devnet"}, status.Networks)
+	require.Equal(t, "646576647635", status.ProtocolID)
+}
+
+func newTestClient(t *testing.T, protocolID [6]byte) *discover.UDPv5 {
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	db, err := enode.OpenDB("")
+	require.NoError(t, err)
+	localNode := enode.NewLocalNode(db, privKey)
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	client, err := discover.ListenV5(conn, localNode, discover.Config{
+		PrivateKey:   privKey,
+		V5ProtocolID: &protocolID,
+	})
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+	return client
+}
+
+func testNode(t *testing.T, port int) *enode.Node {
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	db, err := enode.OpenDB("")
+	require.NoError(t, err)
+	localNode := enode.NewLocalNode(db, privKey)
+	localNode.SetStaticIP(net.IPv4(127, 0, 0, 1))
+	localNode.SetFallbackUDP(port)
+	return localNode.Node()
+}
+
+func freeUDPPort(t *testing.T) int {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	return conn.LocalAddr().(*net.UDPAddr).Port
+}
+
+func hexKey(key *ecdsa.PrivateKey) string {
+	return hex.EncodeToString(crypto.FromECDSA(key))
+}
@@ -0,0 +1,59 @@
+package bootnode
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/ssvlabs/ssv/observability"
+)
+
+const (
+	observabilityName      = "github.com/ssvlabs/ssv/utils/boot_node"
+	observabilityNamespace = "ssv.bootnode"
+)
+
+var (
+	meter = otel.Meter(observabilityName)
+
+	tableSizeGauge = observability.NewMetric(
+		meter.Int64Gauge(
+			metricName("table.size"),
+			metric.WithUnit("{node}"),
+			metric.WithDescription("number of nodes in the discovery routing table")))
+
+	recentNodesGauge = observability.NewMetric(
+		meter.Int64Gauge(
+			metricName("table.recent_nodes"),
+			metric.WithUnit("{node}"),
+			metric.WithDescription("number of nodes in the discovery routing table which recently sent a packet")))
+
+	packetRateGauge = observability.NewMetric(
+		meter.Float64Gauge(
+			metricName("packet.rate"),
+			metric.WithUnit("{packet}/s"),
+			metric.WithDescription("discovery packets per second")))
+)
+
+func metricName(name string) string {
+	return fmt.Sprintf("%s.%s", observabilityNamespace, name)
+}
+
+func protocolAttribute(protocolID string) attribute.KeyValue {
+	return attribute.String(fmt.Sprintf("%s.protocol_id", observabilityNamespace), protocolID)
+}
+
+func directionAttribute(direction string) attribute.KeyValue {
+	return attribute.String(fmt.Sprintf("%s.direction", observabilityNamespace), direction)
+}
+
+func recordListenerStatus(ctx context.Context, status *listenerStatus) {
+	protocol := metric.WithAttributes(protocolAttribute(status.ProtocolID))
+	tableSizeGauge.Record(ctx, int64(status.TableSize), protocol)
+	recentNodesGauge.Record(ctx, int64(status.RecentNodes), protocol)
+	packetRateGauge.Record(ctx, status.ReceivedRate, protocol, metric.WithAttributes(directionAttribute("received")))
+	packetRateGauge.Record(ctx, status.SentRate, protocol, metric.WithAttributes(directionAttribute("sent")))
+}
@@ -0,0 +1,99 @@
+package bootnode
+
+import (
+	"net/netip"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/discover"
+)
+
+// recentWindow is how long after its last packet a node is reported as recently seen.
+const recentWindow = 10 * time.Minute
+
+// packetStats counts the discovery packets of a listener and when each remote address last sent one.
+type packetStats struct {
+	received atomic.Int64
+	sent     atomic.Int64
+
+	mu           sync.Mutex
+	lastSeen     map[netip.AddrPort]time.Time
+	receivedRate float64
+	sentRate     float64
+	lastReceived int64
+	lastSent     int64
+}
+
+func newPacketStats() *packetStats {
+	return &packetStats{
+		lastSeen: make(map[netip.AddrPort]time.Time),
+	}
+}
+
+func (s *packetStats) seen(addr netip.AddrPort) {
+	s.mu.Lock()
+	s.lastSeen[addr] = time.Now()
+	s.mu.Unlock()
+}
+
+// lastSeenAt returns when the given address last sent a packet.
+func (s *packetStats) lastSeenAt(addr netip.AddrPort) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.lastSeen[addr]
+	return t, ok
+}
+
+// rates returns the received and sent packets per second as of the last update.
+func (s *packetStats) rates() (received, sent float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.receivedRate, s.sentRate
+}
+
+// update updates the packet rates since the previous update and forgets addresses which weren't seen recently.
+func (s *packetStats) update(elapsed time.Duration) {
+	received, sent := s.received.Load(), s.sent.Load()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.receivedRate = float64(received-s.lastReceived) / elapsed.Seconds()
+	s.sentRate = float64(sent-s.lastSent) / elapsed.Seconds()
+	s.lastReceived, s.lastSent = received, sent
+	for addr, t := range s.lastSeen {
+		if time.Since(t) > recentWindow {
+			delete(s.lastSeen, addr)
+		}
+	}
+}
+
+// countingConn counts the packets read and written by a discv5 listener.
+// When listeners share a UDP port, every listener but the first reads the packets left unhandled by the previous one,
+// so reading a packet also uncounts it from the previous listener.
+type countingConn struct {
+	discover.UDPConn
+	stats    *packetStats
+	previous *packetStats
+}
+
+func (c *countingConn) ReadFromUDPAddrPort(b []byte) (int, netip.AddrPort, error) {
+	n, addr, err := c.UDPConn.ReadFromUDPAddrPort(b)
+	if err != nil {
+		return n, addr, err
+	}
+	c.stats.received.Add(1)
+	c.stats.seen(addr)
+	if c.previous != nil {
+		c.previous.received.Add(-1)
+	}
+	return n, addr, nil
+}
+
+func (c *countingConn) WriteToUDPAddrPort(b []byte, addr netip.AddrPort) (int, error) {
+	n, err := c.UDPConn.WriteToUDPAddrPort(b, addr)
+	if err == nil {
+		c.stats.sent.Add(1)
+	}
+	return n, err
+}
@@ -0,0 +1,83 @@
+package bootnode
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/utils"
+)
+
+var (
+	storePrefix = []byte("bootnode/")
+	keyKey      = []byte("private_key")
+	nodesPrefix = "nodes/"
+)
+
+// store persists the boot node's private key and the nodes of its routing tables,
+// so that the boot node keeps its ENR and recovers its tables across restarts.
+type store struct {
+	db basedb.Database
+}
+
+// privateKey returns the configured private key, or the stored one, generating and storing a new one if none is.
+func (s *store) privateKey(logger *zap.Logger, configured string) (*ecdsa.PrivateKey, error) {
+	if configured != "" {
+		return utils.ECDSAPrivateKey(logger, configured)
+	}
+
+	obj, found, err := s.db.Get(storePrefix, keyKey)
+	if err != nil {
+		return nil, fmt.Errorf("could not get stored private key: %w", err)
+	}
+	if found {
+		return utils.ECDSAPrivateKey(logger, string(obj.Value))
+	}
+
+	privKey, err := utils.ECDSAPrivateKey(logger, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.Set(storePrefix, keyKey, []byte(hex.EncodeToString(crypto.FromECDSA(privKey)))); err != nil {
+		return nil, fmt.Errorf("could not store private key: %w", err)
+	}
+	return privKey, nil
+}
+
+// saveNodes replaces the stored routing table nodes of the given discovery protocol.
+func (s *store) saveNodes(protocolID [6]byte, nodes []*enode.Node) error {
+	prefix := nodesKeyPrefix(protocolID)
+	if err := s.db.DropPrefix(prefix); err != nil {
+		return err
+	}
+	return s.db.SetMany(prefix, len(nodes), func(i int) (basedb.Obj, error) {
+		return basedb.Obj{
+			Key:   nodes[i].ID().Bytes(),
+			Value: []byte(nodes[i].String()),
+		}, nil
+	})
+}
+
+// nodes returns the stored routing table nodes of the given discovery protocol, skipping invalid records.
+func (s *store) nodes(logger *zap.Logger, protocolID [6]byte) ([]*enode.Node, error) {
+	var nodes []*enode.Node
+	err := s.db.GetAll(nodesKeyPrefix(protocolID), func(_ int, obj basedb.Obj) error {
+		node, err := enode.Parse(enode.ValidSchemes, string(obj.Value))
+		if err != nil {
+			logger.Debug("skipping invalid stored node", zap.Error(err))
+			return nil
+		}
+		nodes = append(nodes, node)
+		return nil
+	})
+	return nodes, err
+}
+
+func nodesKeyPrefix(protocolID [6]byte) []byte {
+	return append(append([]byte{}, storePrefix...), nodesPrefix+hex.EncodeToString(protocolID[:])+"/"...)
+}
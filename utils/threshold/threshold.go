@@ -22,6 +22,17 @@ func Init() {
 // Create receives a bls.SecretKey hex and count.
 // Will split the secret key into count shares
 func Create(skBytes []byte, threshold uint64, count uint64) (map[uint64]*bls.SecretKey, error) {
+	ids := make([]uint64, count)
+	for i := range ids {
+		ids[i] = uint64(i) + 1
+	}
+	return CreateForIDs(skBytes, threshold, ids)
+}
+
+// CreateForIDs splits the secret key into a share for each of the given IDs, any threshold of which recovers it.
+// Signatures are reconstructed with operator IDs as the share indexes, so shares for a committee must be created
+// for the operator IDs of its members.
+func CreateForIDs(skBytes []byte, threshold uint64, ids []uint64) (map[uint64]*bls.SecretKey, error) {
 	// master key Polynomial
 	msk := make([]bls.SecretKey, threshold)
 
@@ -38,12 +49,19 @@ func Create(skBytes []byte, threshold uint64, count uint64) (map[uint64]*bls.Sec
 		msk[i] = sk
 	}
 
-	// evaluate shares - starting from 1 because 0 is master key
+	// evaluate shares - IDs must be non-zero because 0 is master key
 	shares := make(map[uint64]*bls.SecretKey)
-	for i := uint64(1); i <= count; i++ {
+	for _, id := range ids {
+		if id == 0 {
+			return nil, fmt.Errorf("share ID must not be 0")
+		}
+		if _, ok := shares[id]; ok {
+			return nil, fmt.Errorf("duplicate share ID %d", id)
+		}
+
 		blsID := bls.ID{}
 
-		err := blsID.SetDecString(fmt.Sprintf("%d", i))
+		err := blsID.SetDecString(fmt.Sprintf("%d", id))
 		if err != nil {
 			return nil, err
 		}
@@ -55,7 +73,7 @@ func Create(skBytes []byte, threshold uint64, count uint64) (map[uint64]*bls.Sec
 			return nil, err
 		}
 
-		shares[i] = &sk
+		shares[id] = &sk
 	}
 	return shares, nil
 }